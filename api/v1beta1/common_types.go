@@ -17,8 +17,17 @@ limitations under the License.
 package v1beta1
 
 import (
+	"maps"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -39,6 +48,12 @@ const (
 	DesignateUnboundContainerImage = "quay.io/podified-antelope-centos9/openstack-unbound:current-podified"
 	// DesignateBackendbind9ContainerImage is the fall-back container image for DesignateUnbound
 	DesignateBackendbind9ContainerImage = "quay.io/podified-antelope-centos9/openstack-designate-backend-bind9:current-podified"
+	// DesignateBackendPDNSContainerImage is the fall-back container image for DesignateBackendPDNS
+	DesignateBackendPDNSContainerImage = "docker.io/powerdns/pdns-auth-49:latest"
+	// DesignateSinkContainerImage is the fall-back container image for DesignateSink
+	DesignateSinkContainerImage = "quay.io/podified-antelope-centos9/openstack-designate-sink:current-podified"
+	// Bind9ExporterContainerImage is the fall-back container image for the bind9 metrics exporter
+	Bind9ExporterContainerImage = "quay.io/prometheuscommunity/bind-exporter:v0.8.0"
 	// NetUtilsContainerImage is the container image containing support for predictable IP pod injection
 	NetUtilsContainerImage = "quay.io/podified-antelope-centos9/openstack-netutils:current-podified"
 )
@@ -55,6 +70,11 @@ type DesignateTemplate struct {
 	// DatabaseAccount - name of MariaDBAccount which will be used to connect.
 	DatabaseAccount string `json:"databaseAccount"`
 
+	// +kubebuilder:validation:Optional
+	// ExternalDatabase - set when Designate uses a database not managed by mariadb-operator, the
+	// database client configuration is then the one of the Designate CR
+	ExternalDatabase bool `json:"externalDatabase,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// Secret containing OpenStack password information for DesignatePassword
 	Secret string `json:"secret"`
@@ -91,6 +111,12 @@ type DesignateServiceTemplateCore struct {
 	// any global NodeSelector settings within the Designate CR.
 	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// Tolerations - tolerations of the pods of the service, e.g. to run them on the tainted nodes of a dedicated
+	// node pool or availability zone selected with NodeSelector.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// CustomServiceConfig - customize the service config using this parameter to change service defaults,
 	// or overwrite rendered information using raw OpenStack config format. The content gets added to
@@ -112,8 +138,20 @@ type DesignateServiceTemplateCore struct {
 	// +kubebuilder:validation:Optional
 	// Resources - Compute Resources required by this service (Limits/Requests).
 	// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// The operator requests 100m of CPU and 256Mi of memory for the service container when they are not set.
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// InitResources - compute resources of the init containers of the service, which render its config. The
+	// operator requests 10m of CPU and 64Mi of memory when they are not set.
+	InitResources corev1.ResourceRequirements `json:"initResources,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SidecarResources - compute resources of the sidecar containers the operator runs next to the service
+	// container, e.g. the bind_exporter of bind9. The operator requests 10m of CPU and 64Mi of memory when
+	// they are not set.
+	SidecarResources corev1.ResourceRequirements `json:"sidecarResources,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NetworkAttachments is a list of NetworkAttachment resource names to expose the services to the given network
 	NetworkAttachments []string `json:"networkAttachments,omitempty"`
@@ -122,6 +160,247 @@ type DesignateServiceTemplateCore struct {
 	// TopologyRef to apply the Topology defined by the associated CR referenced
 	// by name
 	TopologyRef *topologyv1.TopoRef `json:"topologyRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodDisruptionBudget - limits the voluntary disruptions of the service pods, e.g. by node drains. When not
+	// set, at least one pod is kept available if the service runs more than one replica.
+	PodDisruptionBudget *DesignatePDBSpec `json:"podDisruptionBudget,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Probes - overrides the timings of the probes of the service container
+	Probes *DesignateProbesSpec `json:"probes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=preferred;required
+	// AntiAffinity - with preferred, the default, two pods of the service only run on the same node when no
+	// other node is available. With required they never do. Ignored when topologyRef is set.
+	AntiAffinity string `json:"antiAffinity,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=topologyKey
+	// TopologySpreadConstraints - spread the pods of the service across the domains of the topology keys,
+	// e.g. topology.kubernetes.io/zone. Ignored when topologyRef is set.
+	TopologySpreadConstraints []DesignateTopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Logging - log level and format of the service
+	Logging *DesignateLoggingSpec `json:"logging,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=baseline;restricted
+	// SecurityProfile - with baseline, the default, the containers of the service only get the RuntimeDefault
+	// seccomp profile. With restricted they also run without privilege escalation and all the capabilities but
+	// the ones the service needs, as non root unless the service drops its privileges itself.
+	SecurityProfile string `json:"securityProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ImagePullSecrets - Secrets holding the credentials of the registries the images of the service are pulled
+	// from. When not set, the imagePullSecrets of the Designate CR are used.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// ImagePullPolicy - pull policy of the containers of the service. When not set, the imagePullPolicy of the
+	// Designate CR is used, and otherwise the Kubernetes default.
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraMounts - additional volumes, e.g. Secrets, ConfigMaps or PVCs, mounted in the service container. The
+	// propagation of a volume must include Designate or the kind of the service, e.g. DesignateBackendbind9.
+	ExtraMounts []DesignateExtraVolMounts `json:"extraMounts,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Revert;Report
+	// ConfigDriftPolicy - what is done when the config Secrets generated for the service, e.g. <name>-config-data,
+	// are modified out of band. With Revert, the default, they are rendered again. With Report they are kept as
+	// they are, without the changes of the service spec, and the ConfigInSync condition is set false until they
+	// are restored or deleted.
+	ConfigDriftPolicy ConfigDriftPolicy `json:"configDriftPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ServiceMesh - the sidecar injected into the pods of the service by a service mesh, e.g. Istio or Linkerd,
+	// or by a custom injector
+	ServiceMesh *DesignateServiceMeshSpec `json:"serviceMesh,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodAnnotations - annotations added to the pods of the service, e.g. for kube-downscaler or a backup agent.
+	// The annotations set by the operator and the ones with the designate.openstack.org/ prefix are not overridden.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodLabels - labels added to the pods of the service, e.g. for a cost allocation. The labels set by the
+	// operator, which select the pods, and the ones with the designate.openstack.org/ prefix are not overridden.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PriorityClassName - PriorityClass of the pods of the service, e.g. to keep the DNS servers running when
+	// lower priority workloads are evicted. The PriorityClass must exist, the pods are not created otherwise.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RuntimeClassName - RuntimeClass the containers of the service are run with, e.g. a sandboxed runtime.
+	// When not set, the default runtime of the nodes is used.
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+}
+
+// ServiceMeshProvider - a service mesh injecting its sidecar into the pods
+type ServiceMeshProvider string
+
+const (
+	// ServiceMeshProviderIstio - the sidecar.istio.io/inject annotation is set on the pods
+	ServiceMeshProviderIstio ServiceMeshProvider = "Istio"
+
+	// ServiceMeshProviderLinkerd - the linkerd.io/inject annotation is set on the pods
+	ServiceMeshProviderLinkerd ServiceMeshProvider = "Linkerd"
+)
+
+// DesignateServiceMeshSpec defines the injection of a sidecar into the pods of a service. The DNS ports
+// of the pods are declared, named dns and dns-tcp, and their Services set the udp and tcp appProtocol the
+// meshes select the proxying of the traffic from.
+type DesignateServiceMeshSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Istio;Linkerd
+	// Provider - the service mesh injecting its sidecar, its injection annotation is set on the pods. Not set
+	// for a custom injector, configured with the podAnnotations.
+	Provider ServiceMeshProvider `json:"provider,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Inject - request, or with false prevent, the injection of the sidecar of the provider, e.g. when the
+	// injection is enabled for the whole namespace. When not set the defaults of the mesh apply.
+	Inject *bool `json:"inject,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodAnnotations - annotations passed through to the pods, e.g. the settings of the sidecar or of a
+	// custom injector. The annotations set by the operator are not overridden.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
+
+// ConfigDriftPolicy - what is done with the config Secrets generated by the operator when they are
+// modified out of band
+type ConfigDriftPolicy string
+
+const (
+	// ConfigDriftPolicyRevert - the modified Secrets are rendered again
+	ConfigDriftPolicyRevert ConfigDriftPolicy = "Revert"
+
+	// ConfigDriftPolicyReport - the modified Secrets are kept and reported with the ConfigInSync condition
+	ConfigDriftPolicyReport ConfigDriftPolicy = "Report"
+)
+
+// DesignateLoggingSpec defines the logging of a designate service. The format and the rate limiting
+// only apply to the OpenStack services, the DNS servers only honour the level.
+type DesignateLoggingSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=info
+	// +kubebuilder:validation:Enum=debug;info
+	// Level - minimum level of the logged records
+	Level string `json:"level,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// JSON - log JSON records instead of text
+	JSON bool `json:"json,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// RateLimitInterval - seconds of the rate limiting window, 0 disables the rate limiting
+	RateLimitInterval int32 `json:"rateLimitInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// RateLimitBurst - records logged in a rate limiting window, the others are dropped
+	RateLimitBurst int32 `json:"rateLimitBurst,omitempty"`
+}
+
+// DesignateTopologySpreadConstraint defines how the pods of a designate service are spread across the
+// domains of a topology key
+type DesignateTopologySpreadConstraint struct {
+	// +kubebuilder:validation:Required
+	// TopologyKey - node label whose values are the topology domains
+	TopologyKey string `json:"topologyKey"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// MaxSkew - maximum difference between the number of pods of the service of two domains
+	MaxSkew int32 `json:"maxSkew"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=DoNotSchedule
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	// WhenUnsatisfiable - DoNotSchedule leaves a pod pending rather than exceeding MaxSkew, ScheduleAnyway
+	// only prefers the domains reducing the skew
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable"`
+}
+
+// DesignateProbesSpec overrides the probes of a designate service container. A probe the service
+// doesn't define, e.g. the startup probe of the bind9 servers, is added with the check of the
+// liveness probe.
+type DesignateProbesSpec struct {
+	// +kubebuilder:validation:Optional
+	// LivenessProbe - overrides the liveness probe timings
+	LivenessProbe *DesignateProbeSpec `json:"livenessProbe,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ReadinessProbe - overrides the readiness probe timings
+	ReadinessProbe *DesignateProbeSpec `json:"readinessProbe,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// StartupProbe - overrides the startup probe timings
+	StartupProbe *DesignateProbeSpec `json:"startupProbe,omitempty"`
+}
+
+// DesignateProbeSpec defines the timings of a probe, the unset fields keep the defaults of the service
+type DesignateProbeSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// InitialDelaySeconds - seconds after the container start before the probe is run
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// PeriodSeconds - seconds between two runs of the probe
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TimeoutSeconds - seconds after which the probe times out
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// FailureThreshold - consecutive failures after which the probe is considered failed
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// DesignatePDBSpec defines the PodDisruptionBudget of a designate service, at most one of MinAvailable and
+// MaxUnavailable can be set
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type DesignatePDBSpec struct {
+	// +kubebuilder:validation:Optional
+	// MinAvailable - number or percentage of the pods which must stay available
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MaxUnavailable - number or percentage of the pods which can be unavailable
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// DesignateUpdateStrategySpec defines how the pods of a designate StatefulSet are updated
+// +kubebuilder:validation:XValidation:rule="self.type == 'RollingUpdate' || !has(self.partition)",message="partition requires the RollingUpdate type"
+type DesignateUpdateStrategySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=RollingUpdate
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// Type - RollingUpdate replaces the pods, highest ordinal first, when the pod template changes. With
+	// OnDelete a pod is only updated once it is deleted.
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Partition - only the pods with an ordinal greater than or equal to the partition are updated, e.g.
+	// replicas - 1 to canary the last pod on a new image before lowering it to roll the others
+	Partition *int32 `json:"partition,omitempty"`
 }
 
 // DesignateServiceTemplate defines the input parameters that can be defined for a given
@@ -134,6 +413,58 @@ type DesignateServiceTemplate struct {
 	DesignateServiceTemplateCore `json:",inline"`
 }
 
+// DesignateAutoscalingSpec defines the HorizontalPodAutoscaler of a designate service. The autoscaler
+// owns the number of replicas of the service, the Replicas field is ignored.
+type DesignateAutoscalingSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// MinReplicas - lower limit of the number of replicas
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	// MaxReplicas - upper limit of the number of replicas
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TargetCPUUtilizationPercentage - target average CPU usage of the pods, in percent of their CPU request
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TargetMemoryUtilizationPercentage - target average memory usage of the pods, in percent of their memory
+	// request
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// CustomMetrics - per pod metrics served by the custom metrics API and their target average values
+	CustomMetrics []DesignateAutoscalingCustomMetric `json:"customMetrics,omitempty"`
+}
+
+// GetMinReplicas - returns the lower limit of the number of replicas, 1 when not set
+func (a DesignateAutoscalingSpec) GetMinReplicas() int32 {
+	if a.MinReplicas == nil {
+		return 1
+	}
+	return *a.MinReplicas
+}
+
+// DesignateAutoscalingCustomMetric defines the target average value of a per pod custom metric
+type DesignateAutoscalingCustomMetric struct {
+	// +kubebuilder:validation:Required
+	// Name - name of the metric
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// TargetAverageValue - target value of the metric averaged over the pods
+	TargetAverageValue resource.Quantity `json:"targetAverageValue"`
+}
+
 // PasswordSelector to identify the DB and AdminUser password from the Secret
 type PasswordSelector struct {
 	// +kubebuilder:validation:Optional
@@ -153,3 +484,111 @@ func (instance *DesignateServiceTemplateCore) ValidateTopology(
 		*basePath.Child("topologyRef"), namespace)...)
 	return allErrs
 }
+
+// ValidateControlNetworkName - Returns an ErrorList if the service has network
+// attachments and the control network is not one of them
+func (instance *DesignateServiceTemplateCore) ValidateControlNetworkName(
+	path *field.Path,
+	controlNetworkName string,
+) field.ErrorList {
+	if len(instance.NetworkAttachments) == 0 || slices.Contains(instance.NetworkAttachments, controlNetworkName) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, controlNetworkName,
+		"must be one of the networkAttachments of the service")}
+}
+
+// maxInterfaceNameLength is the longest name of a network interface, the
+// interfaces of the network attachments are named after them
+const maxInterfaceNameLength = 15
+
+// ValidatePredictableIPNetworks - Returns an ErrorList if a network the pods
+// get a predictable IP on is the control network, is listed twice, is not one
+// of the network attachments of the service or is too long to name the
+// interface of the pods
+func (instance *DesignateServiceTemplateCore) ValidatePredictableIPNetworks(
+	path *field.Path,
+	controlNetworkName string,
+	networks []string,
+) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, network := range networks {
+		switch {
+		case network == controlNetworkName:
+			allErrs = append(allErrs, field.Invalid(path.Index(i), network,
+				"the control network always has a predictable IP"))
+		case slices.Contains(networks[:i], network):
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), network))
+		case !slices.Contains(instance.NetworkAttachments, network):
+			allErrs = append(allErrs, field.Invalid(path.Index(i), network,
+				"must be one of the networkAttachments of the service"))
+		case len(network) > maxInterfaceNameLength:
+			allErrs = append(allErrs, field.TooLong(path.Index(i), network, maxInterfaceNameLength))
+		}
+	}
+	return allErrs
+}
+
+// ValidateStaticIPs - Returns an ErrorList if a key of the static IPs is not
+// a pod index, if an address is invalid or more than one address of a family
+// is pinned to a pod, or if an address is pinned to more than one pod. used
+// holds the addresses pinned by the previous services.
+func ValidateStaticIPs(path *field.Path, staticIPs map[string]string, used map[string]bool) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, index := range slices.Sorted(maps.Keys(staticIPs)) {
+		if i, err := strconv.Atoi(index); err != nil || i < 0 || strconv.Itoa(i) != index {
+			allErrs = append(allErrs, field.Invalid(path, index, "the keys must be pod indexes"))
+			continue
+		}
+		families := map[bool]bool{}
+		for ip := range strings.SplitSeq(staticIPs[index], ",") {
+			addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+			switch {
+			case err != nil:
+				allErrs = append(allErrs, field.Invalid(path.Key(index), staticIPs[index], err.Error()))
+			case families[addr.Is4()]:
+				allErrs = append(allErrs, field.Invalid(path.Key(index), staticIPs[index],
+					"at most one address of each IP family can be pinned to a pod"))
+			case used[addr.String()]:
+				allErrs = append(allErrs, field.Duplicate(path.Key(index), addr.String()))
+			}
+			if err == nil {
+				families[addr.Is4()] = true
+				used[addr.String()] = true
+			}
+		}
+	}
+	return allErrs
+}
+
+// ValidateStorageRequest - Returns an ErrorList if the storage request is set
+// and is not a valid quantity
+func ValidateStorageRequest(path *field.Path, storageRequest string) field.ErrorList {
+	if storageRequest == "" {
+		return nil
+	}
+	if _, err := resource.ParseQuantity(storageRequest); err != nil {
+		return field.ErrorList{field.Invalid(path, storageRequest, err.Error())}
+	}
+	return nil
+}
+
+// ValidatePVCRetentionPolicy - Returns an ErrorList if a volume claim retention
+// policy of the StatefulSet of a service is neither Retain nor Delete
+func ValidatePVCRetentionPolicy(path *field.Path, policy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+	supported := []string{
+		string(appsv1.RetainPersistentVolumeClaimRetentionPolicyType),
+		string(appsv1.DeletePersistentVolumeClaimRetentionPolicyType),
+	}
+	if value := string(policy.WhenDeleted); value != "" && !slices.Contains(supported, value) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("whenDeleted"), value, supported))
+	}
+	if value := string(policy.WhenScaled); value != "" && !slices.Contains(supported, value) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("whenScaled"), value, supported))
+	}
+	return allErrs
+}
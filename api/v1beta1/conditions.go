@@ -39,15 +39,63 @@ const (
 	// DesignateBackendbind9ReadyCondition Status=True condition which indicates if the DesignateBackendbind9 is configured and operational
 	DesignateBackendbind9ReadyCondition condition.Type = "DesignateBackendbind9Ready"
 
+	// DesignateBackendPDNSReadyCondition Status=True condition which indicates if the DesignateBackendPDNS is configured and operational
+	DesignateBackendPDNSReadyCondition condition.Type = "DesignateBackendPDNSReady"
+
+	// DesignateSinkReadyCondition Status=True condition which indicates if the DesignateSink is configured and operational
+	DesignateSinkReadyCondition condition.Type = "DesignateSinkReady"
+
 	// DesignateUnboundReadyCondition Status=True condition which indicates if the DesignateUnbound is configured and operational
 	DesignateUnboundReadyCondition condition.Type = "DesignateUnboundReady"
 
 	// DesignateRabbitMqNotificationsTransportURLReadyCondition Status=True condition which indicates if the RabbitMQ notifications transport URL is configured and operational
 	DesignateRabbitMqNotificationsTransportURLReadyCondition condition.Type = "DesignateRabbitMqNotificationsTransportURLReady"
+
+	// DesignatePredictableIPsReadyCondition Status=True condition which indicates if the predictable IPs of the mdns and bind9 pods are reserved
+	DesignatePredictableIPsReadyCondition condition.Type = "DesignatePredictableIPsReady"
+
+	// DesignatePoolUpdateReadyCondition Status=True condition which indicates if the pool of a DesignatePool has been applied
+	DesignatePoolUpdateReadyCondition condition.Type = "DesignatePoolUpdateReady"
+
+	// DesignateZoneReadyCondition Status=True condition which indicates if the zone of a DesignateZone is active in Designate
+	DesignateZoneReadyCondition condition.Type = "DesignateZoneReady"
+
+	// DesignateRecordSetReadyCondition Status=True condition which indicates if the recordset of a DesignateRecordSet is active in Designate
+	DesignateRecordSetReadyCondition condition.Type = "DesignateRecordSetReady"
+
+	// DesignatePreflightReadyCondition Status=True condition which indicates if the pre-flight checks of the services designate depends on passed
+	DesignatePreflightReadyCondition condition.Type = "DesignatePreflightReady"
+
+	// DesignateZonesInSyncCondition Status=True condition which indicates if the bind9 servers serve the current serial of the zones
+	DesignateZonesInSyncCondition condition.Type = "DesignateZonesInSync"
+
+	// DesignateUpdateReadyCondition Status=True condition which indicates if the services run the images of the spec, False while a minor update rolls them out
+	DesignateUpdateReadyCondition condition.Type = "DesignateUpdateReady"
+
+	// DesignateDependenciesReadyCondition Status=True condition which indicates if pools.yaml is generated and the predictable IPs are assigned, DesignateWorker and DesignateProducer are only deployed then
+	DesignateDependenciesReadyCondition condition.Type = "DesignateDependenciesReady"
+
+	// DesignateDatabaseRotationReadyCondition Status=True condition which indicates if the services run with the credentials of the MariaDBAccount, False while rotated credentials are rolled out
+	DesignateDatabaseRotationReadyCondition condition.Type = "DesignateDatabaseRotationReady"
+
+	// DesignateAPIReadOnlyReadyCondition Status=True condition which indicates if the read-only replicas of a DesignateAPI are ready
+	DesignateAPIReadOnlyReadyCondition condition.Type = "DesignateAPIReadOnlyReady"
+
+	// DesignateConfigInSyncCondition Status=True condition which indicates if the generated config Secrets hold the configuration rendered by the operator, False while Secrets modified out of band are kept with the Report config drift policy
+	DesignateConfigInSyncCondition condition.Type = "DesignateConfigInSync"
 )
 
 // Designate Reasons used by API objects.
-const ()
+const (
+	// DesignateZonesLaggingReason - a bind9 server serves zones behind the serial in designate
+	DesignateZonesLaggingReason condition.Reason = "ZonesLagging"
+
+	// DesignateWaitingOnDependenciesReason - a service is not deployed until the resources it depends on are ready
+	DesignateWaitingOnDependenciesReason condition.Reason = "WaitingOnDependencies"
+
+	// DesignateDriftDetectedReason - a generated config Secret was modified out of band
+	DesignateDriftDetectedReason condition.Reason = "DriftDetected"
+)
 
 // Common Messages used by API objects.
 const (
@@ -78,6 +126,9 @@ const (
 	// DesignateWorkerReadyErrorMessage
 	DesignateWorkerReadyErrorMessage = "DesignateWorker error occured %s"
 
+	// DesignateWorkerReadyWaitingMessage
+	DesignateWorkerReadyWaitingMessage = "DesignateWorker waiting on %s"
+
 	//
 	// DesignateMdnsReady condition messages
 	//
@@ -96,6 +147,9 @@ const (
 	// DesignateProducerReadyErrorMessage
 	DesignateProducerReadyErrorMessage = "DesignateProducer error occured %s"
 
+	// DesignateProducerReadyWaitingMessage
+	DesignateProducerReadyWaitingMessage = "DesignateProducer waiting on %s"
+
 	//
 	// DesignateBackendbind9Ready condition messages
 	//
@@ -105,6 +159,24 @@ const (
 	// DesignateBackendbind9ReadyErrorMessage
 	DesignateBackendbind9ReadyErrorMessage = "DesignateBackendbind9 error occured %s"
 
+	//
+	// DesignateBackendPDNSReady condition messages
+	//
+	// DesignateBackendPDNSReadyInitMessage
+	DesignateBackendPDNSReadyInitMessage = "DesignateBackendPDNS not started"
+
+	// DesignateBackendPDNSReadyErrorMessage
+	DesignateBackendPDNSReadyErrorMessage = "DesignateBackendPDNS error occured %s"
+
+	//
+	// DesignateSinkReady condition messages
+	//
+	// DesignateSinkReadyInitMessage
+	DesignateSinkReadyInitMessage = "DesignateSink not started"
+
+	// DesignateSinkReadyErrorMessage
+	DesignateSinkReadyErrorMessage = "DesignateSink error occured %s"
+
 	//
 	// DesignateUnboundReady condition messages
 	//
@@ -113,4 +185,208 @@ const (
 
 	// DesignateUnboundReadyErrorMessage
 	DesignateUnboundReadyErrorMessage = "DesignateUnbound error occured %s"
+
+	//
+	// DBSyncReady condition messages
+	//
+	// DesignateDBSyncReadyApprovalMessage
+	DesignateDBSyncReadyApprovalMessage = "DB sync of image %s waiting for dbSyncApprovedImage"
+
+	// DesignateDBSyncReadyImageTagsMessage
+	DesignateDBSyncReadyImageTagsMessage = "DB sync of image %s waiting for the images of the services to share a release tag: %s"
+
+	// DesignateDBSyncReadyHookMessage
+	DesignateDBSyncReadyHookMessage = "DB sync of image %s waiting for hook %s"
+
+	// DesignateDBSyncReadyHookErrorMessage
+	DesignateDBSyncReadyHookErrorMessage = "DB sync of image %s hook %s error occured %s"
+
+	//
+	// DesignatePredictableIPsReady condition messages
+	//
+	// DesignatePredictableIPsReadyInitMessage
+	DesignatePredictableIPsReadyInitMessage = "Predictable IPs not reserved"
+
+	// DesignatePredictableIPsReadyWaitingMessage
+	DesignatePredictableIPsReadyWaitingMessage = "Waiting for IPSet reservations %s"
+
+	// DesignatePredictableIPsReadyMessage
+	DesignatePredictableIPsReadyMessage = "Predictable IPs reserved"
+
+	// DesignatePredictableIPsReadyErrorMessage
+	DesignatePredictableIPsReadyErrorMessage = "Predictable IPs error occured %s"
+
+	// DesignatePredictableIPsReadyMissingMessage
+	DesignatePredictableIPsReadyMissingMessage = "No predictable IP reserved for pods %s"
+
+	// DesignatePredictableIPsReadyPendingMessage
+	DesignatePredictableIPsReadyPendingMessage = "Waiting for the addresses of pods %s"
+
+	// DesignatePredictableIPsReadyHostNetworkMessage
+	DesignatePredictableIPsReadyHostNetworkMessage = "Using the node addresses of the mdns and bind9 pods, the pools are updated when the pods move to other nodes"
+
+	// DesignatePredictableIPsReadyPodIPMessage
+	DesignatePredictableIPsReadyPodIPMessage = "Using the pod IPs of the mdns and bind9 pods, the pools are updated when the pods are recreated and zone transfers are not restricted to a control network"
+
+	//
+	// DesignatePoolUpdateReady condition messages
+	//
+	// DesignatePoolUpdateReadyInitMessage
+	DesignatePoolUpdateReadyInitMessage = "Pool update not started"
+
+	// DesignatePoolUpdateReadyRunningMessage
+	DesignatePoolUpdateReadyRunningMessage = "Pool update in progress"
+
+	// DesignatePoolUpdateReadyWaitingMessage
+	DesignatePoolUpdateReadyWaitingMessage = "Pool update waiting for %s"
+
+	// DesignatePoolUpdateReadyMessage
+	DesignatePoolUpdateReadyMessage = "Pool update completed"
+
+	// DesignatePoolUpdateReadyErrorMessage
+	DesignatePoolUpdateReadyErrorMessage = "Pool update error occured %s"
+
+	// DesignatePoolUpdateReadyDeletingMessage
+	DesignatePoolUpdateReadyDeletingMessage = "Pool deletion in progress"
+
+	//
+	// DesignateZoneReady condition messages
+	//
+	// DesignateZoneReadyInitMessage
+	DesignateZoneReadyInitMessage = "Zone not created"
+
+	// DesignateZoneReadyWaitingMessage
+	DesignateZoneReadyWaitingMessage = "Zone waiting for %s"
+
+	// DesignateZoneReadyPendingMessage
+	DesignateZoneReadyPendingMessage = "Zone status %s in Designate"
+
+	// DesignateZoneReadyMessage
+	DesignateZoneReadyMessage = "Zone active"
+
+	// DesignateZoneReadyErrorMessage
+	DesignateZoneReadyErrorMessage = "Zone error occured %s"
+
+	//
+	// DesignateRecordSetReady condition messages
+	//
+	// DesignateRecordSetReadyInitMessage
+	DesignateRecordSetReadyInitMessage = "RecordSet not created"
+
+	// DesignateRecordSetReadyWaitingMessage
+	DesignateRecordSetReadyWaitingMessage = "RecordSet waiting for %s"
+
+	// DesignateRecordSetReadyPendingMessage
+	DesignateRecordSetReadyPendingMessage = "RecordSet status %s in Designate"
+
+	// DesignateRecordSetReadyMessage
+	DesignateRecordSetReadyMessage = "RecordSet active"
+
+	// DesignateRecordSetReadyErrorMessage
+	DesignateRecordSetReadyErrorMessage = "RecordSet error occured %s"
+
+	//
+	// DesignateUpdateReady condition messages
+	//
+	// DesignateUpdateReadyInitMessage
+	DesignateUpdateReadyInitMessage = "Update not started"
+
+	// DesignateUpdateReadyRunningMessage
+	DesignateUpdateReadyRunningMessage = "Update waiting for %s"
+
+	// DesignateUpdateReadyPausedMessage
+	DesignateUpdateReadyPausedMessage = "Update paused, %s failed: %s"
+
+	// DesignateUpdateReadyMessage
+	DesignateUpdateReadyMessage = "Services run the images of the spec"
+
+	// DesignateUpdateReadyErrorMessage
+	DesignateUpdateReadyErrorMessage = "Update error occured %s"
+
+	//
+	// DesignateDependenciesReady condition messages
+	//
+	// DesignateDependenciesReadyInitMessage
+	DesignateDependenciesReadyInitMessage = "Dependencies not checked"
+
+	// DesignateDependenciesReadyWaitingMessage
+	DesignateDependenciesReadyWaitingMessage = "Waiting on %s"
+
+	// DesignateDependenciesReadyMessage
+	DesignateDependenciesReadyMessage = "pools.yaml generated and predictable IPs assigned"
+
+	//
+	// DesignateDatabaseRotationReady condition messages
+	//
+	// DesignateDatabaseRotationReadyInitMessage
+	DesignateDatabaseRotationReadyInitMessage = "Database credentials rollout not started"
+
+	// DesignateDatabaseRotationReadyRunningMessage
+	DesignateDatabaseRotationReadyRunningMessage = "Rollout of the database credentials of Secret %s waiting for %s"
+
+	// DesignateDatabaseRotationReadyMessage
+	DesignateDatabaseRotationReadyMessage = "Services run with the database credentials of Secret %s"
+
+	// DesignateDatabaseRotationReadyErrorMessage
+	DesignateDatabaseRotationReadyErrorMessage = "Database credentials rollout error occured %s"
+
+	//
+	// DesignatePreflightReady condition messages
+	//
+	// DesignatePreflightReadyInitMessage
+	DesignatePreflightReadyInitMessage = "Pre-flight checks not started"
+
+	// DesignatePreflightReadyRunningMessage
+	DesignatePreflightReadyRunningMessage = "Pre-flight checks in progress"
+
+	// DesignatePreflightReadyMessage
+	DesignatePreflightReadyMessage = "Pre-flight checks passed"
+
+	// DesignatePreflightReadyErrorMessage
+	DesignatePreflightReadyErrorMessage = "Pre-flight checks failed %s"
+
+	//
+	// DesignateZonesInSync condition messages
+	//
+	// DesignateZonesInSyncMessage
+	DesignateZonesInSyncMessage = "Zones in sync on the bind9 servers"
+
+	// DesignateZonesInSyncLaggingMessage
+	DesignateZonesInSyncLaggingMessage = "Zones behind on the bind9 servers %s"
+
+	// DesignateZonesInSyncErrorMessage
+	DesignateZonesInSyncErrorMessage = "Zone consistency check error occured %s"
+
+	//
+	// DesignateAPIReadOnlyReady condition messages
+	//
+	// DesignateAPIReadOnlyReadyInitMessage
+	DesignateAPIReadOnlyReadyInitMessage = "Read-only API replicas not started"
+
+	// DesignateAPIReadOnlyReadyRunningMessage
+	DesignateAPIReadOnlyReadyRunningMessage = "Read-only API replicas in progress"
+
+	// DesignateAPIReadOnlyReadyMessage
+	DesignateAPIReadOnlyReadyMessage = "Read-only API replicas ready"
+
+	// DesignateAPIReadOnlyReadyErrorMessage
+	DesignateAPIReadOnlyReadyErrorMessage = "Read-only API replicas error occured %s"
+
+	//
+	// DesignateConfigInSync condition messages
+	//
+	// DesignateConfigInSyncInitMessage
+	DesignateConfigInSyncInitMessage = "Config Secrets not checked"
+
+	// DesignateConfigInSyncMessage
+	DesignateConfigInSyncMessage = "Config Secrets in sync"
+
+	// DesignateConfigInSyncDriftMessage
+	DesignateConfigInSyncDriftMessage = "Config Secrets modified out of band %s"
+
+	//
+	// Preview messages
+	//
+	// DesignatePreviewMessage
+	DesignatePreviewMessage = "Preview only, rendered into the objects with the %s suffix, remove the %s annotation to apply it"
 )
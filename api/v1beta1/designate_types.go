@@ -22,6 +22,7 @@ import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -36,8 +37,36 @@ const (
 	// Designate API timeout
 	APITimeout = 120
 
+	// DBSyncPolicyAuto - the database schema is synced as soon as the container image changes
+	DBSyncPolicyAuto = "Auto"
+
+	// DBSyncPolicyManual - the database schema sync of a new container image waits for an approval
+	DBSyncPolicyManual = "Manual"
+
 	// PoolUpdateHash hash
 	PoolUpdateHash = "pool-update"
+
+	// PoolDeleteHash hash
+	PoolDeleteHash = "pool-delete"
+
+	// PreflightHash hash
+	PreflightHash = "preflight"
+
+	// DatabaseAccountHash hash of the credentials of the MariaDBAccount the configuration is rendered with
+	DatabaseAccountHash = "database-account"
+
+	// HookHashPrefix prefix of the hashes of the hook jobs, followed by the name of the hook
+	HookHashPrefix = "hook-"
+
+	// HookStagePreDBSync - the hook runs before the database schema is synced with a new image
+	HookStagePreDBSync = "PreDBSync"
+
+	// HookStagePostDBSync - the hook runs after the database schema is synced with a new image, before
+	// the services are updated to it
+	HookStagePostDBSync = "PostDBSync"
+
+	// HookStagePreBind9Restart - the hook runs before the bind9 servers are restarted with a new image
+	HookStagePreBind9Restart = "PreBind9Restart"
 )
 
 // DesignateAPISpecCore - this version has no containerImage for use with the OpenStackControlplane
@@ -68,9 +97,17 @@ type DesignateSpecCore struct {
 	// DesignateBackendbind9 - Spec definition for the Backendbind9 service of this Designate deployment
 	DesignateBackendbind9 DesignateBackendbind9SpecCore `json:"designateBackendbind9"`
 
+	// +kubebuilder:validation:Optional
+	// DesignateBackendPDNS - Spec definition for the PowerDNS backend service of this Designate deployment
+	DesignateBackendPDNS DesignateBackendPDNSSpecCore `json:"designateBackendPDNS"`
+
 	// +kubebuilder:validation:Optional
 	// DesignateUnbound - Spec definition for the Unbound Resolver service of this Designate deployment
 	DesignateUnbound DesignateUnboundSpecCore `json:"designateUnbound"`
+
+	// +kubebuilder:validation:Optional
+	// DesignateSink - Spec definition for the Sink service of this Designate deployment
+	DesignateSink DesignateSinkSpecCore `json:"designateSink"`
 }
 
 // DesignateAPISpec defines the desired state of DesignateAPI
@@ -101,9 +138,17 @@ type DesignateSpec struct {
 	// DesignateBackendbind9 - Spec definition for the Backendbind9 service of this Designate deployment
 	DesignateBackendbind9 DesignateBackendbind9Spec `json:"designateBackendbind9"`
 
+	// +kubebuilder:validation:Optional
+	// DesignateBackendPDNS - Spec definition for the PowerDNS backend service of this Designate deployment
+	DesignateBackendPDNS DesignateBackendPDNSSpec `json:"designateBackendPDNS"`
+
 	// +kubebuilder:validation:Optional
 	// DesignateUnbound - Spec definition for the Unbound Resolver service of this Designate deployment
 	DesignateUnbound DesignateUnboundSpec `json:"designateUnbound"`
+
+	// +kubebuilder:validation:Optional
+	// DesignateSink - Spec definition for the Sink service of this Designate deployment
+	DesignateSink DesignateSinkSpec `json:"designateSink"`
 }
 
 // DesignateNSRecord defines a DNS nameserver record
@@ -119,6 +164,61 @@ type DesignateNSRecord struct {
 	Priority int `json:"priority"`
 }
 
+// DesignateNotificationsSpec defines the oslo notifications of the designate services
+type DesignateNotificationsSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=messagingv2
+	// +kubebuilder:validation:Enum=messagingv2;messaging;log;noop
+	// Driver - oslo.messaging notification driver, noop disables the notifications
+	Driver string `json:"driver,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// Topics - topics the notifications are sent to, notifications when empty
+	Topics []string `json:"topics,omitempty"`
+}
+
+// DesignateTracingSpec defines the osprofiler tracing of the designate services
+type DesignateTracingSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Endpoint - host:port of the OTLP/HTTP endpoint of the OpenTelemetry collector
+	Endpoint string `json:"endpoint"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// TraceSQLAlchemy - add the database queries to the traces
+	TraceSQLAlchemy bool `json:"traceSQLAlchemy"`
+}
+
+// DesignateDatabaseTuningSpec defines the oslo.db connection pool and retry options of the designate services
+type DesignateDatabaseTuningSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxPoolSize - connections kept open in the pool of each process, 0 for no limit
+	MaxPoolSize *int32 `json:"maxPoolSize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxOverflow - connections opened beyond maxPoolSize under load
+	MaxOverflow *int32 `json:"maxOverflow,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// PoolTimeout - seconds to wait for a connection of the pool before failing
+	PoolTimeout *int32 `json:"poolTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	// DBMaxRetries - retries of a database operation failing on a lost connection or a deadlock, -1 for no limit
+	DBMaxRetries *int32 `json:"dbMaxRetries,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// DBRetryInterval - seconds between the retries of a database operation
+	DBRetryInterval *int32 `json:"dbRetryInterval,omitempty"`
+}
+
 // DesignateSpecBase -
 type DesignateSpecBase struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -129,10 +229,10 @@ type DesignateSpecBase struct {
 	// ServiceUser - optional username used for this service to register in designate
 	ServiceUser string `json:"serviceUser"`
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// MariaDB instance name
-	// Right now required by the maridb-operator to get the credentials from the instance to create the DB
-	// Might not be required in future
+	// Right now required by the maridb-operator to get the credentials from the instance to create the DB,
+	// unless externalDatabase is set
 	DatabaseInstance string `json:"databaseInstance"`
 
 	// +kubebuilder:validation:Optional
@@ -140,6 +240,17 @@ type DesignateSpecBase struct {
 	// DatabaseAccount - name of MariaDBAccount which will be used to connect.
 	DatabaseAccount string `json:"databaseAccount"`
 
+	// +kubebuilder:validation:Optional
+	// ExternalDatabase - MySQL/Galera database managed outside of mariadb-operator, e.g. the existing
+	// database of a brownfield cloud. No MariaDBDatabase nor MariaDBAccount is created for it,
+	// databaseInstance and databaseAccount are ignored. The schema is still synced by the db sync job.
+	ExternalDatabase *DesignateExternalDatabase `json:"externalDatabase,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DatabaseTuning - oslo.db connection pool and retry options of the designate services, e.g. for the large
+	// deployments exhausting the default pool. The options left unset keep the defaults of oslo.db.
+	DatabaseTuning *DesignateDatabaseTuningSpec `json:"databaseTuning,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// RabbitMQ instance name
 	// Needed to request a transportURL that is created and used in Designate
@@ -154,6 +265,15 @@ type DesignateSpecBase struct {
 	// NotificationsBus configuration (cluster, username, and vhost) for notifications
 	NotificationsBus *rabbitmqv1.RabbitMqConfig `json:"notificationsBus,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// Notifications - oslo notifications sent by the designate services, e.g. for the telemetry services. They
+	// are sent on the notificationsBus when it is set, on the messagingBus otherwise.
+	Notifications DesignateNotificationsSpec `json:"notifications,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Tracing - osprofiler tracing of the designate services, exported to an OpenTelemetry collector
+	Tracing *DesignateTracingSpec `json:"tracing,omitempty"`
+
 	// +kubebuilder:validation:Required
 	// Secret containing OpenStack password information for designate AdminPassword
 	Secret string `json:"secret"`
@@ -163,6 +283,12 @@ type DesignateSpecBase struct {
 	// PasswordSelectors - Selectors to identify the DB and AdminUser password from the Secret
 	PasswordSelectors PasswordSelector `json:"passwordSelectors"`
 
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Auth - authentication of the designate services to keystone, e.g. with an application credential
+	// instead of the password of the service user. Used by the services which don't set their own.
+	Auth AuthSpec `json:"auth,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// BackendType - Defines the backend service/configuration we are using, i.e. bind9, unhbound, PowerDNS, BYO, etc..
 	// Helps maintain a single init container/init.sh to do container setup
@@ -184,11 +310,69 @@ type DesignateSpecBase struct {
 	// NodeSelector to target subset of worker nodes running this service
 	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// ImagePullSecrets - Secrets holding the credentials of the registries the images are pulled from, used by
+	// the jobs and by the services not setting their own
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// ImagePullPolicy - pull policy of the containers, used by the jobs and by the services not setting their
+	// own. The Kubernetes default applies when not set.
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExtraMounts - additional volumes mounted in the designate services. The propagation of a volume selects
+	// the services it is mounted in: Designate for all of them, the kind of a service, e.g. DesignateBackendbind9,
+	// or DBSync for the db sync job. They are added to the extraMounts of the services.
+	ExtraMounts []DesignateExtraVolMounts `json:"extraMounts,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	// PreserveJobs - do not delete jobs after they finished e.g. to check logs
 	PreserveJobs bool `json:"preserveJobs,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// PreserveOnDelete - keep the zone data of the bind9 and PowerDNS servers, their volume claims, and the
+	// rndc and PowerDNS API keys when the Designate CR is deleted, to adopt them again with a new Designate CR
+	// of the same name
+	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Revert;Report
+	// ConfigDriftPolicy - what is done when the config Secrets generated for the Designate CR, e.g.
+	// designate-config-data, are modified out of band. With Revert, the default, they are rendered again. With
+	// Report they are kept as they are, without the changes of the spec, and the ConfigInSync condition is set
+	// false until they are restored or deleted. The services have their own policy.
+	ConfigDriftPolicy ConfigDriftPolicy `json:"configDriftPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// PreflightCheck - run a job checking that the database, RabbitMQ, the coordination backend and the
+	// rndc ports of the bind9 servers are reachable before Designate is Ready
+	PreflightCheck bool `json:"preflightCheck,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Auto
+	// +kubebuilder:validation:Enum=Auto;Manual
+	// DBSyncPolicy - with Auto the database schema is synced as soon as the container image changes. With
+	// Manual the schema sync of a new image, and the update of the services to it, wait until the image is
+	// set in dbSyncApprovedImage. The initial sync always runs.
+	DBSyncPolicy string `json:"dbSyncPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DBSyncApprovedImage - the container image the database schema may be synced with when dbSyncPolicy
+	// is Manual
+	DBSyncApprovedImage string `json:"dbSyncApprovedImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// Hooks - Jobs run before or after the database schema is synced with a new image, or before the bind9
+	// servers are restarted with a new image, e.g. to freeze the zones or run rndc sync. The hooks of a
+	// stage run one at a time in the order of the list. They don't run on the initial deployment.
+	Hooks []DesignateHook `json:"hooks,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="# add your customization here"
 	// CustomServiceConfig - customize the service config using this parameter to change service defaults,
@@ -217,6 +401,11 @@ type DesignateSpecBase struct {
 	// RedisServiceName is the name of the Redis instance to be used (must be in the same namespace as designate)
 	RedisServiceName string `json:"redisServiceName"`
 
+	// +kubebuilder:validation:Optional
+	// Coordination - tooz coordination backend of the designate services, not managed by the
+	// operator. When not set, the Redis instance of redisServiceName is used.
+	Coordination DesignateCoordinationSpec `json:"coordination,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=120
 	// Designate API timeout
@@ -231,6 +420,399 @@ type DesignateSpecBase struct {
 	// +listType=atomic
 	// NSRecords contains the list of nameserver records for the Designate pool
 	NSRecords []DesignateNSRecord `json:"nsRecords,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PredictableIPs - configures how the predictable IPs of the mdns and bind9 pods are allocated
+	PredictableIPs PredictableIPSpec `json:"predictableIPs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// ExternalBindServers - BIND servers which are not managed by the operator, added as targets of
+	// the pools next to the DesignateBackendbind9 servers
+	ExternalBindServers []DesignateExternalBindServer `json:"externalBindServers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RndcKeyRotationInterval - when set, the rndc keys of the bind9 servers are rotated
+	// periodically. A rotation can also be requested at any time by setting the
+	// designate.openstack.org/rotate-rndc-keys annotation to a new value.
+	RndcKeyRotationInterval *metav1.Duration `json:"rndcKeyRotationInterval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Telemetry - Prometheus monitoring of the designate services
+	Telemetry DesignateTelemetrySpec `json:"telemetry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkPolicy - restrict the traffic allowed to reach the designate pods
+	NetworkPolicy DesignateNetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ZoneConsistency - periodically compare the serials of the zones in designate with the serials
+	// served by the bind9 servers
+	ZoneConsistency DesignateZoneConsistencySpec `json:"zoneConsistency,omitempty"`
+}
+
+// DesignateZoneConsistencySpec defines the zone consistency check
+type DesignateZoneConsistencySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - query the SOA record of every active zone on each bind9 server, export the lag as the
+	// designate_zone_serial_lag_seconds metric and report the lagging servers in the
+	// DesignateZonesInSync condition
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	// Interval - how often the zones are checked
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	// LagThreshold - how many seconds a bind9 server can be behind before it is reported as lagging.
+	// The serials designate sets are timestamps, their difference is the lag in seconds.
+	LagThreshold int64 `json:"lagThreshold,omitempty"`
+}
+
+// DesignateNetworkPolicySpec defines the NetworkPolicies of the designate services
+type DesignateNetworkPolicySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - create a NetworkPolicy per designate service only admitting the traffic the service
+	// expects: DNS queries to the bind9, PowerDNS and unbound servers, zone transfers from the backends
+	// to mdns, rndc and PowerDNS API calls from the workers and requests to the API. The services
+	// only talking through RabbitMQ don't admit any traffic. NetworkPolicies apply to the pod network,
+	// the mdns and backend traffic on the designate control network is restricted by
+	// MultiNetworkPolicies, which requires the multi-networkpolicy CRD and its enforcement daemon.
+	Enabled bool `json:"enabled"`
+}
+
+// DesignateTelemetrySpec defines the Prometheus monitoring of the designate services
+type DesignateTelemetrySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - enable the bind9 metrics exporters and the Prometheus Operator ServiceMonitors
+	// scraping them. The designate services themselves don't expose Prometheus metrics.
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="30s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// ScrapeInterval - interval at which Prometheus scrapes the metrics
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+}
+
+// DesignateCoordinationSpec defines the coordination backend of the designate services
+// +kubebuilder:validation:XValidation:rule="!(has(self.redisSentinel) && has(self.etcd))",message="only one of redisSentinel and etcd can be set"
+type DesignateCoordinationSpec struct {
+	// +kubebuilder:validation:Optional
+	// RedisSentinel - coordinate through a Redis deployment monitored by Redis Sentinel
+	RedisSentinel *DesignateRedisSentinelSpec `json:"redisSentinel,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Etcd - coordinate through an etcd cluster
+	Etcd *DesignateEtcdSpec `json:"etcd,omitempty"`
+}
+
+// IsExternal returns true if the coordination backend is not the Redis instance of redisServiceName
+func (c DesignateCoordinationSpec) IsExternal() bool {
+	return c.RedisSentinel != nil || c.Etcd != nil
+}
+
+// DesignateEtcdSpec defines an etcd coordination backend. The services use the gRPC gateway
+// of etcd, available from etcd 3.3.
+type DesignateEtcdSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Endpoint - host:port address of the etcd gRPC gateway
+	Endpoint string `json:"endpoint"`
+
+	// +kubebuilder:validation:Optional
+	// TLSSecretName - name of a Secret holding the client certificate and key, tls.crt and
+	// tls.key, and optionally the CA certificate of etcd, ca.crt. When set, the services
+	// connect to etcd with TLS.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// DesignateRedisSentinelSpec defines a coordination backend of Redis servers monitored by Redis
+// Sentinel. The services connect to the master the sentinels report for MasterName.
+type DesignateRedisSentinelSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// Sentinels - host:port addresses of the sentinels, the first one is queried first
+	Sentinels []string `json:"sentinels"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=mymaster
+	// MasterName - name of the master monitored by the sentinels
+	MasterName string `json:"masterName"`
+
+	// +kubebuilder:validation:Optional
+	// PasswordSecret - name of the Secret holding the password of the Redis servers
+	PasswordSecret string `json:"passwordSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=password
+	// PasswordSecretKey - key of PasswordSecret holding the password
+	PasswordSecretKey string `json:"passwordSecretKey"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// TLS - connect to the sentinels and the Redis servers with TLS. Their certificates are
+	// verified with the CA bundle of the designate services, see designateAPI.tls.caBundleSecretName.
+	TLS bool `json:"tls"`
+}
+
+// DesignateExternalDatabase defines a MySQL/Galera database managed outside of mariadb-operator.
+// The database and its user have to exist, the operator only syncs the schema.
+type DesignateExternalDatabase struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Host - address of the database, e.g. the VIP of the Galera cluster
+	Host string `json:"host"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3306
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port - port of the database
+	Port int32 `json:"port"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=designate
+	// DatabaseName - name of the database holding the designate schema
+	DatabaseName string `json:"databaseName"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Secret - name of the Secret holding the credentials of the database user
+	Secret string `json:"secret"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=username
+	// UsernameKey - key of Secret holding the username
+	UsernameKey string `json:"usernameKey"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=password
+	// PasswordKey - key of Secret holding the password
+	PasswordKey string `json:"passwordKey"`
+
+	// +kubebuilder:validation:Optional
+	// CASecret - name of the Secret holding the CA certificate of the database. When set, the
+	// services connect to the database with TLS and verify its certificate.
+	CASecret string `json:"caSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ca.crt
+	// CASecretKey - key of CASecret holding the CA certificate
+	CASecretKey string `json:"caSecretKey"`
+}
+
+// DesignateExternalBindServer defines a BIND server managed outside of the operator. The server has
+// to allow zone transfers from the mdns servers. Servers in a non default pool have to use the
+// shared TSIG key, whose BIND configuration is published in the <backendbind9>-tsig Secret.
+type DesignateExternalBindServer struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name - unique name of the server
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// Host - address of the server
+	Host string `json:"host"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=53
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port - DNS port of the server
+	Port int32 `json:"port"`
+
+	// +kubebuilder:validation:Optional
+	// RNDCHost - address of the rndc control channel, defaults to Host
+	RNDCHost string `json:"rndcHost,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=953
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// RNDCPort - port of the rndc control channel
+	RNDCPort int32 `json:"rndcPort"`
+
+	// +kubebuilder:validation:Required
+	// RNDCKeySecret - name of the Secret holding the rndc key of the server
+	RNDCKeySecret string `json:"rndcKeySecret"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=rndc-key
+	// RNDCKeySecretKey - key of RNDCKeySecret holding the rndc key file content
+	RNDCKeySecretKey string `json:"rndcKeySecretKey"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=default
+	// Pool - name of the pool the server is a target of
+	Pool string `json:"pool"`
+
+	// +kubebuilder:validation:Optional
+	// TSIGKeyName - name of the TSIG key signing the NOTIFY and zone transfer
+	// traffic of the server. TSIG is only configured when TSIGKeySecret is set.
+	TSIGKeyName string `json:"tsigKeyName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=hmac-sha256
+	// +kubebuilder:validation:Enum=hmac-md5;hmac-sha1;hmac-sha224;hmac-sha256;hmac-sha384;hmac-sha512
+	// TSIGKeyAlgorithm - algorithm of the TSIG key
+	TSIGKeyAlgorithm string `json:"tsigKeyAlgorithm,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TSIGKeySecret - name of the Secret holding the base64 encoded secret of the TSIG key
+	TSIGKeySecret string `json:"tsigKeySecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=tsig-key
+	// TSIGKeySecretKey - key of TSIGKeySecret holding the secret of the TSIG key
+	TSIGKeySecretKey string `json:"tsigKeySecretKey,omitempty"`
+}
+
+// HasTSIGKey returns true if the server signs its traffic with a TSIG key
+func (s DesignateExternalBindServer) HasTSIGKey() bool {
+	return s.TSIGKeySecret != ""
+}
+
+// GetTSIGKeyName returns the name of the TSIG key of the server, defaults to the server name
+func (s DesignateExternalBindServer) GetTSIGKeyName() string {
+	if s.TSIGKeyName != "" {
+		return s.TSIGKeyName
+	}
+	return s.Name
+}
+
+// GetRNDCHost returns the address of the rndc control channel of the server
+func (s DesignateExternalBindServer) GetRNDCHost() string {
+	if s.RNDCHost != "" {
+		return s.RNDCHost
+	}
+	return s.Host
+}
+
+// PredictableIPMode - the allocator used for the predictable IPs of the mdns and bind9 pods
+type PredictableIPMode string
+
+const (
+	// PredictableIPModeConfigMap - allocations are tracked by the operator in ConfigMaps, using the
+	// addresses right after the range of the control network NetworkAttachmentDefinition
+	PredictableIPModeConfigMap PredictableIPMode = "ConfigMap"
+
+	// PredictableIPModeIPSet - allocations are requested from infra-operator through IPSet CRs and
+	// are only used once infra-operator has acknowledged the reservation in the IPSet status
+	PredictableIPModeIPSet PredictableIPMode = "IPSet"
+
+	// PredictableIPModeHostNetwork - the mdns and bind9 pods run on the network of their nodes
+	// without a control network attachment, they are reached on the addresses of their nodes
+	PredictableIPModeHostNetwork PredictableIPMode = "HostNetwork"
+
+	// PredictableIPModePodIP - the mdns and bind9 pods are reached on their pod IPs without a control
+	// network attachment, the pools are updated whenever the pods get new addresses
+	PredictableIPModePodIP PredictableIPMode = "PodIP"
+)
+
+// PredictableIPSpec defines how the predictable IPs are allocated
+type PredictableIPSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ConfigMap;IPSet;HostNetwork;PodIP
+	// +kubebuilder:default=ConfigMap
+	// Mode - ConfigMap keeps the allocations in operator managed ConfigMaps. IPSet requests the
+	// allocations from infra-operator, which requires a NetConfig describing the control network.
+	// HostNetwork runs the mdns and bind9 pods on the network of their nodes, for clusters without
+	// Multus, the servers are reached on the addresses of their nodes. PodIP is for clusters without
+	// Multus too, the servers are reached on their pod IPs, which change when the pods are recreated
+	// and trigger a pool update. PowerDNS is not supported in the HostNetwork and PodIP modes.
+	Mode PredictableIPMode `json:"mode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetworkName - name of the network in the NetConfig the IPs are reserved from when Mode is IPSet.
+	// Defaults to the DesignateNetworkAttachment name.
+	NetworkName string `json:"networkName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=subnet1
+	// SubnetName - name of the subnet in the NetConfig network the IPs are reserved from when Mode is IPSet
+	SubnetName string `json:"subnetName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IPv6SubnetName - name of the IPv6 subnet in the NetConfig network the IPs are reserved from when
+	// Mode is IPSet. Required on dual stack networks, SubnetName is then the IPv4 subnet.
+	IPv6SubnetName string `json:"ipv6SubnetName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Exclude - addresses or CIDRs of the control network and of the predictableIPNetworks the predictable
+	// IPs are never allocated from, e.g. gateways, VIPs or addresses used outside of the cluster, in addition
+	// to the exclude ranges of the network attachments. Allocations in them are moved to other addresses.
+	// Only supported in the ConfigMap mode.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// DesignateAppliedPool describes a pool of the pools.yaml applied by Designate
+type DesignateAppliedPool struct {
+	// Name - name of the pool
+	Name string `json:"name"`
+
+	// HiddenPrimary - the bind9 servers of the pool are hidden primaries
+	HiddenPrimary bool `json:"hiddenPrimary,omitempty"`
+
+	// NSRecords - hostnames of the NS records of the zones of the pool
+	NSRecords []string `json:"nsRecords,omitempty"`
+
+	// Nameservers - addresses designate checks the zone changes on
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// Targets - addresses of the servers designate provisions the zones on
+	Targets []string `json:"targets,omitempty"`
+
+	// AlsoNotifies - addresses of the additional servers notified of the zone changes
+	AlsoNotifies []string `json:"alsoNotifies,omitempty"`
+}
+
+// DesignateDatabaseAccountRotation describes a rotation of the credentials of the MariaDBAccount of Designate
+type DesignateDatabaseAccountRotation struct {
+	// Secret - Secret of the MariaDBAccount holding the rotated credentials
+	Secret string `json:"secret"`
+
+	// StartedAt - when the configuration of the services was rendered with the rotated credentials
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// CompletedAt - when all the services were restarted with the rotated credentials
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// DesignateComponentStatus summarizes the status of a sub-CR of Designate
+type DesignateComponentStatus struct {
+	// Kind - kind of the sub-CR, e.g. DesignateAPI
+	Kind string `json:"kind"`
+
+	// Name - name of the sub-CR
+	Name string `json:"name"`
+
+	// Ready - status of the Ready condition of the sub-CR
+	Ready corev1.ConditionStatus `json:"ready"`
+
+	// Replicas - desired replicas of the sub-CR
+	Replicas int32 `json:"replicas"`
+
+	// ReadyCount - ready replicas of the sub-CR
+	ReadyCount int32 `json:"readyCount,omitempty"`
+
+	// ContainerImage - container image of the sub-CR
+	ContainerImage string `json:"containerImage,omitempty"`
+
+	// ImageDigests - digests of the container image the pods of the sub-CR run, more than one while
+	// the pods are updated to a new build
+	ImageDigests []string `json:"imageDigests,omitempty"`
+
+	// Message - message of the Ready condition when it isn't True, e.g. the last error
+	Message string `json:"message,omitempty"`
 }
 
 // DesignateStatus defines the observed state of Designate
@@ -248,6 +830,34 @@ type DesignateStatus struct {
 	// DatabaseHostname - Designate Database Hostname
 	DatabaseHostname string `json:"databaseHostname,omitempty"`
 
+	// DBSyncImage - the container image the database schema was last synced with
+	DBSyncImage string `json:"dbSyncImage,omitempty"`
+
+	// DBSyncPendingImage - the container image waiting for dbSyncApprovedImage before the database
+	// schema is synced with it
+	DBSyncPendingImage string `json:"dbSyncPendingImage,omitempty"`
+
+	// DBSchemaRevision - the database schema revision reported by the last database sync
+	DBSchemaRevision string `json:"dbSchemaRevision,omitempty"`
+
+	// DesignateVersion - the release the services run, the tag of the API image, or its digest when it
+	// is referenced by digest only. It is set once a deployment or a minor update is rolled out to all
+	// the services.
+	DesignateVersion string `json:"designateVersion,omitempty"`
+
+	// DatabaseAccountSecret - the Secret of the MariaDBAccount whose credentials all the services run with
+	DatabaseAccountSecret string `json:"databaseAccountSecret,omitempty"`
+
+	// DatabaseAccountRotation - the last rotation of the credentials of the MariaDBAccount, in progress
+	// until its completedAt is set
+	DatabaseAccountRotation *DesignateDatabaseAccountRotation `json:"databaseAccountRotation,omitempty"`
+
+	// PoolUpdateRevision - the hash of the pools.yaml last applied by the pool update job
+	PoolUpdateRevision string `json:"poolUpdateRevision,omitempty"`
+
+	// Pools - the pools of the pools.yaml last applied by the pool update job
+	Pools []DesignateAppliedPool `json:"pools,omitempty"`
+
 	// TransportURLSecret - Secret containing RabbitMQ transportURL
 	TransportURLSecret string `json:"transportURLSecret,omitempty"`
 
@@ -272,9 +882,18 @@ type DesignateStatus struct {
 	// ReadyCount of Designate Backendbind9 instance
 	DesignateBackendbind9ReadyCount int32 `json:"designateBackendbind9ReadyCount,omitempty"`
 
+	// ReadyCount of Designate BackendPDNS instance
+	DesignateBackendPDNSReadyCount int32 `json:"designateBackendPDNSReadyCount,omitempty"`
+
 	// ReadyCount of Designate Unbound instance
 	DesignateUnboundReadyCount int32 `json:"designateUnboundReadyCount,omitempty"`
 
+	// ReadyCount of Designate Sink instance
+	DesignateSinkReadyCount int32 `json:"designateSinkReadyCount,omitempty"`
+
+	// Components - status of the deployed sub-CRs
+	Components []DesignateComponentStatus `json:"components,omitempty"`
+
 	// ObservedGeneration - the most recent generation observed for this
 	// service. If the observed generation is less than the spec generation,
 	// then the controller has not processed the latest changes injected by
@@ -286,6 +905,9 @@ type DesignateStatus struct {
 
 	// RedisTLS - whether the Redis instance has TLS enabled
 	RedisTLS string `json:"redisTLS,omitempty"`
+
+	// ZoneConsistencyCheckTime - when the zone consistency was last checked
+	ZoneConsistencyCheckTime *metav1.Time `json:"zoneConsistencyCheckTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -315,9 +937,27 @@ func init() {
 	SchemeBuilder.Register(&Designate{}, &DesignateList{})
 }
 
+// GetPredictableIPMode - returns the predictable IP allocator, ConfigMap unless IPSet, HostNetwork or PodIP was requested
+func (instance Designate) GetPredictableIPMode() PredictableIPMode {
+	switch instance.Spec.PredictableIPs.Mode {
+	case PredictableIPModeIPSet, PredictableIPModeHostNetwork, PredictableIPModePodIP:
+		return instance.Spec.PredictableIPs.Mode
+	}
+	return PredictableIPModeConfigMap
+}
+
+// UsesControlNetwork - returns whether the mdns and bind9 pods are reached on predictable IPs of the
+// control network, rather than on the addresses of their nodes or pods
+func (instance Designate) UsesControlNetwork() bool {
+	mode := instance.GetPredictableIPMode()
+	return mode == PredictableIPModeConfigMap || mode == PredictableIPModeIPSet
+}
+
 // IsReady - returns true if all subresources Ready condition is true
 func (instance Designate) IsReady() bool {
 	unboundReady := *instance.Spec.DesignateUnbound.Replicas == 0 || instance.Status.Conditions.IsTrue(DesignateUnboundReadyCondition)
+	pdnsReady := !instance.IsPDNSEnabled() || instance.Status.Conditions.IsTrue(DesignateBackendPDNSReadyCondition)
+	sinkReady := !instance.IsSinkEnabled() || instance.Status.Conditions.IsTrue(DesignateSinkReadyCondition)
 
 	return instance.Status.Conditions.IsTrue(DesignateAPIReadyCondition) &&
 		instance.Status.Conditions.IsTrue(DesignateCentralReadyCondition) &&
@@ -325,7 +965,24 @@ func (instance Designate) IsReady() bool {
 		instance.Status.Conditions.IsTrue(DesignateMdnsReadyCondition) &&
 		instance.Status.Conditions.IsTrue(DesignateProducerReadyCondition) &&
 		instance.Status.Conditions.IsTrue(DesignateBackendbind9ReadyCondition) &&
-		unboundReady
+		unboundReady &&
+		pdnsReady &&
+		sinkReady
+}
+
+// IsPDNSEnabled - returns true if PowerDNS backend servers are requested
+func (instance Designate) IsPDNSEnabled() bool {
+	return instance.Spec.DesignateBackendPDNS.Replicas != nil && *instance.Spec.DesignateBackendPDNS.Replicas > 0
+}
+
+// UsesExternalDatabase - returns true if designate uses a database not managed by mariadb-operator
+func (instance Designate) UsesExternalDatabase() bool {
+	return instance.Spec.ExternalDatabase != nil
+}
+
+// IsSinkEnabled - returns true if designate-sink is requested
+func (instance Designate) IsSinkEnabled() bool {
+	return instance.Spec.DesignateSink.Replicas != nil && *instance.Spec.DesignateSink.Replicas > 0
 }
 
 // SetupDefaults - initializes any CRD field defaults based on environment variables (the defaulting mechanism itself is implemented via webhooks)
@@ -339,6 +996,9 @@ func SetupDefaults() {
 		WorkerContainerImageURL:       util.GetEnvVar("RELATED_IMAGE_DESIGNATE_WORKER_IMAGE_URL_DEFAULT", DesignateWorkerContainerImage),
 		UnboundContainerImageURL:      util.GetEnvVar("RELATED_IMAGE_DESIGNATE_UNBOUND_IMAGE_URL_DEFAULT", DesignateUnboundContainerImage),
 		Backendbind9ContainerImageURL: util.GetEnvVar("RELATED_IMAGE_DESIGNATE_BACKENDBIND9_IMAGE_URL_DEFAULT", DesignateBackendbind9ContainerImage),
+		BackendPDNSContainerImageURL:  util.GetEnvVar("RELATED_IMAGE_DESIGNATE_BACKENDPDNS_IMAGE_URL_DEFAULT", DesignateBackendPDNSContainerImage),
+		SinkContainerImageURL:         util.GetEnvVar("RELATED_IMAGE_DESIGNATE_SINK_IMAGE_URL_DEFAULT", DesignateSinkContainerImage),
+		Bind9ExporterURL:              util.GetEnvVar("RELATED_IMAGE_DESIGNATE_BIND9_EXPORTER_IMAGE_URL_DEFAULT", Bind9ExporterContainerImage),
 		NetUtilsURL:                   util.GetEnvVar("RELATED_IMAGE_NET_UTILS_IMAGE_URL_DEFAULT", NetUtilsContainerImage),
 		DesignateAPIRouteTimeout:      APITimeout,
 	}
@@ -346,6 +1006,31 @@ func SetupDefaults() {
 	SetupDesignateDefaults(designateDefaults)
 }
 
+// DesignateHook is a Job run during the updates
+type DesignateHook struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name - name of the hook, its Job is named after the Designate CR
+	// and the hook, <designate>-hook-<name>
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=PreDBSync;PostDBSync;PreBind9Restart
+	// Stage - PreDBSync runs the Job before the database schema is synced with a new image, PostDBSync
+	// after it, before the services are updated. PreBind9Restart runs it before the bind9 servers are
+	// restarted with a new image.
+	Stage string `json:"stage"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Job - spec of the Job. The new image of the stage is set in the DESIGNATE_HOOK_IMAGE environment
+	// variable of its containers, the Job runs once per image. The update waits until the Job succeeded,
+	// a failed Job holds it until the hook is fixed.
+	Job batchv1.JobSpec `json:"job"`
+}
+
 // DesignateExtraVolMounts exposes additional parameters processed by the designate-operator
 // and defines the common VolMounts structure provided by the main storage module
 type DesignateExtraVolMounts struct {
@@ -354,6 +1039,9 @@ type DesignateExtraVolMounts struct {
 	// +kubebuilder:validation:Optional
 	Region string `json:"region,omitempty"`
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// VolMounts - the volumes and their mounts, the volumes are converted to core volumes
 	VolMounts []storage.VolMounts `json:"extraVol"`
 }
 
@@ -23,6 +23,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"net/netip"
 
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
@@ -44,7 +45,10 @@ type DesignateDefaults struct {
 	ProducerContainerImageURL     string
 	WorkerContainerImageURL       string
 	Backendbind9ContainerImageURL string
+	BackendPDNSContainerImageURL  string
+	SinkContainerImageURL         string
 	UnboundContainerImageURL      string
+	Bind9ExporterURL              string
 	NetUtilsURL                   string
 	DesignateAPIRouteTimeout      int
 }
@@ -71,33 +75,15 @@ func (r *Designate) Default() {
 // Default  set defaults for this Designate spec
 func (spec *DesignateSpec) Default() {
 	spec.DesignateSpecBase.Default()
-	if spec.DesignateAPI.ContainerImage == "" {
-		spec.DesignateAPI.ContainerImage = designateDefaults.APIContainerImageURL
-	}
-	if spec.DesignateCentral.ContainerImage == "" {
-		spec.DesignateCentral.ContainerImage = designateDefaults.CentralContainerImageURL
-	}
-	if spec.DesignateMdns.ContainerImage == "" {
-		spec.DesignateMdns.ContainerImage = designateDefaults.MdnsContainerImageURL
-	}
-	if spec.DesignateMdns.NetUtilsImage == "" {
-		spec.DesignateMdns.NetUtilsImage = designateDefaults.NetUtilsURL
-	}
-	if spec.DesignateProducer.ContainerImage == "" {
-		spec.DesignateProducer.ContainerImage = designateDefaults.ProducerContainerImageURL
-	}
-	if spec.DesignateWorker.ContainerImage == "" {
-		spec.DesignateWorker.ContainerImage = designateDefaults.WorkerContainerImageURL
-	}
-	if spec.DesignateBackendbind9.ContainerImage == "" {
-		spec.DesignateBackendbind9.ContainerImage = designateDefaults.Backendbind9ContainerImageURL
-	}
-	if spec.DesignateBackendbind9.NetUtilsImage == "" {
-		spec.DesignateBackendbind9.NetUtilsImage = designateDefaults.NetUtilsURL
-	}
-	if spec.DesignateUnbound.ContainerImage == "" {
-		spec.DesignateUnbound.ContainerImage = designateDefaults.UnboundContainerImageURL
-	}
+	spec.DesignateAPI.Default()
+	spec.DesignateCentral.Default()
+	spec.DesignateMdns.Default()
+	spec.DesignateProducer.Default()
+	spec.DesignateWorker.Default()
+	spec.DesignateBackendbind9.Default()
+	spec.DesignateBackendPDNS.Default()
+	spec.DesignateUnbound.Default()
+	spec.DesignateSink.Default()
 }
 
 func (spec *DesignateSpecBase) Default() {
@@ -157,6 +143,24 @@ func (spec *DesignateSpecBase) validateDeprecatedFieldsUpdate(old DesignateSpecB
 	return common_webhook.ValidateDeprecatedFieldsUpdate(deprecatedFields, basePath)
 }
 
+// ValidateDatabase - Returns an ErrorList if neither databaseInstance nor
+// externalDatabase is set, or if externalDatabase is added or removed on
+// update, old is nil on create. The data isn't migrated between databases.
+func (spec *DesignateSpecBase) ValidateDatabase(basePath *field.Path, old *DesignateSpecBase) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.ExternalDatabase == nil && spec.DatabaseInstance == "" {
+		allErrs = append(allErrs, field.Required(basePath.Child("databaseInstance"),
+			"databaseInstance is required unless externalDatabase is set"))
+	}
+	if old != nil && (old.ExternalDatabase == nil) != (spec.ExternalDatabase == nil) {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("externalDatabase"),
+			"externalDatabase can't be added or removed, the data isn't migrated between databases"))
+	}
+
+	return allErrs
+}
+
 var _ webhook.Validator = &Designate{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
@@ -191,8 +195,12 @@ func (r *DesignateSpec) ValidateCreate(basePath *field.Path, namespace string) (
 	warns, errs := r.DesignateSpecBase.validateDeprecatedFieldsCreate(basePath)
 	allWarns = append(allWarns, warns...)
 	allErrs = append(allErrs, errs...)
+	allErrs = append(allErrs, r.ValidateDatabase(basePath, nil)...)
 
 	allErrs = append(allErrs, r.ValidateDesignateTopology(basePath, namespace)...)
+	allErrs = append(allErrs, r.ValidateDesignateNetworks(basePath)...)
+	allErrs = append(allErrs, r.DesignateUnbound.ValidateUnboundConfig(basePath.Child("designateUnbound"))...)
+	allErrs = append(allErrs, r.DesignateSink.ValidateNotificationHandlers(basePath.Child("designateSink"))...)
 
 	// validate the service override key is valid
 	allErrs = append(allErrs, service.ValidateRoutedOverrides(
@@ -210,8 +218,12 @@ func (r *DesignateSpecCore) ValidateCreate(basePath *field.Path, namespace strin
 	warns, errs := r.DesignateSpecBase.validateDeprecatedFieldsCreate(basePath)
 	allWarns = append(allWarns, warns...)
 	allErrs = append(allErrs, errs...)
+	allErrs = append(allErrs, r.ValidateDatabase(basePath, nil)...)
 
 	allErrs = append(allErrs, r.ValidateDesignateTopology(basePath, namespace)...)
+	allErrs = append(allErrs, r.ValidateDesignateNetworks(basePath)...)
+	allErrs = append(allErrs, r.DesignateUnbound.ValidateUnboundConfig(basePath.Child("designateUnbound"))...)
+	allErrs = append(allErrs, r.DesignateSink.ValidateNotificationHandlers(basePath.Child("designateSink"))...)
 
 	// validate the service override key is valid
 	allErrs = append(allErrs, service.ValidateRoutedOverrides(
@@ -258,8 +270,12 @@ func (r *DesignateSpec) ValidateUpdate(old DesignateSpec, basePath *field.Path,
 	warns, errs := r.DesignateSpecBase.validateDeprecatedFieldsUpdate(old.DesignateSpecBase, basePath)
 	allWarns = append(allWarns, warns...)
 	allErrs = append(allErrs, errs...)
+	allErrs = append(allErrs, r.ValidateDatabase(basePath, &old.DesignateSpecBase)...)
 
 	allErrs = append(allErrs, r.ValidateDesignateTopology(basePath, namespace)...)
+	allErrs = append(allErrs, r.ValidateDesignateNetworks(basePath)...)
+	allErrs = append(allErrs, r.DesignateUnbound.ValidateUnboundConfig(basePath.Child("designateUnbound"))...)
+	allErrs = append(allErrs, r.DesignateSink.ValidateNotificationHandlers(basePath.Child("designateSink"))...)
 	// validate the service override key is valid
 	allErrs = append(allErrs, service.ValidateRoutedOverrides(
 		basePath.Child("designateAPI").Child("override").Child("service"),
@@ -276,8 +292,12 @@ func (r *DesignateSpecCore) ValidateUpdate(old DesignateSpecCore, basePath *fiel
 	warns, errs := r.DesignateSpecBase.validateDeprecatedFieldsUpdate(old.DesignateSpecBase, basePath)
 	allWarns = append(allWarns, warns...)
 	allErrs = append(allErrs, errs...)
+	allErrs = append(allErrs, r.ValidateDatabase(basePath, &old.DesignateSpecBase)...)
 
 	allErrs = append(allErrs, r.ValidateDesignateTopology(basePath, namespace)...)
+	allErrs = append(allErrs, r.ValidateDesignateNetworks(basePath)...)
+	allErrs = append(allErrs, r.DesignateUnbound.ValidateUnboundConfig(basePath.Child("designateUnbound"))...)
+	allErrs = append(allErrs, r.DesignateSink.ValidateNotificationHandlers(basePath.Child("designateSink"))...)
 	// validate the service override key is valid
 	allErrs = append(allErrs, service.ValidateRoutedOverrides(
 		basePath.Child("designateAPI").Child("override").Child("service"),
@@ -348,6 +368,12 @@ func (spec *DesignateSpec) ValidateDesignateTopology(basePath *field.Path, names
 	allErrs = append(allErrs,
 		spec.DesignateBackendbind9.ValidateTopology(bind9Path, namespace)...)
 
+	// When a TopologyRef CR is referenced with an override to DesignateBackendPDNS
+	// fail if a different Namespace is referenced because not supported
+	pdnsPath := basePath.Child("designateBackendPDNS")
+	allErrs = append(allErrs,
+		spec.DesignateBackendPDNS.ValidateTopology(pdnsPath, namespace)...)
+
 	// When a TopologyRef CR is referenced with an override to an instance of
 	// DesignateCentral, fail if a different Namespace is referenced because not
 	// supported
@@ -369,6 +395,13 @@ func (spec *DesignateSpec) ValidateDesignateTopology(basePath *field.Path, names
 	allErrs = append(allErrs,
 		spec.DesignateProducer.ValidateTopology(prodPath, namespace)...)
 
+	// When a TopologyRef CR is referenced with an override to an instance of
+	// DesignateSink, fail if a different Namespace is referenced because not
+	// supported
+	sinkPath := basePath.Child("designateSink")
+	allErrs = append(allErrs,
+		spec.DesignateSink.ValidateTopology(sinkPath, namespace)...)
+
 	// When a TopologyRef CR is referenced with an override to an instance of
 	// DesignateUnbound, fail if a different Namespace is referenced because not
 	// supported
@@ -408,6 +441,12 @@ func (spec *DesignateSpecCore) ValidateDesignateTopology(basePath *field.Path, n
 	allErrs = append(allErrs,
 		spec.DesignateBackendbind9.ValidateTopology(bind9Path, namespace)...)
 
+	// When a TopologyRef CR is referenced with an override to DesignateBackendPDNS
+	// fail if a different Namespace is referenced because not supported
+	pdnsPath := basePath.Child("designateBackendPDNS")
+	allErrs = append(allErrs,
+		spec.DesignateBackendPDNS.ValidateTopology(pdnsPath, namespace)...)
+
 	// When a TopologyRef CR is referenced with an override to an instance of
 	// DesignateCentral, fail if a different Namespace is referenced because not
 	// supported
@@ -429,6 +468,13 @@ func (spec *DesignateSpecCore) ValidateDesignateTopology(basePath *field.Path, n
 	allErrs = append(allErrs,
 		spec.DesignateProducer.ValidateTopology(prodPath, namespace)...)
 
+	// When a TopologyRef CR is referenced with an override to an instance of
+	// DesignateSink, fail if a different Namespace is referenced because not
+	// supported
+	sinkPath := basePath.Child("designateSink")
+	allErrs = append(allErrs,
+		spec.DesignateSink.ValidateTopology(sinkPath, namespace)...)
+
 	// When a TopologyRef CR is referenced with an override to an instance of
 	// DesignateUnbound, fail if a different Namespace is referenced because not
 	// supported
@@ -445,3 +491,196 @@ func (spec *DesignateSpecCore) ValidateDesignateTopology(basePath *field.Path, n
 
 	return allErrs
 }
+
+// controlNetworkName returns the control network used by the services when
+// their ControlNetworkName is not set
+func (spec *DesignateSpecBase) controlNetworkName(controlNetworkName string) string {
+	if controlNetworkName != "" {
+		return controlNetworkName
+	}
+	if spec.DesignateNetworkAttachment != "" {
+		return spec.DesignateNetworkAttachment
+	}
+	return "designate"
+}
+
+// validatePDNSControlNetwork returns an ErrorList if PowerDNS servers are
+// requested in the HostNetwork or PodIP predictable IP modes, they need
+// addresses on the control network
+func (spec *DesignateSpecBase) validatePDNSControlNetwork(path *field.Path, pdnsReplicas *int32) field.ErrorList {
+	if pdnsReplicas == nil || *pdnsReplicas == 0 {
+		return nil
+	}
+	if spec.PredictableIPs.Mode != PredictableIPModeHostNetwork && spec.PredictableIPs.Mode != PredictableIPModePodIP {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, spec.PredictableIPs.Mode,
+		"designateBackendPDNS requires a control network")}
+}
+
+// validateNetworkPredictableIPs returns an ErrorList if the mdns or bind9
+// servers get predictable IPs on other networks than the control network in
+// another predictable IP mode than ConfigMap
+func (spec *DesignateSpecBase) validateNetworkPredictableIPs(path *field.Path, networks ...[]string) field.ErrorList {
+	if spec.PredictableIPs.Mode == "" || spec.PredictableIPs.Mode == PredictableIPModeConfigMap {
+		return nil
+	}
+	for _, n := range networks {
+		if len(n) > 0 {
+			return field.ErrorList{field.Invalid(path, spec.PredictableIPs.Mode,
+				"predictableIPNetworks are only supported in the ConfigMap mode")}
+		}
+	}
+	return nil
+}
+
+// validatePredictableIPExclude returns an ErrorList if an address excluded
+// from the predictable IPs is neither an address nor a CIDR, or if addresses
+// are excluded in another predictable IP mode than ConfigMap
+func (spec *DesignateSpecBase) validatePredictableIPExclude(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, value := range spec.PredictableIPs.Exclude {
+		if _, err := netip.ParseAddr(value); err == nil {
+			continue
+		}
+		if _, err := netip.ParsePrefix(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), value, "must be an IP address or a CIDR"))
+		}
+	}
+	mode := spec.PredictableIPs.Mode
+	if len(spec.PredictableIPs.Exclude) > 0 && mode != "" && mode != PredictableIPModeConfigMap {
+		allErrs = append(allErrs, field.Invalid(path, spec.PredictableIPs.Exclude,
+			"excluded addresses are only supported in the ConfigMap mode"))
+	}
+	return allErrs
+}
+
+// validateStaticIPs returns an ErrorList if the static IPs of the mdns and
+// bind9 servers are invalid or pinned twice, or if they are set in a
+// predictable IP mode without addresses on the control network
+func (spec *DesignateSpecBase) validateStaticIPs(basePath *field.Path, mdnsStaticIPs map[string]string, bind9StaticIPs map[string]string) field.ErrorList {
+	if len(mdnsStaticIPs) == 0 && len(bind9StaticIPs) == 0 {
+		return nil
+	}
+	if spec.PredictableIPs.Mode == PredictableIPModeHostNetwork || spec.PredictableIPs.Mode == PredictableIPModePodIP {
+		return field.ErrorList{field.Invalid(basePath.Child("predictableIPs", "mode"), spec.PredictableIPs.Mode,
+			"staticIPs require a control network")}
+	}
+	used := map[string]bool{}
+	allErrs := ValidateStaticIPs(basePath.Child("designateMdns", "staticIPs"), mdnsStaticIPs, used)
+	return append(allErrs, ValidateStaticIPs(basePath.Child("designateBackendbind9", "staticIPs"), bind9StaticIPs, used)...)
+}
+
+// ValidateDesignateNetworks - Returns an ErrorList if the control network of
+// the mdns and backend services is not one of their network attachments, if
+// the storage requested by the backends is not a valid quantity, if the bind9
+// views, secondary servers, networks with predictable IPs, static IPs or
+// excluded addresses are invalid or if PowerDNS is requested without a
+// control network
+func (spec *DesignateSpec) ValidateDesignateNetworks(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	mdnsPath := basePath.Child("designateMdns")
+	allErrs = append(allErrs, spec.DesignateMdns.ValidateControlNetworkName(
+		mdnsPath.Child("controlNetworkName"),
+		spec.controlNetworkName(spec.DesignateMdns.ControlNetworkName))...)
+	allErrs = append(allErrs, spec.DesignateMdns.ValidatePredictableIPNetworks(
+		mdnsPath.Child("predictableIPNetworks"),
+		spec.controlNetworkName(spec.DesignateMdns.ControlNetworkName),
+		spec.DesignateMdns.PredictableIPNetworks)...)
+
+	bind9Path := basePath.Child("designateBackendbind9")
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateControlNetworkName(
+		bind9Path.Child("controlNetworkName"),
+		spec.controlNetworkName(spec.DesignateBackendbind9.ControlNetworkName))...)
+	allErrs = append(allErrs, ValidateStorageRequest(
+		bind9Path.Child("storageRequest"), spec.DesignateBackendbind9.StorageRequest)...)
+	allErrs = append(allErrs, ValidatePVCRetentionPolicy(
+		bind9Path.Child("persistentVolumeClaimRetentionPolicy"),
+		spec.DesignateBackendbind9.PersistentVolumeClaimRetentionPolicy)...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateViews(bind9Path.Child("views"))...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateSecondaryServers(bind9Path.Child("secondaryServers"))...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateHiddenPrimary(bind9Path.Child("hiddenPrimary"))...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidatePredictableIPNetworks(
+		bind9Path.Child("predictableIPNetworks"),
+		spec.controlNetworkName(spec.DesignateBackendbind9.ControlNetworkName),
+		spec.DesignateBackendbind9.PredictableIPNetworks)...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateQueryNetworkName(bind9Path.Child("queryNetworkName"))...)
+
+	pdnsPath := basePath.Child("designateBackendPDNS")
+	allErrs = append(allErrs, spec.DesignateBackendPDNS.ValidateControlNetworkName(
+		pdnsPath.Child("controlNetworkName"),
+		spec.controlNetworkName(spec.DesignateBackendPDNS.ControlNetworkName))...)
+	allErrs = append(allErrs, ValidateStorageRequest(
+		pdnsPath.Child("storageRequest"), spec.DesignateBackendPDNS.StorageRequest)...)
+	allErrs = append(allErrs, ValidatePVCRetentionPolicy(
+		pdnsPath.Child("persistentVolumeClaimRetentionPolicy"),
+		spec.DesignateBackendPDNS.PersistentVolumeClaimRetentionPolicy)...)
+	allErrs = append(allErrs, spec.validatePDNSControlNetwork(
+		basePath.Child("predictableIPs", "mode"), spec.DesignateBackendPDNS.Replicas)...)
+	allErrs = append(allErrs, spec.validateNetworkPredictableIPs(
+		basePath.Child("predictableIPs", "mode"),
+		spec.DesignateMdns.PredictableIPNetworks, spec.DesignateBackendbind9.PredictableIPNetworks)...)
+	allErrs = append(allErrs, spec.validateStaticIPs(
+		basePath, spec.DesignateMdns.StaticIPs, spec.DesignateBackendbind9.StaticIPs)...)
+	allErrs = append(allErrs, spec.validatePredictableIPExclude(basePath.Child("predictableIPs", "exclude"))...)
+
+	return allErrs
+}
+
+// ValidateDesignateNetworks - Returns an ErrorList if the control network of
+// the mdns and backend services is not one of their network attachments, if
+// the storage requested by the backends is not a valid quantity, if the bind9
+// views, secondary servers, networks with predictable IPs, static IPs or
+// excluded addresses are invalid or if PowerDNS is requested without a
+// control network
+func (spec *DesignateSpecCore) ValidateDesignateNetworks(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	mdnsPath := basePath.Child("designateMdns")
+	allErrs = append(allErrs, spec.DesignateMdns.ValidateControlNetworkName(
+		mdnsPath.Child("controlNetworkName"),
+		spec.controlNetworkName(spec.DesignateMdns.ControlNetworkName))...)
+	allErrs = append(allErrs, spec.DesignateMdns.ValidatePredictableIPNetworks(
+		mdnsPath.Child("predictableIPNetworks"),
+		spec.controlNetworkName(spec.DesignateMdns.ControlNetworkName),
+		spec.DesignateMdns.PredictableIPNetworks)...)
+
+	bind9Path := basePath.Child("designateBackendbind9")
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateControlNetworkName(
+		bind9Path.Child("controlNetworkName"),
+		spec.controlNetworkName(spec.DesignateBackendbind9.ControlNetworkName))...)
+	allErrs = append(allErrs, ValidateStorageRequest(
+		bind9Path.Child("storageRequest"), spec.DesignateBackendbind9.StorageRequest)...)
+	allErrs = append(allErrs, ValidatePVCRetentionPolicy(
+		bind9Path.Child("persistentVolumeClaimRetentionPolicy"),
+		spec.DesignateBackendbind9.PersistentVolumeClaimRetentionPolicy)...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateViews(bind9Path.Child("views"))...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateSecondaryServers(bind9Path.Child("secondaryServers"))...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateHiddenPrimary(bind9Path.Child("hiddenPrimary"))...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidatePredictableIPNetworks(
+		bind9Path.Child("predictableIPNetworks"),
+		spec.controlNetworkName(spec.DesignateBackendbind9.ControlNetworkName),
+		spec.DesignateBackendbind9.PredictableIPNetworks)...)
+	allErrs = append(allErrs, spec.DesignateBackendbind9.ValidateQueryNetworkName(bind9Path.Child("queryNetworkName"))...)
+
+	pdnsPath := basePath.Child("designateBackendPDNS")
+	allErrs = append(allErrs, spec.DesignateBackendPDNS.ValidateControlNetworkName(
+		pdnsPath.Child("controlNetworkName"),
+		spec.controlNetworkName(spec.DesignateBackendPDNS.ControlNetworkName))...)
+	allErrs = append(allErrs, ValidateStorageRequest(
+		pdnsPath.Child("storageRequest"), spec.DesignateBackendPDNS.StorageRequest)...)
+	allErrs = append(allErrs, ValidatePVCRetentionPolicy(
+		pdnsPath.Child("persistentVolumeClaimRetentionPolicy"),
+		spec.DesignateBackendPDNS.PersistentVolumeClaimRetentionPolicy)...)
+	allErrs = append(allErrs, spec.validatePDNSControlNetwork(
+		basePath.Child("predictableIPs", "mode"), spec.DesignateBackendPDNS.Replicas)...)
+	allErrs = append(allErrs, spec.validateNetworkPredictableIPs(
+		basePath.Child("predictableIPs", "mode"),
+		spec.DesignateMdns.PredictableIPNetworks, spec.DesignateBackendbind9.PredictableIPNetworks)...)
+	allErrs = append(allErrs, spec.validateStaticIPs(
+		basePath, spec.DesignateMdns.StaticIPs, spec.DesignateBackendbind9.StaticIPs)...)
+	allErrs = append(allErrs, spec.validatePredictableIPExclude(basePath.Child("predictableIPs", "exclude"))...)
+
+	return allErrs
+}
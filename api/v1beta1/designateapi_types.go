@@ -57,9 +57,15 @@ type DesignateAPISpecBase struct {
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Maximum=32
 	// +kubebuilder:validation:Minimum=0
-	// Replicas - Designate API Replicas
+	// +nullable
+	// Replicas - Designate API Replicas. When null the replicas are not managed by the
+	// operator and are kept as they are, e.g. scaled with kubectl scale.
 	Replicas *int32 `json:"replicas"`
 
+	// +kubebuilder:validation:Optional
+	// Autoscaling - scale the Designate API replicas with a HorizontalPodAutoscaler instead of Replicas
+	Autoscaling *DesignateAutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// DatabaseHostname - Designate Database Hostname
 	DatabaseHostname string `json:"databaseHostname,omitempty"`
@@ -88,6 +94,118 @@ type DesignateAPISpecBase struct {
 	// +kubebuilder:validation:Optional
 	// APITimeout for HAProxy and Apache defaults to DesignateSpecCore APITimeout (seconds)
 	APITimeout int `json:"apiTimeout"`
+
+	// +kubebuilder:validation:Optional
+	// APIPolicyOverride - oslo.policy rules of the API overriding the defaults of designate, e.g. to customize
+	// who can manage the zones and the recordsets. A change of the rules restarts the API pods.
+	APIPolicyOverride *APIPolicyOverrideSpec `json:"apiPolicyOverride,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CORS - cross-origin resource sharing of the API, for the web applications served from other origins
+	CORS *APICORSSpec `json:"cors,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxRequestBodySize - maximum size in bytes of the body of the API requests, httpd rejects the larger
+	// ones. 0 keeps the limit of httpd.
+	MaxRequestBodySize int64 `json:"maxRequestBodySize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// WSGI - mod_wsgi daemon processes serving each endpoint of the API in every pod, to size them for the
+	// large zone lists and exports together with the replicas
+	WSGI *APIWSGISpec `json:"wsgi,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// InternalOnly - only expose the internal endpoint of the API, for clouds where designate is consumed
+	// by the internal services only, e.g. the DNS integration of neutron and nova. Neither the public
+	// service nor the public keystone endpoint are created.
+	InternalOnly bool `json:"internalOnly,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Region - keystone region of the API. Defaults to the region of the KeystoneAPI. The URLs of the endpoints
+	// are set with override.service.<endpoint>.endpointURL. A namespace serves a single DesignateAPI, the first
+	// one created, whatever their regions.
+	Region string `json:"region,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ReadOnly - additional API replicas reading from a read replica of the database with the slave_connection
+	// of designate, e.g. for the large zone lists and exports, so they don't load the primary database. They are
+	// exposed by their own <name>-readonly Service and aren't registered in keystone.
+	ReadOnly *APIReadOnlySpec `json:"readOnly,omitempty"`
+}
+
+// APIReadOnlySpec defines the designate API replicas reading from a read replica of the database
+type APIReadOnlySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Maximum=32
+	// +kubebuilder:validation:Minimum=0
+	// Replicas - number of read-only API replicas
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// DatabaseHostname - hostname of the read replica of the database, reached with the credentials and the
+	// TLS settings of the primary database
+	DatabaseHostname string `json:"databaseHostname"`
+}
+
+// APIWSGISpec defines the mod_wsgi daemon processes serving the designate API
+type APIWSGISpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// Processes - number of daemon processes of each endpoint in each API pod
+	Processes int32 `json:"processes"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// Threads - number of threads of each daemon process
+	Threads int32 `json:"threads"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// RequestTimeout - time in seconds after which a daemon process stuck on a request is restarted.
+	// 0 keeps the processes running.
+	RequestTimeout int32 `json:"requestTimeout,omitempty"`
+}
+
+// APICORSSpec defines the cross-origin resource sharing of the designate API
+type APICORSSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// AllowedOrigins - origins allowed to send requests to the API, e.g. https://dashboard.example.org
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// AllowCredentials - let the requests of the allowed origins carry credentials
+	AllowCredentials bool `json:"allowCredentials"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=0
+	// MaxAge - time in seconds the browsers cache the result of a preflight request
+	MaxAge int32 `json:"maxAge"`
+}
+
+// APIPolicyOverrideSpec defines the policy file of the designate API, inline or in a ConfigMap
+// +kubebuilder:validation:XValidation:rule="has(self.policy) != has(self.configMapName)",message="exactly one of policy and configMapName must be set"
+type APIPolicyOverrideSpec struct {
+	// +kubebuilder:validation:Optional
+	// Policy - the rules in the policy.yaml format
+	Policy string `json:"policy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ConfigMapName - ConfigMap holding the rules in the policy.yaml format
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="policy.yaml"
+	// Key - key of the rules in the ConfigMap
+	Key string `json:"key"`
 }
 
 // APIOverrideSpec to override the generated manifest of several child resources.
@@ -110,6 +228,10 @@ type DesignateAPIStatus struct {
 	// Map of hashes to track e.g. job status
 	Hash map[string]string `json:"hash,omitempty"`
 
+	// InputHashes - the hash of every input of the pods, e.g. a mounted Secret, the pods
+	// restart when one of them changes
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
 	// API endpoints
 	APIEndpoints map[string]map[string]string `json:"apiEndpoints,omitempty"`
 
@@ -119,6 +241,12 @@ type DesignateAPIStatus struct {
 	// ReadyCount of designate API instances
 	ReadyCount int32 `json:"readyCount,omitempty"`
 
+	// Selector - label selector of the pods, used by the scale subresource
+	Selector string `json:"selector,omitempty"`
+
+	// ReadOnlyReadyCount of read-only designate API instances
+	ReadOnlyReadyCount int32 `json:"readOnlyReadyCount,omitempty"`
+
 	// NetworkAttachments status of the deployment pods
 	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
 
@@ -134,9 +262,14 @@ type DesignateAPIStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.readyCount,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas",description="Replicas"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyCount",description="Ready replicas"
 //+kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.containerImage",description="Image",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // DesignateAPI is the Schema for the designateapis API
 type DesignateAPI struct {
@@ -162,6 +295,16 @@ func init() {
 
 // IsReady - returns true if service is ready to serve requests
 func (instance DesignateAPI) IsReady() bool {
+	// the HorizontalPodAutoscaler owns the replicas, the service is ready
+	// once the minimum number of replicas is ready
+	if instance.Spec.Autoscaling != nil {
+		return instance.Status.ReadyCount >= instance.Spec.Autoscaling.GetMinReplicas()
+	}
+	// the replicas are managed externally, the service is ready once
+	// its Deployment is
+	if instance.Spec.Replicas == nil {
+		return instance.Status.Conditions.IsTrue(condition.DeploymentReadyCondition)
+	}
 	return instance.Status.ReadyCount == *(instance.Spec.Replicas)
 }
 
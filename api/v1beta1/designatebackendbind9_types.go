@@ -20,6 +20,7 @@ import (
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -72,6 +73,13 @@ type DesignateBackendbind9SpecBase struct {
 	// ControlNetworkName - specify which network attachment is to be used for control, notifys and zone transfers.
 	ControlNetworkName string `json:"controlNetworkName"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// ControlTSIG - sign the SOA queries and zone transfers of the default pool servers to designate-mdns with a
+	// TSIG key scoped to the default pool, as the servers of the other pools do. rndc is authenticated by the rndc
+	// keys, TLS is not available as neither rndc nor designate-mdns support it.
+	ControlTSIG bool `json:"controlTSIG"`
+
 	// +kubebuilder:validation:Optional
 	// StorageClass
 	StorageClass string `json:"storageClass,omitempty"`
@@ -80,17 +88,349 @@ type DesignateBackendbind9SpecBase struct {
 	// StorageRequest
 	StorageRequest string `json:"storageRequest"`
 
+	// +kubebuilder:validation:Optional
+	// PersistentVolumeClaimRetentionPolicy - whether the volume claims of the bind9 pods are deleted with the
+	// StatefulSet and when it is scaled down. By default they are deleted with the StatefulSet and retained on
+	// scale down. They are always retained with the StatefulSet when preserveOnDelete is set on Designate.
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// NetUtilsImage - NetUtils container image
 	NetUtilsImage string `json:"netUtilsImage"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=120
+	// +kubebuilder:validation:Minimum=0
+	// TerminationGracePeriodSeconds - time given to a bind9 pod to finish the running zone transfers and stop
+	// named before it gets killed
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Metrics - expose the named statistics through a bind_exporter sidecar
+	Metrics Bind9MetricsSpec `json:"metrics,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CatalogZone - provision the zones of the pools on the bind9 servers through a catalog zone served by
+	// designate-mdns (RFC 9432). named transfers the member zones listed in the catalog zone by itself.
+	CatalogZone *Bind9CatalogZoneSpec `json:"catalogZone,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=stdout
+	// +kubebuilder:validation:Enum=stdout;file
+	// LogTarget - stdout sends the named logs to the container output, collected by the cluster logging. file
+	// writes them to the files of /var/log/bind.
+	LogTarget string `json:"logTarget"`
+
+	// +kubebuilder:validation:Optional
+	// LogVolume - keep the named log files on a PersistentVolumeClaim of every bind9 pod rather than an emptyDir,
+	// rotated by named. Only used when logTarget is file.
+	LogVolume *Bind9LogVolumeSpec `json:"logVolume,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// QueryLogging - log the queries received by named
+	QueryLogging bool `json:"queryLogging"`
+
+	// +kubebuilder:validation:Optional
+	// RateLimit - response rate limiting (RRL) of named, mitigating the DNS amplification attacks
+	RateLimit *Bind9RateLimitSpec `json:"rateLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ResponsePolicyZone - response policy zone (RPZ) applied by named to its answers, loaded from a ConfigMap
+	ResponsePolicyZone *Bind9ResponsePolicyZoneSpec `json:"responsePolicyZone,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// Views - BIND views answering the clients they match from their own zones, e.g. for an internal and an
+	// external resolution. The zones managed by designate are added to the view with managedZones. Can't be
+	// combined with catalogZone or responsePolicyZone. The zones added before the views are enabled stay in the
+	// _default view of named and have to be synced again.
+	Views []Bind9ViewSpec `json:"views,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// GeoIP - MaxMind databases used by the geoip address match elements of named, e.g. geoip country DE in the
+	// matchClients of a view
+	GeoIP *Bind9GeoIPSpec `json:"geoIP,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// SecondaryServers - external secondary DNS servers transferring the zones from the bind9 servers, e.g. a
+	// corporate DNS. They are notified of the zone changes by designate and admitted by the allow-transfer ACL of
+	// named, by their TSIG key when set or otherwise by their address.
+	SecondaryServers []Bind9SecondaryServerSpec `json:"secondaryServers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// HiddenPrimary - the bind9 servers are hidden primaries, the secondary servers of each pool replace them as
+	// nameservers of the pool and designate checks the zone changes on them. The NS records of the pools are
+	// expected to name the secondary servers. Requires a secondary server in every pool.
+	HiddenPrimary bool `json:"hiddenPrimary"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=53
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// DNSPort - port named answers the DNS queries and receives the notifies on
+	DNSPort int32 `json:"dnsPort,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5354
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// MdnsPort - port of the mdns servers the zones are transferred from, set by Designate from the port
+	// of DesignateMdns
+	MdnsPort int32 `json:"mdnsPort,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// HostNetwork - the pods run on the network of their node and bind the DNS and rndc ports on it, set
+	// by Designate in the HostNetwork predictable IP mode
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodNetwork - the pods are reached on their pod IPs and don't get a predictable IP on the control
+	// network, set by Designate in the PodIP predictable IP mode
+	PodNetwork bool `json:"podNetwork,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PredictableIPNetworks - network attachments, other than the control network, the pods get a predictable IP
+	// on as well, e.g. a network dedicated to the DNS queries. Each must be one of the networkAttachments, the
+	// address is added to the interface named after it. Only supported in the ConfigMap predictable IP mode.
+	PredictableIPNetworks []string `json:"predictableIPNetworks,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// QueryNetworkName - one of the predictableIPNetworks designate sends the notifies and the DNS queries to the
+	// servers on, rndc stays on the control network. The control network is used for both when not set.
+	QueryNetworkName string `json:"queryNetworkName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// StaticIPs - predictable IPs on the control network pinned to pods, keyed by pod index, which take
+	// precedence over the automatic allocation, e.g. to match existing firewall rules or delegations. On dual
+	// stack networks an address of each family can be given, separated by a comma. In multipool mode the index
+	// counts the pods of the previous pools. Not supported in the HostNetwork and PodIP predictable IP modes.
+	StaticIPs map[string]string `json:"staticIPs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// OrchestratedUpdate - the operator replaces the outdated bind9 pods of all the pools one at a time, highest
+	// ordinal first, and only once all the other bind9 pods are ready. The preStop hook of a replaced pod lets its
+	// zone transfers complete before named stops.
+	OrchestratedUpdate bool `json:"orchestratedUpdate"`
+
+	// +kubebuilder:validation:Optional
+	// UpdateStrategy - update strategy of the bind9 StatefulSets, the partition applies to the StatefulSet of
+	// every pool. RollingUpdate when not set, ignored with orchestratedUpdate.
+	UpdateStrategy *DesignateUpdateStrategySpec `json:"updateStrategy,omitempty"`
+
 	// Allows services to be configured for accessing each designate bind pod. For best results, there should be
 	// an override for each replica.
 	// +kubebuilder:validation:Optional
 	Override Bind9OverrideSpec `json:"override,omitempty"`
 }
 
+// Bind9MetricsSpec defines the metrics exposed by the bind9 pods
+type Bind9MetricsSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - enable the named statistics-channels and run a bind_exporter sidecar in every bind9 pod. A
+	// ServiceMonitor is created for the exporters when the Prometheus Operator CRDs are installed.
+	Enabled bool `json:"enabled"`
+
+	// +kubebuilder:validation:Optional
+	// ExporterImage - bind_exporter container image
+	ExporterImage string `json:"exporterImage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="30s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// ScrapeInterval - interval at which Prometheus scrapes the metrics
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+}
+
+// Bind9LogVolumeSpec defines the persistent log volume of the bind9 pods
+type Bind9LogVolumeSpec struct {
+	// +kubebuilder:validation:Optional
+	// StorageClass - storage class of the log volume claims, the default storage class when empty
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1Gi"
+	// StorageRequest - size of the log volume claims
+	StorageRequest string `json:"storageRequest"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="50m"
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kKmMgG]?$`
+	// MaxFileSize - size at which named rotates a log file
+	MaxFileSize string `json:"maxFileSize"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// Versions - rotated versions kept of every log file, named deletes the older ones
+	Versions int32 `json:"versions"`
+}
+
+// Bind9RateLimitSpec defines the response rate limiting of named
+type Bind9RateLimitSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// ResponsesPerSecond - identical responses sent per second to a client network
+	ResponsesPerSecond int32 `json:"responsesPerSecond"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=15
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	// Window - seconds over which the responses are counted
+	Window int32 `json:"window"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// Slip - one of every slip dropped responses is sent truncated, for the legitimate clients to retry over
+	// TCP. 0 drops them all.
+	Slip int32 `json:"slip"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// ExemptClients - CIDRs of the clients which are not rate limited
+	ExemptClients []string `json:"exemptClients,omitempty"`
+}
+
+// Bind9ResponsePolicyZoneSpec defines the response policy zone of named
+type Bind9ResponsePolicyZoneSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+$`
+	// Name - name of the response policy zone, with the trailing dot, e.g. rpz.example.org.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// ConfigMapName - ConfigMap holding the zone file, a change restarts the bind9 pods
+	ConfigMapName string `json:"configMapName"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="db.rpz"
+	// Key - key of the zone file in the ConfigMap
+	Key string `json:"key"`
+}
+
+// Bind9ViewSpec defines a view of named
+type Bind9ViewSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	// Name - name of the view
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// MatchClients - address match list of the clients answered by the view, e.g. 10.0.0.0/8 or any. The views
+	// are matched in order.
+	MatchClients []string `json:"matchClients"`
+
+	// +kubebuilder:validation:Optional
+	// ManagedZones - the zones managed by designate are added to this view, exactly one view sets it. The control
+	// network is matched by the view first so that designate reaches the zones.
+	ManagedZones bool `json:"managedZones,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// CustomViewOptions - extra statements of the view, e.g. recursion no;
+	CustomViewOptions []string `json:"customViewOptions,omitempty"`
+}
+
+// Bind9GeoIPSpec defines the GeoIP databases of named
+type Bind9GeoIPSpec struct {
+	// +kubebuilder:validation:Required
+	// ClaimName - PersistentVolumeClaim holding the MaxMind databases, e.g. GeoIP2-Country.mmdb, mounted read only
+	// by all the bind9 pods. A change of the databases needs a restart of the pods.
+	ClaimName string `json:"claimName"`
+}
+
+// Bind9SecondaryServerSpec defines an external secondary DNS server of the bind9 servers
+type Bind9SecondaryServerSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// Name - unique name of the server
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// Host - IP address of the server
+	Host string `json:"host"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=53
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port - DNS port of the server, the NOTIFY messages are sent to
+	Port int32 `json:"port"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=default
+	// Pool - name of the pool whose zone changes are notified to the server
+	Pool string `json:"pool"`
+
+	// +kubebuilder:validation:Optional
+	// TSIGKeyName - name of the TSIG key signing the zone transfers of the server. TSIG is only configured when
+	// TSIGKeySecret is set.
+	TSIGKeyName string `json:"tsigKeyName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=hmac-sha256
+	// +kubebuilder:validation:Enum=hmac-md5;hmac-sha1;hmac-sha224;hmac-sha256;hmac-sha384;hmac-sha512
+	// TSIGKeyAlgorithm - algorithm of the TSIG key
+	TSIGKeyAlgorithm string `json:"tsigKeyAlgorithm,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TSIGKeySecret - name of the Secret holding the base64 encoded secret of the TSIG key
+	TSIGKeySecret string `json:"tsigKeySecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=tsig-key
+	// TSIGKeySecretKey - key of TSIGKeySecret holding the secret of the TSIG key
+	TSIGKeySecretKey string `json:"tsigKeySecretKey,omitempty"`
+}
+
+// HasTSIGKey returns true if the zone transfers of the server are signed with a TSIG key
+func (s Bind9SecondaryServerSpec) HasTSIGKey() bool {
+	return s.TSIGKeySecret != ""
+}
+
+// GetTSIGKeyName returns the name of the TSIG key of the server, defaults to the server name
+func (s Bind9SecondaryServerSpec) GetTSIGKeyName() string {
+	if s.TSIGKeyName != "" {
+		return s.TSIGKeyName
+	}
+	return s.Name
+}
+
+// Bind9CatalogZoneSpec defines the catalog zone of the bind9 pools
+type Bind9CatalogZoneSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+$`
+	// FQDN - name of the catalog zone of the default pool, with the trailing dot, e.g. catalog.example.org.
+	// The catalog zone of another pool is named after the pool below it, e.g. pool1.catalog.example.org.
+	FQDN string `json:"fqdn"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// Refresh - SOA refresh of the catalog zone in seconds
+	Refresh int32 `json:"refresh"`
+}
+
 type Bind9OverrideSpec struct {
+	// Service - override applied to the Services of all the replicas, e.g. to request LoadBalancer
+	// Services in a metallb.universe.tf/address-pool sharing metallb.universe.tf/allow-shared-ip.
+	// The annotations and labels of the per replica services overrides are merged on top of it,
+	// and their spec replaces it.
+	// +kubebuilder:validation:Optional
+	Service *service.OverrideSpec `json:"service,omitempty"`
+
 	// +listType=atomic
 	Services []service.OverrideSpec `json:"services,omitempty"`
 }
@@ -103,12 +443,19 @@ type DesignateBackendbind9Status struct {
 	// Map of hashes to track e.g. job status
 	Hash map[string]string `json:"hash,omitempty"`
 
+	// InputHashes - the hash of every input of the pods, e.g. a mounted Secret, the pods
+	// restart when one of them changes
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 
 	// NetworkAttachments status of the deployment pods
 	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
 
+	// PodIPs - the predictable IPs of the bind9 pods on the control network, by pod name
+	PodIPs map[string]string `json:"podIPs,omitempty"`
+
 	// ObservedGeneration - the most recent generation observed for this
 	// service. If the observed generation is less than the spec generation,
 	// then the controller has not processed the latest changes injected by
@@ -0,0 +1,162 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var designatebackendbind9log = logf.Log.WithName("designatebackendbind9-resource")
+
+// Default sets the default container images of the DesignateBackendbind9 when not set
+func (r *DesignateBackendbind9) Default() {
+	designatebackendbind9log.Info("default", "name", r.Name)
+	r.Spec.Default()
+}
+
+// Default sets the default container images of this DesignateBackendbind9 spec,
+// which come from the RELATED_IMAGE_* environment variables of the operator
+func (spec *DesignateBackendbind9Spec) Default() {
+	if spec.ContainerImage == "" {
+		spec.ContainerImage = designateDefaults.Backendbind9ContainerImageURL
+	}
+	if spec.NetUtilsImage == "" {
+		spec.NetUtilsImage = designateDefaults.NetUtilsURL
+	}
+	if spec.Metrics.ExporterImage == "" {
+		spec.Metrics.ExporterImage = designateDefaults.Bind9ExporterURL
+	}
+}
+
+// ValidateCreate validates the DesignateBackendbind9 spec upon creation
+func (r *DesignateBackendbind9) ValidateCreate() (admission.Warnings, error) {
+	designatebackendbind9log.Info("validate create", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// ValidateUpdate validates the DesignateBackendbind9 spec upon update
+func (r *DesignateBackendbind9) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	designatebackendbind9log.Info("validate update", "name", r.Name)
+
+	oldBackendbind9, ok := old.(*DesignateBackendbind9)
+	if !ok || oldBackendbind9 == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	return nil, r.validate()
+}
+
+// ValidateDelete validates the DesignateBackendbind9 upon deletion
+func (r *DesignateBackendbind9) ValidateDelete() (admission.Warnings, error) {
+	designatebackendbind9log.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+func (r *DesignateBackendbind9) validate() error {
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec")
+
+	allErrs = append(allErrs, r.Spec.ValidateTopology(basePath, r.Namespace)...)
+	allErrs = append(allErrs, r.Spec.ValidateControlNetworkName(
+		basePath.Child("controlNetworkName"), r.Spec.ControlNetworkName)...)
+	allErrs = append(allErrs, ValidateStorageRequest(
+		basePath.Child("storageRequest"), r.Spec.StorageRequest)...)
+	allErrs = append(allErrs, ValidatePVCRetentionPolicy(
+		basePath.Child("persistentVolumeClaimRetentionPolicy"), r.Spec.PersistentVolumeClaimRetentionPolicy)...)
+	allErrs = append(allErrs, r.Spec.ValidateViews(basePath.Child("views"))...)
+	allErrs = append(allErrs, r.Spec.ValidateSecondaryServers(basePath.Child("secondaryServers"))...)
+	allErrs = append(allErrs, r.Spec.ValidateHiddenPrimary(basePath.Child("hiddenPrimary"))...)
+
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "designate.openstack.org", Kind: "DesignateBackendbind9"},
+			r.Name, allErrs)
+	}
+	return nil
+}
+
+// ValidateViews - Returns an ErrorList if the views are set and the zones
+// managed by designate don't go to exactly one of them, or if they are combined
+// with the catalog or response policy zone which are defined outside of views
+func (spec *DesignateBackendbind9SpecBase) ValidateViews(path *field.Path) field.ErrorList {
+	if len(spec.Views) == 0 {
+		return nil
+	}
+	var allErrs field.ErrorList
+	managed := 0
+	for _, view := range spec.Views {
+		if view.ManagedZones {
+			managed++
+		}
+	}
+	if managed != 1 {
+		allErrs = append(allErrs, field.Invalid(path, len(spec.Views),
+			fmt.Sprintf("exactly one view must set managedZones, %d do", managed)))
+	}
+	if spec.CatalogZone != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "views can't be combined with catalogZone"))
+	}
+	if spec.ResponsePolicyZone != nil {
+		allErrs = append(allErrs, field.Forbidden(path, "views can't be combined with responsePolicyZone"))
+	}
+	return allErrs
+}
+
+// ValidateSecondaryServers - Returns an ErrorList if the host of a secondary
+// server is not an IP address, named can't match a hostname in an ACL
+func (spec *DesignateBackendbind9SpecBase) ValidateSecondaryServers(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, server := range spec.SecondaryServers {
+		if net.ParseIP(server.Host) == nil {
+			allErrs = append(allErrs, field.Invalid(path.Index(i).Child("host"), server.Host,
+				"must be an IP address"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateHiddenPrimary - Returns an ErrorList if the bind9 servers are hidden
+// primaries without secondary servers to publish the zones
+func (spec *DesignateBackendbind9SpecBase) ValidateHiddenPrimary(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.HiddenPrimary && len(spec.SecondaryServers) == 0 {
+		allErrs = append(allErrs, field.Invalid(path, spec.HiddenPrimary,
+			"requires secondaryServers"))
+	}
+	return allErrs
+}
+
+// ValidateQueryNetworkName - Returns an ErrorList if the query network is not
+// one of the networks the servers get a predictable IP on
+func (spec *DesignateBackendbind9SpecBase) ValidateQueryNetworkName(path *field.Path) field.ErrorList {
+	if spec.QueryNetworkName == "" || slices.Contains(spec.PredictableIPNetworks, spec.QueryNetworkName) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, spec.QueryNetworkName,
+		"must be one of the predictableIPNetworks")}
+}
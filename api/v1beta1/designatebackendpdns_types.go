@@ -0,0 +1,157 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DesignateBackendPDNSSpecCore - this version has no containerImage for use with the OpenStackControlplane
+type DesignateBackendPDNSSpecCore struct {
+	// Common input parameters for the Designate BackendPDNS service
+	DesignateServiceTemplateCore `json:",inline"`
+
+	DesignateBackendPDNSSpecBase `json:",inline"`
+}
+
+// DesignateBackendPDNSSpec defines the desired state of DesignateBackendPDNS
+type DesignateBackendPDNSSpec struct {
+	// Common input parameters for the Designate BackendPDNS service
+	DesignateServiceTemplate `json:",inline"`
+
+	DesignateBackendPDNSSpecBase `json:",inline"`
+}
+
+// DesignateBackendPDNSSpecBase -
+type DesignateBackendPDNSSpecBase struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Maximum=32
+	// +kubebuilder:validation:Minimum=0
+	// Replicas - Designate BackendPDNS Replicas. The PowerDNS servers are
+	// only deployed when this is greater than 0.
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Optional
+	// ServiceAccount - service account name used internally to provide Designate services the default SA name
+	ServiceAccount string `json:"serviceAccount"`
+
+	// +kubebuilder:default="designate"
+	// +kubebuilder:validation:Optional
+	// ControlNetworkName - specify which network attachment is to be used for control, notifys and zone transfers.
+	ControlNetworkName string `json:"controlNetworkName"`
+
+	// +kubebuilder:validation:Optional
+	// StorageClass
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1G"
+	// StorageRequest - size of the volume holding the PowerDNS sqlite database
+	StorageRequest string `json:"storageRequest"`
+
+	// +kubebuilder:validation:Optional
+	// PersistentVolumeClaimRetentionPolicy - whether the volume claims of the PowerDNS pods are deleted with the
+	// StatefulSet and when it is scaled down. By default they are deleted with the StatefulSet and retained on
+	// scale down. They are always retained with the StatefulSet when preserveOnDelete is set on Designate.
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NetUtilsImage - NetUtils container image
+	NetUtilsImage string `json:"netUtilsImage"`
+
+	// +kubebuilder:validation:Optional
+	// APIKeySecret - name of the Secret holding the key of the PowerDNS API
+	// used by designate-worker. It is set by the Designate controller.
+	APIKeySecret string `json:"apiKeySecret,omitempty"`
+}
+
+// DesignateBackendPDNSStatus defines the observed state of DesignateBackendPDNS
+type DesignateBackendPDNSStatus struct {
+	// ReadyCount of designate backendpdns instances
+	ReadyCount int32 `json:"readyCount,omitempty"`
+
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// InputHashes - the hash of every input of the pods, e.g. a mounted Secret, the pods
+	// restart when one of them changes
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// NetworkAttachments status of the deployment pods
+	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
+
+	// ObservedGeneration - the most recent generation observed for this
+	// service. If the observed generation is less than the spec generation,
+	// then the controller has not processed the latest changes injected by
+	// the opentack-operator in the top-level CR (e.g. the ContainerImage)
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAppliedTopology - the last applied Topology
+	LastAppliedTopology *topologyv1.TopoRef `json:"lastAppliedTopology,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// DesignateBackendPDNS is the Schema for the designatebackendpdnses API
+type DesignateBackendPDNS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DesignateBackendPDNSSpec   `json:"spec,omitempty"`
+	Status DesignateBackendPDNSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DesignateBackendPDNSList contains a list of DesignateBackendPDNS
+type DesignateBackendPDNSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DesignateBackendPDNS `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DesignateBackendPDNS{}, &DesignateBackendPDNSList{})
+}
+
+// IsReady - returns true if service is ready to serve requests
+func (instance DesignateBackendPDNS) IsReady() bool {
+	return instance.Status.ReadyCount == *(instance.Spec.Replicas)
+}
+
+// GetSpecTopologyRef - Returns the LastAppliedTopology Set in the Status
+func (instance *DesignateBackendPDNS) GetSpecTopologyRef() *topologyv1.TopoRef {
+	return instance.Spec.TopologyRef
+}
+
+// GetLastAppliedTopology - Returns the LastAppliedTopology Set in the Status
+func (instance *DesignateBackendPDNS) GetLastAppliedTopology() *topologyv1.TopoRef {
+	return instance.Status.LastAppliedTopology
+}
+
+// SetLastAppliedTopology - Sets the LastAppliedTopology value in the Status
+func (instance *DesignateBackendPDNS) SetLastAppliedTopology(topologyRef *topologyv1.TopoRef) {
+	instance.Status.LastAppliedTopology = topologyRef
+}
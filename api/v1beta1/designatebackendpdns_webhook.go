@@ -0,0 +1,40 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// log is for logging in this package.
+var designatebackendpdnslog = logf.Log.WithName("designatebackendpdns-resource")
+
+// Default sets the default container images of the DesignateBackendPDNS when not set
+func (r *DesignateBackendPDNS) Default() {
+	designatebackendpdnslog.Info("default", "name", r.Name)
+	r.Spec.Default()
+}
+
+// Default sets the default container images of this DesignateBackendPDNS spec,
+// which come from the RELATED_IMAGE_* environment variables of the operator
+func (spec *DesignateBackendPDNSSpec) Default() {
+	if spec.ContainerImage == "" {
+		spec.ContainerImage = designateDefaults.BackendPDNSContainerImageURL
+	}
+	if spec.NetUtilsImage == "" {
+		spec.NetUtilsImage = designateDefaults.NetUtilsURL
+	}
+}
@@ -50,9 +50,15 @@ type DesignateCentralSpecBase struct {
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Maximum=32
 	// +kubebuilder:validation:Minimum=0
-	// Replicas - Designate Central Replicas
+	// +nullable
+	// Replicas - Designate Central Replicas. When null the replicas are not managed by the
+	// operator and are kept as they are, e.g. scaled with kubectl scale.
 	Replicas *int32 `json:"replicas"`
 
+	// +kubebuilder:validation:Optional
+	// Autoscaling - scale the Designate Central replicas with a HorizontalPodAutoscaler instead of Replicas
+	Autoscaling *DesignateAutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// DatabaseHostname - Designate Database Hostname
 	DatabaseHostname string `json:"databaseHostname,omitempty"`
@@ -79,9 +85,16 @@ type DesignateCentralStatus struct {
 	// ReadyCount of designate central instances
 	ReadyCount int32 `json:"readyCount,omitempty"`
 
+	// Selector - label selector of the pods, used by the scale subresource
+	Selector string `json:"selector,omitempty"`
+
 	// Map of hashes to track e.g. job status
 	Hash map[string]string `json:"hash,omitempty"`
 
+	// InputHashes - the hash of every input of the pods, e.g. a mounted Secret, the pods
+	// restart when one of them changes
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 
@@ -100,9 +113,14 @@ type DesignateCentralStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.readyCount,selectorpath=.status.selector
+//+kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas",description="Replicas"
+//+kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyCount",description="Ready replicas"
 //+kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+//+kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.containerImage",description="Image",priority=1
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // DesignateCentral is the Schema for the designatecentral API
 type DesignateCentral struct {
@@ -128,6 +146,16 @@ func init() {
 
 // IsReady - returns true if service is ready to serve requests
 func (instance DesignateCentral) IsReady() bool {
+	// the HorizontalPodAutoscaler owns the replicas, the service is ready
+	// once the minimum number of replicas is ready
+	if instance.Spec.Autoscaling != nil {
+		return instance.Status.ReadyCount >= instance.Spec.Autoscaling.GetMinReplicas()
+	}
+	// the replicas are managed externally, the service is ready once
+	// its Deployment is
+	if instance.Spec.Replicas == nil {
+		return instance.Status.Conditions.IsTrue(condition.DeploymentReadyCondition)
+	}
 	return instance.Status.ReadyCount == *(instance.Spec.Replicas)
 }
 
@@ -83,9 +83,75 @@ type DesignateMdnsSpecBase struct {
 	// an override for each replica.
 	// +kubebuilder:validation:Optional
 	Override MdnsOverrideSpec `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// UpdateStrategy - update strategy of the mdns StatefulSet, RollingUpdate when not set
+	UpdateStrategy *DesignateUpdateStrategySpec `json:"updateStrategy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// Workers - number of worker processes of each mdns server
+	Workers int32 `json:"workers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1000
+	// +kubebuilder:validation:Minimum=1
+	// Threads - number of green threads of each worker process
+	Threads int32 `json:"threads,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// TCPBacklog - backlog of the TCP connections of the DNS listener, e.g. the zone transfers
+	TCPBacklog int32 `json:"tcpBacklog,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// XFRTimeout - timeout in seconds of the zone transfers
+	XFRTimeout int32 `json:"xfrTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5354
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port - port mdns serves the zone transfers on
+	Port int32 `json:"port,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// HostNetwork - the pods run on the network of their node and bind the port on it, set by Designate
+	// in the HostNetwork predictable IP mode
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodNetwork - the pods are reached on their pod IPs and don't get a predictable IP on the control
+	// network, set by Designate in the PodIP predictable IP mode
+	PodNetwork bool `json:"podNetwork,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PredictableIPNetworks - network attachments, other than the control network, the pods get a predictable IP
+	// on as well and serve the zone transfers on. Each must be one of the networkAttachments, the address is added
+	// to the interface named after it. Only supported in the ConfigMap predictable IP mode.
+	PredictableIPNetworks []string `json:"predictableIPNetworks,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// StaticIPs - predictable IPs on the control network pinned to pods, keyed by pod index, which take
+	// precedence over the automatic allocation, e.g. to match existing firewall rules or delegations. On dual
+	// stack networks an address of each family can be given, separated by a comma. Not supported in the
+	// HostNetwork and PodIP predictable IP modes.
+	StaticIPs map[string]string `json:"staticIPs,omitempty"`
 }
 
 type MdnsOverrideSpec struct {
+	// Service - override applied to the Services of all the replicas, e.g. to request LoadBalancer
+	// Services in a metallb.universe.tf/address-pool sharing metallb.universe.tf/allow-shared-ip.
+	// The annotations and labels of the per replica services overrides are merged on top of it,
+	// and their spec replaces it.
+	// +kubebuilder:validation:Optional
+	Service *service.OverrideSpec `json:"service,omitempty"`
+
 	// +listType=atomic
 	Services []service.OverrideSpec `json:"services,omitempty"`
 }
@@ -101,12 +167,19 @@ type DesignateMdnsStatus struct {
 	// Map of hashes to track e.g. job status
 	Hash map[string]string `json:"hash,omitempty"`
 
+	// InputHashes - the hash of every input of the pods, e.g. a mounted Secret, the pods
+	// restart when one of them changes
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 
 	// NetworkAttachments status of the deployment pods
 	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
 
+	// PodIPs - the predictable IPs of the mdns pods on the control network, by pod name
+	PodIPs map[string]string `json:"podIPs,omitempty"`
+
 	// ObservedGeneration - the most recent generation observed for this
 	// service. If the observed generation is less than the spec generation,
 	// then the controller has not processed the latest changes injected by
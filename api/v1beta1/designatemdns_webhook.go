@@ -0,0 +1,89 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var designatemdnslog = logf.Log.WithName("designatemdns-resource")
+
+// Default sets the default container images of the DesignateMdns when not set
+func (r *DesignateMdns) Default() {
+	designatemdnslog.Info("default", "name", r.Name)
+	r.Spec.Default()
+}
+
+// Default sets the default container images of this DesignateMdns spec,
+// which come from the RELATED_IMAGE_* environment variables of the operator
+func (spec *DesignateMdnsSpec) Default() {
+	if spec.ContainerImage == "" {
+		spec.ContainerImage = designateDefaults.MdnsContainerImageURL
+	}
+	if spec.NetUtilsImage == "" {
+		spec.NetUtilsImage = designateDefaults.NetUtilsURL
+	}
+}
+
+// ValidateCreate validates the DesignateMdns spec upon creation
+func (r *DesignateMdns) ValidateCreate() (admission.Warnings, error) {
+	designatemdnslog.Info("validate create", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// ValidateUpdate validates the DesignateMdns spec upon update
+func (r *DesignateMdns) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	designatemdnslog.Info("validate update", "name", r.Name)
+
+	oldMdns, ok := old.(*DesignateMdns)
+	if !ok || oldMdns == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	return nil, r.validate()
+}
+
+// ValidateDelete validates the DesignateMdns upon deletion
+func (r *DesignateMdns) ValidateDelete() (admission.Warnings, error) {
+	designatemdnslog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+func (r *DesignateMdns) validate() error {
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec")
+
+	allErrs = append(allErrs, r.Spec.ValidateTopology(basePath, r.Namespace)...)
+	allErrs = append(allErrs, r.Spec.ValidateControlNetworkName(
+		basePath.Child("controlNetworkName"), r.Spec.ControlNetworkName)...)
+
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "designate.openstack.org", Kind: "DesignateMdns"},
+			r.Name, allErrs)
+	}
+	return nil
+}
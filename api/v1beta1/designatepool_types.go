@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DesignatePoolSpec defines a Designate pool, it is rendered into a pools.yaml
+// and applied with designate-manage pool update
+type DesignatePoolSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=designate
+	// DesignateName - name of the Designate CR the pool is applied to
+	DesignateName string `json:"designateName"`
+
+	// +kubebuilder:validation:Optional
+	// PoolName - name of the pool in Designate, defaults to the name of the CR.
+	// A pool also generated by the Designate CR (e.g. default) is left to this CR.
+	PoolName string `json:"poolName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Description - description of the pool
+	Description string `json:"description,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Attributes - pool attributes used by the pool scheduler filters
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// NSRecords - NS records of the zones hosted in the pool
+	NSRecords []DesignateNSRecord `json:"nsRecords"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// Nameservers - the nameservers polled to check the zones are up to date
+	Nameservers []DesignatePoolServer `json:"nameservers"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// Targets - the backends designate-worker provisions the zones on
+	Targets []DesignatePoolTarget `json:"targets"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// AlsoNotifies - additional servers notified of zone changes, e.g. external secondaries
+	AlsoNotifies []DesignatePoolServer `json:"alsoNotifies,omitempty"`
+}
+
+// DesignatePoolServer - a DNS server address of a pool
+type DesignatePoolServer struct {
+	// +kubebuilder:validation:Required
+	// Host - address of the server
+	Host string `json:"host"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=53
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port - DNS port of the server
+	Port int32 `json:"port"`
+}
+
+// DesignatePoolMaster - a server the target transfers the zones from
+type DesignatePoolMaster struct {
+	// +kubebuilder:validation:Required
+	// Host - address of the master
+	Host string `json:"host"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=5354
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// Port - port of the master
+	Port int32 `json:"port"`
+}
+
+// DesignatePoolTarget - a backend of the pool
+type DesignatePoolTarget struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=bind9
+	// Type - designate backend driver of the target, e.g. bind9 or pdns4
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	// Description - description of the target
+	Description string `json:"description,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// Masters - servers the target transfers the zones from. Defaults to the
+	// mdns servers of the Designate CR.
+	Masters []DesignatePoolMaster `json:"masters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Options - backend driver options, e.g. host, port, rndc_host, rndc_port and
+	// rndc_key_file for bind9
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// DesignatePoolStatus defines the observed state of DesignatePool
+type DesignatePoolStatus struct {
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this
+	// service. If the observed generation is less than the spec generation,
+	// then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// DesignatePool is the Schema for the designatepools API
+type DesignatePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DesignatePoolSpec   `json:"spec,omitempty"`
+	Status DesignatePoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DesignatePoolList contains a list of DesignatePool
+type DesignatePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DesignatePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DesignatePool{}, &DesignatePoolList{})
+}
+
+// IsReady - returns true if the pool has been applied
+func (instance DesignatePool) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(DesignatePoolUpdateReadyCondition)
+}
+
+// GetPoolName - returns the name of the pool in Designate
+func (instance DesignatePool) GetPoolName() string {
+	if instance.Spec.PoolName != "" {
+		return instance.Spec.PoolName
+	}
+	return instance.Name
+}
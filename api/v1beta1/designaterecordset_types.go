@@ -0,0 +1,108 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DesignateRecordSetSpec defines a recordset of a DesignateZone
+type DesignateRecordSetSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="zoneRef is immutable"
+	// ZoneRef - name of the DesignateZone CR the recordset belongs to
+	ZoneRef string `json:"zoneRef"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	// Name - fully qualified name of the recordset, with the trailing dot, e.g. www.example.com.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=A;AAAA;CAA;CNAME;MX;NAPTR;NS;PTR;SPF;SRV;SSHFP;TXT
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	// Type - DNS type of the recordset
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// Records - data of the records, e.g. the addresses of an A recordset
+	Records []string `json:"records"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TTL - TTL of the recordset in seconds, defaults to the TTL of the zone
+	TTL *int32 `json:"ttl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Description - description of the recordset
+	Description string `json:"description,omitempty"`
+}
+
+// DesignateRecordSetStatus defines the observed state of DesignateRecordSet
+type DesignateRecordSetStatus struct {
+	// RecordSetID - ID of the recordset in Designate
+	RecordSetID string `json:"recordSetID,omitempty"`
+
+	// ZoneID - ID of the zone of the recordset in Designate
+	ZoneID string `json:"zoneID,omitempty"`
+
+	// RecordSetStatus - status of the recordset reported by Designate, e.g. ACTIVE, PENDING or ERROR
+	RecordSetStatus string `json:"recordSetStatus,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this
+	// service. If the observed generation is less than the spec generation,
+	// then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name",description="Name"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="Type"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// DesignateRecordSet is the Schema for the designaterecordsets API
+type DesignateRecordSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DesignateRecordSetSpec   `json:"spec,omitempty"`
+	Status DesignateRecordSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DesignateRecordSetList contains a list of DesignateRecordSet
+type DesignateRecordSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DesignateRecordSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DesignateRecordSet{}, &DesignateRecordSetList{})
+}
+
+// IsReady - returns true if the recordset is active in Designate
+func (instance DesignateRecordSet) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(DesignateRecordSetReadyCondition)
+}
@@ -0,0 +1,195 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SinkHandlerNovaFixed - handler creating records for the fixed IPs of nova instances
+	SinkHandlerNovaFixed = "nova_fixed"
+
+	// SinkHandlerNeutronFloatingIP - handler creating records for neutron floating IPs
+	SinkHandlerNeutronFloatingIP = "neutron_floatingip"
+)
+
+// DesignateSinkSpecCore - this version has no containerImage for use with the OpenStackControlplane
+type DesignateSinkSpecCore struct {
+	// Common input parameters for the Designate Sink service
+	DesignateServiceTemplateCore `json:",inline"`
+
+	DesignateSinkSpecBase `json:",inline"`
+}
+
+// DesignateSinkSpec the desired state of DesignateSink
+type DesignateSinkSpec struct {
+	// Common input parameters for the Designate Sink service
+	DesignateServiceTemplate `json:",inline"`
+
+	DesignateSinkSpecBase `json:",inline"`
+}
+
+// DesignateSinkSpecBase defines the desired state of DesignateSink
+type DesignateSinkSpecBase struct {
+	// Common input parameters for all Designate services
+	DesignateTemplate `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Maximum=32
+	// +kubebuilder:validation:Minimum=0
+	// Replicas - Designate Sink Replicas. designate-sink is only deployed
+	// when this is greater than 0.
+	Replicas *int32 `json:"replicas"`
+
+	// +kubebuilder:validation:Optional
+	// DatabaseHostname - Designate Database Hostname
+	DatabaseHostname string `json:"databaseHostname,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Secret containing RabbitMq transport URL
+	TransportURLSecret string `json:"transportURLSecret,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ServiceAccount - service account name used internally to provide Designate services the default SA name
+	ServiceAccount string `json:"serviceAccount"`
+
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// TLS - Parameters related to the TLS
+	TLS tls.Ca `json:"tls,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	// NotificationHandlers - the notification handlers enabled in designate-sink,
+	// creating records for the IPs reported in the nova and neutron notifications
+	NotificationHandlers []DesignateSinkHandler `json:"notificationHandlers,omitempty"`
+}
+
+// DesignateSinkHandler - configuration of a designate-sink notification handler
+type DesignateSinkHandler struct {
+	// +kubebuilder:validation:Enum=nova_fixed;neutron_floatingip
+	// Name - the notification handler, nova_fixed for the fixed IPs of the
+	// nova instances or neutron_floatingip for the neutron floating IPs
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`
+	// ZoneID - ID of the designate zone the records are created in
+	ZoneID string `json:"zoneID"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={notifications}
+	// +listType=atomic
+	// NotificationTopics - the topics the handler listens to
+	NotificationTopics []string `json:"notificationTopics,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ControlExchange - the exchange the notifications are sent on, nova for
+	// nova_fixed and neutron for neutron_floatingip when not set
+	ControlExchange string `json:"controlExchange,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// FormatV4 - formats of the records created for IPv4 addresses, e.g.
+	// %(octet0)s-%(octet1)s-%(octet2)s-%(octet3)s.%(zone)s
+	FormatV4 []string `json:"formatV4,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// FormatV6 - formats of the records created for IPv6 addresses, e.g.
+	// %(hostname)s.%(zone)s
+	FormatV6 []string `json:"formatV6,omitempty"`
+}
+
+// DesignateSinkStatus defines the observed state of DesignateSink
+type DesignateSinkStatus struct {
+	// ReadyCount of designate Sink instances
+	ReadyCount int32 `json:"readyCount,omitempty"`
+
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// InputHashes - the hash of every input of the pods, e.g. a mounted Secret, the pods
+	// restart when one of them changes
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// NetworkAttachments status of the deployment pods
+	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
+
+	// ObservedGeneration - the most recent generation observed for this
+	// service. If the observed generation is less than the spec generation,
+	// then the controller has not processed the latest changes injected by
+	// the opentack-operator in the top-level CR (e.g. the ContainerImage)
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAppliedTopology - the last applied Topology
+	LastAppliedTopology *topologyv1.TopoRef `json:"lastAppliedTopology,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+
+// DesignateSink is the Schema for the designatesinks API
+type DesignateSink struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DesignateSinkSpec   `json:"spec,omitempty"`
+	Status DesignateSinkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DesignateSinkList contains a list of DesignateSink
+type DesignateSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DesignateSink `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DesignateSink{}, &DesignateSinkList{})
+}
+
+// IsReady - returns true if service is ready to serve requests
+func (instance DesignateSink) IsReady() bool {
+	return instance.Status.ReadyCount == *(instance.Spec.Replicas)
+}
+
+// GetSpecTopologyRef - Returns the LastAppliedTopology Set in the Status
+func (instance *DesignateSink) GetSpecTopologyRef() *topologyv1.TopoRef {
+	return instance.Spec.TopologyRef
+}
+
+// GetLastAppliedTopology - Returns the LastAppliedTopology Set in the Status
+func (instance *DesignateSink) GetLastAppliedTopology() *topologyv1.TopoRef {
+	return instance.Status.LastAppliedTopology
+}
+
+// SetLastAppliedTopology - Sets the LastAppliedTopology value in the Status
+func (instance *DesignateSink) SetLastAppliedTopology(topologyRef *topologyv1.TopoRef) {
+	instance.Status.LastAppliedTopology = topologyRef
+}
@@ -0,0 +1,120 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var designatesinklog = logf.Log.WithName("designatesink-resource")
+
+// Default sets the default container images of the DesignateSink when not set
+func (r *DesignateSink) Default() {
+	designatesinklog.Info("default", "name", r.Name)
+	r.Spec.Default()
+}
+
+// Default sets the default container images of this DesignateSink spec,
+// which come from the RELATED_IMAGE_* environment variables of the operator
+func (spec *DesignateSinkSpec) Default() {
+	if spec.ContainerImage == "" {
+		spec.ContainerImage = designateDefaults.SinkContainerImageURL
+	}
+}
+
+// ValidateCreate validates the DesignateSink spec upon creation
+func (r *DesignateSink) ValidateCreate() (admission.Warnings, error) {
+	designatesinklog.Info("validate create", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// ValidateUpdate validates the DesignateSink spec upon update
+func (r *DesignateSink) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	designatesinklog.Info("validate update", "name", r.Name)
+
+	oldSink, ok := old.(*DesignateSink)
+	if !ok || oldSink == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	return nil, r.validate()
+}
+
+// ValidateDelete validates the DesignateSink upon deletion
+func (r *DesignateSink) ValidateDelete() (admission.Warnings, error) {
+	designatesinklog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+func (r *DesignateSink) validate() error {
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec")
+
+	allErrs = append(allErrs, r.Spec.ValidateTopology(basePath, r.Namespace)...)
+	allErrs = append(allErrs, r.Spec.ValidateNotificationHandlers(basePath)...)
+
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "designate.openstack.org", Kind: "DesignateSink"},
+			r.Name, allErrs)
+	}
+	return nil
+}
+
+// ValidateNotificationHandlers - Returns an ErrorList if designate-sink is
+// requested without handlers, or if a handler has no usable record format
+func (spec *DesignateSinkSpecBase) ValidateNotificationHandlers(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	handlersPath := basePath.Child("notificationHandlers")
+
+	if spec.Replicas != nil && *spec.Replicas > 0 && len(spec.NotificationHandlers) == 0 {
+		allErrs = append(allErrs, field.Required(handlersPath,
+			"at least one notification handler is required to deploy designate-sink"))
+	}
+
+	for i, handler := range spec.NotificationHandlers {
+		path := handlersPath.Index(i)
+		if len(handler.FormatV4) == 0 && len(handler.FormatV6) == 0 {
+			allErrs = append(allErrs, field.Required(path,
+				"at least one of formatV4 or formatV6 is required"))
+		}
+		for j, format := range handler.FormatV4 {
+			if strings.Contains(format, ",") {
+				allErrs = append(allErrs, field.Invalid(path.Child("formatV4").Index(j), format,
+					"must not contain a comma"))
+			}
+		}
+		for j, format := range handler.FormatV6 {
+			if strings.Contains(format, ",") {
+				allErrs = append(allErrs, field.Invalid(path.Child("formatV6").Index(j), format,
+					"must not contain a comma"))
+			}
+		}
+	}
+
+	return allErrs
+}
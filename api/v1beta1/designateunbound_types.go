@@ -20,6 +20,7 @@ import (
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -62,6 +63,65 @@ type DesignateUnboundSpecBase struct {
 	// +kubebuilder:validation:Optional
 	// +listType=atomic
 	StubZones []StubZone `json:"stubZones,omitempty"`
+
+	// Allows configuring forward zone entries in the managed Unbound servers, e.g. to send
+	// queries for some zones, or all of them with ".", to upstream resolvers.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	ForwardZones []ForwardZone `json:"forwardZones,omitempty"`
+
+	// AccessControl - additional access-control entries for the Unbound servers. The join
+	// subnets of the cluster network and the CIDRs of the network attachments are always allowed.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	AccessControl []UnboundAccessControl `json:"accessControl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// NumThreads - number of threads used by each Unbound server
+	NumThreads int32 `json:"numThreads,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="50m"
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kKmMgG]?$`
+	// MsgCacheSize - size of the message cache of each Unbound server
+	MsgCacheSize string `json:"msgCacheSize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="100m"
+	// +kubebuilder:validation:Pattern=`^[0-9]+[kKmMgG]?$`
+	// RRSetCacheSize - size of the RRset cache of each Unbound server
+	RRSetCacheSize string `json:"rrsetCacheSize,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// CacheMinTTL - minimum time to live in seconds of the cached records, 0 keeps the time to live of the
+	// answers
+	CacheMinTTL int32 `json:"cacheMinTTL,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=86400
+	// +kubebuilder:validation:Minimum=1
+	// CacheMaxTTL - maximum time to live in seconds of the cached records
+	CacheMaxTTL int32 `json:"cacheMaxTTL,omitempty"`
+
+	// RateLimit - limits the queries of the Unbound servers, both the ones sent to the nameservers of a zone
+	// and the ones accepted from a client address
+	// +kubebuilder:validation:Optional
+	RateLimit *UnboundRateLimitSpec `json:"rateLimit,omitempty"`
+
+	// EncryptedDNS - DNS-over-TLS and DNS-over-HTTPS listeners of the Unbound servers. The
+	// listeners are exposed on the per replica services of override.services, next to port 53.
+	// +kubebuilder:validation:Optional
+	EncryptedDNS UnboundEncryptedDNSSpec `json:"encryptedDNS,omitempty"`
+
+	// ClientSubnet - EDNS Client Subnet (RFC 7871) sent by the Unbound servers to the nameservers, so that they
+	// answer based on the address of the client. This requires an Unbound build with subnetcache support.
+	// +kubebuilder:validation:Optional
+	ClientSubnet *UnboundClientSubnetSpec `json:"clientSubnet,omitempty"`
 }
 
 type UnboundOverrideSpec struct {
@@ -75,6 +135,96 @@ type StubZone struct {
 	Options map[string]string `json:"options,omitempty"`
 }
 
+// ForwardZone - a forward-zone entry of the managed Unbound servers
+type ForwardZone struct {
+	Name string `json:"name"`
+	// Forwarders - IP addresses of the servers queries are forwarded to, with an optional
+	// port, e.g. 192.0.2.1 or 192.0.2.1@5353
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Forwarders []string `json:"forwarders"`
+	// +kubebuilder:validation:Optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// UnboundAccessControl - an access-control entry of the managed Unbound servers
+type UnboundAccessControl struct {
+	// CIDR - the netblock the action applies to
+	CIDR string `json:"cidr"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=allow
+	// +kubebuilder:validation:Enum=allow;deny;refuse;allow_snoop;deny_non_local;refuse_non_local
+	// Action - the unbound access-control action for the netblock
+	Action string `json:"action,omitempty"`
+}
+
+// UnboundRateLimitSpec - query rate limits of the managed Unbound servers
+type UnboundRateLimitSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1000
+	// +kubebuilder:validation:Minimum=0
+	// QueriesPerSecond - queries per second sent to the nameservers of a zone, 0 disables the limit
+	QueriesPerSecond int32 `json:"queriesPerSecond"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=0
+	// IPQueriesPerSecond - queries per second accepted from a client address, 0 disables the limit
+	IPQueriesPerSecond int32 `json:"ipQueriesPerSecond"`
+}
+
+// UnboundClientSubnetSpec - EDNS Client Subnet of the managed Unbound servers
+type UnboundClientSubnetSpec struct {
+	// SendTo - addresses or netblocks of the nameservers the client subnet is sent to, e.g. the addresses of
+	// the bind9 servers
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	SendTo []string `json:"sendTo"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=24
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=32
+	// MaxPrefixIPv4 - prefix length of the IPv4 client addresses sent to the nameservers
+	MaxPrefixIPv4 int32 `json:"maxPrefixIPv4"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=56
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	// MaxPrefixIPv6 - prefix length of the IPv6 client addresses sent to the nameservers
+	MaxPrefixIPv6 int32 `json:"maxPrefixIPv6"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// AlwaysForward - send the client subnet to the nameservers even when the query doesn't carry it
+	AlwaysForward bool `json:"alwaysForward"`
+}
+
+// UnboundEncryptedDNSSpec - encrypted recursive DNS listeners of the managed Unbound servers
+// +kubebuilder:validation:XValidation:rule="!(self.dnsOverTLS || self.dnsOverHTTPS) || has(self.secretName)",message="secretName is required when dnsOverTLS or dnsOverHTTPS is enabled"
+type UnboundEncryptedDNSSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// DNSOverTLS - enable a DNS-over-TLS (RFC 7858) listener on port 853
+	DNSOverTLS bool `json:"dnsOverTLS"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// DNSOverHTTPS - enable a DNS-over-HTTPS (RFC 8484) listener on port 443. This requires
+	// an Unbound build with libnghttp2 support in the container image.
+	DNSOverHTTPS bool `json:"dnsOverHTTPS"`
+
+	// secretName of the kubernetes.io/tls Secret with the certificate served on the
+	// encrypted listeners, e.g. the one issued for a cert-manager Certificate
+	tls.GenericService `json:",inline"`
+}
+
+// Enabled - returns true if any of the encrypted listeners is enabled
+func (e UnboundEncryptedDNSSpec) Enabled() bool {
+	return e.DNSOverTLS || e.DNSOverHTTPS
+}
+
 // DesignateUnboundStatus defines the observed state of DesignateUnbound
 type DesignateUnboundStatus struct {
 	// ReadyCount of designate central instances
@@ -83,12 +233,19 @@ type DesignateUnboundStatus struct {
 	// Map of hashes to track e.g. job status
 	Hash map[string]string `json:"hash,omitempty"`
 
+	// InputHashes - the hash of every input of the pods, e.g. a mounted Secret, the pods
+	// restart when one of them changes
+	InputHashes map[string]string `json:"inputHashes,omitempty"`
+
 	// Conditions
 	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
 
 	// NetworkAttachments status of the deployment pods
 	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
 
+	// PodIPs - the IPs of the unbound pods on their first network attachment, by pod name
+	PodIPs map[string]string `json:"podIPs,omitempty"`
+
 	// ObservedGeneration - the most recent generation observed for this
 	// service. If the observed generation is less than the spec generation,
 	// then the controller has not processed the latest changes injected by
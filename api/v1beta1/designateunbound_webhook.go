@@ -0,0 +1,143 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var designateunboundlog = logf.Log.WithName("designateunbound-resource")
+
+// Default sets the default container images of the DesignateUnbound when not set
+func (r *DesignateUnbound) Default() {
+	designateunboundlog.Info("default", "name", r.Name)
+	r.Spec.Default()
+}
+
+// Default sets the default container images of this DesignateUnbound spec,
+// which come from the RELATED_IMAGE_* environment variables of the operator
+func (spec *DesignateUnboundSpec) Default() {
+	if spec.ContainerImage == "" {
+		spec.ContainerImage = designateDefaults.UnboundContainerImageURL
+	}
+}
+
+// ValidateCreate validates the DesignateUnbound spec upon creation
+func (r *DesignateUnbound) ValidateCreate() (admission.Warnings, error) {
+	designateunboundlog.Info("validate create", "name", r.Name)
+
+	return nil, r.validate()
+}
+
+// ValidateUpdate validates the DesignateUnbound spec upon update
+func (r *DesignateUnbound) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	designateunboundlog.Info("validate update", "name", r.Name)
+
+	oldUnbound, ok := old.(*DesignateUnbound)
+	if !ok || oldUnbound == nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to convert existing object"))
+	}
+
+	return nil, r.validate()
+}
+
+// ValidateDelete validates the DesignateUnbound upon deletion
+func (r *DesignateUnbound) ValidateDelete() (admission.Warnings, error) {
+	designateunboundlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+func (r *DesignateUnbound) validate() error {
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec")
+
+	allErrs = append(allErrs, r.Spec.ValidateTopology(basePath, r.Namespace)...)
+	allErrs = append(allErrs, r.Spec.ValidateUnboundConfig(basePath)...)
+
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "designate.openstack.org", Kind: "DesignateUnbound"},
+			r.Name, allErrs)
+	}
+	return nil
+}
+
+// ValidateUnboundConfig - Returns an ErrorList if the forward zones or the
+// access-control entries would not render into a valid unbound.conf
+func (spec *DesignateUnboundSpecBase) ValidateUnboundConfig(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	stubZones := make(map[string]bool, len(spec.StubZones))
+	for _, zone := range spec.StubZones {
+		stubZones[strings.TrimSuffix(zone.Name, ".")] = true
+	}
+
+	forwardZones := make(map[string]bool, len(spec.ForwardZones))
+	for i, zone := range spec.ForwardZones {
+		path := basePath.Child("forwardZones").Index(i)
+		name := strings.TrimSuffix(zone.Name, ".")
+		switch {
+		case zone.Name == "":
+			allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+		case stubZones[name]:
+			allErrs = append(allErrs, field.Invalid(path.Child("name"), zone.Name,
+				"zone is already configured as a stub zone"))
+		case forwardZones[name]:
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), zone.Name))
+		}
+		forwardZones[name] = true
+
+		for j, forwarder := range zone.Forwarders {
+			if !validForwarder(forwarder) {
+				allErrs = append(allErrs, field.Invalid(path.Child("forwarders").Index(j), forwarder,
+					"must be an IP address with an optional @port suffix"))
+			}
+		}
+	}
+
+	for i, acl := range spec.AccessControl {
+		if _, _, err := net.ParseCIDR(acl.CIDR); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				basePath.Child("accessControl").Index(i).Child("cidr"), acl.CIDR, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validForwarder returns true if the forwarder is an IP address, optionally
+// followed by @ and a port as unbound expects in forward-addr
+func validForwarder(forwarder string) bool {
+	addr, port, hasPort := strings.Cut(forwarder, "@")
+	if hasPort {
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return false
+		}
+	}
+	return net.ParseIP(addr) != nil
+}
@@ -0,0 +1,134 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DesignateZoneType - the type of a zone in Designate
+type DesignateZoneType string
+
+const (
+	// DesignateZoneTypePrimary - the zone is managed by Designate
+	DesignateZoneTypePrimary DesignateZoneType = "PRIMARY"
+	// DesignateZoneTypeSecondary - the zone is transferred from the masters
+	DesignateZoneTypeSecondary DesignateZoneType = "SECONDARY"
+)
+
+// DesignateZoneSpec defines a DNS zone managed through the Designate API
+type DesignateZoneSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=designate
+	// DesignateName - name of the Designate CR hosting the zone
+	DesignateName string `json:"designateName"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?\.)+$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="zoneName is immutable"
+	// ZoneName - fully qualified name of the zone, with the trailing dot, e.g. example.com.
+	ZoneName string `json:"zoneName"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=PRIMARY
+	// +kubebuilder:validation:Enum=PRIMARY;SECONDARY
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	// Type - PRIMARY zones are managed by Designate, SECONDARY zones are
+	// transferred from the Masters
+	Type DesignateZoneType `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	// Email - email of the zone administrator, required for PRIMARY zones
+	Email string `json:"email,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TTL - default TTL of the zone in seconds, defaults to the Designate default
+	TTL *int32 `json:"ttl,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Description - description of the zone
+	Description string `json:"description,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// Masters - servers a SECONDARY zone is transferred from
+	Masters []string `json:"masters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="attributes are immutable"
+	// Attributes - zone attributes used by the pool scheduler filters, e.g. pool_id
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// DesignateZoneStatus defines the observed state of DesignateZone
+type DesignateZoneStatus struct {
+	// ZoneID - ID of the zone in Designate
+	ZoneID string `json:"zoneID,omitempty"`
+
+	// Serial - serial of the zone reported by Designate
+	Serial int64 `json:"serial,omitempty"`
+
+	// ZoneStatus - status of the zone reported by Designate, e.g. ACTIVE, PENDING or ERROR
+	ZoneStatus string `json:"zoneStatus,omitempty"`
+
+	// PoolID - ID of the pool hosting the zone
+	PoolID string `json:"poolID,omitempty"`
+
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this
+	// service. If the observed generation is less than the spec generation,
+	// then the controller has not processed the latest changes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Zone",type="string",JSONPath=".spec.zoneName",description="Zone"
+// +kubebuilder:printcolumn:name="Serial",type="integer",JSONPath=".status.serial",description="Serial"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
+// +kubebuilder:validation:XValidation:rule="self.spec.type != 'PRIMARY' || has(self.spec.email)",message="email is required for PRIMARY zones"
+// +kubebuilder:validation:XValidation:rule="self.spec.type != 'SECONDARY' || (has(self.spec.masters) && size(self.spec.masters) > 0)",message="masters are required for SECONDARY zones"
+
+// DesignateZone is the Schema for the designatezones API
+type DesignateZone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DesignateZoneSpec   `json:"spec,omitempty"`
+	Status DesignateZoneStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DesignateZoneList contains a list of DesignateZone
+type DesignateZoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DesignateZone `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DesignateZone{}, &DesignateZoneList{})
+}
+
+// IsReady - returns true if the zone is active in Designate
+func (instance DesignateZone) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(DesignateZoneReadyCondition)
+}
@@ -26,9 +26,33 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APICORSSpec) DeepCopyInto(out *APICORSSpec) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APICORSSpec.
+func (in *APICORSSpec) DeepCopy() *APICORSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APICORSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIOverrideSpec) DeepCopyInto(out *APIOverrideSpec) {
 	*out = *in
@@ -51,6 +75,56 @@ func (in *APIOverrideSpec) DeepCopy() *APIOverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIPolicyOverrideSpec) DeepCopyInto(out *APIPolicyOverrideSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIPolicyOverrideSpec.
+func (in *APIPolicyOverrideSpec) DeepCopy() *APIPolicyOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIPolicyOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIReadOnlySpec) DeepCopyInto(out *APIReadOnlySpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIReadOnlySpec.
+func (in *APIReadOnlySpec) DeepCopy() *APIReadOnlySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIReadOnlySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIWSGISpec) DeepCopyInto(out *APIWSGISpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIWSGISpec.
+func (in *APIWSGISpec) DeepCopy() *APIWSGISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIWSGISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthSpec) DeepCopyInto(out *AuthSpec) {
 	*out = *in
@@ -66,9 +140,74 @@ func (in *AuthSpec) DeepCopy() *AuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9CatalogZoneSpec) DeepCopyInto(out *Bind9CatalogZoneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9CatalogZoneSpec.
+func (in *Bind9CatalogZoneSpec) DeepCopy() *Bind9CatalogZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9CatalogZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9GeoIPSpec) DeepCopyInto(out *Bind9GeoIPSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9GeoIPSpec.
+func (in *Bind9GeoIPSpec) DeepCopy() *Bind9GeoIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9GeoIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9LogVolumeSpec) DeepCopyInto(out *Bind9LogVolumeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9LogVolumeSpec.
+func (in *Bind9LogVolumeSpec) DeepCopy() *Bind9LogVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9LogVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9MetricsSpec) DeepCopyInto(out *Bind9MetricsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9MetricsSpec.
+func (in *Bind9MetricsSpec) DeepCopy() *Bind9MetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9MetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bind9OverrideSpec) DeepCopyInto(out *Bind9OverrideSpec) {
 	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(service.OverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]service.OverrideSpec, len(*in))
@@ -88,6 +227,81 @@ func (in *Bind9OverrideSpec) DeepCopy() *Bind9OverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9RateLimitSpec) DeepCopyInto(out *Bind9RateLimitSpec) {
+	*out = *in
+	if in.ExemptClients != nil {
+		in, out := &in.ExemptClients, &out.ExemptClients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9RateLimitSpec.
+func (in *Bind9RateLimitSpec) DeepCopy() *Bind9RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9ResponsePolicyZoneSpec) DeepCopyInto(out *Bind9ResponsePolicyZoneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9ResponsePolicyZoneSpec.
+func (in *Bind9ResponsePolicyZoneSpec) DeepCopy() *Bind9ResponsePolicyZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9ResponsePolicyZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9SecondaryServerSpec) DeepCopyInto(out *Bind9SecondaryServerSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9SecondaryServerSpec.
+func (in *Bind9SecondaryServerSpec) DeepCopy() *Bind9SecondaryServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9SecondaryServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bind9ViewSpec) DeepCopyInto(out *Bind9ViewSpec) {
+	*out = *in
+	if in.MatchClients != nil {
+		in, out := &in.MatchClients, &out.MatchClients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomViewOptions != nil {
+		in, out := &in.CustomViewOptions, &out.CustomViewOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind9ViewSpec.
+func (in *Bind9ViewSpec) DeepCopy() *Bind9ViewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind9ViewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Designate) DeepCopyInto(out *Designate) {
 	*out = *in
@@ -200,9 +414,34 @@ func (in *DesignateAPISpecBase) DeepCopyInto(out *DesignateAPISpecBase) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(DesignateAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Override.DeepCopyInto(&out.Override)
 	in.TLS.DeepCopyInto(&out.TLS)
 	out.Auth = in.Auth
+	if in.APIPolicyOverride != nil {
+		in, out := &in.APIPolicyOverride, &out.APIPolicyOverride
+		*out = new(APIPolicyOverrideSpec)
+		**out = **in
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(APICORSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WSGI != nil {
+		in, out := &in.WSGI, &out.WSGI
+		*out = new(APIWSGISpec)
+		**out = **in
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(APIReadOnlySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateAPISpecBase.
@@ -242,6 +481,13 @@ func (in *DesignateAPIStatus) DeepCopyInto(out *DesignateAPIStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.APIEndpoints != nil {
 		in, out := &in.APIEndpoints, &out.APIEndpoints
 		*out = make(map[string]map[string]string, len(*in))
@@ -317,7 +563,95 @@ func (in *DesignateAPITemplate) DeepCopy() *DesignateAPITemplate {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateBackendbind9) DeepCopyInto(out *DesignateBackendbind9) {
+func (in *DesignateAppliedPool) DeepCopyInto(out *DesignateAppliedPool) {
+	*out = *in
+	if in.NSRecords != nil {
+		in, out := &in.NSRecords, &out.NSRecords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlsoNotifies != nil {
+		in, out := &in.AlsoNotifies, &out.AlsoNotifies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateAppliedPool.
+func (in *DesignateAppliedPool) DeepCopy() *DesignateAppliedPool {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateAppliedPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateAutoscalingCustomMetric) DeepCopyInto(out *DesignateAutoscalingCustomMetric) {
+	*out = *in
+	out.TargetAverageValue = in.TargetAverageValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateAutoscalingCustomMetric.
+func (in *DesignateAutoscalingCustomMetric) DeepCopy() *DesignateAutoscalingCustomMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateAutoscalingCustomMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateAutoscalingSpec) DeepCopyInto(out *DesignateAutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CustomMetrics != nil {
+		in, out := &in.CustomMetrics, &out.CustomMetrics
+		*out = make([]DesignateAutoscalingCustomMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateAutoscalingSpec.
+func (in *DesignateAutoscalingSpec) DeepCopy() *DesignateAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateBackendPDNS) DeepCopyInto(out *DesignateBackendPDNS) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -325,18 +659,18 @@ func (in *DesignateBackendbind9) DeepCopyInto(out *DesignateBackendbind9) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9.
-func (in *DesignateBackendbind9) DeepCopy() *DesignateBackendbind9 {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendPDNS.
+func (in *DesignateBackendPDNS) DeepCopy() *DesignateBackendPDNS {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateBackendbind9)
+	out := new(DesignateBackendPDNS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateBackendbind9) DeepCopyObject() runtime.Object {
+func (in *DesignateBackendPDNS) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -344,31 +678,31 @@ func (in *DesignateBackendbind9) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateBackendbind9List) DeepCopyInto(out *DesignateBackendbind9List) {
+func (in *DesignateBackendPDNSList) DeepCopyInto(out *DesignateBackendPDNSList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DesignateBackendbind9, len(*in))
+		*out = make([]DesignateBackendPDNS, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9List.
-func (in *DesignateBackendbind9List) DeepCopy() *DesignateBackendbind9List {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendPDNSList.
+func (in *DesignateBackendPDNSList) DeepCopy() *DesignateBackendPDNSList {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateBackendbind9List)
+	out := new(DesignateBackendPDNSList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateBackendbind9List) DeepCopyObject() runtime.Object {
+func (in *DesignateBackendPDNSList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -376,68 +710,66 @@ func (in *DesignateBackendbind9List) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateBackendbind9Spec) DeepCopyInto(out *DesignateBackendbind9Spec) {
+func (in *DesignateBackendPDNSSpec) DeepCopyInto(out *DesignateBackendPDNSSpec) {
 	*out = *in
 	in.DesignateServiceTemplate.DeepCopyInto(&out.DesignateServiceTemplate)
-	in.DesignateBackendbind9SpecBase.DeepCopyInto(&out.DesignateBackendbind9SpecBase)
+	in.DesignateBackendPDNSSpecBase.DeepCopyInto(&out.DesignateBackendPDNSSpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9Spec.
-func (in *DesignateBackendbind9Spec) DeepCopy() *DesignateBackendbind9Spec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendPDNSSpec.
+func (in *DesignateBackendPDNSSpec) DeepCopy() *DesignateBackendPDNSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateBackendbind9Spec)
+	out := new(DesignateBackendPDNSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateBackendbind9SpecBase) DeepCopyInto(out *DesignateBackendbind9SpecBase) {
+func (in *DesignateBackendPDNSSpecBase) DeepCopyInto(out *DesignateBackendPDNSSpecBase) {
 	*out = *in
-	out.DesignateTemplate = in.DesignateTemplate
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
 		**out = **in
 	}
-	if in.CustomBindOptions != nil {
-		in, out := &in.CustomBindOptions, &out.CustomBindOptions
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
 	}
-	in.Override.DeepCopyInto(&out.Override)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9SpecBase.
-func (in *DesignateBackendbind9SpecBase) DeepCopy() *DesignateBackendbind9SpecBase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendPDNSSpecBase.
+func (in *DesignateBackendPDNSSpecBase) DeepCopy() *DesignateBackendPDNSSpecBase {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateBackendbind9SpecBase)
+	out := new(DesignateBackendPDNSSpecBase)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateBackendbind9SpecCore) DeepCopyInto(out *DesignateBackendbind9SpecCore) {
+func (in *DesignateBackendPDNSSpecCore) DeepCopyInto(out *DesignateBackendPDNSSpecCore) {
 	*out = *in
 	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
-	in.DesignateBackendbind9SpecBase.DeepCopyInto(&out.DesignateBackendbind9SpecBase)
+	in.DesignateBackendPDNSSpecBase.DeepCopyInto(&out.DesignateBackendPDNSSpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9SpecCore.
-func (in *DesignateBackendbind9SpecCore) DeepCopy() *DesignateBackendbind9SpecCore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendPDNSSpecCore.
+func (in *DesignateBackendPDNSSpecCore) DeepCopy() *DesignateBackendPDNSSpecCore {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateBackendbind9SpecCore)
+	out := new(DesignateBackendPDNSSpecCore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateBackendbind9Status) DeepCopyInto(out *DesignateBackendbind9Status) {
+func (in *DesignateBackendPDNSStatus) DeepCopyInto(out *DesignateBackendPDNSStatus) {
 	*out = *in
 	if in.Hash != nil {
 		in, out := &in.Hash, &out.Hash
@@ -446,6 +778,13 @@ func (in *DesignateBackendbind9Status) DeepCopyInto(out *DesignateBackendbind9St
 			(*out)[key] = val
 		}
 	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -476,18 +815,18 @@ func (in *DesignateBackendbind9Status) DeepCopyInto(out *DesignateBackendbind9St
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9Status.
-func (in *DesignateBackendbind9Status) DeepCopy() *DesignateBackendbind9Status {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendPDNSStatus.
+func (in *DesignateBackendPDNSStatus) DeepCopy() *DesignateBackendPDNSStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateBackendbind9Status)
+	out := new(DesignateBackendPDNSStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateCentral) DeepCopyInto(out *DesignateCentral) {
+func (in *DesignateBackendbind9) DeepCopyInto(out *DesignateBackendbind9) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -495,18 +834,18 @@ func (in *DesignateCentral) DeepCopyInto(out *DesignateCentral) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentral.
-func (in *DesignateCentral) DeepCopy() *DesignateCentral {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9.
+func (in *DesignateBackendbind9) DeepCopy() *DesignateBackendbind9 {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateCentral)
+	out := new(DesignateBackendbind9)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateCentral) DeepCopyObject() runtime.Object {
+func (in *DesignateBackendbind9) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -514,31 +853,31 @@ func (in *DesignateCentral) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateCentralList) DeepCopyInto(out *DesignateCentralList) {
+func (in *DesignateBackendbind9List) DeepCopyInto(out *DesignateBackendbind9List) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DesignateCentral, len(*in))
+		*out = make([]DesignateBackendbind9, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralList.
-func (in *DesignateCentralList) DeepCopy() *DesignateCentralList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9List.
+func (in *DesignateBackendbind9List) DeepCopy() *DesignateBackendbind9List {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateCentralList)
+	out := new(DesignateBackendbind9List)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateCentralList) DeepCopyObject() runtime.Object {
+func (in *DesignateBackendbind9List) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -546,24 +885,24 @@ func (in *DesignateCentralList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateCentralSpec) DeepCopyInto(out *DesignateCentralSpec) {
+func (in *DesignateBackendbind9Spec) DeepCopyInto(out *DesignateBackendbind9Spec) {
 	*out = *in
 	in.DesignateServiceTemplate.DeepCopyInto(&out.DesignateServiceTemplate)
-	in.DesignateCentralSpecBase.DeepCopyInto(&out.DesignateCentralSpecBase)
+	in.DesignateBackendbind9SpecBase.DeepCopyInto(&out.DesignateBackendbind9SpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralSpec.
-func (in *DesignateCentralSpec) DeepCopy() *DesignateCentralSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9Spec.
+func (in *DesignateBackendbind9Spec) DeepCopy() *DesignateBackendbind9Spec {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateCentralSpec)
+	out := new(DesignateBackendbind9Spec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateCentralSpecBase) DeepCopyInto(out *DesignateCentralSpecBase) {
+func (in *DesignateBackendbind9SpecBase) DeepCopyInto(out *DesignateBackendbind9SpecBase) {
 	*out = *in
 	out.DesignateTemplate = in.DesignateTemplate
 	if in.Replicas != nil {
@@ -571,43 +910,108 @@ func (in *DesignateCentralSpecBase) DeepCopyInto(out *DesignateCentralSpecBase)
 		*out = new(int32)
 		**out = **in
 	}
-	out.TLS = in.TLS
-	if in.RedisHostIPs != nil {
-		in, out := &in.RedisHostIPs, &out.RedisHostIPs
+	if in.CustomBindOptions != nil {
+		in, out := &in.CustomBindOptions, &out.CustomBindOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	out.Metrics = in.Metrics
+	if in.CatalogZone != nil {
+		in, out := &in.CatalogZone, &out.CatalogZone
+		*out = new(Bind9CatalogZoneSpec)
+		**out = **in
+	}
+	if in.LogVolume != nil {
+		in, out := &in.LogVolume, &out.LogVolume
+		*out = new(Bind9LogVolumeSpec)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(Bind9RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponsePolicyZone != nil {
+		in, out := &in.ResponsePolicyZone, &out.ResponsePolicyZone
+		*out = new(Bind9ResponsePolicyZoneSpec)
+		**out = **in
+	}
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]Bind9ViewSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GeoIP != nil {
+		in, out := &in.GeoIP, &out.GeoIP
+		*out = new(Bind9GeoIPSpec)
+		**out = **in
+	}
+	if in.SecondaryServers != nil {
+		in, out := &in.SecondaryServers, &out.SecondaryServers
+		*out = make([]Bind9SecondaryServerSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.PredictableIPNetworks != nil {
+		in, out := &in.PredictableIPNetworks, &out.PredictableIPNetworks
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StaticIPs != nil {
+		in, out := &in.StaticIPs, &out.StaticIPs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DesignateUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Override.DeepCopyInto(&out.Override)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralSpecBase.
-func (in *DesignateCentralSpecBase) DeepCopy() *DesignateCentralSpecBase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9SpecBase.
+func (in *DesignateBackendbind9SpecBase) DeepCopy() *DesignateBackendbind9SpecBase {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateCentralSpecBase)
+	out := new(DesignateBackendbind9SpecBase)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateCentralSpecCore) DeepCopyInto(out *DesignateCentralSpecCore) {
+func (in *DesignateBackendbind9SpecCore) DeepCopyInto(out *DesignateBackendbind9SpecCore) {
 	*out = *in
 	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
-	in.DesignateCentralSpecBase.DeepCopyInto(&out.DesignateCentralSpecBase)
+	in.DesignateBackendbind9SpecBase.DeepCopyInto(&out.DesignateBackendbind9SpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralSpecCore.
-func (in *DesignateCentralSpecCore) DeepCopy() *DesignateCentralSpecCore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9SpecCore.
+func (in *DesignateBackendbind9SpecCore) DeepCopy() *DesignateBackendbind9SpecCore {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateCentralSpecCore)
+	out := new(DesignateBackendbind9SpecCore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateCentralStatus) DeepCopyInto(out *DesignateCentralStatus) {
+func (in *DesignateBackendbind9Status) DeepCopyInto(out *DesignateBackendbind9Status) {
 	*out = *in
 	if in.Hash != nil {
 		in, out := &in.Hash, &out.Hash
@@ -616,6 +1020,13 @@ func (in *DesignateCentralStatus) DeepCopyInto(out *DesignateCentralStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -639,6 +1050,13 @@ func (in *DesignateCentralStatus) DeepCopyInto(out *DesignateCentralStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PodIPs != nil {
+		in, out := &in.PodIPs, &out.PodIPs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.LastAppliedTopology != nil {
 		in, out := &in.LastAppliedTopology, &out.LastAppliedTopology
 		*out = new(topologyv1beta1.TopoRef)
@@ -646,87 +1064,18 @@ func (in *DesignateCentralStatus) DeepCopyInto(out *DesignateCentralStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralStatus.
-func (in *DesignateCentralStatus) DeepCopy() *DesignateCentralStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(DesignateCentralStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateDefaults) DeepCopyInto(out *DesignateDefaults) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateDefaults.
-func (in *DesignateDefaults) DeepCopy() *DesignateDefaults {
-	if in == nil {
-		return nil
-	}
-	out := new(DesignateDefaults)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateExtraVolMounts) DeepCopyInto(out *DesignateExtraVolMounts) {
-	*out = *in
-	if in.VolMounts != nil {
-		in, out := &in.VolMounts, &out.VolMounts
-		*out = make([]storage.VolMounts, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateExtraVolMounts.
-func (in *DesignateExtraVolMounts) DeepCopy() *DesignateExtraVolMounts {
-	if in == nil {
-		return nil
-	}
-	out := new(DesignateExtraVolMounts)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateList) DeepCopyInto(out *DesignateList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Designate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateList.
-func (in *DesignateList) DeepCopy() *DesignateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateBackendbind9Status.
+func (in *DesignateBackendbind9Status) DeepCopy() *DesignateBackendbind9Status {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateList)
+	out := new(DesignateBackendbind9Status)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateMdns) DeepCopyInto(out *DesignateMdns) {
+func (in *DesignateCentral) DeepCopyInto(out *DesignateCentral) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -734,18 +1083,18 @@ func (in *DesignateMdns) DeepCopyInto(out *DesignateMdns) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdns.
-func (in *DesignateMdns) DeepCopy() *DesignateMdns {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentral.
+func (in *DesignateCentral) DeepCopy() *DesignateCentral {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateMdns)
+	out := new(DesignateCentral)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateMdns) DeepCopyObject() runtime.Object {
+func (in *DesignateCentral) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -753,31 +1102,31 @@ func (in *DesignateMdns) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateMdnsList) DeepCopyInto(out *DesignateMdnsList) {
+func (in *DesignateCentralList) DeepCopyInto(out *DesignateCentralList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DesignateMdns, len(*in))
+		*out = make([]DesignateCentral, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsList.
-func (in *DesignateMdnsList) DeepCopy() *DesignateMdnsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralList.
+func (in *DesignateCentralList) DeepCopy() *DesignateCentralList {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateMdnsList)
+	out := new(DesignateCentralList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateMdnsList) DeepCopyObject() runtime.Object {
+func (in *DesignateCentralList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -785,24 +1134,24 @@ func (in *DesignateMdnsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateMdnsSpec) DeepCopyInto(out *DesignateMdnsSpec) {
+func (in *DesignateCentralSpec) DeepCopyInto(out *DesignateCentralSpec) {
 	*out = *in
 	in.DesignateServiceTemplate.DeepCopyInto(&out.DesignateServiceTemplate)
-	in.DesignateMdnsSpecBase.DeepCopyInto(&out.DesignateMdnsSpecBase)
+	in.DesignateCentralSpecBase.DeepCopyInto(&out.DesignateCentralSpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsSpec.
-func (in *DesignateMdnsSpec) DeepCopy() *DesignateMdnsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralSpec.
+func (in *DesignateCentralSpec) DeepCopy() *DesignateCentralSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateMdnsSpec)
+	out := new(DesignateCentralSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateMdnsSpecBase) DeepCopyInto(out *DesignateMdnsSpecBase) {
+func (in *DesignateCentralSpecBase) DeepCopyInto(out *DesignateCentralSpecBase) {
 	*out = *in
 	out.DesignateTemplate = in.DesignateTemplate
 	if in.Replicas != nil {
@@ -810,39 +1159,48 @@ func (in *DesignateMdnsSpecBase) DeepCopyInto(out *DesignateMdnsSpecBase) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(DesignateAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.TLS = in.TLS
-	in.Override.DeepCopyInto(&out.Override)
+	if in.RedisHostIPs != nil {
+		in, out := &in.RedisHostIPs, &out.RedisHostIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsSpecBase.
-func (in *DesignateMdnsSpecBase) DeepCopy() *DesignateMdnsSpecBase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralSpecBase.
+func (in *DesignateCentralSpecBase) DeepCopy() *DesignateCentralSpecBase {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateMdnsSpecBase)
+	out := new(DesignateCentralSpecBase)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateMdnsSpecCore) DeepCopyInto(out *DesignateMdnsSpecCore) {
+func (in *DesignateCentralSpecCore) DeepCopyInto(out *DesignateCentralSpecCore) {
 	*out = *in
 	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
-	in.DesignateMdnsSpecBase.DeepCopyInto(&out.DesignateMdnsSpecBase)
+	in.DesignateCentralSpecBase.DeepCopyInto(&out.DesignateCentralSpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsSpecCore.
-func (in *DesignateMdnsSpecCore) DeepCopy() *DesignateMdnsSpecCore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralSpecCore.
+func (in *DesignateCentralSpecCore) DeepCopy() *DesignateCentralSpecCore {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateMdnsSpecCore)
+	out := new(DesignateCentralSpecCore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateMdnsStatus) DeepCopyInto(out *DesignateMdnsStatus) {
+func (in *DesignateCentralStatus) DeepCopyInto(out *DesignateCentralStatus) {
 	*out = *in
 	if in.Hash != nil {
 		in, out := &in.Hash, &out.Hash
@@ -851,6 +1209,13 @@ func (in *DesignateMdnsStatus) DeepCopyInto(out *DesignateMdnsStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -881,23 +1246,470 @@ func (in *DesignateMdnsStatus) DeepCopyInto(out *DesignateMdnsStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsStatus.
-func (in *DesignateMdnsStatus) DeepCopy() *DesignateMdnsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCentralStatus.
+func (in *DesignateCentralStatus) DeepCopy() *DesignateCentralStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateMdnsStatus)
+	out := new(DesignateCentralStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateNSRecord) DeepCopyInto(out *DesignateNSRecord) {
+func (in *DesignateComponentStatus) DeepCopyInto(out *DesignateComponentStatus) {
 	*out = *in
+	if in.ImageDigests != nil {
+		in, out := &in.ImageDigests, &out.ImageDigests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateNSRecord.
-func (in *DesignateNSRecord) DeepCopy() *DesignateNSRecord {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateComponentStatus.
+func (in *DesignateComponentStatus) DeepCopy() *DesignateComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateCoordinationSpec) DeepCopyInto(out *DesignateCoordinationSpec) {
+	*out = *in
+	if in.RedisSentinel != nil {
+		in, out := &in.RedisSentinel, &out.RedisSentinel
+		*out = new(DesignateRedisSentinelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(DesignateEtcdSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateCoordinationSpec.
+func (in *DesignateCoordinationSpec) DeepCopy() *DesignateCoordinationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateCoordinationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateDatabaseAccountRotation) DeepCopyInto(out *DesignateDatabaseAccountRotation) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateDatabaseAccountRotation.
+func (in *DesignateDatabaseAccountRotation) DeepCopy() *DesignateDatabaseAccountRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateDatabaseAccountRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateDatabaseTuningSpec) DeepCopyInto(out *DesignateDatabaseTuningSpec) {
+	*out = *in
+	if in.MaxPoolSize != nil {
+		in, out := &in.MaxPoolSize, &out.MaxPoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxOverflow != nil {
+		in, out := &in.MaxOverflow, &out.MaxOverflow
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PoolTimeout != nil {
+		in, out := &in.PoolTimeout, &out.PoolTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DBMaxRetries != nil {
+		in, out := &in.DBMaxRetries, &out.DBMaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DBRetryInterval != nil {
+		in, out := &in.DBRetryInterval, &out.DBRetryInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateDatabaseTuningSpec.
+func (in *DesignateDatabaseTuningSpec) DeepCopy() *DesignateDatabaseTuningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateDatabaseTuningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateDefaults) DeepCopyInto(out *DesignateDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateDefaults.
+func (in *DesignateDefaults) DeepCopy() *DesignateDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateEtcdSpec) DeepCopyInto(out *DesignateEtcdSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateEtcdSpec.
+func (in *DesignateEtcdSpec) DeepCopy() *DesignateEtcdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateEtcdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateExternalBindServer) DeepCopyInto(out *DesignateExternalBindServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateExternalBindServer.
+func (in *DesignateExternalBindServer) DeepCopy() *DesignateExternalBindServer {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateExternalBindServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateExternalDatabase) DeepCopyInto(out *DesignateExternalDatabase) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateExternalDatabase.
+func (in *DesignateExternalDatabase) DeepCopy() *DesignateExternalDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateExternalDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateExtraVolMounts) DeepCopyInto(out *DesignateExtraVolMounts) {
+	*out = *in
+	if in.VolMounts != nil {
+		in, out := &in.VolMounts, &out.VolMounts
+		*out = make([]storage.VolMounts, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateExtraVolMounts.
+func (in *DesignateExtraVolMounts) DeepCopy() *DesignateExtraVolMounts {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateExtraVolMounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateHook) DeepCopyInto(out *DesignateHook) {
+	*out = *in
+	in.Job.DeepCopyInto(&out.Job)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateHook.
+func (in *DesignateHook) DeepCopy() *DesignateHook {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateList) DeepCopyInto(out *DesignateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Designate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateList.
+func (in *DesignateList) DeepCopy() *DesignateList {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateLoggingSpec) DeepCopyInto(out *DesignateLoggingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateLoggingSpec.
+func (in *DesignateLoggingSpec) DeepCopy() *DesignateLoggingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateLoggingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateMdns) DeepCopyInto(out *DesignateMdns) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdns.
+func (in *DesignateMdns) DeepCopy() *DesignateMdns {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateMdns)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateMdns) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateMdnsList) DeepCopyInto(out *DesignateMdnsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DesignateMdns, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsList.
+func (in *DesignateMdnsList) DeepCopy() *DesignateMdnsList {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateMdnsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateMdnsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateMdnsSpec) DeepCopyInto(out *DesignateMdnsSpec) {
+	*out = *in
+	in.DesignateServiceTemplate.DeepCopyInto(&out.DesignateServiceTemplate)
+	in.DesignateMdnsSpecBase.DeepCopyInto(&out.DesignateMdnsSpecBase)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsSpec.
+func (in *DesignateMdnsSpec) DeepCopy() *DesignateMdnsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateMdnsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateMdnsSpecBase) DeepCopyInto(out *DesignateMdnsSpecBase) {
+	*out = *in
+	out.DesignateTemplate = in.DesignateTemplate
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	out.TLS = in.TLS
+	in.Override.DeepCopyInto(&out.Override)
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(DesignateUpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PredictableIPNetworks != nil {
+		in, out := &in.PredictableIPNetworks, &out.PredictableIPNetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaticIPs != nil {
+		in, out := &in.StaticIPs, &out.StaticIPs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsSpecBase.
+func (in *DesignateMdnsSpecBase) DeepCopy() *DesignateMdnsSpecBase {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateMdnsSpecBase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateMdnsSpecCore) DeepCopyInto(out *DesignateMdnsSpecCore) {
+	*out = *in
+	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
+	in.DesignateMdnsSpecBase.DeepCopyInto(&out.DesignateMdnsSpecBase)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsSpecCore.
+func (in *DesignateMdnsSpecCore) DeepCopy() *DesignateMdnsSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateMdnsSpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateMdnsStatus) DeepCopyInto(out *DesignateMdnsStatus) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.PodIPs != nil {
+		in, out := &in.PodIPs, &out.PodIPs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastAppliedTopology != nil {
+		in, out := &in.LastAppliedTopology, &out.LastAppliedTopology
+		*out = new(topologyv1beta1.TopoRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateMdnsStatus.
+func (in *DesignateMdnsStatus) DeepCopy() *DesignateMdnsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateMdnsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateNSRecord) DeepCopyInto(out *DesignateNSRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateNSRecord.
+func (in *DesignateNSRecord) DeepCopy() *DesignateNSRecord {
 	if in == nil {
 		return nil
 	}
@@ -907,7 +1719,786 @@ func (in *DesignateNSRecord) DeepCopy() *DesignateNSRecord {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateProducer) DeepCopyInto(out *DesignateProducer) {
+func (in *DesignateNetworkPolicySpec) DeepCopyInto(out *DesignateNetworkPolicySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateNetworkPolicySpec.
+func (in *DesignateNetworkPolicySpec) DeepCopy() *DesignateNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateNotificationsSpec) DeepCopyInto(out *DesignateNotificationsSpec) {
+	*out = *in
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateNotificationsSpec.
+func (in *DesignateNotificationsSpec) DeepCopy() *DesignateNotificationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateNotificationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePDBSpec) DeepCopyInto(out *DesignatePDBSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePDBSpec.
+func (in *DesignatePDBSpec) DeepCopy() *DesignatePDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePDBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePool) DeepCopyInto(out *DesignatePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePool.
+func (in *DesignatePool) DeepCopy() *DesignatePool {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignatePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePoolList) DeepCopyInto(out *DesignatePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DesignatePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePoolList.
+func (in *DesignatePoolList) DeepCopy() *DesignatePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignatePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePoolMaster) DeepCopyInto(out *DesignatePoolMaster) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePoolMaster.
+func (in *DesignatePoolMaster) DeepCopy() *DesignatePoolMaster {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePoolMaster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePoolServer) DeepCopyInto(out *DesignatePoolServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePoolServer.
+func (in *DesignatePoolServer) DeepCopy() *DesignatePoolServer {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePoolServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePoolSpec) DeepCopyInto(out *DesignatePoolSpec) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NSRecords != nil {
+		in, out := &in.NSRecords, &out.NSRecords
+		*out = make([]DesignateNSRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]DesignatePoolServer, len(*in))
+		copy(*out, *in)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]DesignatePoolTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AlsoNotifies != nil {
+		in, out := &in.AlsoNotifies, &out.AlsoNotifies
+		*out = make([]DesignatePoolServer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePoolSpec.
+func (in *DesignatePoolSpec) DeepCopy() *DesignatePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePoolStatus) DeepCopyInto(out *DesignatePoolStatus) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePoolStatus.
+func (in *DesignatePoolStatus) DeepCopy() *DesignatePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignatePoolTarget) DeepCopyInto(out *DesignatePoolTarget) {
+	*out = *in
+	if in.Masters != nil {
+		in, out := &in.Masters, &out.Masters
+		*out = make([]DesignatePoolMaster, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignatePoolTarget.
+func (in *DesignatePoolTarget) DeepCopy() *DesignatePoolTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignatePoolTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProbeSpec) DeepCopyInto(out *DesignateProbeSpec) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProbeSpec.
+func (in *DesignateProbeSpec) DeepCopy() *DesignateProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProbesSpec) DeepCopyInto(out *DesignateProbesSpec) {
+	*out = *in
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(DesignateProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(DesignateProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(DesignateProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProbesSpec.
+func (in *DesignateProbesSpec) DeepCopy() *DesignateProbesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProbesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProducer) DeepCopyInto(out *DesignateProducer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducer.
+func (in *DesignateProducer) DeepCopy() *DesignateProducer {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProducer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateProducer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProducerList) DeepCopyInto(out *DesignateProducerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DesignateProducer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerList.
+func (in *DesignateProducerList) DeepCopy() *DesignateProducerList {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProducerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateProducerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProducerSpec) DeepCopyInto(out *DesignateProducerSpec) {
+	*out = *in
+	in.DesignateServiceTemplate.DeepCopyInto(&out.DesignateServiceTemplate)
+	in.DesignateProducerSpecBase.DeepCopyInto(&out.DesignateProducerSpecBase)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerSpec.
+func (in *DesignateProducerSpec) DeepCopy() *DesignateProducerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProducerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProducerSpecBase) DeepCopyInto(out *DesignateProducerSpecBase) {
+	*out = *in
+	out.DesignateTemplate = in.DesignateTemplate
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(DesignateAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.TLS = in.TLS
+	if in.RedisHostIPs != nil {
+		in, out := &in.RedisHostIPs, &out.RedisHostIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ZonePurge != nil {
+		in, out := &in.ZonePurge, &out.ZonePurge
+		*out = new(DesignateZonePurgeSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerSpecBase.
+func (in *DesignateProducerSpecBase) DeepCopy() *DesignateProducerSpecBase {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProducerSpecBase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProducerSpecCore) DeepCopyInto(out *DesignateProducerSpecCore) {
+	*out = *in
+	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
+	in.DesignateProducerSpecBase.DeepCopyInto(&out.DesignateProducerSpecBase)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerSpecCore.
+func (in *DesignateProducerSpecCore) DeepCopy() *DesignateProducerSpecCore {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProducerSpecCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateProducerStatus) DeepCopyInto(out *DesignateProducerStatus) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.LastAppliedTopology != nil {
+		in, out := &in.LastAppliedTopology, &out.LastAppliedTopology
+		*out = new(topologyv1beta1.TopoRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerStatus.
+func (in *DesignateProducerStatus) DeepCopy() *DesignateProducerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateProducerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateRecordSet) DeepCopyInto(out *DesignateRecordSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateRecordSet.
+func (in *DesignateRecordSet) DeepCopy() *DesignateRecordSet {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateRecordSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateRecordSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateRecordSetList) DeepCopyInto(out *DesignateRecordSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DesignateRecordSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateRecordSetList.
+func (in *DesignateRecordSetList) DeepCopy() *DesignateRecordSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateRecordSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateRecordSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateRecordSetSpec) DeepCopyInto(out *DesignateRecordSetSpec) {
+	*out = *in
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateRecordSetSpec.
+func (in *DesignateRecordSetSpec) DeepCopy() *DesignateRecordSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateRecordSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateRecordSetStatus) DeepCopyInto(out *DesignateRecordSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateRecordSetStatus.
+func (in *DesignateRecordSetStatus) DeepCopy() *DesignateRecordSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateRecordSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateRedisSentinelSpec) DeepCopyInto(out *DesignateRedisSentinelSpec) {
+	*out = *in
+	if in.Sentinels != nil {
+		in, out := &in.Sentinels, &out.Sentinels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateRedisSentinelSpec.
+func (in *DesignateRedisSentinelSpec) DeepCopy() *DesignateRedisSentinelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateRedisSentinelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateServiceMeshSpec) DeepCopyInto(out *DesignateServiceMeshSpec) {
+	*out = *in
+	if in.Inject != nil {
+		in, out := &in.Inject, &out.Inject
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateServiceMeshSpec.
+func (in *DesignateServiceMeshSpec) DeepCopy() *DesignateServiceMeshSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateServiceMeshSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateServiceTemplate) DeepCopyInto(out *DesignateServiceTemplate) {
+	*out = *in
+	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateServiceTemplate.
+func (in *DesignateServiceTemplate) DeepCopy() *DesignateServiceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateServiceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateServiceTemplateCore) DeepCopyInto(out *DesignateServiceTemplateCore) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CustomServiceConfigSecrets != nil {
+		in, out := &in.CustomServiceConfigSecrets, &out.CustomServiceConfigSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultConfigOverwrite != nil {
+		in, out := &in.DefaultConfigOverwrite, &out.DefaultConfigOverwrite
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.InitResources.DeepCopyInto(&out.InitResources)
+	in.SidecarResources.DeepCopyInto(&out.SidecarResources)
+	if in.NetworkAttachments != nil {
+		in, out := &in.NetworkAttachments, &out.NetworkAttachments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopologyRef != nil {
+		in, out := &in.TopologyRef, &out.TopologyRef
+		*out = new(topologyv1beta1.TopoRef)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(DesignatePDBSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(DesignateProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]DesignateTopologySpreadConstraint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(DesignateLoggingSpec)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = make([]DesignateExtraVolMounts, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(DesignateServiceMeshSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateServiceTemplateCore.
+func (in *DesignateServiceTemplateCore) DeepCopy() *DesignateServiceTemplateCore {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateServiceTemplateCore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateSink) DeepCopyInto(out *DesignateSink) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -915,18 +2506,18 @@ func (in *DesignateProducer) DeepCopyInto(out *DesignateProducer) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducer.
-func (in *DesignateProducer) DeepCopy() *DesignateProducer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSink.
+func (in *DesignateSink) DeepCopy() *DesignateSink {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateProducer)
+	out := new(DesignateSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateProducer) DeepCopyObject() runtime.Object {
+func (in *DesignateSink) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -934,31 +2525,61 @@ func (in *DesignateProducer) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateProducerList) DeepCopyInto(out *DesignateProducerList) {
+func (in *DesignateSinkHandler) DeepCopyInto(out *DesignateSinkHandler) {
+	*out = *in
+	if in.NotificationTopics != nil {
+		in, out := &in.NotificationTopics, &out.NotificationTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FormatV4 != nil {
+		in, out := &in.FormatV4, &out.FormatV4
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FormatV6 != nil {
+		in, out := &in.FormatV6, &out.FormatV6
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSinkHandler.
+func (in *DesignateSinkHandler) DeepCopy() *DesignateSinkHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateSinkHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateSinkList) DeepCopyInto(out *DesignateSinkList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DesignateProducer, len(*in))
+		*out = make([]DesignateSink, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerList.
-func (in *DesignateProducerList) DeepCopy() *DesignateProducerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSinkList.
+func (in *DesignateSinkList) DeepCopy() *DesignateSinkList {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateProducerList)
+	out := new(DesignateSinkList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DesignateProducerList) DeepCopyObject() runtime.Object {
+func (in *DesignateSinkList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -966,24 +2587,24 @@ func (in *DesignateProducerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateProducerSpec) DeepCopyInto(out *DesignateProducerSpec) {
+func (in *DesignateSinkSpec) DeepCopyInto(out *DesignateSinkSpec) {
 	*out = *in
 	in.DesignateServiceTemplate.DeepCopyInto(&out.DesignateServiceTemplate)
-	in.DesignateProducerSpecBase.DeepCopyInto(&out.DesignateProducerSpecBase)
+	in.DesignateSinkSpecBase.DeepCopyInto(&out.DesignateSinkSpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerSpec.
-func (in *DesignateProducerSpec) DeepCopy() *DesignateProducerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSinkSpec.
+func (in *DesignateSinkSpec) DeepCopy() *DesignateSinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateProducerSpec)
+	out := new(DesignateSinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateProducerSpecBase) DeepCopyInto(out *DesignateProducerSpecBase) {
+func (in *DesignateSinkSpecBase) DeepCopyInto(out *DesignateSinkSpecBase) {
 	*out = *in
 	out.DesignateTemplate = in.DesignateTemplate
 	if in.Replicas != nil {
@@ -992,42 +2613,44 @@ func (in *DesignateProducerSpecBase) DeepCopyInto(out *DesignateProducerSpecBase
 		**out = **in
 	}
 	out.TLS = in.TLS
-	if in.RedisHostIPs != nil {
-		in, out := &in.RedisHostIPs, &out.RedisHostIPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NotificationHandlers != nil {
+		in, out := &in.NotificationHandlers, &out.NotificationHandlers
+		*out = make([]DesignateSinkHandler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerSpecBase.
-func (in *DesignateProducerSpecBase) DeepCopy() *DesignateProducerSpecBase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSinkSpecBase.
+func (in *DesignateSinkSpecBase) DeepCopy() *DesignateSinkSpecBase {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateProducerSpecBase)
+	out := new(DesignateSinkSpecBase)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateProducerSpecCore) DeepCopyInto(out *DesignateProducerSpecCore) {
+func (in *DesignateSinkSpecCore) DeepCopyInto(out *DesignateSinkSpecCore) {
 	*out = *in
 	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
-	in.DesignateProducerSpecBase.DeepCopyInto(&out.DesignateProducerSpecBase)
+	in.DesignateSinkSpecBase.DeepCopyInto(&out.DesignateSinkSpecBase)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerSpecCore.
-func (in *DesignateProducerSpecCore) DeepCopy() *DesignateProducerSpecCore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSinkSpecCore.
+func (in *DesignateSinkSpecCore) DeepCopy() *DesignateSinkSpecCore {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateProducerSpecCore)
+	out := new(DesignateSinkSpecCore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateProducerStatus) DeepCopyInto(out *DesignateProducerStatus) {
+func (in *DesignateSinkStatus) DeepCopyInto(out *DesignateSinkStatus) {
 	*out = *in
 	if in.Hash != nil {
 		in, out := &in.Hash, &out.Hash
@@ -1036,6 +2659,13 @@ func (in *DesignateProducerStatus) DeepCopyInto(out *DesignateProducerStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -1066,77 +2696,12 @@ func (in *DesignateProducerStatus) DeepCopyInto(out *DesignateProducerStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateProducerStatus.
-func (in *DesignateProducerStatus) DeepCopy() *DesignateProducerStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(DesignateProducerStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateServiceTemplate) DeepCopyInto(out *DesignateServiceTemplate) {
-	*out = *in
-	in.DesignateServiceTemplateCore.DeepCopyInto(&out.DesignateServiceTemplateCore)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateServiceTemplate.
-func (in *DesignateServiceTemplate) DeepCopy() *DesignateServiceTemplate {
-	if in == nil {
-		return nil
-	}
-	out := new(DesignateServiceTemplate)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateServiceTemplateCore) DeepCopyInto(out *DesignateServiceTemplateCore) {
-	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = new(map[string]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make(map[string]string, len(*in))
-			for key, val := range *in {
-				(*out)[key] = val
-			}
-		}
-	}
-	if in.CustomServiceConfigSecrets != nil {
-		in, out := &in.CustomServiceConfigSecrets, &out.CustomServiceConfigSecrets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.DefaultConfigOverwrite != nil {
-		in, out := &in.DefaultConfigOverwrite, &out.DefaultConfigOverwrite
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.NetworkAttachments != nil {
-		in, out := &in.NetworkAttachments, &out.NetworkAttachments
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.TopologyRef != nil {
-		in, out := &in.TopologyRef, &out.TopologyRef
-		*out = new(topologyv1beta1.TopoRef)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateServiceTemplateCore.
-func (in *DesignateServiceTemplateCore) DeepCopy() *DesignateServiceTemplateCore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSinkStatus.
+func (in *DesignateSinkStatus) DeepCopy() *DesignateSinkStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateServiceTemplateCore)
+	out := new(DesignateSinkStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1151,7 +2716,9 @@ func (in *DesignateSpec) DeepCopyInto(out *DesignateSpec) {
 	in.DesignateMdns.DeepCopyInto(&out.DesignateMdns)
 	in.DesignateProducer.DeepCopyInto(&out.DesignateProducer)
 	in.DesignateBackendbind9.DeepCopyInto(&out.DesignateBackendbind9)
+	in.DesignateBackendPDNS.DeepCopyInto(&out.DesignateBackendPDNS)
 	in.DesignateUnbound.DeepCopyInto(&out.DesignateUnbound)
+	in.DesignateSink.DeepCopyInto(&out.DesignateSink)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSpec.
@@ -1167,13 +2734,30 @@ func (in *DesignateSpec) DeepCopy() *DesignateSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DesignateSpecBase) DeepCopyInto(out *DesignateSpecBase) {
 	*out = *in
+	if in.ExternalDatabase != nil {
+		in, out := &in.ExternalDatabase, &out.ExternalDatabase
+		*out = new(DesignateExternalDatabase)
+		**out = **in
+	}
+	if in.DatabaseTuning != nil {
+		in, out := &in.DatabaseTuning, &out.DatabaseTuning
+		*out = new(DesignateDatabaseTuningSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.MessagingBus = in.MessagingBus
 	if in.NotificationsBus != nil {
 		in, out := &in.NotificationsBus, &out.NotificationsBus
 		*out = new(rabbitmqv1beta1.RabbitMqConfig)
 		**out = **in
 	}
+	in.Notifications.DeepCopyInto(&out.Notifications)
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(DesignateTracingSpec)
+		**out = **in
+	}
 	out.PasswordSelectors = in.PasswordSelectors
+	out.Auth = in.Auth
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = new(map[string]string)
@@ -1185,6 +2769,25 @@ func (in *DesignateSpecBase) DeepCopyInto(out *DesignateSpecBase) {
 			}
 		}
 	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = make([]DesignateExtraVolMounts, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]DesignateHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DefaultConfigOverwrite != nil {
 		in, out := &in.DefaultConfigOverwrite, &out.DefaultConfigOverwrite
 		*out = make(map[string]string, len(*in))
@@ -1193,6 +2796,7 @@ func (in *DesignateSpecBase) DeepCopyInto(out *DesignateSpecBase) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	in.Coordination.DeepCopyInto(&out.Coordination)
 	if in.TopologyRef != nil {
 		in, out := &in.TopologyRef, &out.TopologyRef
 		*out = new(topologyv1beta1.TopoRef)
@@ -1203,6 +2807,20 @@ func (in *DesignateSpecBase) DeepCopyInto(out *DesignateSpecBase) {
 		*out = make([]DesignateNSRecord, len(*in))
 		copy(*out, *in)
 	}
+	in.PredictableIPs.DeepCopyInto(&out.PredictableIPs)
+	if in.ExternalBindServers != nil {
+		in, out := &in.ExternalBindServers, &out.ExternalBindServers
+		*out = make([]DesignateExternalBindServer, len(*in))
+		copy(*out, *in)
+	}
+	if in.RndcKeyRotationInterval != nil {
+		in, out := &in.RndcKeyRotationInterval, &out.RndcKeyRotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.Telemetry = in.Telemetry
+	out.NetworkPolicy = in.NetworkPolicy
+	out.ZoneConsistency = in.ZoneConsistency
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSpecBase.
@@ -1225,7 +2843,9 @@ func (in *DesignateSpecCore) DeepCopyInto(out *DesignateSpecCore) {
 	in.DesignateMdns.DeepCopyInto(&out.DesignateMdns)
 	in.DesignateProducer.DeepCopyInto(&out.DesignateProducer)
 	in.DesignateBackendbind9.DeepCopyInto(&out.DesignateBackendbind9)
+	in.DesignateBackendPDNS.DeepCopyInto(&out.DesignateBackendPDNS)
 	in.DesignateUnbound.DeepCopyInto(&out.DesignateUnbound)
+	in.DesignateSink.DeepCopyInto(&out.DesignateSink)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateSpecCore.
@@ -1262,35 +2882,103 @@ func (in *DesignateStatus) DeepCopyInto(out *DesignateStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DatabaseAccountRotation != nil {
+		in, out := &in.DatabaseAccountRotation, &out.DatabaseAccountRotation
+		*out = new(DesignateDatabaseAccountRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]DesignateAppliedPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]DesignateComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.RedisHostIPs != nil {
 		in, out := &in.RedisHostIPs, &out.RedisHostIPs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ZoneConsistencyCheckTime != nil {
+		in, out := &in.ZoneConsistencyCheckTime, &out.ZoneConsistencyCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateStatus.
+func (in *DesignateStatus) DeepCopy() *DesignateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateTelemetrySpec) DeepCopyInto(out *DesignateTelemetrySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateTelemetrySpec.
+func (in *DesignateTelemetrySpec) DeepCopy() *DesignateTelemetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateTelemetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateTemplate) DeepCopyInto(out *DesignateTemplate) {
+	*out = *in
+	out.PasswordSelectors = in.PasswordSelectors
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateTemplate.
+func (in *DesignateTemplate) DeepCopy() *DesignateTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateTemplate)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateStatus.
-func (in *DesignateStatus) DeepCopy() *DesignateStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateTopologySpreadConstraint) DeepCopyInto(out *DesignateTopologySpreadConstraint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateTopologySpreadConstraint.
+func (in *DesignateTopologySpreadConstraint) DeepCopy() *DesignateTopologySpreadConstraint {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateStatus)
+	out := new(DesignateTopologySpreadConstraint)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DesignateTemplate) DeepCopyInto(out *DesignateTemplate) {
+func (in *DesignateTracingSpec) DeepCopyInto(out *DesignateTracingSpec) {
 	*out = *in
-	out.PasswordSelectors = in.PasswordSelectors
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateTemplate.
-func (in *DesignateTemplate) DeepCopy() *DesignateTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateTracingSpec.
+func (in *DesignateTracingSpec) DeepCopy() *DesignateTracingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DesignateTemplate)
+	out := new(DesignateTracingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1387,6 +3075,29 @@ func (in *DesignateUnboundSpecBase) DeepCopyInto(out *DesignateUnboundSpecBase)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ForwardZones != nil {
+		in, out := &in.ForwardZones, &out.ForwardZones
+		*out = make([]ForwardZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AccessControl != nil {
+		in, out := &in.AccessControl, &out.AccessControl
+		*out = make([]UnboundAccessControl, len(*in))
+		copy(*out, *in)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(UnboundRateLimitSpec)
+		**out = **in
+	}
+	in.EncryptedDNS.DeepCopyInto(&out.EncryptedDNS)
+	if in.ClientSubnet != nil {
+		in, out := &in.ClientSubnet, &out.ClientSubnet
+		*out = new(UnboundClientSubnetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateUnboundSpecBase.
@@ -1426,6 +3137,13 @@ func (in *DesignateUnboundStatus) DeepCopyInto(out *DesignateUnboundStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -1449,6 +3167,13 @@ func (in *DesignateUnboundStatus) DeepCopyInto(out *DesignateUnboundStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.PodIPs != nil {
+		in, out := &in.PodIPs, &out.PodIPs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.LastAppliedTopology != nil {
 		in, out := &in.LastAppliedTopology, &out.LastAppliedTopology
 		*out = new(topologyv1beta1.TopoRef)
@@ -1466,6 +3191,26 @@ func (in *DesignateUnboundStatus) DeepCopy() *DesignateUnboundStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateUpdateStrategySpec) DeepCopyInto(out *DesignateUpdateStrategySpec) {
+	*out = *in
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateUpdateStrategySpec.
+func (in *DesignateUpdateStrategySpec) DeepCopy() *DesignateUpdateStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateUpdateStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DesignateWorker) DeepCopyInto(out *DesignateWorker) {
 	*out = *in
@@ -1551,6 +3296,11 @@ func (in *DesignateWorkerSpecBase) DeepCopyInto(out *DesignateWorkerSpecBase) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(DesignateAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.TLS = in.TLS
 }
 
@@ -1591,6 +3341,13 @@ func (in *DesignateWorkerStatus) DeepCopyInto(out *DesignateWorkerStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.InputHashes != nil {
+		in, out := &in.InputHashes, &out.InputHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(condition.Conditions, len(*in))
@@ -1631,9 +3388,185 @@ func (in *DesignateWorkerStatus) DeepCopy() *DesignateWorkerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateZone) DeepCopyInto(out *DesignateZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateZone.
+func (in *DesignateZone) DeepCopy() *DesignateZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateZoneConsistencySpec) DeepCopyInto(out *DesignateZoneConsistencySpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateZoneConsistencySpec.
+func (in *DesignateZoneConsistencySpec) DeepCopy() *DesignateZoneConsistencySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateZoneConsistencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateZoneList) DeepCopyInto(out *DesignateZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DesignateZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateZoneList.
+func (in *DesignateZoneList) DeepCopy() *DesignateZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DesignateZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateZonePurgeSpec) DeepCopyInto(out *DesignateZonePurgeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateZonePurgeSpec.
+func (in *DesignateZonePurgeSpec) DeepCopy() *DesignateZonePurgeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateZonePurgeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateZoneSpec) DeepCopyInto(out *DesignateZoneSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Masters != nil {
+		in, out := &in.Masters, &out.Masters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateZoneSpec.
+func (in *DesignateZoneSpec) DeepCopy() *DesignateZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DesignateZoneStatus) DeepCopyInto(out *DesignateZoneStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesignateZoneStatus.
+func (in *DesignateZoneStatus) DeepCopy() *DesignateZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DesignateZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardZone) DeepCopyInto(out *ForwardZone) {
+	*out = *in
+	if in.Forwarders != nil {
+		in, out := &in.Forwarders, &out.Forwarders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardZone.
+func (in *ForwardZone) DeepCopy() *ForwardZone {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MdnsOverrideSpec) DeepCopyInto(out *MdnsOverrideSpec) {
 	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(service.OverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]service.OverrideSpec, len(*in))
@@ -1668,6 +3601,26 @@ func (in *PasswordSelector) DeepCopy() *PasswordSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictableIPSpec) DeepCopyInto(out *PredictableIPSpec) {
+	*out = *in
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictableIPSpec.
+func (in *PredictableIPSpec) DeepCopy() *PredictableIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictableIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StubZone) DeepCopyInto(out *StubZone) {
 	*out = *in
@@ -1690,6 +3643,57 @@ func (in *StubZone) DeepCopy() *StubZone {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnboundAccessControl) DeepCopyInto(out *UnboundAccessControl) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnboundAccessControl.
+func (in *UnboundAccessControl) DeepCopy() *UnboundAccessControl {
+	if in == nil {
+		return nil
+	}
+	out := new(UnboundAccessControl)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnboundClientSubnetSpec) DeepCopyInto(out *UnboundClientSubnetSpec) {
+	*out = *in
+	if in.SendTo != nil {
+		in, out := &in.SendTo, &out.SendTo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnboundClientSubnetSpec.
+func (in *UnboundClientSubnetSpec) DeepCopy() *UnboundClientSubnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UnboundClientSubnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnboundEncryptedDNSSpec) DeepCopyInto(out *UnboundEncryptedDNSSpec) {
+	*out = *in
+	in.GenericService.DeepCopyInto(&out.GenericService)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnboundEncryptedDNSSpec.
+func (in *UnboundEncryptedDNSSpec) DeepCopy() *UnboundEncryptedDNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UnboundEncryptedDNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnboundOverrideSpec) DeepCopyInto(out *UnboundOverrideSpec) {
 	*out = *in
@@ -1711,3 +3715,18 @@ func (in *UnboundOverrideSpec) DeepCopy() *UnboundOverrideSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnboundRateLimitSpec) DeepCopyInto(out *UnboundRateLimitSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnboundRateLimitSpec.
+func (in *UnboundRateLimitSpec) DeepCopy() *UnboundRateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UnboundRateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -23,16 +23,21 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -45,8 +50,13 @@ import (
 
 	// +kubebuilder:scaffold:imports
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	oshiftapi "github.com/openshift/api/operator/v1"
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
@@ -71,6 +81,7 @@ func init() {
 	utilruntime.Must(redisv1.AddToScheme(scheme))
 	utilruntime.Must(networkv1.AddToScheme(scheme))
 	utilruntime.Must(topologyv1.AddToScheme(scheme))
+	utilruntime.Must(infranetworkv1.AddToScheme(scheme))
 	utilruntime.Must(oshiftapi.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -86,7 +97,9 @@ func main() {
 	var webhookPort int
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var cacheSyncPeriod time.Duration
 	var tlsOpts []func(*tls.Config)
+	reconcileOptions := controller.DefaultReconcileOptions()
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -106,6 +119,18 @@ func main() {
 	flag.IntVar(&webhookPort, "webhook-bind-address", 9443, "The port the webhook server binds to.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&reconcileOptions.MaxConcurrentReconciles, "max-concurrent-reconciles",
+		reconcileOptions.MaxConcurrentReconciles, "The number of objects of a kind each controller reconciles in parallel.")
+	flag.DurationVar(&reconcileOptions.RateLimiterBaseDelay, "rate-limiter-base-delay",
+		reconcileOptions.RateLimiterBaseDelay, "The delay of the first retry of a failed reconcile, doubled at each failure.")
+	flag.DurationVar(&reconcileOptions.RateLimiterMaxDelay, "rate-limiter-max-delay",
+		reconcileOptions.RateLimiterMaxDelay, "The maximum delay between the retries of a failed reconcile.")
+	flag.Float64Var(&reconcileOptions.RateLimiterQPS, "rate-limiter-qps",
+		reconcileOptions.RateLimiterQPS, "The overall rate of the reconciles of each controller.")
+	flag.IntVar(&reconcileOptions.RateLimiterBurst, "rate-limiter-burst",
+		reconcileOptions.RateLimiterBurst, "The burst of the reconciles of each controller above the rate-limiter-qps.")
+	flag.DurationVar(&cacheSyncPeriod, "cache-sync-period", 0,
+		"The period the watched objects are all reconciled again. Leave as 0 for the controller-runtime default.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -231,6 +256,24 @@ func main() {
 		setupLog.Error(err, "unable to set manager options")
 		os.Exit(1)
 	}
+	if cacheSyncPeriod > 0 {
+		options.Cache.SyncPeriod = &cacheSyncPeriod
+	}
+	// restrict the watches to the namespaces of WATCH_NAMESPACE, so the
+	// operator can run with namespace scoped RBAC
+	if namespaces := getWatchNamespaces(); len(namespaces) > 0 {
+		setupLog.Info("Watching namespaces", "namespaces", namespaces)
+		options.Cache.DefaultNamespaces = map[string]cache.Config{}
+		for _, namespace := range namespaces {
+			options.Cache.DefaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+	// cache only the pods of the designate services
+	if options.Cache.ByObject == nil {
+		options.Cache.ByObject = map[client.Object]cache.ByObject{}
+	}
+	options.Cache.ByObject[&corev1.Pod{}] = cache.ByObject{Label: controller.PodCacheSelector()}
+	controller.SetupReconcileOptions(reconcileOptions)
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
@@ -251,9 +294,10 @@ func main() {
 	}
 
 	if err := (&controller.DesignateReconciler{
-		Client:  mgr.GetClient(),
-		Scheme:  mgr.GetScheme(),
-		Kclient: kclient,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("designate-controller"),
 	}).SetupWithManager(context.Background(), mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Designate")
 		os.Exit(1)
@@ -275,9 +319,10 @@ func main() {
 		os.Exit(1)
 	}
 	if err := (&controller.DesignateMdnsReconciler{
-		Client:  mgr.GetClient(),
-		Scheme:  mgr.GetScheme(),
-		Kclient: kclient,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("designatemdns-controller"),
 	}).SetupWithManager(context.Background(), mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DesignateMdns")
 		os.Exit(1)
@@ -290,6 +335,14 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DesignateProducer")
 		os.Exit(1)
 	}
+	if err := (&controller.DesignateSinkReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DesignateSink")
+		os.Exit(1)
+	}
 	if err := (&controller.DesignateWorkerReconciler{
 		Client:  mgr.GetClient(),
 		Scheme:  mgr.GetScheme(),
@@ -299,11 +352,20 @@ func main() {
 		os.Exit(1)
 	}
 	if err := (&controller.DesignateBackendbind9Reconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("designatebackendbind9-controller"),
+	}).SetupWithManager(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DesignateBackendbind9")
+		os.Exit(1)
+	}
+	if err := (&controller.DesignateBackendPDNSReconciler{
 		Client:  mgr.GetClient(),
 		Scheme:  mgr.GetScheme(),
 		Kclient: kclient,
 	}).SetupWithManager(context.Background(), mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "DesignateBackendbind9")
+		setupLog.Error(err, "unable to create controller", "controller", "DesignateBackendPDNS")
 		os.Exit(1)
 	}
 	if err := (&controller.UnboundReconciler{
@@ -314,6 +376,30 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DesignateUnbound")
 		os.Exit(1)
 	}
+	if err := (&controller.DesignatePoolReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DesignatePool")
+		os.Exit(1)
+	}
+	if err := (&controller.DesignateZoneReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DesignateZone")
+		os.Exit(1)
+	}
+	if err := (&controller.DesignateRecordSetReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DesignateRecordSet")
+		os.Exit(1)
+	}
 
 	// Acquire environmental defaults and initialize operator defaults with them
 	designatev1beta1.SetupDefaults()
@@ -325,6 +411,42 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Designate")
 			os.Exit(1)
 		}
+		if err := webhookv1beta1.SetupDesignateBackendbind9WebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateBackendbind9")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateMdnsWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateMdns")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateAPIWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateAPI")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateCentralWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateCentral")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateWorkerWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateWorker")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateProducerWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateProducer")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateSinkWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateSink")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateBackendPDNSWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateBackendPDNS")
+			os.Exit(1)
+		}
+		if err := webhookv1beta1.SetupDesignateUnboundWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DesignateUnbound")
+			os.Exit(1)
+		}
 
 		// Register ConfigMap webhook for multipool validation
 		mgr.GetWebhookServer().Register("/validate-v1-configmap", &webhook.Admission{
@@ -360,9 +482,54 @@ func main() {
 		os.Exit(1)
 	}
 
+	shutdownTracing, err := setupTracing(context.Background())
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	shutdownTracing()
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// setupTracing installs the OpenTelemetry tracer provider exporting the reconcile spans when
+// an OTLP endpoint is set through the standard OTEL_EXPORTER_OTLP_* variables. The sampling
+// ratio is set by OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG, every trace is sampled by default.
+func setupTracing(ctx context.Context) (func(), error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func() {}, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	setupLog.Info("tracing enabled")
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tp.Shutdown(ctx); err != nil {
+			setupLog.Error(err, "unable to flush the traces")
+		}
+	}, nil
+}
+
+// getWatchNamespaces returns the namespaces of the comma separated
+// WATCH_NAMESPACE list, all the namespaces are watched when it is empty
+func getWatchNamespaces() []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(os.Getenv("WATCH_NAMESPACE"), ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
@@ -18,11 +18,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Static errors for Application Credential handling
@@ -31,6 +41,114 @@ var (
 	ErrACSecretMissingKeys = errors.New("ApplicationCredential secret missing required keys")
 )
 
+// ErrExternalBindKeyMissing is returned when the rndc or TSIG key Secret of an external BIND
+// server doesn't contain the configured key
+var ErrExternalBindKeyMissing = errors.New("external BIND server key missing")
+
+// ErrCoordinationSecretKeyMissing is returned when the password or TLS Secret of the
+// coordination backend doesn't contain a required key
+var ErrCoordinationSecretKeyMissing = errors.New("coordination backend Secret key missing")
+
+// ErrResponsePolicyZoneMissing is returned when the ConfigMap of the bind9 response policy
+// zone doesn't contain the zone file
+var ErrResponsePolicyZoneMissing = errors.New("response policy zone file missing")
+
+// ErrSecondaryTSIGKeyMissing is returned when the Secret of the TSIG key of a bind9
+// secondary server doesn't contain the configured key
+var ErrSecondaryTSIGKeyMissing = errors.New("secondary server TSIG key missing")
+
+// ErrAPIPolicyInvalid is returned when the policy override of the API is missing from its
+// ConfigMap or is not a map of rules
+var ErrAPIPolicyInvalid = errors.New("invalid API policy override")
+
+// ErrAPIRegionConflict is returned when another DesignateAPI of the namespace
+// serves the same keystone region
+var ErrAPIRegionConflict = errors.New("keystone region already served by another DesignateAPI")
+
+// ErrAPINamespaceConflict is returned when another DesignateAPI of the
+// namespace serves another keystone region, the services and the keystone
+// service of the API are shared by the namespace
+var ErrAPINamespaceConflict = errors.New("namespace already served by another DesignateAPI")
+
+// podLabelingRetryInterval is how long the controllers wait before labeling
+// again the pods which could not be labeled with their predictable IP
+const podLabelingRetryInterval = 10 * time.Second
+
+// isReconcilePaused returns true when the reconciliation of instance is
+// paused with the designate.PausedAnnotation. The deletion of a paused
+// instance is not blocked, its finalizers are still handled.
+func isReconcilePaused(log logr.Logger, instance client.Object) bool {
+	if !instance.GetDeletionTimestamp().IsZero() || !designate.IsPaused(instance) {
+		return false
+	}
+	log.Info(fmt.Sprintf("Reconciliation of %s paused, remove the %s annotation to resume it",
+		instance.GetName(), designate.PausedAnnotation))
+	return true
+}
+
+// isPreviewed returns true when instance is in preview with the
+// designate.PreviewAnnotation, t is then set false pointing to the preview.
+// The rendered objects are only stored for a review, the reconciliation stops
+// before applying them.
+func isPreviewed(log logr.Logger, conditionUpdater conditionUpdater, t condition.Type, instance client.Object) bool {
+	if !designate.IsPreview(instance) {
+		return false
+	}
+	conditionUpdater.Set(condition.FalseCondition(
+		t,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		designatev1beta1.DesignatePreviewMessage,
+		designate.PreviewSuffix,
+		designate.PreviewAnnotation))
+	log.Info(fmt.Sprintf("%s in preview, remove the %s annotation to apply it",
+		instance.GetName(), designate.PreviewAnnotation))
+	return true
+}
+
+// ensureConfigSecrets renders the config Secrets of the templates, owned by
+// instance, and reports in the DesignateConfigInSyncCondition the ones modified
+// out of band and kept with the Report driftPolicy
+func ensureConfigSecrets(
+	ctx context.Context,
+	h *helper.Helper,
+	instance client.Object,
+	conditionUpdater conditionUpdater,
+	driftPolicy designatev1beta1.ConfigDriftPolicy,
+	templates []util.Template,
+	envVars *map[string]env.Setter,
+) error {
+	drifted, err := designate.EnsureSecrets(ctx, h, instance, templates, envVars, driftPolicy)
+	if err != nil {
+		return err
+	}
+	if designate.IsPreview(instance) {
+		// only the preview Secrets were rendered
+		return nil
+	}
+	if len(drifted) > 0 {
+		conditionUpdater.Set(condition.FalseCondition(
+			designatev1beta1.DesignateConfigInSyncCondition,
+			designatev1beta1.DesignateDriftDetectedReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateConfigInSyncDriftMessage,
+			strings.Join(drifted, ", ")))
+		return nil
+	}
+	conditionUpdater.MarkTrue(designatev1beta1.DesignateConfigInSyncCondition, designatev1beta1.DesignateConfigInSyncMessage)
+	return nil
+}
+
+// inputHashes returns the hash of every input of the pods, keyed by the name
+// of the input, from the env vars the input hash of the pods is computed from
+func inputHashes(envVars []corev1.EnvVar) map[string]string {
+	hashes := make(map[string]string, len(envVars))
+	for _, envVar := range envVars {
+		hashes[envVar.Name] = envVar.Value
+	}
+	return hashes
+}
+
 type conditionUpdater interface {
 	Set(c *condition.Condition)
 	MarkTrue(t condition.Type, messageFormat string, messageArgs ...any)
@@ -81,3 +199,41 @@ func ensureTopology(
 	}
 	return topology, nil
 }
+
+// setPredictableIPsCondition sets the predictable IPs condition of a sub-CR
+// from the pods which have no predictable IP. The missing IPs are also
+// reported with a warning event on the sub-CR and on the existing pods, as
+// the pods cannot start without them.
+func setPredictableIPsCondition(
+	ctx context.Context,
+	reader client.Reader,
+	recorder record.EventRecorder,
+	instance client.Object,
+	conditionUpdater conditionUpdater,
+	missing []string,
+) {
+	if len(missing) == 0 {
+		conditionUpdater.MarkTrue(
+			designatev1beta1.DesignatePredictableIPsReadyCondition,
+			designatev1beta1.DesignatePredictableIPsReadyMessage)
+		return
+	}
+	conditionUpdater.Set(condition.FalseCondition(
+		designatev1beta1.DesignatePredictableIPsReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		designatev1beta1.DesignatePredictableIPsReadyMissingMessage,
+		strings.Join(missing, ", ")))
+	recorder.Eventf(instance, corev1.EventTypeWarning, "PredictableIPsMissing",
+		designatev1beta1.DesignatePredictableIPsReadyMissingMessage, strings.Join(missing, ", "))
+	for _, podName := range missing {
+		pod := &corev1.Pod{}
+		err := reader.Get(ctx, client.ObjectKey{Name: podName, Namespace: instance.GetNamespace()}, pod)
+		if err != nil {
+			// the pod is not created yet
+			continue
+		}
+		recorder.Event(pod, corev1.EventTypeWarning, "PredictableIPMissing",
+			"No predictable IP is allocated for the pod, check the DesignatePredictableIPsReady condition of the Designate CR")
+	}
+}
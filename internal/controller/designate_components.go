@@ -0,0 +1,174 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateapi"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendbind9"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendpdns"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatecentral"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatemdns"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateproducer"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatesink"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateunbound"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateworker"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// designateComponent is a sub-CR of Designate, its pods have the component
+// label. summary returns its desired replicas, container image, ready
+// replicas, conditions and observed generation. deployment returns the name
+// of the Deployment of the sub-CR name, nil for the ones deployed with a
+// StatefulSet.
+type designateComponent struct {
+	kind       string
+	suffix     string
+	component  string
+	object     client.Object
+	summary    func() (*int32, string, int32, condition.Conditions, int64)
+	deployment func(name string) string
+}
+
+// designateComponents returns the sub-CRs of Designate, in the order they
+// are deployed
+func designateComponents() []designateComponent {
+	api := &designatev1beta1.DesignateAPI{}
+	central := &designatev1beta1.DesignateCentral{}
+	worker := &designatev1beta1.DesignateWorker{}
+	mdns := &designatev1beta1.DesignateMdns{}
+	producer := &designatev1beta1.DesignateProducer{}
+	bind9 := &designatev1beta1.DesignateBackendbind9{}
+	pdns := &designatev1beta1.DesignateBackendPDNS{}
+	unbound := &designatev1beta1.DesignateUnbound{}
+	sink := &designatev1beta1.DesignateSink{}
+	// the API Deployment is named after the component, the others after
+	// their sub-CR
+	apiDeployment := func(string) string { return designateapi.Component }
+	subCRDeployment := func(name string) string { return name }
+	return []designateComponent{
+		{kind: "DesignateAPI", suffix: "api", component: designateapi.Component, object: api, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return api.Spec.Replicas, api.Spec.ContainerImage, api.Status.ReadyCount, api.Status.Conditions, api.Status.ObservedGeneration
+		}, deployment: apiDeployment},
+		{kind: "DesignateCentral", suffix: "central", component: designatecentral.Component, object: central, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return central.Spec.Replicas, central.Spec.ContainerImage, central.Status.ReadyCount, central.Status.Conditions, central.Status.ObservedGeneration
+		}, deployment: subCRDeployment},
+		{kind: "DesignateWorker", suffix: "worker", component: designateworker.Component, object: worker, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return worker.Spec.Replicas, worker.Spec.ContainerImage, worker.Status.ReadyCount, worker.Status.Conditions, worker.Status.ObservedGeneration
+		}, deployment: subCRDeployment},
+		{kind: "DesignateMdns", suffix: "mdns", component: designatemdns.Component, object: mdns, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return mdns.Spec.Replicas, mdns.Spec.ContainerImage, mdns.Status.ReadyCount, mdns.Status.Conditions, mdns.Status.ObservedGeneration
+		}},
+		{kind: "DesignateProducer", suffix: "producer", component: designateproducer.Component, object: producer, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return producer.Spec.Replicas, producer.Spec.ContainerImage, producer.Status.ReadyCount, producer.Status.Conditions, producer.Status.ObservedGeneration
+		}, deployment: subCRDeployment},
+		{kind: "DesignateBackendbind9", suffix: "backendbind9", component: designatebackendbind9.Component, object: bind9, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return bind9.Spec.Replicas, bind9.Spec.ContainerImage, bind9.Status.ReadyCount, bind9.Status.Conditions, bind9.Status.ObservedGeneration
+		}},
+		{kind: "DesignateBackendPDNS", suffix: "backendpdns", component: designatebackendpdns.Component, object: pdns, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return pdns.Spec.Replicas, pdns.Spec.ContainerImage, pdns.Status.ReadyCount, pdns.Status.Conditions, pdns.Status.ObservedGeneration
+		}},
+		{kind: "DesignateUnbound", suffix: "unbound", component: designateunbound.Component, object: unbound, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return unbound.Spec.Replicas, unbound.Spec.ContainerImage, unbound.Status.ReadyCount, unbound.Status.Conditions, unbound.Status.ObservedGeneration
+		}},
+		{kind: "DesignateSink", suffix: "sink", component: designatesink.Component, object: sink, summary: func() (*int32, string, int32, condition.Conditions, int64) {
+			return sink.Spec.Replicas, sink.Spec.ContainerImage, sink.Status.ReadyCount, sink.Status.Conditions, sink.Status.ObservedGeneration
+		}},
+	}
+}
+
+// componentStatus summarizes the status of a sub-CR, the message of its
+// Ready condition is only kept when the condition isn't True
+func componentStatus(kind, name string, replicas *int32, image string, readyCount int32, conditions condition.Conditions) designatev1beta1.DesignateComponentStatus {
+	status := designatev1beta1.DesignateComponentStatus{
+		Kind:           kind,
+		Name:           name,
+		Ready:          corev1.ConditionUnknown,
+		Replicas:       ptr.Deref(replicas, 0),
+		ReadyCount:     readyCount,
+		ContainerImage: image,
+	}
+	if ready := conditions.Get(condition.ReadyCondition); ready != nil {
+		status.Ready = ready.Status
+		if ready.Status != corev1.ConditionTrue {
+			status.Message = ready.Message
+		}
+	}
+	return status
+}
+
+// componentStatuses rolls the status of the sub-CRs of instance up, with the
+// digests of the images their pods run. The sub-CRs which are not deployed,
+// e.g. sink when it isn't enabled, are left out.
+func (r *DesignateReconciler) componentStatuses(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+) ([]designatev1beta1.DesignateComponentStatus, error) {
+	statuses := []designatev1beta1.DesignateComponentStatus{}
+	for _, component := range designateComponents() {
+		name := fmt.Sprintf("%s-%s", instance.Name, component.suffix)
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, component.object)
+		if k8s_errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		replicas, image, readyCount, conditions, _ := component.summary()
+		if replicas == nil && component.deployment != nil {
+			// the replicas are managed externally, e.g. by an autoscaler
+			replicas, err = r.deploymentReplicas(ctx, component.deployment(name), instance.Namespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+		status := componentStatus(component.kind, name, replicas, image, readyCount, conditions)
+
+		pods := &corev1.PodList{}
+		err = r.List(ctx, pods,
+			client.InNamespace(instance.Namespace),
+			client.MatchingLabels{common.ComponentSelector: component.component})
+		if err != nil {
+			return nil, err
+		}
+		status.ImageDigests = designate.RunningImageDigests(pods.Items, image)
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// deploymentReplicas returns the desired replicas of the Deployment name, nil
+// when it does not exist yet
+func (r *DesignateReconciler) deploymentReplicas(ctx context.Context, name string, namespace string) (*int32, error) {
+	depl := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, depl)
+	if k8s_errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return depl.Spec.Replicas, nil
+}
@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+func Test_componentStatus(t *testing.T) {
+	failed := condition.Conditions{}
+	failed.Set(condition.FalseCondition(
+		condition.ReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		"Deployment error occurred %s",
+		"image pull failed"))
+	ready := condition.Conditions{}
+	ready.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+
+	tests := []struct {
+		name       string
+		replicas   *int32
+		conditions condition.Conditions
+		expected   designatev1beta1.DesignateComponentStatus
+	}{
+		{
+			name:       "failed",
+			replicas:   ptr.To[int32](3),
+			conditions: failed,
+			expected: designatev1beta1.DesignateComponentStatus{
+				Kind: "DesignateAPI", Name: "designate-api", Ready: corev1.ConditionFalse,
+				Replicas: 3, ReadyCount: 1, ContainerImage: "api:latest",
+				Message: "Deployment error occurred image pull failed",
+			},
+		},
+		{
+			name:       "ready",
+			replicas:   ptr.To[int32](3),
+			conditions: ready,
+			expected: designatev1beta1.DesignateComponentStatus{
+				Kind: "DesignateAPI", Name: "designate-api", Ready: corev1.ConditionTrue,
+				Replicas: 3, ReadyCount: 1, ContainerImage: "api:latest",
+			},
+		},
+		{
+			name: "not reconciled yet",
+			expected: designatev1beta1.DesignateComponentStatus{
+				Kind: "DesignateAPI", Name: "designate-api", Ready: corev1.ConditionUnknown,
+				ReadyCount: 1, ContainerImage: "api:latest",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := componentStatus("DesignateAPI", "designate-api", tt.replicas, "api:latest", 1, tt.conditions)
+			if !reflect.DeepEqual(status, tt.expected) {
+				t.Errorf("componentStatus() = %+v, expected %+v", status, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDesignateReconciler_componentStatusesExternalReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = designatev1beta1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	instance := &designatev1beta1.Designate{
+		ObjectMeta: metav1.ObjectMeta{Name: "designate", Namespace: "openstack"},
+	}
+	// the replicas of the API are managed by an autoscaler, the ones of
+	// central aren't reconciled yet
+	api := &designatev1beta1.DesignateAPI{
+		ObjectMeta: metav1.ObjectMeta{Name: "designate-api", Namespace: "openstack"},
+	}
+	central := &designatev1beta1.DesignateCentral{
+		ObjectMeta: metav1.ObjectMeta{Name: "designate-central", Namespace: "openstack"},
+	}
+	depl := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "designate-api", Namespace: "openstack"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](4)},
+	}
+	r := &DesignateReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(api, central, depl).Build(),
+	}
+
+	statuses, err := r.componentStatuses(context.TODO(), instance)
+	if err != nil {
+		t.Fatalf("componentStatuses() failed: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected the statuses of the API and central, got %+v", statuses)
+	}
+	if statuses[0].Replicas != 4 {
+		t.Errorf("expected the 4 replicas of the API Deployment, got %d", statuses[0].Replicas)
+	}
+	if statuses[1].Replicas != 0 {
+		t.Errorf("expected no replicas without a central Deployment, got %d", statuses[1].Replicas)
+	}
+}
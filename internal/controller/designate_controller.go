@@ -31,7 +31,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -41,6 +43,10 @@ import (
 	"github.com/go-logr/logr"
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateapi"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendbind9"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatemdns"
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
@@ -59,6 +65,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -133,8 +140,9 @@ func (r *DesignateReconciler) GetScheme() *runtime.Scheme {
 // DesignateReconciler reconciles a Designate object
 type DesignateReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient  kubernetes.Interface
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // validatePoolRemovals checks if any pools have been removed from the multipool config
@@ -275,11 +283,18 @@ func (r *DesignateReconciler) validatePoolRemovals(
 // +kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendbind9s,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendbind9s/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendbind9s/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendpdnses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendpdnses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendpdnses/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=designate.openstack.org,resources=designatesinks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=designate.openstack.org,resources=designatesinks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=designate.openstack.org,resources=designatesinks/finalizers,verbs=update;patch
 // +kubebuilder:rbac:groups=designate.openstack.org,resources=designateunbounds,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=designate.openstack.org,resources=designateunbounds/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=designate.openstack.org,resources=designateunbounds/finalizers,verbs=update;patch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;create;update;patch;delete;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=mariadb.openstack.org,resources=mariadbdatabases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=mariadb.openstack.org,resources=mariadbaccounts,verbs=get;list;watch;create;update;patch;delete
@@ -288,6 +303,7 @@ func (r *DesignateReconciler) validatePoolRemovals(
 // +kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=transporturls,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=redis.openstack.org,resources=redises,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.openstack.org,resources=ipsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;create;update;patch;delete;watch
 
 // service account, role, rolebinding
@@ -298,9 +314,14 @@ func (r *DesignateReconciler) validatePoolRemovals(
 // +kubebuilder:rbac:groups="security.openshift.io",resourceNames=anyuid;privileged,resources=securitycontextconstraints,verbs=use
 // +kubebuilder:rbac:groups="",resources=pods,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=multinetworkpolicies,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile -
 func (r *DesignateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "Designate", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
 	Log := r.GetLogger(ctx)
 
 	// Fetch the Designate instance
@@ -317,6 +338,10 @@ func (r *DesignateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -353,7 +378,15 @@ func (r *DesignateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			instance.Status.Conditions.Set(
 				instance.Status.Conditions.Mirror(condition.ReadyCondition))
 		}
-		err := helper.PatchInstance(ctx, instance)
+		// roll the sub-CRs up even when the reconcile stopped early, their
+		// last errors are what explains it
+		components, err := r.componentStatuses(ctx, instance)
+		if err != nil {
+			Log.Error(err, "Failed to get the status of the sub-CRs")
+		} else {
+			instance.Status.Components = components
+		}
+		err = helper.PatchInstance(ctx, instance)
 		if err != nil {
 			_err = err
 			return
@@ -376,15 +409,43 @@ func (r *DesignateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		condition.UnknownCondition(designatev1beta1.DesignateUnboundReadyCondition, condition.InitReason, designatev1beta1.DesignateUnboundReadyInitMessage),
 		condition.UnknownCondition(designatev1beta1.DesignateWorkerReadyCondition, condition.InitReason, designatev1beta1.DesignateWorkerReadyInitMessage),
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
-		condition.UnknownCondition(mariadbv1.MariaDBAccountReadyCondition, condition.InitReason, mariadbv1.MariaDBAccountReadyInitMessage),
 		condition.UnknownCondition(condition.RabbitMqTransportURLReadyCondition, condition.InitReason, condition.RabbitMqTransportURLReadyInitMessage),
 		condition.UnknownCondition(designatev1beta1.DesignateRabbitMqNotificationsTransportURLReadyCondition, condition.InitReason, condition.RabbitMqTransportURLReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignatePredictableIPsReadyCondition, condition.InitReason, designatev1beta1.DesignatePredictableIPsReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateUpdateReadyCondition, condition.InitReason, designatev1beta1.DesignateUpdateReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateDependenciesReadyCondition, condition.InitReason, designatev1beta1.DesignateDependenciesReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateDatabaseRotationReadyCondition, condition.InitReason, designatev1beta1.DesignateDatabaseRotationReadyInitMessage),
 		// service account, role, rolebinding conditions
 		condition.UnknownCondition(condition.RoleBindingReadyCondition, condition.InitReason, condition.RoleBindingReadyInitMessage),
 		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceAccountReadyCondition, condition.InitReason, condition.ServiceAccountReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
 	)
+	// No MariaDBAccount is managed for an external database
+	if !instance.UsesExternalDatabase() {
+		cl.Set(condition.UnknownCondition(mariadbv1.MariaDBAccountReadyCondition, condition.InitReason, mariadbv1.MariaDBAccountReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(mariadbv1.MariaDBAccountReadyCondition)
+	}
+	// The PowerDNS backend is optional, only track its condition when requested
+	if instance.IsPDNSEnabled() {
+		cl.Set(condition.UnknownCondition(designatev1beta1.DesignateBackendPDNSReadyCondition, condition.InitReason, designatev1beta1.DesignateBackendPDNSReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(designatev1beta1.DesignateBackendPDNSReadyCondition)
+	}
+	// designate-sink is optional as well
+	if instance.IsSinkEnabled() {
+		cl.Set(condition.UnknownCondition(designatev1beta1.DesignateSinkReadyCondition, condition.InitReason, designatev1beta1.DesignateSinkReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(designatev1beta1.DesignateSinkReadyCondition)
+	}
+	// so are the pre-flight checks
+	if instance.Spec.PreflightCheck {
+		cl.Set(condition.UnknownCondition(designatev1beta1.DesignatePreflightReadyCondition, condition.InitReason, designatev1beta1.DesignatePreflightReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(designatev1beta1.DesignatePreflightReadyCondition)
+	}
 	instance.Status.Conditions.Init(&cl)
 	instance.Status.ObservedGeneration = instance.Generation
 
@@ -412,8 +473,11 @@ const (
 	caBundleSecretNameField = ".spec.tls.caBundleSecretName" // #nosec G101
 	tlsAPIInternalField     = ".spec.tls.api.internal.secretName"
 	tlsAPIPublicField       = ".spec.tls.api.public.secretName"
+	tlsEncryptedDNSField    = ".spec.encryptedDNS.secretName"
 	topologyField           = ".spec.topologyRef.Name"
 	authAppCredSecretField  = ".spec.auth.applicationCredentialSecret" // #nosec G101
+	designateNameField      = ".spec.designateName"
+	apiPolicyConfigMapField = ".spec.apiPolicyOverride.configMapName"
 )
 
 // SetupWithManager sets up the controller with the Manager.
@@ -489,10 +553,138 @@ func (r *DesignateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Man
 		return result
 	}
 
+	// Watch for changes to the rndc key Secrets of the external BIND servers
+	externalBindSecretFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		designates := &designatev1beta1.DesignateList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(o.GetNamespace()),
+		}
+		if err := r.List(context.Background(), designates, listOpts...); err != nil {
+			Log.Error(err, "Unable to retrieve Designate CRs")
+			return nil
+		}
+		var result []reconcile.Request
+		for _, cr := range designates.Items {
+			for _, server := range cr.Spec.ExternalBindServers {
+				if server.RNDCKeySecret == o.GetName() {
+					Log.Info(fmt.Sprintf("rndc key Secret %s of external BIND server %s changed, triggering reconciliation for Designate CR %s", o.GetName(), server.Name, cr.Name))
+					result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{
+						Namespace: o.GetNamespace(),
+						Name:      cr.Name,
+					}})
+					break
+				}
+			}
+		}
+		return result
+	}
+
+	// Watch for changes to the password or TLS Secret of the coordination backend
+	coordinationSecretFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		designates := &designatev1beta1.DesignateList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(o.GetNamespace()),
+		}
+		if err := r.List(context.Background(), designates, listOpts...); err != nil {
+			Log.Error(err, "Unable to retrieve Designate CRs")
+			return nil
+		}
+		var result []reconcile.Request
+		for _, cr := range designates.Items {
+			sentinel := cr.Spec.Coordination.RedisSentinel
+			etcd := cr.Spec.Coordination.Etcd
+			if (sentinel != nil && sentinel.PasswordSecret == o.GetName()) ||
+				(etcd != nil && etcd.TLSSecretName == o.GetName()) {
+				Log.Info(fmt.Sprintf("Coordination Secret %s changed, triggering reconciliation for Designate CR %s", o.GetName(), cr.Name))
+				result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{
+					Namespace: o.GetNamespace(),
+					Name:      cr.Name,
+				}})
+			}
+		}
+		return result
+	}
+
+	// Watch for changes to the Secret of the MariaDBAccount of Designate, or
+	// to the Secrets of its external database, the configuration is rendered
+	// again when its credentials are rotated
+	databaseAccountSecretFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		designates := &designatev1beta1.DesignateList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(o.GetNamespace()),
+		}
+		if err := r.List(context.Background(), designates, listOpts...); err != nil {
+			Log.Error(err, "Unable to retrieve Designate CRs")
+			return nil
+		}
+		var result []reconcile.Request
+		for _, cr := range designates.Items {
+			if external := cr.Spec.ExternalDatabase; external != nil {
+				if external.Secret != o.GetName() && external.CASecret != o.GetName() {
+					continue
+				}
+			} else {
+				account := &mariadbv1.MariaDBAccount{}
+				err := r.Get(context.Background(), types.NamespacedName{
+					Name:      cr.Spec.DatabaseAccount,
+					Namespace: cr.Namespace,
+				}, account)
+				if err != nil || account.Spec.Secret != o.GetName() {
+					continue
+				}
+			}
+			Log.Info(fmt.Sprintf("Database account Secret %s changed, triggering reconciliation for Designate CR %s", o.GetName(), cr.Name))
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{
+				Namespace: o.GetNamespace(),
+				Name:      cr.Name,
+			}})
+		}
+		return result
+	}
+
+	designatePoolFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		cr, ok := o.(*designatev1beta1.DesignatePool)
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{
+			{NamespacedName: client.ObjectKey{Namespace: cr.Namespace, Name: cr.Spec.DesignateName}},
+		}
+	}
+
+	// Watch for the addresses of the mdns and bind9 pods, pools.yaml and the
+	// IP maps are regenerated when they change in the HostNetwork and PodIP
+	// predictable IP modes
+	podAddressFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		designates := &designatev1beta1.DesignateList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(o.GetNamespace()),
+		}
+		if err := r.List(context.Background(), designates, listOpts...); err != nil {
+			Log.Error(err, "Unable to retrieve Designate CRs")
+			return nil
+		}
+		var result []reconcile.Request
+		for _, cr := range designates.Items {
+			if cr.UsesControlNetwork() {
+				continue
+			}
+			app := o.GetLabels()[common.AppSelector]
+			if app == fmt.Sprintf("%s-mdns", cr.Name) || app == fmt.Sprintf("%s-backendbind9", cr.Name) {
+				result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{
+					Namespace: o.GetNamespace(),
+					Name:      cr.Name,
+				}})
+			}
+		}
+		return result
+	}
+
 	// TODO(beagles):
 	// - Watch for changes to the redis PODs and resync the headless hostnames for the PODs if necessary.
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&designatev1beta1.Designate{}).
+		WithOptions(controllerOptions()).
 		Owns(&mariadbv1.MariaDBDatabase{}).
 		Owns(&mariadbv1.MariaDBAccount{}).
 		Owns(&designatev1beta1.DesignateAPI{}).
@@ -501,7 +693,9 @@ func (r *DesignateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Man
 		Owns(&designatev1beta1.DesignateMdns{}).
 		Owns(&designatev1beta1.DesignateProducer{}).
 		Owns(&designatev1beta1.DesignateBackendbind9{}).
+		Owns(&designatev1beta1.DesignateBackendPDNS{}).
 		Owns(&designatev1beta1.DesignateUnbound{}).
+		Owns(&designatev1beta1.DesignateSink{}).
 		Owns(&corev1.Secret{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&rabbitmqv1.TransportURL{}).
@@ -510,18 +704,289 @@ func (r *DesignateReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Man
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
+		Owns(&infranetworkv1.IPSet{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		// Watch for multipool ConfigMap changes to regenerate pools.yaml
 		Watches(&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.findDesignatesForMultipoolConfigMap)).
 		// Watch for TransportURL Secrets which belong to any TransportURLs created by Designate CRs
 		Watches(&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(transportURLSecretFn)).
+		// Watch for the rndc key Secrets of the external BIND servers
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(externalBindSecretFn)).
+		// Watch for the password or TLS Secret of the coordination backend
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(coordinationSecretFn)).
+		// Watch for the Secret of the MariaDBAccount or of the external database
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(databaseAccountSecretFn)).
 		// Watch for Redis CR changes (e.g. TLS configuration)
 		Watches(&redisv1.Redis{},
 			handler.EnqueueRequestsFromMapFunc(redisWatchFn)).
+		// Watch for DesignatePools taking over or releasing a generated pool
+		Watches(&designatev1beta1.DesignatePool{},
+			handler.EnqueueRequestsFromMapFunc(designatePoolFn)).
+		// Watch for the addresses of the mdns and bind9 pods
+		Watches(&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(podAddressFn),
+			builder.WithPredicates(podIPsChangedPredicate)).
 		Complete(r)
 }
 
+// reconcilePDNSAPIKey ensures the secret holding the key of the PowerDNS API
+// shared by the PowerDNS servers and designate-worker. An existing key is
+// never regenerated.
+func (r *DesignateReconciler) reconcilePDNSAPIKey(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	secretLabels map[string]string,
+) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      designate.DesignatePDNSAPIKeySecret,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, h.GetClient(), secret, func() error {
+		secret.Labels = util.MergeStringMaps(secret.Labels, secretLabels)
+		if _, exists := secret.Data[designate.PDNSAPIKeySecretKey]; !exists {
+			apiKey, err := designate.CreatePDNSAPIKey()
+			if err != nil {
+				return err
+			}
+			secret.Data = map[string][]byte{designate.PDNSAPIKeySecretKey: []byte(apiKey)}
+		}
+		// the key is kept with the zone data of the servers with preserveOnDelete
+		if instance.Spec.PreserveOnDelete {
+			designate.RemoveOwnerReference(instance, secret)
+			return nil
+		}
+		return controllerutil.SetControllerReference(instance, secret, h.GetScheme())
+	})
+	return err
+}
+
+// verifyExternalBindSecrets checks the rndc and TSIG key Secrets of the
+// external BIND servers exist and hold the configured keys
+func (r *DesignateReconciler) verifyExternalBindSecrets(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+) error {
+	for _, server := range instance.Spec.ExternalBindServers {
+		refs := [][2]string{{server.RNDCKeySecret, server.RNDCKeySecretKey}}
+		if server.HasTSIGKey() {
+			refs = append(refs, [2]string{server.TSIGKeySecret, server.TSIGKeySecretKey})
+		}
+		for _, ref := range refs {
+			keySecret := &corev1.Secret{}
+			err := r.Get(ctx, types.NamespacedName{Name: ref[0], Namespace: instance.Namespace}, keySecret)
+			if err != nil {
+				return err
+			}
+			if _, ok := keySecret.Data[ref[1]]; !ok {
+				return fmt.Errorf("%w: %s not found in Secret %s of %s", ErrExternalBindKeyMissing, ref[1], ref[0], server.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyCoordinationSecrets checks the password or TLS Secret of the
+// coordination backend exists and holds the required keys
+func (r *DesignateReconciler) verifyCoordinationSecrets(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+) error {
+	if sentinel := instance.Spec.Coordination.RedisSentinel; sentinel != nil && sentinel.PasswordSecret != "" {
+		passwordSecret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: sentinel.PasswordSecret, Namespace: instance.Namespace}, passwordSecret)
+		if err != nil {
+			return err
+		}
+		if _, ok := passwordSecret.Data[sentinel.PasswordSecretKey]; !ok {
+			return fmt.Errorf("%w: %s not found in Secret %s", ErrCoordinationSecretKeyMissing, sentinel.PasswordSecretKey, sentinel.PasswordSecret)
+		}
+	}
+	if etcd := instance.Spec.Coordination.Etcd; etcd != nil && etcd.TLSSecretName != "" {
+		tlsSecret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: etcd.TLSSecretName, Namespace: instance.Namespace}, tlsSecret)
+		if err != nil {
+			return err
+		}
+		for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+			if _, ok := tlsSecret.Data[key]; !ok {
+				return fmt.Errorf("%w: %s not found in Secret %s", ErrCoordinationSecretKeyMissing, key, etcd.TLSSecretName)
+			}
+		}
+	}
+	return nil
+}
+
+// coordinationBackendURL returns the tooz backend_url of the coordination
+// backend of the designate services
+func (r *DesignateReconciler) coordinationBackendURL(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+) (string, error) {
+	if sentinel := instance.Spec.Coordination.RedisSentinel; sentinel != nil {
+		password := ""
+		if sentinel.PasswordSecret != "" {
+			passwordSecret := &corev1.Secret{}
+			err := r.Get(ctx, types.NamespacedName{Name: sentinel.PasswordSecret, Namespace: instance.Namespace}, passwordSecret)
+			if err != nil {
+				return "", err
+			}
+			password = string(passwordSecret.Data[sentinel.PasswordSecretKey])
+		}
+		return designate.RedisSentinelBackendURL(sentinel, password), nil
+	}
+
+	if etcd := instance.Spec.Coordination.Etcd; etcd != nil {
+		withCA := false
+		if etcd.TLSSecretName != "" {
+			tlsSecret := &corev1.Secret{}
+			err := r.Get(ctx, types.NamespacedName{Name: etcd.TLSSecretName, Namespace: instance.Namespace}, tlsSecret)
+			if err != nil {
+				return "", err
+			}
+			_, withCA = tlsSecret.Data[designate.CoordinationCAKey]
+		}
+		return designate.EtcdBackendURL(etcd, withCA), nil
+	}
+
+	// We should never get here, but just in case.
+	if len(instance.Status.RedisHostIPs) == 0 {
+		return "", designate.ErrRedisRequired
+	}
+	backendURL := fmt.Sprintf("redis://%s:6379/", instance.Status.RedisHostIPs[0])
+	if instance.Status.RedisTLS == "true" {
+		backendURL = fmt.Sprintf("%s?ssl=true", backendURL)
+	}
+	return backendURL, nil
+}
+
+// reconcileCoordinationSecret copies the client certificates of the etcd
+// coordination backend into the secret mounted by designate-central,
+// designate-producer and designate-worker
+func (r *DesignateReconciler) reconcileCoordinationSecret(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	secretLabels map[string]string,
+) error {
+	certs := make(map[string][]byte)
+	if etcd := instance.Spec.Coordination.Etcd; etcd != nil && etcd.TLSSecretName != "" {
+		tlsSecret := &corev1.Secret{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{
+			Name:      etcd.TLSSecretName,
+			Namespace: instance.Namespace,
+		}, tlsSecret)
+		if err != nil {
+			return fmt.Errorf("failed to get TLS Secret of the etcd coordination backend: %w", err)
+		}
+		for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, designate.CoordinationCAKey} {
+			if value, ok := tlsSecret.Data[key]; ok {
+				certs[key] = value
+			}
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      designate.DesignateCoordinationSecret,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, h.GetClient(), secret, func() error {
+		if err := designate.AdoptControllerReference(ctx, h.GetClient(), instance, secret, secretLabels, h.GetScheme()); err != nil {
+			return err
+		}
+		secret.Labels = util.MergeStringMaps(secret.Labels, secretLabels)
+		secret.Data = certs
+		return nil
+	})
+	return err
+}
+
+// reconcileExternalBindKeys copies the rndc keys of the external BIND servers
+// into the secret mounted by designate-worker
+func (r *DesignateReconciler) reconcileExternalBindKeys(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	secretLabels map[string]string,
+) error {
+	keys := make(map[string][]byte)
+	for _, server := range instance.Spec.ExternalBindServers {
+		rndcSecret := &corev1.Secret{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{
+			Name:      server.RNDCKeySecret,
+			Namespace: instance.Namespace,
+		}, rndcSecret)
+		if err != nil {
+			return fmt.Errorf("failed to get rndc key of external BIND server %s: %w", server.Name, err)
+		}
+		key, ok := rndcSecret.Data[server.RNDCKeySecretKey]
+		if !ok {
+			return fmt.Errorf("%w: %s not found in Secret %s", ErrExternalBindKeyMissing, server.RNDCKeySecretKey, server.RNDCKeySecret)
+		}
+		keys[designate.ExternalRndcKeyName(server.Name)] = key
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      designate.DesignateExternalBindKeySecret,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, h.GetClient(), secret, func() error {
+		if err := designate.AdoptControllerReference(ctx, h.GetClient(), instance, secret, secretLabels, h.GetScheme()); err != nil {
+			return err
+		}
+		secret.Labels = util.MergeStringMaps(secret.Labels, secretLabels)
+		secret.Data = keys
+		return nil
+	})
+	return err
+}
+
+// excludeDesignatePools drops the pools which are defined by a DesignatePool
+// CR referencing instance
+func (r *DesignateReconciler) excludeDesignatePools(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+	pools []designate.Pool,
+) ([]designate.Pool, error) {
+	Log := r.GetLogger(ctx)
+
+	poolList := &designatev1beta1.DesignatePoolList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+	}
+	if err := r.List(ctx, poolList, listOpts...); err != nil {
+		return nil, err
+	}
+
+	crPools := make(map[string]bool)
+	for _, cr := range poolList.Items {
+		if cr.Spec.DesignateName == instance.Name && cr.DeletionTimestamp.IsZero() {
+			crPools[cr.GetPoolName()] = true
+		}
+	}
+
+	var result []designate.Pool
+	for _, pool := range pools {
+		if crPools[pool.Name] {
+			Log.Info(fmt.Sprintf("Pool %s is managed by a DesignatePool", pool.Name))
+			continue
+		}
+		result = append(result, pool)
+	}
+	return result, nil
+}
+
 // findDesignatesForMultipoolConfigMap watches the multipool ConfigMap and triggers
 // reconciliation of all Designate CRs when it changes or is deleted
 func (r *DesignateReconciler) findDesignatesForMultipoolConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -561,21 +1026,25 @@ func (r *DesignateReconciler) reconcileDelete(ctx context.Context, instance *des
 
 	Log.Info(fmt.Sprintf("Reconciling Service '%s' delete", instance.Name))
 
-	// remove db finalizer first
-	designateDb, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, helper, designate.DatabaseCRName, instance.Spec.DatabaseAccount, instance.Namespace)
-	if err != nil && !k8s_errors.IsNotFound(err) {
-		return ctrl.Result{}, err
-	}
-
-	if !k8s_errors.IsNotFound(err) {
-		if err := designateDb.DeleteFinalizer(ctx, helper); err != nil {
+	// remove db finalizer first, there is none on an external database
+	if !instance.UsesExternalDatabase() {
+		designateDb, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, helper, designate.DatabaseCRName, instance.Spec.DatabaseAccount, instance.Namespace)
+		if err != nil && !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
+
+		if !k8s_errors.IsNotFound(err) {
+			if err := designateDb.DeleteFinalizer(ctx, helper); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
 	// TODO: We might need to control how the sub-services (API, Backup, Scheduler and Volumes) are
 	// deleted (when their parent Designate CR is deleted) once we further develop their functionality
 
+	designate.DeletePredictableIPUsage(instance.Namespace, instance.Name)
+
 	// Service is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
 	Log.Info(fmt.Sprintf("Reconciled Service '%s' delete successfully", instance.Name))
@@ -623,6 +1092,10 @@ func (r *DesignateReconciler) reconcileInit(
 		return ctrl.Result{}, err
 	}
 	Log.Info("post generateConfigMap ....")
+	if designate.IsPreview(instance) {
+		// rendered into the preview Secrets
+		return ctrl.Result{}, nil
+	}
 
 	//
 	// create hash over all the different input resources to identify if any those changed
@@ -647,8 +1120,54 @@ func (r *DesignateReconciler) reconcileInit(
 	//
 	// run Designate db sync
 	//
+	// With the Manual dbSyncPolicy the schema is not synced with a new image,
+	// nor are the services updated to it, until the image is approved
+	instance.Status.DBSyncPendingImage = designate.DbSyncPendingImage(instance)
+	if instance.Status.DBSyncPendingImage != "" {
+		Log.Info(fmt.Sprintf("DB sync of image %s waiting for approval", instance.Status.DBSyncPendingImage))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBSyncReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateDBSyncReadyApprovalMessage,
+			instance.Status.DBSyncPendingImage))
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+	}
+	// An upgrade only starts once the images of the services are from the
+	// same release, a partially updated set of images is not rolled out
+	upgrade := instance.Status.DBSyncImage != "" && instance.Spec.DesignateAPI.ContainerImage != instance.Status.DBSyncImage
+	if upgrade {
+		if tags := designate.InconsistentImageTags(designate.ReleaseImages(instance)); tags != nil {
+			Log.Info(fmt.Sprintf("DB sync of image %s waiting for consistent image tags: %s",
+				instance.Spec.DesignateAPI.ContainerImage, strings.Join(tags, ", ")))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.DBSyncReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateDBSyncReadyImageTagsMessage,
+				instance.Spec.DesignateAPI.ContainerImage,
+				strings.Join(tags, ", ")))
+			return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+		}
+		// the hooks run around the DB sync of a new image, not of the
+		// initial one
+		ctrlResult, err := r.reconcileDBSyncHooks(ctx, helper, instance, designatev1beta1.HookStagePreDBSync)
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
+	}
+
 	dbSyncHash := instance.Status.Hash[designatev1beta1.DbSyncHash]
-	jobDef := designate.DbSyncJob(instance, serviceLabels, serviceAnnotations)
+	jobDef, err := designate.DbSyncJob(instance, serviceLabels, serviceAnnotations)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBSyncReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DBSyncReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
 
 	Log.Info("Initializing db sync job")
 	dbSyncjob := job.NewJob(
@@ -682,7 +1201,22 @@ func (r *DesignateReconciler) reconcileInit(
 	if dbSyncjob.HasChanged() {
 		instance.Status.Hash[designatev1beta1.DbSyncHash] = dbSyncjob.GetHash()
 		Log.Info(fmt.Sprintf("Service '%s' - Job %s hash added - %s", instance.Name, jobDef.Name, instance.Status.Hash[designatev1beta1.DbSyncHash]))
+
+		revision, err := designate.DbSyncRevision(ctx, helper, jobDef)
+		if err != nil {
+			Log.Error(err, "unable to get the schema revision of the db sync job")
+		} else if revision != "" {
+			instance.Status.DBSchemaRevision = revision
+		}
+	}
+	// the services are only updated to the new image once the hooks ran
+	if upgrade {
+		ctrlResult, err = r.reconcileDBSyncHooks(ctx, helper, instance, designatev1beta1.HookStagePostDBSync)
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
 	}
+	instance.Status.DBSyncImage = instance.Spec.DesignateAPI.ContainerImage
 	instance.Status.Conditions.MarkTrue(condition.DBSyncReadyCondition, condition.DBSyncReadyMessage)
 
 	// run Designate db sync - end
@@ -696,7 +1230,10 @@ func (r *DesignateReconciler) ensureDB(
 	ctx context.Context,
 	helper *helper.Helper,
 	instance *designatev1beta1.Designate,
-) (*mariadbv1.Database, ctrl.Result, error) {
+) (*designate.Database, ctrl.Result, error) {
+	if instance.UsesExternalDatabase() {
+		return r.ensureExternalDB(ctx, helper, instance)
+	}
 
 	// ensure MariaDBAccount exists.  This account record may be created by
 	// openstack-operator or the cloud operator up front without a specific
@@ -745,7 +1282,7 @@ func (r *DesignateReconciler) ensureDB(
 			condition.SeverityWarning,
 			condition.DBReadyErrorMessage,
 			err.Error()))
-		return designateDb, ctrl.Result{}, err
+		return &designate.Database{Database: designateDb, Status: designate.DBFailed}, ctrl.Result{}, err
 	}
 	if (ctrlResult != ctrl.Result{}) {
 		instance.Status.Conditions.Set(condition.FalseCondition(
@@ -753,7 +1290,7 @@ func (r *DesignateReconciler) ensureDB(
 			condition.RequestedReason,
 			condition.SeverityInfo,
 			condition.DBReadyRunningMessage))
-		return designateDb, ctrlResult, nil
+		return &designate.Database{Database: designateDb, Status: designate.DBCreating}, ctrlResult, nil
 	}
 	// wait for the DB to be setup
 	ctrlResult, err = designateDb.WaitForDBCreated(ctx, helper)
@@ -764,7 +1301,7 @@ func (r *DesignateReconciler) ensureDB(
 			condition.SeverityWarning,
 			condition.DBReadyErrorMessage,
 			err.Error()))
-		return designateDb, ctrlResult, nil
+		return &designate.Database{Database: designateDb, Status: designate.DBFailed}, ctrlResult, nil
 	}
 	if (ctrlResult != ctrl.Result{}) {
 		instance.Status.Conditions.Set(condition.FalseCondition(
@@ -772,18 +1309,55 @@ func (r *DesignateReconciler) ensureDB(
 			condition.RequestedReason,
 			condition.SeverityInfo,
 			condition.DBReadyRunningMessage))
-		return designateDb, ctrlResult, nil
+		return &designate.Database{Database: designateDb, Status: designate.DBCreating}, ctrlResult, nil
 	}
 
 	// update Status.DatabaseHostname, used to config the service
 	instance.Status.DatabaseHostname = designateDb.GetDatabaseHostname()
 	instance.Status.Conditions.MarkTrue(condition.DBReadyCondition, condition.DBReadyMessage)
 
-	return designateDb, ctrl.Result{}, nil
+	return &designate.Database{Database: designateDb, Status: designate.DBCompleted}, ctrl.Result{}, nil
 	// create service DB - end
 }
 
-func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *designatev1beta1.Designate, helper *helper.Helper) (ctrl.Result, error) {
+// ensureExternalDB - get the credentials of the external database, neither a
+// MariaDBDatabase nor a MariaDBAccount is created for it. The schema is
+// synced by the db sync job as for a mariadb-operator database.
+func (r *DesignateReconciler) ensureExternalDB(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.Designate,
+) (*designate.Database, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	designateDb, err := designate.GetExternalDatabase(ctx, helper, instance.Spec.ExternalDatabase, instance.Namespace)
+	if k8s_errors.IsNotFound(err) {
+		// the Secrets are watched, the reconcile is triggered once created
+		Log.Info(fmt.Sprintf("Secret of the external database not found: %s", err))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DBReadyRunningMessage))
+		return nil, ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	} else if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DBReadyErrorMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
+	}
+
+	// update Status.DatabaseHostname, used to config the service
+	instance.Status.DatabaseHostname = designateDb.GetDatabaseHostname()
+	instance.Status.Conditions.MarkTrue(condition.DBReadyCondition, condition.DBReadyMessage)
+
+	return designateDb, ctrl.Result{}, nil
+}
+
+func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *designatev1beta1.Designate, helper *helper.Helper) (ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 
 	Log.Info(fmt.Sprintf("Reconciling Service '%s'", instance.Name))
@@ -889,13 +1463,78 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	}
 	// end notifications transportURL
 
-	// TODO(beagles): Due to how the Redis operator manages the Redis service,
-	// we only need a single IP service endpoint. Even for dual-stack setups,
-	// configuring just one is likely sufficient.
-	hostIPs, err := getRedisServiceIPs(ctx, instance, helper)
-	if err != nil {
+	if instance.Spec.Coordination.IsExternal() {
+		// The coordination backend is not managed by the operator, only its
+		// password or TLS Secret can be checked
+		if err := r.verifyCoordinationSecrets(ctx, instance); err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("Waiting for the Secrets of the coordination backend: %s", err.Error()))
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.InputReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					condition.InputReadyWaitingMessage))
+				return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		instance.Status.RedisHostIPs = nil
+		instance.Status.RedisTLS = ""
+	} else {
+		// TODO(beagles): Due to how the Redis operator manages the Redis service,
+		// we only need a single IP service endpoint. Even for dual-stack setups,
+		// configuring just one is likely sufficient.
+		hostIPs, err := getRedisServiceIPs(ctx, instance, helper)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("Redis service %s not found, waiting for it to be created", instance.Spec.RedisServiceName))
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.InputReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					condition.InputReadyWaitingMessage))
+				return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		if len(hostIPs) == 0 {
+			err = designate.ErrRedisRequired
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		sort.Strings(hostIPs)
+
+		instance.Status.RedisHostIPs = hostIPs
+
+		redisTLS, err := isRedisTLS(ctx, instance, helper)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.RedisTLS = fmt.Sprintf("%t", redisTLS)
+	}
+
+	// The rndc and TSIG keys of the external BIND servers are provided by the user
+	if err := r.verifyExternalBindSecrets(ctx, instance); err != nil {
 		if k8s_errors.IsNotFound(err) {
-			Log.Info(fmt.Sprintf("Redis service %s not found, waiting for it to be created", instance.Spec.RedisServiceName))
+			Log.Info(fmt.Sprintf("Waiting for the key Secrets of the external BIND servers: %s", err.Error()))
 			instance.Status.Conditions.Set(condition.FalseCondition(
 				condition.InputReadyCondition,
 				condition.RequestedReason,
@@ -912,26 +1551,6 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 		return ctrl.Result{}, err
 	}
 
-	if len(hostIPs) == 0 {
-		err = designate.ErrRedisRequired
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.InputReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			condition.InputReadyErrorMessage,
-			err.Error()))
-		return ctrl.Result{}, err
-	}
-	sort.Strings(hostIPs)
-
-	instance.Status.RedisHostIPs = hostIPs
-
-	redisTLS, err := isRedisTLS(ctx, instance, helper)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	instance.Status.RedisTLS = fmt.Sprintf("%t", redisTLS)
-
 	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
 
 	//
@@ -951,9 +1570,14 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	} else if (ctrlResult != ctrl.Result{}) {
 		return ctrlResult, nil
 	}
+	// only the configuration is rendered in preview, the database schema is
+	// not synced and the sub-CRs are not updated
+	if isPreviewed(Log, &instance.Status.Conditions, condition.ServiceConfigReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
 
 	// Handle service update
-	ctrlResult, err = r.reconcileUpdate(ctx, instance)
+	heldImages, ctrlResult, err := r.reconcileUpdate(ctx, helper, instance)
 	if err != nil {
 		return ctrlResult, err
 	} else if (ctrlResult != ctrl.Result{}) {
@@ -1038,30 +1662,7 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	newPoolList := strings.Join(currentPoolNames, ",")
 	instance.Status.Hash["multipool-pools"] = newPoolList
 
-	// Handle Mdns predictable IPs configmap
-	nad, err := nad.GetNADWithName(ctx, helper, instance.Spec.DesignateNetworkAttachment, instance.Namespace)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	networkParameters, err := designate.GetNetworkParametersFromNAD(nad)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	//
-	// Predictable IPs.
-	//
-	// NOTE(oschwart): refactoring this might be nice. This could also  be
-	// optimized but the data sets are small (nodes an IP ranges are less than
-	// 100) so optimization might be a waste.
-	//
-	predictableIPParams, err := designate.GetPredictableIPAM(networkParameters)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Fetch allocated ips from Mdns and Bind config maps and store them in allocatedIPs
+	// Fetch allocated ips from Mdns config map
 	mdnsLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})
 	mdnsConfigMap, err := r.handleConfigMap(ctx, helper, instance, designate.MdnsPredIPConfigMap, mdnsLabels)
 	if err != nil {
@@ -1069,10 +1670,6 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	}
 
 	bindLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})
-	bindConfigMap, err := r.handleConfigMap(ctx, helper, instance, designate.BindPredIPConfigMap, bindLabels)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
 
 	nsRecordsLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})
 	nsRecords, err := r.getNSRecords(ctx, helper, instance, nsRecordsLabels)
@@ -1080,15 +1677,6 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 		return ctrl.Result{}, err
 	}
 
-	allocatedIPs := make(map[string]bool)
-	for _, key := range slices.Sorted(maps.Keys(bindConfigMap.Data)) {
-		allocatedIPs[bindConfigMap.Data[key]] = true
-	}
-	for _, key := range slices.Sorted(maps.Keys(mdnsConfigMap.Data)) {
-		allocatedIPs[mdnsConfigMap.Data[key]] = true
-	}
-
-	// Handle Mdns predictable IPs configmap
 	// We cannot have 0 mDNS pods so even though the CRD validation allows 0, don't allow it.
 	mdnsReplicaCount := max(int(*instance.Spec.DesignateMdns.Replicas), 1)
 	var mdnsNames []string
@@ -1096,23 +1684,6 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 		mdnsNames = append(mdnsNames, fmt.Sprintf("mdns_address_%d", i))
 	}
 
-	updatedMap, allocatedIPs, err := r.allocatePredictableIPs(ctx, predictableIPParams, mdnsNames, mdnsConfigMap.Data, allocatedIPs)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), mdnsConfigMap, func() error {
-		mdnsConfigMap.Labels = util.MergeStringMaps(mdnsConfigMap.Labels, mdnsLabels)
-		mdnsConfigMap.Data = updatedMap
-		return controllerutil.SetControllerReference(instance, mdnsConfigMap, helper.GetScheme())
-	})
-
-	if err != nil {
-		Log.Info("Unable to create config map for mdns ips...")
-		return ctrl.Result{}, err
-	}
-
-	// Handle Bind predictable IPs configmap
 	// Unlike mDNS, we can have 0 binds when byob is used.
 	// NOTE(beagles) Really it might make more sense to have BYOB be an explicit flag and not assume that a 0
 	// value is a byob case. Something to think about.
@@ -1125,80 +1696,245 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	} else {
 		totalBinds = int(*instance.Spec.DesignateBackendbind9.Replicas)
 	}
-	for i := range totalBinds {
+	// On a scale down the servers being removed keep their predictable IPs
+	// until they are out of pools.yaml and their pods are gone
+	deployedBinds, err := r.deployedBindReplicas(ctx, instance, multipoolConfig)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	bindScaleDown := deployedBinds > totalBinds
+	var bindScaleDownResult, poolUpdateResult ctrl.Result
+	var poolsGenerated bool
+	for i := range max(totalBinds, deployedBinds) {
 		bindNames = append(bindNames, fmt.Sprintf("bind_address_%d", i))
 	}
 
-	updatedBindMap, _, err := r.allocatePredictableIPs(ctx, predictableIPParams, bindNames, bindConfigMap.Data, allocatedIPs)
+	var pdnsNames []string
+	if instance.IsPDNSEnabled() {
+		for i := range int(*instance.Spec.DesignateBackendPDNS.Replicas) {
+			pdnsNames = append(pdnsNames, fmt.Sprintf(designate.PDNSAddressKeyTemplate, i))
+		}
+	}
+
+	//
+	// Predictable IPs.
+	//
+	bindLayouts := getBindConfigMapLayouts(multipoolConfig, len(bindNames))
+	storedIPs, err := r.storedPredictableIPs(ctx, instance, mdnsConfigMap.Data, bindLayouts)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var updatedMap, updatedBindMap, updatedPDNSMap, queryBindMap map[string]string
+	var podAddressPending []string
+	switch instance.GetPredictableIPMode() {
+	case designatev1beta1.PredictableIPModeIPSet:
+		designate.DeletePredictableIPUsage(instance.Namespace, instance.Name)
+		updatedMap, updatedBindMap, updatedPDNSMap, ctrlResult, err = r.reserveIPSetPredictableIPs(ctx, helper, instance, mdnsNames, bindNames, pdnsNames)
+	case designatev1beta1.PredictableIPModeHostNetwork, designatev1beta1.PredictableIPModePodIP:
+		designate.DeletePredictableIPUsage(instance.Namespace, instance.Name)
+		updatedMap, updatedBindMap, podAddressPending, err = r.getPodAddresses(ctx, helper, instance, mdnsNames, bindLayouts)
+	default:
+		// drop the series of the removed networks, the allocations export
+		// the usage of the current ones
+		designate.DeletePredictableIPUsage(instance.Namespace, instance.Name)
+		updatedMap, updatedBindMap, updatedPDNSMap, err = r.reserveConfigMapPredictableIPs(ctx, helper, instance, mdnsNames, bindLayouts, pdnsNames, bindLabels)
+		if err == nil {
+			queryBindMap, err = r.reserveNetworkPredictableIPs(ctx, helper, instance, mdnsNames, bindLayouts, bindLabels)
+		}
+	}
 	if err != nil {
+		r.recordPredictableIPError(instance, err)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePredictableIPsReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignatePredictableIPsReadyErrorMessage,
+			err.Error()))
 		return ctrl.Result{}, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+	r.recordPredictableIPEvents(instance, storedIPs, updatedMap, updatedBindMap, updatedPDNSMap)
+	switch {
+	case len(podAddressPending) > 0:
+		// the pods are created further down, pools.yaml is generated once
+		// they all have an address
+		Log.Info(fmt.Sprintf("Waiting for the addresses of pods %s", strings.Join(podAddressPending, ", ")))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePredictableIPsReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignatePredictableIPsReadyPendingMessage,
+			strings.Join(podAddressPending, ", ")))
+		poolUpdateResult = ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}
+	case instance.GetPredictableIPMode() == designatev1beta1.PredictableIPModeHostNetwork:
+		instance.Status.Conditions.MarkTrue(designatev1beta1.DesignatePredictableIPsReadyCondition, designatev1beta1.DesignatePredictableIPsReadyHostNetworkMessage)
+	case instance.GetPredictableIPMode() == designatev1beta1.PredictableIPModePodIP:
+		instance.Status.Conditions.MarkTrue(designatev1beta1.DesignatePredictableIPsReadyCondition, designatev1beta1.DesignatePredictableIPsReadyPodIPMessage)
+	default:
+		instance.Status.Conditions.MarkTrue(designatev1beta1.DesignatePredictableIPsReadyCondition, designatev1beta1.DesignatePredictableIPsReadyMessage)
 	}
 
+	// Handle Mdns predictable IPs configmap
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), mdnsConfigMap, func() error {
+		if err := designate.AdoptControllerReference(ctx, helper.GetClient(), instance, mdnsConfigMap, mdnsLabels, helper.GetScheme()); err != nil {
+			return err
+		}
+		mdnsConfigMap.Labels = util.MergeStringMaps(mdnsConfigMap.Labels, mdnsLabels)
+		mdnsConfigMap.Data = updatedMap
+		return nil
+	})
+
+	if err != nil {
+		Log.Info("Unable to create config map for mdns ips...")
+		return ctrl.Result{}, err
+	}
+
+	// Handle Bind predictable IPs configmap
 	// Reconcile all bind IP ConfigMaps (main ConfigMap + per-pool ConfigMaps in multipool mode)
 	ctrlResult, err = r.reconcileBindConfigMaps(ctx, instance, helper, multipoolConfig, updatedBindMap, bindLabels)
 	if err != nil || (ctrlResult != ctrl.Result{}) {
 		return ctrlResult, err
 	}
 
-	if len(nsRecords) > 0 && instance.Status.DesignateCentralReadyCount > 0 {
-		Log.Info("NS records data found")
-		poolsYamlConfigMap := &corev1.ConfigMap{
+	// Handle PowerDNS predictable IPs configmap
+	if instance.IsPDNSEnabled() {
+		pdnsConfigMap := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      designate.PoolsYamlConfigMap,
+				Name:      designate.PDNSPredIPConfigMap,
 				Namespace: instance.GetNamespace(),
-				Labels:    bindLabels,
 			},
-			Data: make(map[string]string),
 		}
-
-		poolsYaml, poolsYamlHash, err := designate.GeneratePoolsYamlDataAndHash(updatedBindMap, mdnsConfigMap.Data, nsRecords, multipoolConfig)
+		_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), pdnsConfigMap, func() error {
+			if err := designate.AdoptControllerReference(ctx, helper.GetClient(), instance, pdnsConfigMap, bindLabels, helper.GetScheme()); err != nil {
+				return err
+			}
+			pdnsConfigMap.Labels = util.MergeStringMaps(pdnsConfigMap.Labels, bindLabels)
+			pdnsConfigMap.Data = updatedPDNSMap
+			return nil
+		})
 		if err != nil {
+			Log.Info("Unable to create config map for pdns ips...")
 			return ctrl.Result{}, err
 		}
+	}
 
-		Log.Info(fmt.Sprintf("pools.yaml content is\n%v", poolsYaml))
-		updatedPoolsYaml := make(map[string]string)
-		updatedPoolsYaml[designate.PoolsYamlContent] = poolsYaml
-
-		_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), poolsYamlConfigMap, func() error {
-			poolsYamlConfigMap.Labels = util.MergeStringMaps(poolsYamlConfigMap.Labels, bindLabels)
-			poolsYamlConfigMap.Data = updatedPoolsYaml
-			return controllerutil.SetControllerReference(instance, poolsYamlConfigMap, helper.GetScheme())
-		})
+	if len(nsRecords) > 0 && instance.Status.DesignateCentralReadyCount > 0 && len(podAddressPending) == 0 {
+		Log.Info("NS records data found")
+		pools, err := designate.GeneratePools(activeBindAddresses(updatedBindMap, totalBinds), mdnsConfigMap.Data, nsRecords, multipoolConfig)
 		if err != nil {
-			Log.Info("Unable to create config map for pools.yaml file")
 			return ctrl.Result{}, err
 		}
-
-		if instance.Status.Hash == nil {
-			instance.Status.Hash = make(map[string]string)
+		designate.SetBind9Port(pools, int(designatebackendbind9.DNSPort(&instance.Spec.DesignateBackendbind9.DesignateBackendbind9SpecBase)))
+		designate.SetBind9QueryHosts(pools, updatedBindMap, queryBindMap)
+		designate.SetCatalogZones(pools, instance.Spec.DesignateBackendbind9.CatalogZone)
+		designate.SetBind9Views(pools, instance.Spec.DesignateBackendbind9.Views)
+		if instance.Spec.DesignateBackendbind9.HiddenPrimary {
+			err = designate.SetHiddenPrimary(pools, instance.Spec.DesignateBackendbind9.SecondaryServers)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		pools, err = designate.AddExternalBindServers(pools, instance.Spec.ExternalBindServers, mdnsConfigMap.Data)
+		if err != nil {
+			return ctrl.Result{}, err
 		}
+		pools, err = designate.AddSecondaryServers(pools, instance.Spec.DesignateBackendbind9.SecondaryServers)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		pools, err = designate.AddPDNSServers(pools, updatedPDNSMap, mdnsConfigMap.Data)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		designate.SetMdnsPort(pools, int(designatemdns.Port(&instance.Spec.DesignateMdns.DesignateMdnsSpecBase)))
+		// pools defined by a DesignatePool CR are applied by its own controller
+		pools, err = r.excludeDesignatePools(ctx, instance, pools)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(pools) == 0 {
+			Log.Info("All pools are managed by DesignatePools, skipping pool update")
+			poolsGenerated = true
+		} else {
+			poolsYamlConfigMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      designate.PoolsYamlConfigMap,
+					Namespace: instance.GetNamespace(),
+					Labels:    bindLabels,
+				},
+				Data: make(map[string]string),
+			}
 
-		oldHash := instance.Status.Hash[designatev1beta1.PoolUpdateHash]
-		if oldHash != poolsYamlHash {
-			Log.Info(fmt.Sprintf("Old poolsYamlHash %s is different than new poolsYamlHash %s.\nLaunching pool update job", oldHash, poolsYamlHash))
+			poolsYaml, poolsYamlHash, err := designate.GeneratePoolsYaml(pools)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			Log.Info(fmt.Sprintf("pools.yaml content is\n%v", poolsYaml))
+			updatedPoolsYaml := make(map[string]string)
+			updatedPoolsYaml[designate.PoolsYamlContent] = poolsYaml
+
+			_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), poolsYamlConfigMap, func() error {
+				if err := designate.AdoptControllerReference(ctx, helper.GetClient(), instance, poolsYamlConfigMap, bindLabels, helper.GetScheme()); err != nil {
+					return err
+				}
+				poolsYamlConfigMap.Labels = util.MergeStringMaps(poolsYamlConfigMap.Labels, bindLabels)
+				poolsYamlConfigMap.Data = updatedPoolsYaml
+				return nil
+			})
+			if err != nil {
+				Log.Info("Unable to create config map for pools.yaml file")
+				return ctrl.Result{}, err
+			}
+			poolsGenerated = true
 
-			jobDef := designate.PoolUpdateJob(instance, serviceLabels, serviceAnnotations)
-			Log.Info("Initializing pool update job")
+			if instance.Status.Hash == nil {
+				instance.Status.Hash = make(map[string]string)
+			}
+
+			// the job only runs again when pools.yaml changes
+			jobDef := designate.PoolUpdateJob(instance, poolsYamlHash, serviceLabels, serviceAnnotations)
 			poolUpdatejob := job.NewJob(
 				jobDef,
 				designatev1beta1.PoolUpdateHash,
 				instance.Spec.PreserveJobs,
 				time.Duration(15)*time.Second,
-				oldHash,
+				instance.Status.Hash[designatev1beta1.PoolUpdateHash],
 			)
 
-			_, err = poolUpdatejob.DoJob(ctx, helper)
+			jobResult, err := poolUpdatejob.DoJob(ctx, helper)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
-			Log.Info("Pool update job completed successfully")
-			instance.Status.Hash[designatev1beta1.PoolUpdateHash] = poolsYamlHash
+			if (jobResult != ctrl.Result{}) {
+				if bindScaleDown {
+					// the bind9 servers are only removed once the pool
+					// update job has taken them out of the pool
+					Log.Info("Waiting for the pool update job before scaling down the bind9 servers")
+					bindScaleDownResult = jobResult
+				} else {
+					poolUpdateResult = jobResult
+				}
+			} else if poolUpdatejob.HasChanged() {
+				instance.Status.Hash[designatev1beta1.PoolUpdateHash] = poolUpdatejob.GetHash()
+				instance.Status.PoolUpdateRevision = poolsYamlHash
+				instance.Status.Pools = designate.PoolsStatus(pools, instance.Spec.DesignateBackendbind9.HiddenPrimary)
+				Log.Info(fmt.Sprintf("Pool update job completed - pools.yaml revision %s", poolsYamlHash))
+			}
 		}
 	}
 
+	// designate-worker and designate-producer are only deployed once the
+	// pools they manage are known, instead of crash looping on backends
+	// they can't reach
+	pendingDependencies, err := r.pendingDependencies(ctx, instance, poolsGenerated)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// deploy designate-central
-	designateCentral, op, err := r.centralDeploymentCreateOrUpdate(ctx, instance)
+	designateCentral, op, err := r.centralDeploymentCreateOrUpdate(ctx, instance, heldImages["central"])
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			designatev1beta1.DesignateCentralReadyCondition,
@@ -1240,48 +1976,62 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	Log.Info("Deployment Central task reconciled")
 
 	// deploy designate-worker
-	designateWorker, op, err := r.workerDeploymentCreateOrUpdate(ctx, instance)
+	workerWaiting, err := r.waitOnDependencies(ctx, instance, &designatev1beta1.DesignateWorker{}, "worker", pendingDependencies)
 	if err != nil {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			designatev1beta1.DesignateWorkerReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			designatev1beta1.DesignateWorkerReadyErrorMessage,
-			err.Error()))
 		return ctrl.Result{}, err
 	}
-	workerObsGen, err := r.checkDesignateWorkerGeneration(instance)
-	if err != nil {
+	if !workerWaiting {
+		designateWorker, op, err := r.workerDeploymentCreateOrUpdate(ctx, instance, heldImages["worker"])
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateWorkerReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateWorkerReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		workerObsGen, err := r.checkDesignateWorkerGeneration(instance)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateWorkerReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateWorkerReadyErrorMessage,
+				err.Error()))
+			return ctrlResult, nil
+		}
+		if !workerObsGen {
+			instance.Status.Conditions.Set(condition.UnknownCondition(
+				designatev1beta1.DesignateWorkerReadyCondition,
+				condition.InitReason,
+				designatev1beta1.DesignateWorkerReadyInitMessage,
+			))
+		} else {
+			// Mirror DesignateWorker status' ReadyCount to this parent CR
+			instance.Status.DesignateWorkerReadyCount = designateWorker.Status.ReadyCount
+			// Mirror DesignateWorker's condition status
+			c := designateWorker.Status.Conditions.Mirror(designatev1beta1.DesignateWorkerReadyCondition)
+			if c != nil {
+				instance.Status.Conditions.Set(c)
+			}
+		}
+		if op != controllerutil.OperationResultNone && workerObsGen {
+			Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+		}
+	} else {
+		Log.Info(fmt.Sprintf("DesignateWorker waiting on %s", strings.Join(pendingDependencies, ", ")))
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			designatev1beta1.DesignateWorkerReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			designatev1beta1.DesignateWorkerReadyErrorMessage,
-			err.Error()))
-		return ctrlResult, nil
-	}
-	if !workerObsGen {
-		instance.Status.Conditions.Set(condition.UnknownCondition(
-			designatev1beta1.DesignateWorkerReadyCondition,
-			condition.InitReason,
-			designatev1beta1.DesignateWorkerReadyInitMessage,
-		))
-	} else {
-		// Mirror DesignateWorker status' ReadyCount to this parent CR
-		instance.Status.DesignateWorkerReadyCount = designateWorker.Status.ReadyCount
-		// Mirror DesignateWorker's condition status
-		c := designateWorker.Status.Conditions.Mirror(designatev1beta1.DesignateWorkerReadyCondition)
-		if c != nil {
-			instance.Status.Conditions.Set(c)
-		}
-	}
-	if op != controllerutil.OperationResultNone && workerObsGen {
-		Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+			designatev1beta1.DesignateWaitingOnDependenciesReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateWorkerReadyWaitingMessage,
+			strings.Join(pendingDependencies, ", ")))
 	}
 	Log.Info("Deployment Worker task reconciled")
 
 	// deploy designate-mdns
-	designateMdns, op, err := r.mdnsStatefulSetCreateOrUpdate(ctx, instance)
+	designateMdns, op, err := r.mdnsStatefulSetCreateOrUpdate(ctx, instance, heldImages["mdns"])
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			designatev1beta1.DesignateMdnsReadyCondition,
@@ -1322,48 +2072,62 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	Log.Info("Deployment Mdns task reconciled")
 
 	// deploy designate-producer
-	designateProducer, op, err := r.producerDeploymentCreateOrUpdate(ctx, instance)
+	producerWaiting, err := r.waitOnDependencies(ctx, instance, &designatev1beta1.DesignateProducer{}, "producer", pendingDependencies)
 	if err != nil {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			designatev1beta1.DesignateProducerReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			designatev1beta1.DesignateProducerReadyErrorMessage,
-			err.Error()))
 		return ctrl.Result{}, err
 	}
-	prodObsGen, err := r.checkDesignateProducerGeneration(instance)
-	if err != nil {
+	if !producerWaiting {
+		designateProducer, op, err := r.producerDeploymentCreateOrUpdate(ctx, instance, heldImages["producer"])
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateProducerReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateProducerReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		prodObsGen, err := r.checkDesignateProducerGeneration(instance)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateProducerReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateProducerReadyErrorMessage,
+				err.Error()))
+			return ctrlResult, nil
+		}
+		if !prodObsGen {
+			instance.Status.Conditions.Set(condition.UnknownCondition(
+				designatev1beta1.DesignateProducerReadyCondition,
+				condition.InitReason,
+				designatev1beta1.DesignateProducerReadyInitMessage,
+			))
+		} else {
+			// Mirror DesignateProducer status' ReadyCount to this parent CR
+			instance.Status.DesignateProducerReadyCount = designateProducer.Status.ReadyCount
+			// Mirror DesignateProducer's condition status
+			c := designateProducer.Status.Conditions.Mirror(designatev1beta1.DesignateProducerReadyCondition)
+			if c != nil {
+				instance.Status.Conditions.Set(c)
+			}
+		}
+		if op != controllerutil.OperationResultNone && prodObsGen {
+			Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+		}
+	} else {
+		Log.Info(fmt.Sprintf("DesignateProducer waiting on %s", strings.Join(pendingDependencies, ", ")))
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			designatev1beta1.DesignateProducerReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			designatev1beta1.DesignateProducerReadyErrorMessage,
-			err.Error()))
-		return ctrlResult, nil
-	}
-	if !prodObsGen {
-		instance.Status.Conditions.Set(condition.UnknownCondition(
-			designatev1beta1.DesignateProducerReadyCondition,
-			condition.InitReason,
-			designatev1beta1.DesignateProducerReadyInitMessage,
-		))
-	} else {
-		// Mirror DesignateProducer status' ReadyCount to this parent CR
-		instance.Status.DesignateProducerReadyCount = designateProducer.Status.ReadyCount
-		// Mirror DesignateProducer's condition status
-		c := designateProducer.Status.Conditions.Mirror(designatev1beta1.DesignateProducerReadyCondition)
-		if c != nil {
-			instance.Status.Conditions.Set(c)
-		}
-	}
-	if op != controllerutil.OperationResultNone && prodObsGen {
-		Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+			designatev1beta1.DesignateWaitingOnDependenciesReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateProducerReadyWaitingMessage,
+			strings.Join(pendingDependencies, ", ")))
 	}
 	Log.Info("Deployment Producer task reconciled")
 
 	// deploy designate-backendbind9
-	designateBackendbind9, op, err := r.backendbind9StatefulSetCreateOrUpdate(ctx, instance)
+	designateBackendbind9, op, err := r.backendbind9StatefulSetCreateOrUpdate(ctx, instance, bindScaleDownResult != ctrl.Result{}, heldImages["backendbind9"])
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			designatev1beta1.DesignateBackendbind9ReadyCondition,
@@ -1403,8 +2167,57 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 	}
 	Log.Info("Deployment Backendbind9 task reconciled")
 
+	// deploy designate-backendpdns, the PowerDNS servers are only deployed
+	// when requested
+	if instance.IsPDNSEnabled() {
+		designateBackendPDNS, op, err := r.backendpdnsStatefulSetCreateOrUpdate(ctx, instance, heldImages["backendpdns"])
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateBackendPDNSReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateBackendPDNSReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		pdnsObsGen, err := r.checkDesignatePDNSGeneration(instance)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateBackendPDNSReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateBackendPDNSReadyErrorMessage,
+				err.Error()))
+			return ctrlResult, nil
+		}
+		if !pdnsObsGen {
+			instance.Status.Conditions.Set(condition.UnknownCondition(
+				designatev1beta1.DesignateBackendPDNSReadyCondition,
+				condition.InitReason,
+				designatev1beta1.DesignateBackendPDNSReadyInitMessage,
+			))
+		} else {
+			// Mirror DesignateBackendPDNS status' ReadyCount to this parent CR
+			instance.Status.DesignateBackendPDNSReadyCount = designateBackendPDNS.Status.ReadyCount
+			// Mirror DesignateBackendPDNS's condition status
+			c := designateBackendPDNS.Status.Conditions.Mirror(designatev1beta1.DesignateBackendPDNSReadyCondition)
+			if c != nil {
+				instance.Status.Conditions.Set(c)
+			}
+		}
+		if op != controllerutil.OperationResultNone && pdnsObsGen {
+			Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+		}
+	} else {
+		if err := r.backendpdnsDelete(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.DesignateBackendPDNSReadyCount = 0
+	}
+	Log.Info("Deployment BackendPDNS task reconciled")
+
 	// deploy the unbound reconcilier if necessary
-	designateUnbound, op, err := r.unboundStatefulSetCreateOrUpdate(ctx, instance)
+	designateUnbound, op, err := r.unboundStatefulSetCreateOrUpdate(ctx, instance, heldImages["unbound"])
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			designatev1beta1.DesignateUnboundReadyCondition,
@@ -1438,25 +2251,121 @@ func (r *DesignateReconciler) reconcileNormal(ctx context.Context, instance *des
 			instance.Status.Conditions.Set(c)
 		}
 	}
-	if op != controllerutil.OperationResultNone && unbObsGen {
-		Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+	if op != controllerutil.OperationResultNone && unbObsGen {
+		Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+	}
+	Log.Info("Deployment Unbound task reconciled")
+
+	// deploy designate-sink, it is only deployed when requested
+	if instance.IsSinkEnabled() {
+		designateSink, op, err := r.sinkDeploymentCreateOrUpdate(ctx, instance, heldImages["sink"])
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateSinkReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateSinkReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		sinkObsGen, err := r.checkDesignateSinkGeneration(instance)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateSinkReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateSinkReadyErrorMessage,
+				err.Error()))
+			return ctrlResult, nil
+		}
+		if !sinkObsGen {
+			instance.Status.Conditions.Set(condition.UnknownCondition(
+				designatev1beta1.DesignateSinkReadyCondition,
+				condition.InitReason,
+				designatev1beta1.DesignateSinkReadyInitMessage,
+			))
+		} else {
+			// Mirror DesignateSink status' ReadyCount to this parent CR
+			instance.Status.DesignateSinkReadyCount = designateSink.Status.ReadyCount
+			// Mirror DesignateSink's condition status
+			c := designateSink.Status.Conditions.Mirror(designatev1beta1.DesignateSinkReadyCondition)
+			if c != nil {
+				instance.Status.Conditions.Set(c)
+			}
+		}
+		if op != controllerutil.OperationResultNone && sinkObsGen {
+			Log.Info(fmt.Sprintf("Deployment %s successfully reconciled - operation: %s", instance.Name, string(op)))
+		}
+	} else {
+		if err := r.sinkDelete(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+		instance.Status.DesignateSinkReadyCount = 0
+	}
+	Log.Info("Deployment Sink task reconciled")
+
+	// rotate the rndc keys once the services run with the current ones
+	rotationResult, err := r.reconcileRndcKeyRotation(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// follow the restart of the services with rotated database credentials
+	dbRotationResult, err := r.reconcileDatabaseAccountRotation(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.reconcileNetworkPolicies(ctx, helper, instance)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
-	Log.Info("Deployment Unbound task reconciled")
 
-	// remove finalizers from unused MariaDBAccount records
-	err = mariadbv1.DeleteUnusedMariaDBAccountFinalizers(ctx, helper, designate.DatabaseCRName, instance.Spec.DatabaseAccount, instance.Namespace)
+	ctrlResult, err = r.reconcilePreflight(ctx, helper, instance, serviceLabels, activeBindAddresses(updatedBindMap, totalBinds))
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
+	// compare the serials the bind9 servers serve with designate
+	zoneConsistencyResult, err := r.reconcileZoneConsistency(ctx, helper, instance, multipoolConfig != nil)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// remove finalizers from unused MariaDBAccount records
+	if !instance.UsesExternalDatabase() {
+		err = mariadbv1.DeleteUnusedMariaDBAccountFinalizers(ctx, helper, designate.DatabaseCRName, instance.Spec.DatabaseAccount, instance.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// We reached the end of the Reconcile, update the Ready condition based on
-	// the sub conditions
+	// the sub conditions. Lagging zones are reported without affecting Ready.
+	zonesInSync := instance.Status.Conditions.Get(designatev1beta1.DesignateZonesInSyncCondition)
+	instance.Status.Conditions.Remove(designatev1beta1.DesignateZonesInSyncCondition)
 	if instance.Status.Conditions.AllSubConditionIsTrue() {
 		instance.Status.Conditions.MarkTrue(
 			condition.ReadyCondition, condition.ReadyMessage)
 	}
+	if zonesInSync != nil {
+		instance.Status.Conditions.Set(zonesInSync)
+	}
 	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
+	if (bindScaleDownResult != ctrl.Result{}) {
+		return bindScaleDownResult, nil
+	}
+	if (poolUpdateResult != ctrl.Result{}) {
+		return poolUpdateResult, nil
+	}
+	if (dbRotationResult != ctrl.Result{}) {
+		return dbRotationResult, nil
+	}
+	if zoneConsistencyResult.RequeueAfter > 0 &&
+		(rotationResult.RequeueAfter == 0 || zoneConsistencyResult.RequeueAfter < rotationResult.RequeueAfter) {
+		return zoneConsistencyResult, nil
+	}
+	return rotationResult, nil
 }
 
 func (r *DesignateReconciler) getNSRecords(ctx context.Context, helper *helper.Helper, instance *designatev1beta1.Designate, labels map[string]string) ([]designatev1beta1.DesignateNSRecord, error) {
@@ -1519,52 +2428,235 @@ func (r *DesignateReconciler) handleConfigMap(ctx context.Context, helper *helpe
 	return nodeConfigMap, nil
 }
 
-func (r *DesignateReconciler) allocatePredictableIPs(ctx context.Context, predictableIPParams *designate.NADIpam, ipHolders []string, existingMap map[string]string, allocatedIPs map[string]bool) (map[string]string, map[string]bool, error) {
+// reserveConfigMapPredictableIPs allocates the mdns, bind and PowerDNS
+// predictable IPs from the addresses following the range of the control
+// network NAD, keeping the allocations already stored in the ConfigMaps
+// unless they are excluded by the NAD or the spec. The ConfigMaps, including
+// the per-pool bind ConfigMaps of multipool mode, are written with their
+// final content in a single conflict checked transaction.
+// The static IPs of the mdns and bind pods take precedence over the
+// allocations. The returned bind map is keyed by the global bind IP holders.
+func (r *DesignateReconciler) reserveConfigMapPredictableIPs(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.Designate,
+	mdnsNames []string,
+	bindLayouts []bindConfigMapLayout,
+	pdnsNames []string,
+	configMapLabels map[string]string,
+) (map[string]string, map[string]string, map[string]string, error) {
 	Log := r.GetLogger(ctx)
 
-	updatedMap := make(map[string]string)
-	var predictableIPsRequired []string
-
-	// First scan existing allocations so we can keep existing allocations.
-	// Keeping track of what's required and what already exists. If a node is
-	// removed from the cluster, it's IPs will not be added to the allocated
-	// list and are effectively recycled.
-	for _, ipHolder := range ipHolders {
-		if ipValue, ok := existingMap[ipHolder]; ok {
-			updatedMap[ipHolder] = ipValue
-			Log.Info(fmt.Sprintf("%s has IP mapping: %s", ipHolder, ipValue))
-		} else {
-			predictableIPsRequired = append(predictableIPsRequired, ipHolder)
-		}
+	// Release the reservations of a previous IPSet mode
+	if err := r.releasePredictableIPSets(ctx, helper, instance, nil); err != nil {
+		return nil, nil, nil, err
 	}
 
-	// Get new IPs using the range from predictableIPParmas minus the
-	// allocatedIPs captured above.
-	Log.Info(fmt.Sprintf("Allocating %d predictable IPs", len(predictableIPsRequired)))
-	for _, nodeName := range predictableIPsRequired {
-		ipAddress, err := designate.GetNextIP(predictableIPParams, allocatedIPs)
-		if err != nil {
-			// An error here is really unexpected- it means either we have
-			// messed up the allocatedIPs list or the range we are assuming is
-			// too small for the number of mdns pod.
-			return nil, nil, err
+	nad, err := nad.GetNADWithName(ctx, helper, instance.Spec.DesignateNetworkAttachment, instance.Namespace)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// One set of parameters per IP family on dual stack networks
+	networkParameters, err := designate.GetAllNetworkParametersFromNAD(nad)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	exclude, err := designate.ParseExcludes(instance.Spec.PredictableIPs.Exclude)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	predictableIPParams, err := designate.GetPredictableIPAMs(networkParameters, exclude)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The mdns ConfigMap comes first so its holders keep getting the lowest
+	// addresses of the range, as before.
+	requests := []designate.PredictableIPRequest{
+		{
+			ConfigMapName: designate.MdnsPredIPConfigMap,
+			IPHolders:     mdnsNames,
+			StaticIPs:     designate.StaticIPHolders("mdns_address_%d", instance.Spec.DesignateMdns.StaticIPs),
+		},
+	}
+	bindStaticIPs := designate.StaticIPHolders("bind_address_%d", instance.Spec.DesignateBackendbind9.StaticIPs)
+	for _, layout := range bindLayouts {
+		// the static IPs are pinned to the global bind IP holders
+		staticIPs := make(map[string]string)
+		for i, localName := range layout.LocalNames {
+			if value, ok := bindStaticIPs[layout.GlobalNames[i]]; ok {
+				staticIPs[localName] = value
+			}
+		}
+		requests = append(requests, designate.PredictableIPRequest{
+			ConfigMapName: layout.ConfigMapName,
+			IPHolders:     layout.LocalNames,
+			Extra:         layout.RNDCKeys,
+			StaticIPs:     staticIPs,
+		})
+	}
+	// The PowerDNS servers come last so enabling them never moves the
+	// addresses of the mdns and bind pods. The request is made even without
+	// PowerDNS servers so the addresses of a previous deployment are released
+	// instead of being kept in the ConfigMap and handed out a second time.
+	requests = append(requests, designate.PredictableIPRequest{
+		ConfigMapName: designate.PDNSPredIPConfigMap,
+		IPHolders:     pdnsNames,
+	})
+	allocations, err := designate.AllocatePredictableIPs(
+		ctx,
+		helper.GetClient(),
+		instance.Namespace,
+		predictableIPParams,
+		requests,
+		func(cm *corev1.ConfigMap) error {
+			if err := designate.AdoptControllerReference(ctx, helper.GetClient(), instance, cm, configMapLabels, helper.GetScheme()); err != nil {
+				return err
+			}
+			cm.Labels = util.MergeStringMaps(cm.Labels, configMapLabels)
+			return nil
+		},
+	)
+	if err != nil {
+		// An error here is really unexpected- it means either we have
+		// messed up the allocatedIPs list or the range we are assuming is
+		// too small for the number of pods.
+		return nil, nil, nil, err
+	}
+	designate.RecordPredictableIPUsage(instance.Namespace, instance.Name, instance.Spec.DesignateNetworkAttachment, predictableIPParams, allocations)
+	updatedBindMap := make(map[string]string)
+	for _, layout := range bindLayouts {
+		for i, localName := range layout.LocalNames {
+			updatedBindMap[layout.GlobalNames[i]] = allocations[layout.ConfigMapName][localName]
 		}
-		updatedMap[nodeName] = ipAddress
+	}
+	Log.Info(fmt.Sprintf("Predictable IPs allocated for %d mdns, %d bind and %d pdns pods", len(mdnsNames), len(updatedBindMap), len(pdnsNames)))
+
+	return allocations[designate.MdnsPredIPConfigMap], updatedBindMap, allocations[designate.PDNSPredIPConfigMap], nil
+}
+
+// reserveIPSetPredictableIPs requests the mdns, bind and PowerDNS predictable IPs from
+// infra-operator, with the static IPs of the mdns and bind pods as fixed IPs.
+// A requeue is returned until all the reservations are made.
+func (r *DesignateReconciler) reserveIPSetPredictableIPs(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.Designate,
+	mdnsNames []string,
+	bindNames []string,
+	pdnsNames []string,
+) (map[string]string, map[string]string, map[string]string, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	// A single subnet would silently leave the pods without an address of
+	// the other family
+	nad, err := nad.GetNADWithName(ctx, helper, instance.Spec.DesignateNetworkAttachment, instance.Namespace)
+	if err != nil {
+		return nil, nil, nil, ctrl.Result{}, err
+	}
+	networkParameters, err := designate.GetAllNetworkParametersFromNAD(nad)
+	if err != nil {
+		return nil, nil, nil, ctrl.Result{}, err
+	}
+	if len(networkParameters) > 1 && instance.Spec.PredictableIPs.IPv6SubnetName == "" {
+		return nil, nil, nil, ctrl.Result{}, fmt.Errorf("%w: %s", designate.ErrIPv6SubnetRequired, instance.Spec.DesignateNetworkAttachment)
+	}
+
+	staticIPs := designate.StaticIPHolders("mdns_address_%d", instance.Spec.DesignateMdns.StaticIPs)
+	maps.Copy(staticIPs, designate.StaticIPHolders("bind_address_%d", instance.Spec.DesignateBackendbind9.StaticIPs))
+	reserved, pending, err := r.reconcilePredictableIPSets(ctx, helper, instance, slices.Concat(mdnsNames, bindNames, pdnsNames), staticIPs)
+	if err != nil {
+		return nil, nil, nil, ctrl.Result{}, err
+	}
+	if len(pending) > 0 {
+		Log.Info(fmt.Sprintf("Waiting for IPSet reservations: %s", strings.Join(pending, ", ")))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePredictableIPsReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignatePredictableIPsReadyWaitingMessage,
+			strings.Join(pending, ", ")))
+		return nil, nil, nil, ctrl.Result{RequeueAfter: time.Duration(5) * time.Second}, nil
+	}
+
+	updatedMap := make(map[string]string)
+	for _, ipHolder := range mdnsNames {
+		updatedMap[ipHolder] = reserved[ipHolder]
+	}
+	updatedBindMap := make(map[string]string)
+	for _, ipHolder := range bindNames {
+		updatedBindMap[ipHolder] = reserved[ipHolder]
+	}
+	updatedPDNSMap := make(map[string]string)
+	for _, ipHolder := range pdnsNames {
+		updatedPDNSMap[ipHolder] = reserved[ipHolder]
 	}
 
-	return updatedMap, allocatedIPs, nil
+	return updatedMap, updatedBindMap, updatedPDNSMap, ctrl.Result{}, nil
 }
 
-func (r *DesignateReconciler) reconcileUpdate(ctx context.Context, instance *designatev1beta1.Designate) (ctrl.Result, error) {
+// reconcileUpdate sequences the minor updates, the images of the sub-CRs are
+// rolled out in stages once the database schema is synced with the new API
+// image. It returns the suffixes of the sub-CRs which keep their current image.
+func (r *DesignateReconciler) reconcileUpdate(ctx context.Context, h *helper.Helper, instance *designatev1beta1.Designate) (map[string]bool, ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 
 	Log.Info(fmt.Sprintf("Reconciling Service '%s' update", instance.Name))
 
-	// TODO: should have minor update tasks if required
-	// - delete dbsync hash from status to rerun it?
+	update, err := r.updateProgress(ctx, instance)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateUpdateReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateUpdateReadyErrorMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
+	}
+	// the bind9 servers are restarted with their new image once the hooks ran,
+	// the Job events reconcile the update again
+	if update.updating["backendbind9"] {
+		hook, _, err := r.reconcileHooks(ctx, h, instance, designatev1beta1.HookStagePreBind9Restart, instance.Spec.DesignateBackendbind9.ContainerImage)
+		if hook != "" {
+			update.held["backendbind9"] = true
+			update.waiting = append(update.waiting, fmt.Sprintf("hook %s", hook))
+		}
+		if err != nil && update.failed == "" {
+			update.failed, update.failure = fmt.Sprintf("hook %s", hook), err.Error()
+		}
+	}
+	switch {
+	case update.outdated && update.failed != "":
+		// the later stages are held until the failure is fixed
+		Log.Info(fmt.Sprintf("Update paused, %s failed: %s", update.failed, update.failure))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateUpdateReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateUpdateReadyPausedMessage,
+			update.failed,
+			update.failure))
+	case update.outdated:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateUpdateReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateUpdateReadyRunningMessage,
+			strings.Join(update.waiting, ", ")))
+	default:
+		instance.Status.Conditions.MarkTrue(
+			designatev1beta1.DesignateUpdateReadyCondition,
+			designatev1beta1.DesignateUpdateReadyMessage)
+	}
+	// the version changes once all the sub-CRs run the new images
+	if len(update.waiting) == 0 {
+		instance.Status.DesignateVersion = designate.ReleaseVersion(instance)
+	}
 
 	Log.Info(fmt.Sprintf("Reconciled Service '%s' update successfully", instance.Name))
-	return ctrl.Result{}, nil
+	return update.held, ctrl.Result{}, nil
 }
 
 func (r *DesignateReconciler) reconcileUpgrade(ctx context.Context, instance *designatev1beta1.Designate) (ctrl.Result, error) {
@@ -1585,7 +2677,7 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 	h *helper.Helper,
 	instance *designatev1beta1.Designate,
 	envVars *map[string]env.Setter,
-	designateDb *mariadbv1.Database,
+	designateDb *designate.Database,
 ) error {
 	//
 	// create Configmap/Secret required for designate input
@@ -1609,7 +2701,12 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 			replicas += int(pool.BindReplicas)
 		}
 	} else {
-		replicas = int(*instance.Spec.DesignateBackendbind9.Replicas)
+		// the servers being scaled down keep their keys until their pods are gone
+		deployedReplicas, err := r.deployedBindReplicas(ctx, instance, multipoolConfig)
+		if err != nil {
+			return err
+		}
+		replicas = max(int(*instance.Spec.DesignateBackendbind9.Replicas), deployedReplicas)
 	}
 
 	// Get the secret first by providing the same name and namespace
@@ -1655,9 +2752,21 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 				newKeysMap[keyName] = []byte(rndcKeyContent)
 				Log.Info(fmt.Sprintf("key %s did not exist, was created and added", keyName))
 			}
+			// keep the keys of an ongoing rotation
+			for _, suffix := range []string{designate.RndcNextKeySuffix, designate.RndcPreviousKeySuffix} {
+				if key, exists := secret.Data[keyName+suffix]; exists {
+					newKeysMap[keyName+suffix] = key
+				}
+			}
 		}
 		secret.Data = newKeysMap
-		return nil
+		// the rndc keys are kept with the zone data of the servers with
+		// preserveOnDelete, they are shared by the Designate CRs of the namespace
+		if instance.Spec.PreserveOnDelete {
+			designate.RemoveOwnerReference(instance, secret)
+			return nil
+		}
+		return controllerutil.SetOwnerReference(instance, secret, h.GetScheme())
 	})
 
 	if err != nil {
@@ -1665,6 +2774,20 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 		return err
 	}
 
+	if err := r.reconcileExternalBindKeys(ctx, h, instance, cmLabels); err != nil {
+		return err
+	}
+
+	if err := r.reconcileCoordinationSecret(ctx, h, instance, cmLabels); err != nil {
+		return err
+	}
+
+	if instance.IsPDNSEnabled() {
+		if err := r.reconcilePDNSAPIKey(ctx, h, instance, cmLabels); err != nil {
+			return err
+		}
+	}
+
 	// TLS handling
 	var tlsCfg *tls.Service
 	if instance.Spec.DesignateAPI.TLS.CaBundleSecretName != "" {
@@ -1679,20 +2802,25 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 		common.CustomServiceConfigFileName: instance.Spec.CustomServiceConfig,
 		"my.cnf":                           designateDb.GetDatabaseClientConfig(tlsCfg), //(oschwart) for now just get the default my.cnf
 	}
-
-	databaseAccount := designateDb.GetAccount()
-	dbSecret := designateDb.GetSecret()
+	// the services copy the CA certificate of an external database from
+	// their merged configuration
+	if designateDb.CACert != "" {
+		customData[designate.DatabaseCAFileName] = designateDb.CACert
+	}
+	// the read-only API replicas read from the read replica of the database,
+	// with the credentials of the database
+	if readOnly := instance.Spec.DesignateAPI.ReadOnly; readOnly != nil {
+		customData[designateapi.ReadOnlyConfigFileName] = fmt.Sprintf(
+			"[database]\nslave_connection=%[1]s\n\n[storage:sqlalchemy]\nslave_connection=%[1]s\n",
+			designateDb.GetReadOnlyConnection(readOnly.DatabaseHostname))
+	}
 
 	// We only need a minimal 00-config.conf that is only used by db-sync job,
 	// hence only passing the database related parameters
 	templateParameters := map[string]any{
-		"MinimalConfig": true, // This tells the template to generate a minimal config
-		"DatabaseConnection": fmt.Sprintf("mysql+pymysql://%s:%s@%s/%s?read_default_file=/etc/my.cnf",
-			databaseAccount.Spec.UserName,
-			string(dbSecret.Data[mariadbv1.DatabasePasswordSelector]),
-			instance.Status.DatabaseHostname,
-			designate.DatabaseName,
-		),
+		"MinimalConfig":      true, // This tells the template to generate a minimal config
+		"DatabaseConnection": designateDb.GetConnection(),
+		"DatabaseOptions":    designate.DatabaseTuningOptions(instance.Spec.DatabaseTuning),
 	}
 
 	transportURLSecret, _, err := oko_secret.GetSecret(ctx, h, instance.Status.TransportURLSecret, instance.Namespace)
@@ -1744,6 +2872,20 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 		templateParameters["NotificationsTransportURL"] = string(notificationsTransportURLSecret.Data["transport_url"])
 	}
 
+	templateParameters["NotificationsDriver"] = instance.Spec.Notifications.Driver
+	if templateParameters["NotificationsDriver"] == "" {
+		templateParameters["NotificationsDriver"] = "messagingv2"
+	}
+	templateParameters["NotificationsTopics"] = "notifications"
+	if len(instance.Spec.Notifications.Topics) > 0 {
+		templateParameters["NotificationsTopics"] = strings.Join(instance.Spec.Notifications.Topics, ",")
+	}
+
+	if instance.Spec.Tracing != nil {
+		templateParameters["TracingEndpoint"] = instance.Spec.Tracing.Endpoint
+		templateParameters["TracingSQLAlchemy"] = instance.Spec.Tracing.TraceSQLAlchemy
+	}
+
 	adminPasswordSecret, _, err := oko_secret.GetSecret(ctx, h, instance.Spec.Secret, instance.Namespace)
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
@@ -1767,9 +2909,8 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 	}
 	templateParameters["AdminPassword"] = string(adminPasswordSecret.Data["DesignatePassword"])
 
-	// We should never get here, but just in case.
-	if len(instance.Status.RedisHostIPs) == 0 {
-		err = designate.ErrRedisRequired
+	backendURL, err := r.coordinationBackendURL(ctx, instance)
+	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.InputReadyCondition,
 			condition.ErrorReason,
@@ -1778,11 +2919,6 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 			err.Error()))
 		return err
 	}
-
-	backendURL := fmt.Sprintf("redis://%s:6379/", instance.Status.RedisHostIPs[0])
-	if instance.Status.RedisTLS == "true" {
-		backendURL = fmt.Sprintf("%s?ssl=true", backendURL)
-	}
 	templateParameters["CoordinationBackendURL"] = backendURL
 
 	cms := []util.Template{
@@ -1819,7 +2955,7 @@ func (r *DesignateReconciler) generateServiceConfigMaps(
 		},
 	}
 
-	err = oko_secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+	err = ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
 	if err != nil {
 		return err
 	}
@@ -1943,10 +3079,21 @@ func (r *DesignateReconciler) notificationsTransportURLCreateOrUpdate(
 	return transportURL, op, err
 }
 
+// inheritImagePullOptions - copy the image pull options of the central Spec to a sub-spec template without its own.
+func inheritImagePullOptions(src *designatev1beta1.DesignateSpecBase, dest *designatev1beta1.DesignateServiceTemplateCore) {
+	if dest.ImagePullSecrets == nil {
+		dest.ImagePullSecrets = src.ImagePullSecrets
+	}
+	if dest.ImagePullPolicy == "" {
+		dest.ImagePullPolicy = src.ImagePullPolicy
+	}
+}
+
 // copyDesignateTemplateItems - copy elements from the central Spec to the sub-spec template.
 func copyDesignateTemplateItems(src *designatev1beta1.DesignateSpecBase, dest *designatev1beta1.DesignateTemplate) {
 	dest.ServiceUser = getOrDefault(src.ServiceUser, "designate")
 	dest.DatabaseAccount = getOrDefault(src.DatabaseAccount, "designate")
+	dest.ExternalDatabase = src.ExternalDatabase != nil
 	dest.Secret = src.Secret
 	dest.PasswordSelectors.Service = getOrDefault(src.PasswordSelectors.Service, "DesignatePassword")
 }
@@ -1968,9 +3115,22 @@ func (r *DesignateReconciler) apiDeploymentCreateOrUpdate(ctx context.Context, i
 	if instance.Spec.DesignateAPI.TopologyRef == nil {
 		instance.Spec.DesignateAPI.TopologyRef = instance.Spec.TopologyRef
 	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateAPI.DesignateServiceTemplateCore)
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		// replicas not set in the template are managed on the API CR, keep them
+		replicas := deployment.Spec.Replicas
 		deployment.Spec = instance.Spec.DesignateAPI
+		// the extra mounts of the Designate CR are added to the ones of the service
+		deployment.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateAPI.ExtraMounts, instance.Spec.ExtraMounts)
+		if instance.Spec.DesignateAPI.Replicas == nil {
+			deployment.Spec.Replicas = replicas
+		}
+		// the API authenticates to keystone as set on the Designate CR unless
+		// its template sets its own application credential
+		if deployment.Spec.Auth.ApplicationCredentialSecret == "" {
+			deployment.Spec.Auth = instance.Spec.Auth
+		}
 		// Add in transfers from umbrella Designate (this instance) spec
 		// TODO: Add logic to determine when to set/overwrite, etc
 		copyDesignateTemplateItems(&instance.Spec.DesignateSpecBase, &deployment.Spec.DesignateTemplate)
@@ -1980,7 +3140,10 @@ func (r *DesignateReconciler) apiDeploymentCreateOrUpdate(ctx context.Context, i
 		deployment.Spec.TransportURLSecret = instance.Status.TransportURLSecret
 		deployment.Spec.NodeSelector = instance.Spec.DesignateAPI.NodeSelector
 		deployment.Spec.TopologyRef = instance.Spec.DesignateAPI.TopologyRef
-		deployment.Spec.APITimeout = instance.Spec.APITimeout
+		// the template of the API can override the timeout of the Designate CR
+		if instance.Spec.DesignateAPI.APITimeout == 0 {
+			deployment.Spec.APITimeout = instance.Spec.APITimeout
+		}
 
 		err := controllerutil.SetControllerReference(instance, deployment, r.Scheme)
 		if err != nil {
@@ -1993,7 +3156,7 @@ func (r *DesignateReconciler) apiDeploymentCreateOrUpdate(ctx context.Context, i
 	return deployment, op, err
 }
 
-func (r *DesignateReconciler) centralDeploymentCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate) (*designatev1beta1.DesignateCentral, controllerutil.OperationResult, error) {
+func (r *DesignateReconciler) centralDeploymentCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate, holdImage bool) (*designatev1beta1.DesignateCentral, controllerutil.OperationResult, error) {
 	deployment := &designatev1beta1.DesignateCentral{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-central", instance.Name),
@@ -2010,9 +3173,22 @@ func (r *DesignateReconciler) centralDeploymentCreateOrUpdate(ctx context.Contex
 	if instance.Spec.DesignateCentral.TopologyRef == nil {
 		instance.Spec.DesignateCentral.TopologyRef = instance.Spec.TopologyRef
 	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateCentral.DesignateServiceTemplateCore)
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		// replicas not set in the template are managed on the Central CR, keep them
+		replicas := deployment.Spec.Replicas
+		currentImage := deployment.Spec.ContainerImage
 		deployment.Spec = instance.Spec.DesignateCentral
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			deployment.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		deployment.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateCentral.ExtraMounts, instance.Spec.ExtraMounts)
+		if instance.Spec.DesignateCentral.Replicas == nil {
+			deployment.Spec.Replicas = replicas
+		}
 		// Add in transfers from umbrella Designate CR (this instance) spec
 		// TODO: Add logic to determine when to set/overwrite, etc
 		copyDesignateTemplateItems(&instance.Spec.DesignateSpecBase, &deployment.Spec.DesignateTemplate)
@@ -2036,7 +3212,7 @@ func (r *DesignateReconciler) centralDeploymentCreateOrUpdate(ctx context.Contex
 	return deployment, op, err
 }
 
-func (r *DesignateReconciler) workerDeploymentCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate) (*designatev1beta1.DesignateWorker, controllerutil.OperationResult, error) {
+func (r *DesignateReconciler) workerDeploymentCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate, holdImage bool) (*designatev1beta1.DesignateWorker, controllerutil.OperationResult, error) {
 	deployment := &designatev1beta1.DesignateWorker{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-worker", instance.Name),
@@ -2053,9 +3229,22 @@ func (r *DesignateReconciler) workerDeploymentCreateOrUpdate(ctx context.Context
 	if instance.Spec.DesignateWorker.TopologyRef == nil {
 		instance.Spec.DesignateWorker.TopologyRef = instance.Spec.TopologyRef
 	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateWorker.DesignateServiceTemplateCore)
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		// replicas not set in the template are managed on the Worker CR, keep them
+		replicas := deployment.Spec.Replicas
+		currentImage := deployment.Spec.ContainerImage
 		deployment.Spec = instance.Spec.DesignateWorker
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			deployment.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		deployment.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateWorker.ExtraMounts, instance.Spec.ExtraMounts)
+		if instance.Spec.DesignateWorker.Replicas == nil {
+			deployment.Spec.Replicas = replicas
+		}
 		// Add in transfers from umbrella Designate CR (this instance) spec
 		// TODO: Add logic to determine when to set/overwrite, etc
 		copyDesignateTemplateItems(&instance.Spec.DesignateSpecBase, &deployment.Spec.DesignateTemplate)
@@ -2077,7 +3266,7 @@ func (r *DesignateReconciler) workerDeploymentCreateOrUpdate(ctx context.Context
 	return deployment, op, err
 }
 
-func (r *DesignateReconciler) mdnsStatefulSetCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate) (*designatev1beta1.DesignateMdns, controllerutil.OperationResult, error) {
+func (r *DesignateReconciler) mdnsStatefulSetCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate, holdImage bool) (*designatev1beta1.DesignateMdns, controllerutil.OperationResult, error) {
 	statefulSet := &designatev1beta1.DesignateMdns{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-mdns", instance.Name),
@@ -2094,6 +3283,7 @@ func (r *DesignateReconciler) mdnsStatefulSetCreateOrUpdate(ctx context.Context,
 	if instance.Spec.DesignateMdns.TopologyRef == nil {
 		instance.Spec.DesignateMdns.TopologyRef = instance.Spec.TopologyRef
 	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateMdns.DesignateServiceTemplateCore)
 
 	if int(*instance.Spec.DesignateMdns.Replicas) < 1 {
 		var minReplicas int32 = 1
@@ -2105,7 +3295,14 @@ func (r *DesignateReconciler) mdnsStatefulSetCreateOrUpdate(ctx context.Context,
 	}
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, func() error {
+		currentImage := statefulSet.Spec.ContainerImage
 		statefulSet.Spec = instance.Spec.DesignateMdns
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			statefulSet.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		statefulSet.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateMdns.ExtraMounts, instance.Spec.ExtraMounts)
 		// Add in transfers from umbrella Designate CR (this instance) spec
 		// TODO: Add logic to determine when to set/overwrite, etc
 		copyDesignateTemplateItems(&instance.Spec.DesignateSpecBase, &statefulSet.Spec.DesignateTemplate)
@@ -2116,6 +3313,12 @@ func (r *DesignateReconciler) mdnsStatefulSetCreateOrUpdate(ctx context.Context,
 		statefulSet.Spec.NodeSelector = instance.Spec.DesignateMdns.NodeSelector
 		statefulSet.Spec.TopologyRef = instance.Spec.DesignateMdns.TopologyRef
 		statefulSet.Spec.ControlNetworkName = instance.Spec.DesignateMdns.ControlNetworkName
+		// the pods are reached on the addresses of their nodes or pods
+		statefulSet.Spec.HostNetwork = instance.GetPredictableIPMode() == designatev1beta1.PredictableIPModeHostNetwork
+		statefulSet.Spec.PodNetwork = instance.GetPredictableIPMode() == designatev1beta1.PredictableIPModePodIP
+		if statefulSet.Spec.HostNetwork {
+			statefulSet.Spec.NetworkAttachments = nil
+		}
 
 		err := controllerutil.SetControllerReference(instance, statefulSet, r.Scheme)
 		if err != nil {
@@ -2128,7 +3331,7 @@ func (r *DesignateReconciler) mdnsStatefulSetCreateOrUpdate(ctx context.Context,
 	return statefulSet, op, err
 }
 
-func (r *DesignateReconciler) producerDeploymentCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate) (*designatev1beta1.DesignateProducer, controllerutil.OperationResult, error) {
+func (r *DesignateReconciler) producerDeploymentCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate, holdImage bool) (*designatev1beta1.DesignateProducer, controllerutil.OperationResult, error) {
 	deployment := &designatev1beta1.DesignateProducer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-producer", instance.Name),
@@ -2145,9 +3348,22 @@ func (r *DesignateReconciler) producerDeploymentCreateOrUpdate(ctx context.Conte
 	if instance.Spec.DesignateProducer.TopologyRef == nil {
 		instance.Spec.DesignateProducer.TopologyRef = instance.Spec.TopologyRef
 	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateProducer.DesignateServiceTemplateCore)
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		// replicas not set in the template are managed on the Producer CR, keep them
+		replicas := deployment.Spec.Replicas
+		currentImage := deployment.Spec.ContainerImage
 		deployment.Spec = instance.Spec.DesignateProducer
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			deployment.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		deployment.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateProducer.ExtraMounts, instance.Spec.ExtraMounts)
+		if instance.Spec.DesignateProducer.Replicas == nil {
+			deployment.Spec.Replicas = replicas
+		}
 		// Add in transfers from umbrella Designate CR (this instance) spec
 		// TODO: Add logic to determine when to set/overwrite, etc
 		copyDesignateTemplateItems(&instance.Spec.DesignateSpecBase, &deployment.Spec.DesignateTemplate)
@@ -2170,7 +3386,67 @@ func (r *DesignateReconciler) producerDeploymentCreateOrUpdate(ctx context.Conte
 	return deployment, op, err
 }
 
-func (r *DesignateReconciler) backendbind9StatefulSetCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate) (*designatev1beta1.DesignateBackendbind9, controllerutil.OperationResult, error) {
+func (r *DesignateReconciler) sinkDeploymentCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate, holdImage bool) (*designatev1beta1.DesignateSink, controllerutil.OperationResult, error) {
+	deployment := &designatev1beta1.DesignateSink{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-sink", instance.Name),
+			Namespace: instance.Namespace,
+		},
+	}
+
+	if instance.Spec.DesignateSink.NodeSelector == nil {
+		instance.Spec.DesignateSink.NodeSelector = instance.Spec.NodeSelector
+	}
+
+	// If topology is not present in the underlying Service template,
+	// inherit from the top-level CR
+	if instance.Spec.DesignateSink.TopologyRef == nil {
+		instance.Spec.DesignateSink.TopologyRef = instance.Spec.TopologyRef
+	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateSink.DesignateServiceTemplateCore)
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		currentImage := deployment.Spec.ContainerImage
+		deployment.Spec = instance.Spec.DesignateSink
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			deployment.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		deployment.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateSink.ExtraMounts, instance.Spec.ExtraMounts)
+		// Add in transfers from umbrella Designate CR (this instance) spec
+		copyDesignateTemplateItems(&instance.Spec.DesignateSpecBase, &deployment.Spec.DesignateTemplate)
+		deployment.Spec.DatabaseHostname = instance.Status.DatabaseHostname
+		deployment.Spec.TransportURLSecret = instance.Status.TransportURLSecret
+		deployment.Spec.ServiceAccount = instance.RbacResourceName()
+		deployment.Spec.TLS = instance.Spec.DesignateAPI.TLS.Ca
+		deployment.Spec.NodeSelector = instance.Spec.DesignateSink.NodeSelector
+		deployment.Spec.TopologyRef = instance.Spec.DesignateSink.TopologyRef
+
+		return controllerutil.SetControllerReference(instance, deployment, r.Scheme)
+	})
+
+	return deployment, op, err
+}
+
+// sinkDelete removes designate-sink once it is no longer requested
+func (r *DesignateReconciler) sinkDelete(ctx context.Context, instance *designatev1beta1.Designate) error {
+	sink := &designatev1beta1.DesignateSink{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-sink", instance.Name),
+			Namespace: instance.Namespace,
+		},
+	}
+	if err := r.Delete(ctx, sink); err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// backendbind9StatefulSetCreateOrUpdate creates or updates the
+// DesignateBackendbind9 CR. When holdReplicas is set the CR keeps its current
+// replicas, the servers being scaled down are still in a pool.
+func (r *DesignateReconciler) backendbind9StatefulSetCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate, holdReplicas bool, holdImage bool) (*designatev1beta1.DesignateBackendbind9, controllerutil.OperationResult, error) {
 	statefulSet := &designatev1beta1.DesignateBackendbind9{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-backendbind9", instance.Name),
@@ -2187,13 +3463,25 @@ func (r *DesignateReconciler) backendbind9StatefulSetCreateOrUpdate(ctx context.
 	if instance.Spec.DesignateBackendbind9.TopologyRef == nil {
 		instance.Spec.DesignateBackendbind9.TopologyRef = instance.Spec.TopologyRef
 	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateBackendbind9.DesignateServiceTemplateCore)
 
 	if len(instance.Spec.DesignateBackendbind9.ControlNetworkName) == 0 {
 		instance.Spec.DesignateBackendbind9.ControlNetworkName = getOrDefault(instance.Spec.DesignateNetworkAttachment, "designate")
 	}
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, func() error {
+		currentReplicas := statefulSet.Spec.Replicas
+		currentImage := statefulSet.Spec.ContainerImage
 		statefulSet.Spec = instance.Spec.DesignateBackendbind9
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			statefulSet.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		statefulSet.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateBackendbind9.ExtraMounts, instance.Spec.ExtraMounts)
+		if holdReplicas && currentReplicas != nil {
+			statefulSet.Spec.Replicas = currentReplicas
+		}
 		// Add in transfers from umbrella Designate CR (this instance) spec
 		// TODO: Add logic to determine when to set/overwrite, etc
 		statefulSet.Spec.ServiceUser = instance.Spec.ServiceUser
@@ -2202,12 +3490,28 @@ func (r *DesignateReconciler) backendbind9StatefulSetCreateOrUpdate(ctx context.
 		statefulSet.Spec.NodeSelector = instance.Spec.DesignateBackendbind9.NodeSelector
 		statefulSet.Spec.TopologyRef = instance.Spec.DesignateBackendbind9.TopologyRef
 		statefulSet.Spec.ControlNetworkName = instance.Spec.DesignateBackendbind9.ControlNetworkName
+		// the zone data is kept for a later re-adoption with preserveOnDelete
+		statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = designate.PVCRetentionPolicy(
+			instance.Spec.DesignateBackendbind9.PersistentVolumeClaimRetentionPolicy, instance.Spec.PreserveOnDelete)
 
 		networkAttachment := "designate"
 		if instance.Spec.DesignateNetworkAttachment != "" {
 			networkAttachment = instance.Spec.DesignateNetworkAttachment
 		}
 		statefulSet.Spec.ControlNetworkName = getOrDefault(instance.Spec.DesignateBackendbind9.ControlNetworkName, networkAttachment)
+		statefulSet.Spec.HostNetwork = instance.GetPredictableIPMode() == designatev1beta1.PredictableIPModeHostNetwork
+		statefulSet.Spec.PodNetwork = instance.GetPredictableIPMode() == designatev1beta1.PredictableIPModePodIP
+		if statefulSet.Spec.HostNetwork {
+			statefulSet.Spec.NetworkAttachments = nil
+		}
+		// named transfers the catalog zones from the mdns servers
+		statefulSet.Spec.MdnsPort = designatemdns.Port(&instance.Spec.DesignateMdns.DesignateMdnsSpecBase)
+
+		// the bind9 metrics exporters are part of the telemetry
+		if instance.Spec.Telemetry.Enabled {
+			statefulSet.Spec.Metrics.Enabled = true
+			statefulSet.Spec.Metrics.ScrapeInterval = instance.Spec.Telemetry.ScrapeInterval
+		}
 
 		err := controllerutil.SetControllerReference(instance, statefulSet, r.Scheme)
 		if err != nil {
@@ -2220,9 +3524,73 @@ func (r *DesignateReconciler) backendbind9StatefulSetCreateOrUpdate(ctx context.
 	return statefulSet, op, err
 }
 
+func (r *DesignateReconciler) backendpdnsStatefulSetCreateOrUpdate(ctx context.Context, instance *designatev1beta1.Designate, holdImage bool) (*designatev1beta1.DesignateBackendPDNS, controllerutil.OperationResult, error) {
+	statefulSet := &designatev1beta1.DesignateBackendPDNS{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-backendpdns", instance.Name),
+			Namespace: instance.Namespace,
+		},
+	}
+
+	if instance.Spec.DesignateBackendPDNS.NodeSelector == nil {
+		instance.Spec.DesignateBackendPDNS.NodeSelector = instance.Spec.NodeSelector
+	}
+
+	// If topology is not present in the underlying Service template,
+	// inherit from the top-level CR
+	if instance.Spec.DesignateBackendPDNS.TopologyRef == nil {
+		instance.Spec.DesignateBackendPDNS.TopologyRef = instance.Spec.TopologyRef
+	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateBackendPDNS.DesignateServiceTemplateCore)
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, func() error {
+		currentImage := statefulSet.Spec.ContainerImage
+		statefulSet.Spec = instance.Spec.DesignateBackendPDNS
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			statefulSet.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		statefulSet.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateBackendPDNS.ExtraMounts, instance.Spec.ExtraMounts)
+		// Add in transfers from umbrella Designate CR (this instance) spec
+		statefulSet.Spec.ServiceUser = instance.Spec.ServiceUser
+		statefulSet.Spec.Secret = instance.Spec.Secret
+		statefulSet.Spec.ServiceAccount = instance.RbacResourceName()
+		statefulSet.Spec.NodeSelector = instance.Spec.DesignateBackendPDNS.NodeSelector
+		statefulSet.Spec.TopologyRef = instance.Spec.DesignateBackendPDNS.TopologyRef
+		statefulSet.Spec.ControlNetworkName = getOrDefault(
+			instance.Spec.DesignateBackendPDNS.ControlNetworkName,
+			getOrDefault(instance.Spec.DesignateNetworkAttachment, "designate"))
+		statefulSet.Spec.APIKeySecret = designate.DesignatePDNSAPIKeySecret
+		// the zone data is kept for a later re-adoption with preserveOnDelete
+		statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = designate.PVCRetentionPolicy(
+			instance.Spec.DesignateBackendPDNS.PersistentVolumeClaimRetentionPolicy, instance.Spec.PreserveOnDelete)
+
+		return controllerutil.SetControllerReference(instance, statefulSet, r.Scheme)
+	})
+
+	return statefulSet, op, err
+}
+
+// backendpdnsDelete removes the PowerDNS servers once they are no longer
+// requested
+func (r *DesignateReconciler) backendpdnsDelete(ctx context.Context, instance *designatev1beta1.Designate) error {
+	pdns := &designatev1beta1.DesignateBackendPDNS{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-backendpdns", instance.Name),
+			Namespace: instance.Namespace,
+		},
+	}
+	if err := r.Delete(ctx, pdns); err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func (r *DesignateReconciler) unboundStatefulSetCreateOrUpdate(
 	ctx context.Context,
 	instance *designatev1beta1.Designate,
+	holdImage bool,
 ) (*designatev1beta1.DesignateUnbound, controllerutil.OperationResult, error) {
 	statefulSet := &designatev1beta1.DesignateUnbound{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2240,9 +3608,17 @@ func (r *DesignateReconciler) unboundStatefulSetCreateOrUpdate(
 	if instance.Spec.DesignateUnbound.TopologyRef == nil {
 		instance.Spec.DesignateUnbound.TopologyRef = instance.Spec.TopologyRef
 	}
+	inheritImagePullOptions(&instance.Spec.DesignateSpecBase, &instance.Spec.DesignateUnbound.DesignateServiceTemplateCore)
 
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, func() error {
+		currentImage := statefulSet.Spec.ContainerImage
 		statefulSet.Spec = instance.Spec.DesignateUnbound
+		// the image is kept while the previous stages of a minor update roll out
+		if holdImage && currentImage != "" {
+			statefulSet.Spec.ContainerImage = currentImage
+		}
+		// the extra mounts of the Designate CR are added to the ones of the service
+		statefulSet.Spec.ExtraMounts = slices.Concat(instance.Spec.DesignateUnbound.ExtraMounts, instance.Spec.ExtraMounts)
 		// Add in transfers from umbrella Designate CR (this instance) spec
 		// TODO: Add logic to determine when to set/overwrite, etc
 		statefulSet.Spec.ServiceAccount = instance.RbacResourceName()
@@ -2386,6 +3762,48 @@ func (r *DesignateReconciler) checkDesignateBindGeneration(
 	return true, nil
 }
 
+// checkDesignateSinkGeneration -
+func (r *DesignateReconciler) checkDesignateSinkGeneration(
+	instance *designatev1beta1.Designate,
+) (bool, error) {
+	Log := r.GetLogger(context.Background())
+	snk := &designatev1beta1.DesignateSinkList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+	}
+	if err := r.List(context.Background(), snk, listOpts...); err != nil {
+		Log.Error(err, "Unable to retrieve DesignateSink %w")
+		return false, err
+	}
+	for _, item := range snk.Items {
+		if item.Generation != item.Status.ObservedGeneration {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// checkDesignatePDNSGeneration -
+func (r *DesignateReconciler) checkDesignatePDNSGeneration(
+	instance *designatev1beta1.Designate,
+) (bool, error) {
+	Log := r.GetLogger(context.Background())
+	prd := &designatev1beta1.DesignateBackendPDNSList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+	}
+	if err := r.List(context.Background(), prd, listOpts...); err != nil {
+		Log.Error(err, "Unable to retrieve DesignateBackendPDNS %w")
+		return false, err
+	}
+	for _, item := range prd.Items {
+		if item.Generation != item.Status.ObservedGeneration {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // checkDesignateUnboundGeneration -
 func (r *DesignateReconciler) checkDesignateUnboundGeneration(
 	instance *designatev1beta1.Designate,
@@ -0,0 +1,182 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+)
+
+// dbAccountRotationPollInterval is how often the rollout of rotated database
+// credentials is checked
+const dbAccountRotationPollInterval = time.Duration(10) * time.Second
+
+// designateServices are the suffixes of the sub-CRs running the designate
+// services, they connect to the database with the credentials rendered in the
+// configuration of Designate
+var designateServices = []string{"api", "central", "worker", "mdns", "producer", "sink"}
+
+// reconcileDatabaseAccountRotation records a rotation of the credentials of
+// the MariaDBAccount of instance, e.g. when mariadb-operator moves it to a new
+// Secret, or of its external database. The configuration is already rendered with the new credentials,
+// which restarts the services. The rotation is completed once they all run
+// with it, they are checked in the order of the update stages.
+func (r *DesignateReconciler) reconcileDatabaseAccountRotation(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	designateDb, err := designate.GetDatabase(ctx, h, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	dbSecretName := designateDb.GetSecretName()
+	hash, err := util.ObjectHash(map[string]string{
+		"username": designateDb.GetUsername(),
+		"password": designateDb.GetPassword(),
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = make(map[string]string)
+	}
+
+	rotation := instance.Status.DatabaseAccountRotation
+	switch current := instance.Status.Hash[designatev1beta1.DatabaseAccountHash]; {
+	case current == "":
+		// the services were deployed with these credentials
+		instance.Status.Hash[designatev1beta1.DatabaseAccountHash] = hash
+		instance.Status.DatabaseAccountSecret = dbSecretName
+	case current != hash:
+		Log.Info(fmt.Sprintf("Database credentials rotated to Secret %s", dbSecretName))
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "DatabaseCredentialsRotated",
+			"Restarting the services with the database credentials of Secret %s", dbSecretName)
+		instance.Status.Hash[designatev1beta1.DatabaseAccountHash] = hash
+		rotation = &designatev1beta1.DesignateDatabaseAccountRotation{
+			Secret:    dbSecretName,
+			StartedAt: metav1.Now(),
+		}
+		instance.Status.DatabaseAccountRotation = rotation
+	case rotation == nil || rotation.CompletedAt != nil:
+		// the same credentials can be moved to another Secret
+		instance.Status.DatabaseAccountSecret = dbSecretName
+	}
+
+	if rotation != nil && rotation.CompletedAt == nil {
+		pending, err := r.databaseRotationPending(ctx, instance, rotation.StartedAt)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateDatabaseRotationReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				designatev1beta1.DesignateDatabaseRotationReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		if pending != "" {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateDatabaseRotationReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				designatev1beta1.DesignateDatabaseRotationReadyRunningMessage,
+				rotation.Secret,
+				pending))
+			return ctrl.Result{RequeueAfter: dbAccountRotationPollInterval}, nil
+		}
+		completed := metav1.Now()
+		rotation.CompletedAt = &completed
+		instance.Status.DatabaseAccountSecret = rotation.Secret
+		Log.Info(fmt.Sprintf("Services restarted with the database credentials of Secret %s", rotation.Secret))
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "DatabaseCredentialsRolledOut",
+			"Services restarted with the database credentials of Secret %s", rotation.Secret)
+	}
+
+	instance.Status.Conditions.MarkTrue(
+		designatev1beta1.DesignateDatabaseRotationReadyCondition,
+		designatev1beta1.DesignateDatabaseRotationReadyMessage,
+		instance.Status.DatabaseAccountSecret)
+	return ctrl.Result{}, nil
+}
+
+// databaseRotationPending returns the name of the first sub-CR running the
+// designate services, in the order of the update stages, which is not ready or
+// still has pods started before the rotation, or "" once they all run with
+// the rotated credentials
+func (r *DesignateReconciler) databaseRotationPending(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+	startedAt metav1.Time,
+) (string, error) {
+	components := map[string]designateComponent{}
+	for _, component := range designateComponents() {
+		components[component.suffix] = component
+	}
+	for _, stage := range designateUpdateStages {
+		for _, suffix := range stage {
+			if !slices.Contains(designateServices, suffix) || updateTargetImage(instance, suffix) == "" {
+				continue
+			}
+			component := components[suffix]
+			name := fmt.Sprintf("%s-%s", instance.Name, suffix)
+			err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, component.object)
+			if k8s_errors.IsNotFound(err) {
+				// created with the rotated credentials
+				continue
+			} else if err != nil {
+				return "", err
+			}
+			replicas, _, readyCount, conditions, observedGeneration := component.summary()
+			if component.object.GetGeneration() != observedGeneration ||
+				!conditions.IsTrue(condition.ReadyCondition) ||
+				readyCount != ptr.Deref(replicas, 0) {
+				return name, nil
+			}
+
+			pods := &corev1.PodList{}
+			err = r.List(ctx, pods,
+				client.InNamespace(instance.Namespace),
+				client.MatchingLabels{common.ComponentSelector: component.component})
+			if err != nil {
+				return "", err
+			}
+			for _, pod := range pods.Items {
+				if pod.DeletionTimestamp == nil && pod.CreationTimestamp.Before(&startedAt) {
+					return name, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
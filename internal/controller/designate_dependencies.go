@@ -0,0 +1,86 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// pendingDependencies returns the dependencies designate-worker and
+// designate-producer wait on before they are deployed: the predictable IPs of
+// the mdns and bind9 pods, and pools.yaml which lists them. poolsGenerated is
+// set when the pools were generated by this reconcile.
+func (r *DesignateReconciler) pendingDependencies(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+	poolsGenerated bool,
+) ([]string, error) {
+	pending := []string{}
+	if !instance.Status.Conditions.IsTrue(designatev1beta1.DesignatePredictableIPsReadyCondition) {
+		pending = append(pending, "predictable IPs")
+	}
+	if !poolsGenerated {
+		err := r.Get(ctx, types.NamespacedName{Name: designate.PoolsYamlConfigMap, Namespace: instance.Namespace}, &corev1.ConfigMap{})
+		if k8s_errors.IsNotFound(err) {
+			pending = append(pending, "pools.yaml")
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(pending) > 0 {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateDependenciesReadyCondition,
+			designatev1beta1.DesignateWaitingOnDependenciesReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateDependenciesReadyWaitingMessage,
+			strings.Join(pending, ", ")))
+	} else {
+		instance.Status.Conditions.MarkTrue(designatev1beta1.DesignateDependenciesReadyCondition, designatev1beta1.DesignateDependenciesReadyMessage)
+	}
+	return pending, nil
+}
+
+// waitOnDependencies returns if the sub-CR of instance with suffix is not
+// deployed yet while dependencies are pending. Once deployed, the sub-CR keeps
+// being reconciled, e.g. while the pods of bind9 move to other nodes.
+func (r *DesignateReconciler) waitOnDependencies(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+	object client.Object,
+	suffix string,
+	pending []string,
+) (bool, error) {
+	if len(pending) == 0 {
+		return false, nil
+	}
+	name := fmt.Sprintf("%s-%s", instance.Name, suffix)
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, object)
+	if k8s_errors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+)
+
+// reconcileHooks runs the Jobs of the hooks of stage for image, one at a time
+// in the order of the spec. It returns the hook running or failed, if any.
+func (r *DesignateReconciler) reconcileHooks(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	stage string,
+	image string,
+) (string, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	labels := map[string]string{
+		common.AppSelector: designate.ServiceName,
+	}
+	for _, hook := range instance.Spec.Hooks {
+		if hook.Stage != stage {
+			continue
+		}
+		hashName := designatev1beta1.HookHashPrefix + hook.Name
+		jobDef := designate.HookJob(instance, hook, image, labels)
+		hookJob := job.NewJob(
+			jobDef,
+			hashName,
+			instance.Spec.PreserveJobs,
+			time.Duration(5)*time.Second,
+			instance.Status.Hash[hashName],
+		)
+		ctrlResult, err := hookJob.DoJob(ctx, h)
+		if (ctrlResult != ctrl.Result{}) {
+			Log.Info(fmt.Sprintf("Waiting for the %s hook %s", stage, hook.Name))
+			return hook.Name, ctrlResult, nil
+		}
+		if err != nil {
+			return hook.Name, ctrl.Result{}, err
+		}
+		if hookJob.HasChanged() {
+			instance.Status.Hash[hashName] = hookJob.GetHash()
+			Log.Info(fmt.Sprintf("Service '%s' - Job %s hash added - %s", instance.Name, jobDef.Name, instance.Status.Hash[hashName]))
+		}
+	}
+	return "", ctrl.Result{}, nil
+}
+
+// reconcileDBSyncHooks runs the hooks of stage around the DB sync of a new API
+// image, DBSyncReady reports the hook the DB sync waits for
+func (r *DesignateReconciler) reconcileDBSyncHooks(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	stage string,
+) (ctrl.Result, error) {
+	image := instance.Spec.DesignateAPI.ContainerImage
+	hook, ctrlResult, err := r.reconcileHooks(ctx, h, instance, stage, image)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBSyncReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateDBSyncReadyHookErrorMessage,
+			image,
+			hook,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBSyncReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateDBSyncReadyHookMessage,
+			image,
+			hook))
+		return ctrlResult, nil
+	}
+	return ctrl.Result{}, nil
+}
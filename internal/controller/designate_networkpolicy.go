@@ -0,0 +1,243 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateapi"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendbind9"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendpdns"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatecentral"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatemdns"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateproducer"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatesink"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateunbound"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateworker"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+// networkPolicyComponent describes the NetworkPolicy of a designate
+// component, named after the Designate CR and suffix. The sources of the
+// admitted traffic are given as the suffixes of their components.
+type networkPolicyComponent struct {
+	suffix    string
+	component string
+	ingress   []networkPolicyIngress
+	// controlIngress - traffic admitted on the designate control network
+	// attachment, enforced through a MultiNetworkPolicy. Components without
+	// controlIngress don't restrict the traffic of the attachment.
+	controlIngress []networkPolicyIngress
+}
+
+// networkPolicyIngress describes traffic admitted from the components with
+// the from suffixes, or from any source when from is empty
+type networkPolicyIngress struct {
+	from  []string
+	ports []networkingv1.NetworkPolicyPort
+}
+
+// networkPolicyComponents returns the traffic admitted to the pods of each
+// designate component. Central, producer, worker and sink only talk to the
+// other services through RabbitMQ and don't admit any traffic. On the
+// control network, the backends only admit DNS from mdns, the workers and
+// unbound, and rndc or PowerDNS API calls from the workers, and mdns only
+// admits zone transfers from the backends and the workers. mdns and bind9
+// are admitted on the ports of the spec of instance.
+func networkPolicyComponents(instance *designatev1beta1.Designate) []networkPolicyComponent {
+	fromWorkers := []string{"worker"}
+	fromBackends := []string{"backendbind9", "backendpdns", "worker"}
+	fromResolvers := []string{"mdns", "worker", "unbound"}
+	mdnsPort := designatemdns.Port(&instance.Spec.DesignateMdns.DesignateMdnsSpecBase)
+	bind9Port := designatebackendbind9.DNSPort(&instance.Spec.DesignateBackendbind9.DesignateBackendbind9SpecBase)
+
+	return []networkPolicyComponent{
+		{
+			suffix:    "api",
+			component: designateapi.Component,
+			ingress: []networkPolicyIngress{
+				{ports: []networkingv1.NetworkPolicyPort{designate.TCPPort(designate.DesignatePublicPort)}},
+			},
+		},
+		{
+			suffix:    "api-readonly",
+			component: designateapi.ReadOnlyComponent,
+			ingress: []networkPolicyIngress{
+				{ports: []networkingv1.NetworkPolicyPort{designate.TCPPort(designate.DesignateInternalPort)}},
+			},
+		},
+		{suffix: "central", component: designatecentral.Component},
+		{suffix: "producer", component: designateproducer.Component},
+		{suffix: "worker", component: designateworker.Component},
+		{suffix: "sink", component: designatesink.Component},
+		{
+			// zone transfers and notifies from the backends and the workers
+			suffix:    "mdns",
+			component: designatemdns.Component,
+			ingress: []networkPolicyIngress{
+				{from: fromBackends, ports: designate.DNSPorts(mdnsPort)},
+			},
+			controlIngress: []networkPolicyIngress{
+				{from: fromBackends, ports: designate.DNSPorts(mdnsPort)},
+			},
+		},
+		{
+			suffix:    "backendbind9",
+			component: designatebackendbind9.Component,
+			ingress: []networkPolicyIngress{
+				{ports: designate.DNSPorts(bind9Port)},
+				{from: fromWorkers, ports: []networkingv1.NetworkPolicyPort{designate.TCPPort(designate.RNDCPort)}},
+				{ports: []networkingv1.NetworkPolicyPort{designate.TCPPort(designatebackendbind9.MetricsPort)}},
+			},
+			controlIngress: []networkPolicyIngress{
+				{from: fromResolvers, ports: designate.DNSPorts(bind9Port)},
+				{from: fromWorkers, ports: []networkingv1.NetworkPolicyPort{designate.TCPPort(designate.RNDCPort)}},
+			},
+		},
+		{
+			suffix:    "backendpdns",
+			component: designatebackendpdns.Component,
+			ingress: []networkPolicyIngress{
+				{ports: designate.DNSPorts(designate.DNSPort)},
+				{from: fromWorkers, ports: []networkingv1.NetworkPolicyPort{designate.TCPPort(designate.PDNSAPIPort)}},
+			},
+			controlIngress: []networkPolicyIngress{
+				{from: fromResolvers, ports: designate.DNSPorts(designate.DNSPort)},
+				{from: fromWorkers, ports: []networkingv1.NetworkPolicyPort{designate.TCPPort(designate.PDNSAPIPort)}},
+			},
+		},
+		{
+			suffix:    "unbound",
+			component: designateunbound.Component,
+			ingress: []networkPolicyIngress{
+				{ports: designate.DNSPorts(designate.DNSPort)},
+			},
+		},
+	}
+}
+
+// networkPolicyPodLabels returns the labels selecting the pods of the
+// component with suffix of instance. The pods are selected through the
+// service label holding the name of their sub-CR, the API pods share their
+// service label with the other instances and are selected through
+// designate.InstanceLabel, like the read-only API pods.
+func networkPolicyPodLabels(instance *designatev1beta1.Designate, suffix string) map[string]string {
+	components := map[string]string{}
+	for _, c := range networkPolicyComponents(instance) {
+		components[c.suffix] = c.component
+	}
+	subCRName := fmt.Sprintf("%s-%s", instance.Name, suffix)
+	if suffix == "api" || suffix == "api-readonly" {
+		// the read-only API pods belong to the DesignateAPI sub-CR
+		return map[string]string{
+			common.ComponentSelector: components[suffix],
+			designate.InstanceLabel:  fmt.Sprintf("%s-api", instance.Name),
+		}
+	}
+	return map[string]string{
+		common.ComponentSelector: components[suffix],
+		common.AppSelector:       subCRName,
+	}
+}
+
+// networkPolicyIngressRules resolves the sources of ingress to the pods of
+// instance
+func networkPolicyIngressRules(
+	instance *designatev1beta1.Designate,
+	ingress []networkPolicyIngress,
+) []designate.NetworkPolicyIngress {
+	rules := []designate.NetworkPolicyIngress{}
+	for _, in := range ingress {
+		rule := designate.NetworkPolicyIngress{Ports: in.ports}
+		for _, from := range in.from {
+			rule.From = append(rule.From, networkPolicyPodLabels(instance, from))
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// reconcileNetworkPolicies creates a NetworkPolicy per designate component
+// when enabled, and removes them otherwise. The components admitting traffic
+// on the control network get a MultiNetworkPolicy for the designate network
+// attachment as well, when the MultiNetworkPolicy CRD is installed and the
+// mdns and bind9 pods are attached to the control network.
+func (r *DesignateReconciler) reconcileNetworkPolicies(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+) error {
+	Log := r.GetLogger(ctx)
+
+	for _, c := range networkPolicyComponents(instance) {
+		name := fmt.Sprintf("%s-%s", instance.Name, c.suffix)
+		enabled := instance.Spec.NetworkPolicy.Enabled
+		switch c.component {
+		case designatebackendpdns.Component:
+			enabled = enabled && instance.IsPDNSEnabled()
+		case designatesink.Component:
+			enabled = enabled && instance.IsSinkEnabled()
+		case designateapi.ReadOnlyComponent:
+			enabled = enabled && instance.Spec.DesignateAPI.ReadOnly != nil
+		}
+		if !enabled {
+			if err := designate.DeleteNetworkPolicy(ctx, h, name, instance.Namespace); err != nil {
+				return err
+			}
+			if err := designate.DeleteMultiNetworkPolicy(ctx, h, name, instance.Namespace); err != nil {
+				return err
+			}
+			continue
+		}
+
+		podLabels := networkPolicyPodLabels(instance, c.suffix)
+		np := designate.NetworkPolicy(name, instance.Namespace, podLabels, networkPolicyIngressRules(instance, c.ingress))
+		if err := designate.EnsureNetworkPolicy(ctx, h, np); err != nil {
+			return err
+		}
+
+		if len(c.controlIngress) > 0 && !instance.UsesControlNetwork() {
+			// the pods are not attached to the control network, their
+			// traffic goes through the pod network and its NetworkPolicy
+			if err := designate.DeleteMultiNetworkPolicy(ctx, h, name, instance.Namespace); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(c.controlIngress) == 0 || instance.Spec.DesignateNetworkAttachment == "" {
+			continue
+		}
+		mnp, err := designate.MultiNetworkPolicy(name, instance.Namespace, instance.Spec.DesignateNetworkAttachment,
+			podLabels, networkPolicyIngressRules(instance, c.controlIngress))
+		if err != nil {
+			return err
+		}
+		installed, err := designate.EnsureMultiNetworkPolicy(ctx, h, mnp)
+		if err != nil {
+			return err
+		}
+		if !installed {
+			Log.Info("MultiNetworkPolicy CRD not installed, the control network traffic is not restricted")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,386 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+)
+
+// errPredictableIPRelease is returned when the reservation of a predictable
+// IP which is not required anymore cannot be released
+var errPredictableIPRelease = errors.New("failed to release predictable IP")
+
+// storedPredictableIPs returns the predictable IPs stored in the ConfigMaps
+// before they are updated, keyed like the updated maps: the mdns and PowerDNS
+// IP holders and the global bind IP holders
+func (r *DesignateReconciler) storedPredictableIPs(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+	mdnsData map[string]string,
+	bindLayouts []bindConfigMapLayout,
+) (map[string]string, error) {
+	stored := make(map[string]string)
+	maps.Copy(stored, mdnsData)
+	for _, layout := range bindLayouts {
+		data, err := r.storedConfigMapData(ctx, instance.Namespace, layout.ConfigMapName)
+		if err != nil {
+			return nil, err
+		}
+		for i, localName := range layout.LocalNames {
+			if value, ok := data[localName]; ok {
+				stored[layout.GlobalNames[i]] = value
+			}
+		}
+	}
+	if instance.IsPDNSEnabled() {
+		data, err := r.storedConfigMapData(ctx, instance.Namespace, designate.PDNSPredIPConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(stored, data)
+	}
+	return stored, nil
+}
+
+// storedConfigMapData returns the data of a ConfigMap, which is empty when
+// the ConfigMap doesn't exist yet
+func (r *DesignateReconciler) storedConfigMapData(ctx context.Context, namespace string, name string) (map[string]string, error) {
+	configMap := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+	if k8s_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return configMap.Data, nil
+}
+
+// recordPredictableIPEvents reports the predictable IPs which were allocated
+// or released since they were stored with events on the Designate CR, so they
+// show up in kubectl describe
+func (r *DesignateReconciler) recordPredictableIPEvents(
+	instance *designatev1beta1.Designate,
+	stored map[string]string,
+	updatedMaps ...map[string]string,
+) {
+	updated := make(map[string]string)
+	for _, m := range updatedMaps {
+		maps.Copy(updated, m)
+	}
+
+	var allocated, released []string
+	for _, ipHolder := range slices.Sorted(maps.Keys(updated)) {
+		if value := updated[ipHolder]; value != "" && value != stored[ipHolder] {
+			allocated = append(allocated, fmt.Sprintf("%s=%s", ipHolder, value))
+		}
+	}
+	for _, ipHolder := range slices.Sorted(maps.Keys(stored)) {
+		if _, ok := updated[ipHolder]; !ok && stored[ipHolder] != "" {
+			released = append(released, fmt.Sprintf("%s=%s", ipHolder, stored[ipHolder]))
+		}
+	}
+	if len(allocated) > 0 {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "PredictableIPsAllocated",
+			"Predictable IPs allocated: %s", strings.Join(allocated, ", "))
+	}
+	if len(released) > 0 {
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "PredictableIPsReleased",
+			"Predictable IPs released: %s", strings.Join(released, ", "))
+	}
+}
+
+// recordPredictableIPError reports a failed allocation or release of the
+// predictable IPs with a warning event on the Designate CR
+func (r *DesignateReconciler) recordPredictableIPError(instance *designatev1beta1.Designate, err error) {
+	reason := "PredictableIPAllocationFailed"
+	switch {
+	case errors.Is(err, errPredictableIPRelease):
+		reason = "PredictableIPReleaseFailed"
+	case errors.Is(err, designate.ErrPredictableIPOutOfAddresses),
+		errors.Is(err, designate.ErrPredictableIPAllocation),
+		errors.Is(err, designate.ErrCannotAllocateIPAddresses):
+		reason = "PredictableIPRangeExhausted"
+	}
+	r.Recorder.Event(instance, corev1.EventTypeWarning, reason, err.Error())
+}
+
+// reconcilePredictableIPSets ensures there is one IPSet CR per predictable IP
+// holder and returns the addresses infra-operator reserved for them. Holders
+// whose reservation has not been made yet are returned in the pending list.
+// IPSets of holders that are no longer required (e.g. after a scale down) are
+// deleted so infra-operator can release their addresses. On dual stack
+// networks an address is reserved from each subnet and the addresses are
+// joined like the ConfigMap allocations. The static IPs of the holders are
+// requested as fixed IPs.
+func (r *DesignateReconciler) reconcilePredictableIPSets(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.Designate,
+	ipHolders []string,
+	staticIPs map[string]string,
+) (map[string]string, []string, error) {
+	Log := r.GetLogger(ctx)
+
+	networkName := getOrDefault(instance.Spec.PredictableIPs.NetworkName, instance.Spec.DesignateNetworkAttachment)
+	subnetNames := []string{getOrDefault(instance.Spec.PredictableIPs.SubnetName, designate.DefaultIPSetSubnetName)}
+	if instance.Spec.PredictableIPs.IPv6SubnetName != "" {
+		subnetNames = append(subnetNames, instance.Spec.PredictableIPs.IPv6SubnetName)
+	}
+	ipSetLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})
+
+	reserved := make(map[string]string)
+	var pending []string
+	required := make(map[string]bool)
+
+	for _, ipHolder := range ipHolders {
+		required[ipHolder] = true
+		ipSet := &infranetworkv1.IPSet{}
+		ipSet.Name = designate.IPSetName(instance.Name, ipHolder)
+		ipSet.Namespace = instance.Namespace
+
+		op, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), ipSet, func() error {
+			ipSet.Labels = util.MergeStringMaps(ipSet.Labels, ipSetLabels, map[string]string{
+				designate.IPSetHolderLabel: ipHolder,
+			})
+			ipSet.Spec.Networks = designate.IPSetNetworks(networkName, subnetNames, staticIPs[ipHolder])
+			return controllerutil.SetControllerReference(instance, ipSet, helper.GetScheme())
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if op != controllerutil.OperationResultNone {
+			Log.Info(fmt.Sprintf("IPSet %s for %s - operation: %s", ipSet.Name, ipHolder, string(op)))
+		}
+
+		var addresses []string
+		for _, subnetName := range subnetNames {
+			if address, ok := designate.GetIPSetAddress(ipSet, networkName, subnetName); ok {
+				addresses = append(addresses, address)
+			}
+		}
+		if len(addresses) != len(subnetNames) {
+			pending = append(pending, ipHolder)
+			continue
+		}
+		reserved[ipHolder] = designate.JoinPredictableIPs(addresses)
+	}
+
+	// Release the reservations which are not needed anymore
+	if err := r.releasePredictableIPSets(ctx, helper, instance, required); err != nil {
+		return nil, nil, err
+	}
+
+	return reserved, pending, nil
+}
+
+// releasePredictableIPSets deletes the IPSets of the instance whose holder is
+// not in required. With an empty required map all of them are deleted, e.g.
+// after switching back to the ConfigMap mode.
+func (r *DesignateReconciler) releasePredictableIPSets(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.Designate,
+	required map[string]bool,
+) error {
+	Log := r.GetLogger(ctx)
+
+	ipSetList := &infranetworkv1.IPSetList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels(labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})),
+		client.HasLabels{designate.IPSetHolderLabel},
+	}
+	if err := helper.GetClient().List(ctx, ipSetList, listOpts...); err != nil {
+		return err
+	}
+	for i := range ipSetList.Items {
+		ipSet := &ipSetList.Items[i]
+		ipHolder := ipSet.Labels[designate.IPSetHolderLabel]
+		if required[ipHolder] || !metav1.IsControlledBy(ipSet, instance) {
+			continue
+		}
+		Log.Info(fmt.Sprintf("Deleting IPSet %s, %s is not required anymore", ipSet.Name, ipHolder))
+		if err := helper.GetClient().Delete(ctx, ipSet); err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("%w: IPSet %s of %s: %w", errPredictableIPRelease, ipSet.Name, ipHolder, err)
+		}
+	}
+	return nil
+}
+
+// getPodAddresses returns the addresses of the mdns and bind pods in the
+// HostNetwork and PodIP predictable IP modes, where the pods are reached on
+// the addresses of their nodes or on their pod IPs. Every IP holder is kept
+// in the maps so the rndc keys of the bind pods are mapped from the start,
+// the holders of the pods without an address yet are left empty and their
+// pods returned as pending. The returned bind map is keyed by the global
+// bind IP holders.
+func (r *DesignateReconciler) getPodAddresses(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.Designate,
+	mdnsNames []string,
+	bindLayouts []bindConfigMapLayout,
+) (map[string]string, map[string]string, []string, error) {
+	// Release the reservations of a previous IPSet mode
+	if err := r.releasePredictableIPSets(ctx, helper, instance, nil); err != nil {
+		return nil, nil, nil, err
+	}
+
+	mdnsName := fmt.Sprintf("%s-mdns", instance.Name)
+	mdnsIPs, err := designate.GetPodIPs(ctx, helper, mdnsName, instance.Namespace)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bindName := fmt.Sprintf("%s-backendbind9", instance.Name)
+	bindIPs, err := designate.GetPodIPs(ctx, helper, bindName, instance.Namespace)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var pending []string
+	updatedMap := make(map[string]string)
+	for i, ipHolder := range mdnsNames {
+		podName := fmt.Sprintf("%s-%d", mdnsName, i)
+		updatedMap[ipHolder] = mdnsIPs[podName]
+		if updatedMap[ipHolder] == "" {
+			pending = append(pending, podName)
+		}
+	}
+	updatedBindMap := make(map[string]string)
+	for poolIdx, layout := range bindLayouts {
+		// the pods of the pools other than the default one are named after their pool
+		statefulSetName := bindName
+		if poolIdx > 0 {
+			statefulSetName = fmt.Sprintf("%s-pool%d", bindName, poolIdx)
+		}
+		for i, globalName := range layout.GlobalNames {
+			podName := fmt.Sprintf("%s-%d", statefulSetName, i)
+			updatedBindMap[globalName] = bindIPs[podName]
+			if updatedBindMap[globalName] == "" {
+				pending = append(pending, podName)
+			}
+		}
+	}
+	return updatedMap, updatedBindMap, pending, nil
+}
+
+// reserveNetworkPredictableIPs allocates the predictable IPs of the mdns and
+// bind pods on the networks other than the control network, in a ConfigMap
+// per network and per ConfigMap of the control network. The addresses of the
+// pods not using a network anymore are released. The returned map holds the
+// addresses of the bind pods on their query network, keyed by the global
+// bind IP holders, it is empty when the queries use the control network.
+func (r *DesignateReconciler) reserveNetworkPredictableIPs(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.Designate,
+	mdnsNames []string,
+	bindLayouts []bindConfigMapLayout,
+	configMapLabels map[string]string,
+) (map[string]string, error) {
+	mdnsNetworks := instance.Spec.DesignateMdns.PredictableIPNetworks
+	bindNetworks := instance.Spec.DesignateBackendbind9.PredictableIPNetworks
+	networks := slices.Concat(mdnsNetworks, bindNetworks)
+	slices.Sort(networks)
+	networks = slices.Compact(networks)
+
+	exclude, err := designate.ParseExcludes(instance.Spec.PredictableIPs.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	queryMap := make(map[string]string)
+	for _, network := range networks {
+		netAtt, err := nad.GetNADWithName(ctx, helper, network, instance.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		networkParameters, err := designate.GetAllNetworkParametersFromNAD(netAtt)
+		if err != nil {
+			return nil, err
+		}
+		predictableIPParams, err := designate.GetPredictableIPAMs(networkParameters, exclude)
+		if err != nil {
+			return nil, err
+		}
+
+		mdnsRequest := designate.PredictableIPRequest{
+			ConfigMapName: designate.NetworkPredIPConfigMap(designate.MdnsPredIPConfigMap, network),
+		}
+		if slices.Contains(mdnsNetworks, network) {
+			mdnsRequest.IPHolders = mdnsNames
+		}
+		requests := []designate.PredictableIPRequest{mdnsRequest}
+		for _, layout := range bindLayouts {
+			bindRequest := designate.PredictableIPRequest{
+				ConfigMapName: designate.NetworkPredIPConfigMap(layout.ConfigMapName, network),
+			}
+			if slices.Contains(bindNetworks, network) {
+				bindRequest.IPHolders = layout.LocalNames
+			}
+			requests = append(requests, bindRequest)
+		}
+		allocations, err := designate.AllocatePredictableIPs(
+			ctx,
+			helper.GetClient(),
+			instance.Namespace,
+			predictableIPParams,
+			requests,
+			func(cm *corev1.ConfigMap) error {
+				if err := designate.AdoptControllerReference(ctx, helper.GetClient(), instance, cm, configMapLabels, helper.GetScheme()); err != nil {
+					return err
+				}
+				cm.Labels = util.MergeStringMaps(cm.Labels, configMapLabels)
+				return nil
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate the predictable IPs on %s: %w", network, err)
+		}
+		designate.RecordPredictableIPUsage(instance.Namespace, instance.Name, network, predictableIPParams, allocations)
+
+		if network != instance.Spec.DesignateBackendbind9.QueryNetworkName {
+			continue
+		}
+		for _, layout := range bindLayouts {
+			addresses := allocations[designate.NetworkPredIPConfigMap(layout.ConfigMapName, network)]
+			for i, localName := range layout.LocalNames {
+				queryMap[layout.GlobalNames[i]] = addresses[localName]
+			}
+		}
+	}
+	return queryMap, nil
+}
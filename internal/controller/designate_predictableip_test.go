@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+)
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func Test_recordPredictableIPEvents(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &DesignateReconciler{Recorder: recorder}
+	instance := &designatev1beta1.Designate{}
+
+	stored := map[string]string{
+		"mdns_address_0": "172.28.0.31",
+		"bind_address_0": "172.28.0.32",
+		"bind_address_1": "172.28.0.33",
+	}
+	r.recordPredictableIPEvents(instance, stored,
+		map[string]string{"mdns_address_0": "172.28.0.31", "mdns_address_1": "172.28.0.34"},
+		map[string]string{"bind_address_0": "172.28.0.35"},
+	)
+
+	events := drainEvents(recorder)
+	expected := []string{
+		"Normal PredictableIPsAllocated Predictable IPs allocated: bind_address_0=172.28.0.35, mdns_address_1=172.28.0.34",
+		"Normal PredictableIPsReleased Predictable IPs released: bind_address_1=172.28.0.33",
+	}
+	if fmt.Sprint(events) != fmt.Sprint(expected) {
+		t.Errorf("unexpected events %v, expected %v", events, expected)
+	}
+
+	// nothing changed
+	r.recordPredictableIPEvents(instance, stored, stored)
+	if events := drainEvents(recorder); len(events) != 0 {
+		t.Errorf("unexpected events %v", events)
+	}
+}
+
+func Test_recordPredictableIPError(t *testing.T) {
+	tests := []struct {
+		err    error
+		reason string
+	}{
+		{err: designate.ErrPredictableIPOutOfAddresses, reason: "PredictableIPRangeExhausted"},
+		{err: fmt.Errorf("%w: 25 IP addresses in 172.28.0.0/28", designate.ErrPredictableIPAllocation), reason: "PredictableIPRangeExhausted"},
+		{err: fmt.Errorf("%w: IPSet designate-mdns-address-2", errPredictableIPRelease), reason: "PredictableIPReleaseFailed"},
+		{err: fmt.Errorf("network attachment not found"), reason: "PredictableIPAllocationFailed"},
+	}
+	for _, tt := range tests {
+		recorder := record.NewFakeRecorder(1)
+		r := &DesignateReconciler{Recorder: recorder}
+		r.recordPredictableIPError(&designatev1beta1.Designate{}, tt.err)
+		expected := fmt.Sprintf("Warning %s %s", tt.reason, tt.err)
+		if event := <-recorder.Events; event != expected {
+			t.Errorf("unexpected event %q, expected %q", event, expected)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+)
+
+// preflightNetworkAttachments returns the network attachments of the control
+// network, when the mdns and bind9 pods are attached to it, and of the
+// services of instance, without duplicates
+func preflightNetworkAttachments(instance *designatev1beta1.Designate) []string {
+	names := []string{}
+	if instance.Spec.DesignateNetworkAttachment != "" && instance.UsesControlNetwork() {
+		names = append(names, instance.Spec.DesignateNetworkAttachment)
+	}
+	names = slices.Concat(names,
+		instance.Spec.DesignateAPI.NetworkAttachments,
+		instance.Spec.DesignateCentral.NetworkAttachments,
+		instance.Spec.DesignateWorker.NetworkAttachments,
+		instance.Spec.DesignateMdns.NetworkAttachments,
+		instance.Spec.DesignateProducer.NetworkAttachments,
+		instance.Spec.DesignateBackendbind9.NetworkAttachments,
+		instance.Spec.DesignateUnbound.NetworkAttachments,
+	)
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// reconcilePreflight checks the network attachments of the services exist
+// and runs the pre-flight job checking the database, RabbitMQ, the
+// coordination backend and the rndc ports of the bind9 servers at the
+// addresses of bindMap. The job runs again when its inputs change.
+func (r *DesignateReconciler) reconcilePreflight(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	serviceLabels map[string]string,
+	bindMap map[string]string,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	if !instance.Spec.PreflightCheck {
+		return ctrl.Result{}, nil
+	}
+
+	nadList := []networkv1.NetworkAttachmentDefinition{}
+	for _, name := range preflightNetworkAttachments(instance) {
+		netAtt, err := nad.GetNADWithName(ctx, h, name, instance.Namespace)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					designatev1beta1.DesignatePreflightReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					designatev1beta1.DesignatePreflightReadyErrorMessage,
+					fmt.Sprintf("network attachment %s not found", name)))
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		if name == instance.Spec.DesignateNetworkAttachment {
+			nadList = append(nadList, *netAtt)
+		}
+	}
+	annotations, err := nad.EnsureNetworksAnnotation(nadList)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the NetworkPolicies only admit rndc from the workers, the job could
+	// not reach the bind9 servers
+	rndcTargets := []string{}
+	if instance.Spec.NetworkPolicy.Enabled {
+		Log.Info("NetworkPolicies enabled, skipping the rndc pre-flight checks")
+	} else {
+		rndcTargets = designate.PreflightRndcTargets(bindMap)
+	}
+
+	jobDef := designate.PreflightJob(instance, serviceLabels, annotations, rndcTargets)
+	preflightJob := job.NewJob(
+		jobDef,
+		designatev1beta1.PreflightHash,
+		instance.Spec.PreserveJobs,
+		time.Duration(5)*time.Second,
+		instance.Status.Hash[designatev1beta1.PreflightHash],
+	)
+	ctrlResult, err := preflightJob.DoJob(ctx, h)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePreflightReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignatePreflightReadyRunningMessage))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		message := err.Error()
+		failures, ferr := designate.PreflightFailures(ctx, h, jobDef)
+		if ferr != nil {
+			Log.Error(ferr, "unable to get the failed checks of the pre-flight job")
+		} else if failures != "" {
+			message = failures
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePreflightReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignatePreflightReadyErrorMessage,
+			message))
+		return ctrl.Result{}, err
+	}
+	if preflightJob.HasChanged() {
+		instance.Status.Hash[designatev1beta1.PreflightHash] = preflightJob.GetHash()
+		Log.Info(fmt.Sprintf("Service '%s' - Job %s hash added - %s", instance.Name, jobDef.Name, instance.Status.Hash[designatev1beta1.PreflightHash]))
+	}
+	instance.Status.Conditions.MarkTrue(designatev1beta1.DesignatePreflightReadyCondition, designatev1beta1.DesignatePreflightReadyMessage)
+
+	return ctrl.Result{}, nil
+}
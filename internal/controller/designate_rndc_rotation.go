@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+// rndcRotationPollInterval is how often the rollout of a rotation phase is checked
+const rndcRotationPollInterval = time.Duration(10) * time.Second
+
+// reconcileRndcKeyRotation moves an rndc key rotation to its next phase once
+// the current phase is deployed, or starts a rotation when one is requested
+// or due. The returned result requeues until the next check.
+func (r *DesignateReconciler) reconcileRndcKeyRotation(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	rndcSecret := &corev1.Secret{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{
+		Name:      designate.DesignateBindKeySecret,
+		Namespace: instance.Namespace,
+	}, rndcSecret)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	state := designate.GetRndcRotationState(rndcSecret)
+	now := time.Now().UTC()
+
+	var nextPhase string
+	switch rndcSecret.Annotations[designate.RndcRotationPhaseAnnotation] {
+	case designate.RndcRotationPhaseStaged:
+		// designate-worker must not get the new keys before every bind9
+		// server accepts them
+		deployed, err := r.rndcRotationDeployed(ctx, instance, state, false)
+		if err != nil || !deployed {
+			return ctrl.Result{RequeueAfter: rndcRotationPollInterval}, err
+		}
+		nextPhase = designate.RndcRotationPhasePromoted
+	case designate.RndcRotationPhasePromoted:
+		// the previous keys are in use until designate-worker is restarted
+		deployed, err := r.rndcRotationDeployed(ctx, instance, state, true)
+		if err != nil || !deployed {
+			return ctrl.Result{RequeueAfter: rndcRotationPollInterval}, err
+		}
+		nextPhase = ""
+	default:
+		start, wait := rndcRotationDue(instance, rndcSecret, now)
+		if !start {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		nextPhase = designate.RndcRotationPhaseStaged
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, h.GetClient(), rndcSecret, func() error {
+		if rndcSecret.Annotations == nil {
+			rndcSecret.Annotations = map[string]string{}
+		}
+		if rndcSecret.Data == nil {
+			rndcSecret.Data = map[string][]byte{}
+		}
+		switch nextPhase {
+		case designate.RndcRotationPhaseStaged:
+			if err := designate.StageRndcKeys(rndcSecret.Data, now); err != nil {
+				return err
+			}
+			rndcSecret.Annotations[designate.RndcRotationTimeAnnotation] = now.Format(time.RFC3339)
+			rndcSecret.Annotations[designate.RndcRotationRequestAnnotation] = instance.Annotations[designate.RndcRotateAnnotation]
+			rndcSecret.Annotations[designate.RndcRotationPhaseAnnotation] = nextPhase
+		case designate.RndcRotationPhasePromoted:
+			designate.PromoteRndcKeys(rndcSecret.Data)
+			rndcSecret.Annotations[designate.RndcRotationPhaseAnnotation] = nextPhase
+		default:
+			designate.CleanupRndcKeys(rndcSecret.Data)
+			delete(rndcSecret.Annotations, designate.RndcRotationPhaseAnnotation)
+		}
+		return nil
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if nextPhase == "" {
+		Log.Info("rndc key rotation completed")
+		return ctrl.Result{}, nil
+	}
+	Log.Info(fmt.Sprintf("rndc key rotation moved to phase %s", nextPhase))
+	return ctrl.Result{RequeueAfter: rndcRotationPollInterval}, nil
+}
+
+// rndcRotationDue returns whether an rndc key rotation has to be started, or
+// otherwise how long to wait for the next scheduled rotation
+func rndcRotationDue(
+	instance *designatev1beta1.Designate,
+	rndcSecret *corev1.Secret,
+	now time.Time,
+) (bool, time.Duration) {
+	requested := instance.Annotations[designate.RndcRotateAnnotation]
+	if requested != "" && requested != rndcSecret.Annotations[designate.RndcRotationRequestAnnotation] {
+		return true, 0
+	}
+	if instance.Spec.RndcKeyRotationInterval == nil || instance.Spec.RndcKeyRotationInterval.Duration <= 0 {
+		return false, 0
+	}
+	last := rndcSecret.CreationTimestamp.Time
+	if t, err := time.Parse(time.RFC3339, rndcSecret.Annotations[designate.RndcRotationTimeAnnotation]); err == nil {
+		last = t
+	}
+	next := last.Add(instance.Spec.RndcKeyRotationInterval.Duration)
+	if !now.Before(next) {
+		return true, 0
+	}
+	return false, next.Sub(now)
+}
+
+// rndcRotationDeployed returns true when all the bind9 servers, and the
+// designate-workers if requested, run with the rndc keys of state
+func (r *DesignateReconciler) rndcRotationDeployed(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+	state string,
+	withWorkers bool,
+) (bool, error) {
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+	}
+	binds := &designatev1beta1.DesignateBackendbind9List{}
+	if err := r.List(ctx, binds, listOpts...); err != nil {
+		return false, err
+	}
+	for _, item := range binds.Items {
+		if designate.GetOwningDesignateName(&item) != instance.Name {
+			continue
+		}
+		if item.Generation != item.Status.ObservedGeneration || item.Status.Hash[designate.RndcRotationHash] != state {
+			return false, nil
+		}
+	}
+	if !withWorkers {
+		return true, nil
+	}
+	workers := &designatev1beta1.DesignateWorkerList{}
+	if err := r.List(ctx, workers, listOpts...); err != nil {
+		return false, err
+	}
+	for _, item := range workers.Items {
+		if designate.GetOwningDesignateName(&item) != instance.Name {
+			continue
+		}
+		if item.Generation != item.Status.ObservedGeneration || item.Status.Hash[designate.RndcRotationHash] != state {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+)
+
+func Test_rndcRotationDue(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	rndcSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+		},
+	}
+	instance := &designatev1beta1.Designate{}
+
+	if due, _ := rndcRotationDue(instance, rndcSecret, now); due {
+		t.Errorf("no rotation expected without an interval or a request")
+	}
+
+	instance.Spec.RndcKeyRotationInterval = &metav1.Duration{Duration: 24 * time.Hour}
+	if due, _ := rndcRotationDue(instance, rndcSecret, now); !due {
+		t.Errorf("expected a rotation once the interval elapsed since the secret creation")
+	}
+
+	rndcSecret.Annotations = map[string]string{
+		designate.RndcRotationTimeAnnotation: now.Add(-6 * time.Hour).Format(time.RFC3339),
+	}
+	due, wait := rndcRotationDue(instance, rndcSecret, now)
+	if due || wait != 18*time.Hour {
+		t.Errorf("expected the next rotation in 18h, got due=%v wait=%v", due, wait)
+	}
+
+	instance.Annotations = map[string]string{designate.RndcRotateAnnotation: "1"}
+	if due, _ := rndcRotationDue(instance, rndcSecret, now); !due {
+		t.Errorf("expected a rotation on request")
+	}
+	rndcSecret.Annotations[designate.RndcRotationRequestAnnotation] = "1"
+	if due, _ := rndcRotationDue(instance, rndcSecret, now); due {
+		t.Errorf("a handled request must not start another rotation")
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const tracerName = "github.com/openstack-k8s-operators/designate-operator"
+
+// startReconcileSpan starts the span covering a reconcile of the given kind. The spans are
+// only exported when the manager installed a tracer provider, they are noop otherwise.
+func startReconcileSpan(ctx context.Context, kind string, req ctrl.Request) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, kind+".Reconcile", trace.WithAttributes(
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("k8s.object.name", req.Name),
+	))
+}
+
+// endReconcileSpan records the error returned by the reconcile on the span and ends it
+func endReconcileSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,166 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// designateUpdateStages are the sub-CRs of Designate, by suffix, in the order
+// a minor update rolls the new images out once the database schema is
+// synced. The sub-CRs of a stage are updated together, once the ones of the
+// previous stages run their new image and are ready.
+var designateUpdateStages = [][]string{
+	{"api"},
+	{"central"},
+	{"producer", "worker", "sink"},
+	{"mdns"},
+	{"backendbind9", "backendpdns", "unbound"},
+}
+
+// updateState is the state of a deployed sub-CR during a minor update
+type updateState struct {
+	// name - name of the sub-CR
+	name string
+	// current - image of the sub-CR, target - image of its template
+	current, target string
+	// rolledOut - the sub-CR reconciled its spec and is ready
+	rolledOut bool
+	// failure - message of the Ready condition of the sub-CR when it failed
+	failure string
+}
+
+// designateUpdate is the progress of a minor update
+type designateUpdate struct {
+	// held - suffixes of the sub-CRs which keep their current image
+	held map[string]bool
+	// updating - suffixes of the sub-CRs updated to the image of their template
+	updating map[string]bool
+	// waiting - names of the sub-CRs of the stage being rolled out
+	waiting []string
+	// failed - first sub-CR of waiting which failed, with its failure
+	failed, failure string
+	// outdated - a sub-CR doesn't run the image of its template yet
+	outdated bool
+}
+
+// updateTargetImage returns the image of the template of the sub-CR with
+// suffix, or "" when the sub-CR isn't deployed
+func updateTargetImage(instance *designatev1beta1.Designate, suffix string) string {
+	switch suffix {
+	case "api":
+		return instance.Spec.DesignateAPI.ContainerImage
+	case "central":
+		return instance.Spec.DesignateCentral.ContainerImage
+	case "worker":
+		return instance.Spec.DesignateWorker.ContainerImage
+	case "mdns":
+		return instance.Spec.DesignateMdns.ContainerImage
+	case "producer":
+		return instance.Spec.DesignateProducer.ContainerImage
+	case "backendbind9":
+		return instance.Spec.DesignateBackendbind9.ContainerImage
+	case "backendpdns":
+		if instance.IsPDNSEnabled() {
+			return instance.Spec.DesignateBackendPDNS.ContainerImage
+		}
+	case "unbound":
+		return instance.Spec.DesignateUnbound.ContainerImage
+	case "sink":
+		if instance.IsSinkEnabled() {
+			return instance.Spec.DesignateSink.ContainerImage
+		}
+	}
+	return ""
+}
+
+// planUpdate walks the update stages with the states of the deployed sub-CRs
+// by suffix. The first stage with a sub-CR not rolled out to its target image
+// is the one being rolled out, the sub-CRs of the later stages are held.
+func planUpdate(states map[string]updateState) designateUpdate {
+	update := designateUpdate{held: map[string]bool{}, updating: map[string]bool{}}
+	for _, stage := range designateUpdateStages {
+		// the stage being rolled out is before this one
+		hold := len(update.waiting) > 0
+		for _, suffix := range stage {
+			state, ok := states[suffix]
+			if !ok {
+				continue
+			}
+			if state.current != state.target {
+				update.outdated = true
+			}
+			if hold {
+				update.held[suffix] = true
+				continue
+			}
+			if state.current == state.target && state.rolledOut {
+				continue
+			}
+			if state.current != state.target {
+				update.updating[suffix] = true
+			}
+			update.waiting = append(update.waiting, state.name)
+			if state.failure != "" && update.failed == "" {
+				update.failed, update.failure = state.name, state.failure
+			}
+		}
+	}
+	return update
+}
+
+// updateProgress returns the progress of the minor update of the sub-CRs of
+// instance to the images of their templates
+func (r *DesignateReconciler) updateProgress(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+) (designateUpdate, error) {
+	states := map[string]updateState{}
+	for _, component := range designateComponents() {
+		target := updateTargetImage(instance, component.suffix)
+		if target == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s-%s", instance.Name, component.suffix)
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, component.object)
+		if k8s_errors.IsNotFound(err) {
+			// created with the image of its template
+			continue
+		} else if err != nil {
+			return designateUpdate{}, err
+		}
+		_, image, _, conditions, observedGeneration := component.summary()
+		state := updateState{
+			name:      name,
+			current:   image,
+			target:    target,
+			rolledOut: component.object.GetGeneration() == observedGeneration && conditions.IsTrue(condition.ReadyCondition),
+		}
+		if ready := conditions.Get(condition.ReadyCondition); ready != nil &&
+			ready.Status == corev1.ConditionFalse && ready.Reason == condition.ErrorReason {
+			state.failure = ready.Message
+		}
+		states[component.suffix] = state
+	}
+	return planUpdate(states), nil
+}
@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func Test_planUpdate(t *testing.T) {
+	// the states of the sub-CRs updated from old to new up to stage
+	states := func(updated ...string) map[string]updateState {
+		states := map[string]updateState{}
+		for _, suffix := range []string{"api", "central", "producer", "worker", "mdns", "backendbind9", "unbound"} {
+			state := updateState{name: "designate-" + suffix, current: "old", target: "new"}
+			if slices.Contains(updated, suffix) {
+				state.current = "new"
+				state.rolledOut = true
+			}
+			states[suffix] = state
+		}
+		return states
+	}
+
+	tests := []struct {
+		name     string
+		states   map[string]updateState
+		held     []string
+		updating []string
+		waiting  []string
+		failed   string
+		outdated bool
+	}{
+		{
+			name:     "update started",
+			states:   states(),
+			held:     []string{"backendbind9", "central", "mdns", "producer", "unbound", "worker"},
+			updating: []string{"api"},
+			waiting:  []string{"designate-api"},
+			outdated: true,
+		},
+		{
+			name:     "API updated",
+			states:   states("api", "central"),
+			held:     []string{"backendbind9", "mdns", "unbound"},
+			updating: []string{"producer", "worker"},
+			waiting:  []string{"designate-producer", "designate-worker"},
+			outdated: true,
+		},
+		{
+			name: "central failed",
+			states: func() map[string]updateState {
+				s := states("api")
+				central := s["central"]
+				central.current = "new"
+				central.failure = "Deployment error occurred"
+				s["central"] = central
+				return s
+			}(),
+			held:     []string{"backendbind9", "mdns", "producer", "unbound", "worker"},
+			waiting:  []string{"designate-central"},
+			failed:   "designate-central",
+			outdated: true,
+		},
+		{
+			name:   "update completed",
+			states: states("api", "central", "producer", "worker", "mdns", "backendbind9", "unbound"),
+			held:   []string{},
+		},
+		{
+			name: "not ready without update",
+			states: func() map[string]updateState {
+				s := states("api", "central", "producer", "worker", "mdns", "backendbind9", "unbound")
+				api := s["api"]
+				api.rolledOut = false
+				s["api"] = api
+				return s
+			}(),
+			held:    []string{"backendbind9", "central", "mdns", "producer", "unbound", "worker"},
+			waiting: []string{"designate-api"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := planUpdate(tt.states)
+			if held := slices.Sorted(maps.Keys(update.held)); !slices.Equal(held, tt.held) {
+				t.Errorf("held = %v, expected %v", held, tt.held)
+			}
+			if updating := slices.Sorted(maps.Keys(update.updating)); !slices.Equal(updating, tt.updating) {
+				t.Errorf("updating = %v, expected %v", updating, tt.updating)
+			}
+			if !slices.Equal(update.waiting, tt.waiting) {
+				t.Errorf("waiting = %v, expected %v", update.waiting, tt.waiting)
+			}
+			if update.failed != tt.failed {
+				t.Errorf("failed = %q, expected %q", update.failed, tt.failed)
+			}
+			if update.outdated != tt.outdated {
+				t.Errorf("outdated = %v, expected %v", update.outdated, tt.outdated)
+			}
+		})
+	}
+}
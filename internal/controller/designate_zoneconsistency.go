@@ -0,0 +1,222 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendbind9"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+// zoneConsistencyDefaultInterval is how often the zones are checked when the
+// interval of the spec is not set
+const zoneConsistencyDefaultInterval = time.Duration(5) * time.Minute
+
+// zoneSerialLag exports how far behind designate each bind9 server serves
+// each zone, on the metrics endpoint of the operator
+var zoneSerialLag = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "designate_zone_serial_lag_seconds",
+		Help: "Seconds the serial of a zone served by a bind9 server is behind the serial in designate",
+	},
+	[]string{"namespace", "designate", "server", "zone"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(zoneSerialLag)
+}
+
+// zoneConsistencyInterval returns how often the zones of instance are checked
+func zoneConsistencyInterval(instance *designatev1beta1.Designate) time.Duration {
+	if instance.Spec.ZoneConsistency.Interval.Duration <= 0 {
+		return zoneConsistencyDefaultInterval
+	}
+	return instance.Spec.ZoneConsistency.Interval.Duration
+}
+
+// zoneConsistencyDue returns whether the zones have to be checked, or
+// otherwise how long to wait for the next check
+func zoneConsistencyDue(instance *designatev1beta1.Designate, now time.Time) (bool, time.Duration) {
+	if instance.Status.ZoneConsistencyCheckTime == nil {
+		return true, 0
+	}
+	next := instance.Status.ZoneConsistencyCheckTime.Add(zoneConsistencyInterval(instance))
+	if !now.Before(next) {
+		return true, 0
+	}
+	return false, next.Sub(now)
+}
+
+// reconcileZoneConsistency compares the serials of the active zones in
+// designate with the serials the bind9 servers serve, when the check is
+// enabled and due. Lagging servers are reported in the DesignateZonesInSync
+// condition, which is informational and doesn't affect the Ready condition.
+// The returned result requeues until the next check.
+func (r *DesignateReconciler) reconcileZoneConsistency(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	multipool bool,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	seriesLabels := prometheus.Labels{"namespace": instance.Namespace, "designate": instance.Name}
+	if !instance.Spec.ZoneConsistency.Enabled || len(instance.Spec.DesignateBackendbind9.Views) > 0 {
+		if instance.Spec.ZoneConsistency.Enabled {
+			// the servers answer the queries of the operator from the view
+			// matching it, which might not serve the designate zones
+			Log.Info("Skipping the zone consistency check, the bind9 servers are configured with views")
+		}
+		zoneSerialLag.DeletePartialMatch(seriesLabels)
+		instance.Status.Conditions.Remove(designatev1beta1.DesignateZonesInSyncCondition)
+		instance.Status.ZoneConsistencyCheckTime = nil
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now().UTC()
+	due, wait := zoneConsistencyDue(instance, now)
+	if !due {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+	instance.Status.ZoneConsistencyCheckTime = &metav1.Time{Time: now}
+	result := ctrl.Result{RequeueAfter: zoneConsistencyInterval(instance)}
+
+	lagging, err := r.checkZoneConsistency(ctx, h, instance, multipool, seriesLabels)
+	if err != nil {
+		// a failed check is retried at the next interval, it must not
+		// block the reconciliation of the services
+		Log.Error(err, "Zone consistency check failed")
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateZonesInSyncCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateZonesInSyncErrorMessage,
+			err.Error()))
+		return result, nil
+	}
+	if len(lagging) > 0 {
+		Log.Info(fmt.Sprintf("bind9 servers lagging behind designate: %s", strings.Join(lagging, ", ")))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateZonesInSyncCondition,
+			designatev1beta1.DesignateZonesLaggingReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateZonesInSyncLaggingMessage,
+			strings.Join(lagging, ", ")))
+		return result, nil
+	}
+	instance.Status.Conditions.MarkTrue(
+		designatev1beta1.DesignateZonesInSyncCondition,
+		designatev1beta1.DesignateZonesInSyncMessage)
+	return result, nil
+}
+
+// checkZoneConsistency queries the SOA record of the active zones on the DNS
+// Service of each bind9 pod, exports the lags and returns the servers
+// lagging more than the threshold. With multiple pools a server only serves
+// the zones of its pool, the zones it doesn't serve are ignored.
+func (r *DesignateReconciler) checkZoneConsistency(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+	multipool bool,
+	seriesLabels prometheus.Labels,
+) ([]string, error) {
+	osclient, err := designate.GetOpenstackClient(ctx, instance.Namespace, h)
+	if err != nil {
+		return nil, err
+	}
+	zoneList, err := designate.ListActiveZones(ctx, osclient)
+	if err != nil {
+		return nil, err
+	}
+
+	services := &corev1.ServiceList{}
+	err = h.GetClient().List(ctx, services,
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			common.AppSelector:       fmt.Sprintf("%s-backendbind9", instance.Name),
+			common.ComponentSelector: designatebackendbind9.Component,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := map[string]string{}
+	for _, svc := range services.Items {
+		if svc.Spec.ClusterIP == "" || svc.Spec.ClusterIP == corev1.ClusterIPNone {
+			continue
+		}
+		servers[svc.Name] = net.JoinHostPort(svc.Spec.ClusterIP, strconv.Itoa(int(designatebackendbind9.DNSPort(&instance.Spec.DesignateBackendbind9.DesignateBackendbind9SpecBase))))
+	}
+	zoneNames := make([]string, 0, len(zoneList))
+	for _, zone := range zoneList {
+		zoneNames = append(zoneNames, zone.Name)
+	}
+	// the servers are queried concurrently, the unreachable ones delay the
+	// reconciliation by SOAQueryTimeout at most
+	serials, queryErr := designate.QueryZoneSerials(ctx, servers, zoneNames)
+
+	// drop the series of the removed servers and zones
+	zoneSerialLag.DeletePartialMatch(seriesLabels)
+	lagging := []string{}
+	for server, served := range serials {
+		behind := false
+		for _, zone := range zoneList {
+			serial, ok := served[zone.Name]
+			if !ok && multipool {
+				// the zone belongs to the pool of another server
+				continue
+			}
+			lag := designate.ZoneSerialLag(uint32(zone.Serial), serial) // #nosec G115
+			zoneSerialLag.With(prometheus.Labels{
+				"namespace": instance.Namespace,
+				"designate": instance.Name,
+				"server":    server,
+				"zone":      zone.Name,
+			}).Set(float64(lag))
+			if lag > instance.Spec.ZoneConsistency.LagThreshold {
+				behind = true
+			}
+		}
+		if behind {
+			lagging = append(lagging, server)
+		}
+	}
+	if queryErr != nil {
+		// the lags of the servers which answered are still exported
+		return nil, queryErr
+	}
+	slices.Sort(lagging)
+	return lagging, nil
+}
@@ -0,0 +1,44 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+func Test_zoneConsistencyDue(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	instance := &designatev1beta1.Designate{}
+
+	if due, _ := zoneConsistencyDue(instance, now); !due {
+		t.Errorf("expected a check when the zones were never checked")
+	}
+
+	instance.Status.ZoneConsistencyCheckTime = &metav1.Time{Time: now.Add(-time.Minute)}
+	due, wait := zoneConsistencyDue(instance, now)
+	if due || wait != 4*time.Minute {
+		t.Errorf("expected the next check in 4m with the default interval, got due=%v wait=%v", due, wait)
+	}
+
+	instance.Spec.ZoneConsistency.Interval = metav1.Duration{Duration: time.Minute}
+	if due, _ := zoneConsistencyDue(instance, now); !due {
+		t.Errorf("expected a check once the interval elapsed")
+	}
+}
@@ -21,12 +21,18 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"net/url"
 	"slices"
+	"strings"
 	"time"
 
+	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -112,6 +118,8 @@ var keystoneServices = []map[string]string{
 // +kubebuilder:rbac:groups=keystone.openstack.org,resources=keystoneendpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -123,6 +131,9 @@ var keystoneServices = []map[string]string{
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
 func (r *DesignateAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateAPI", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
 	Log := r.GetLogger(ctx)
 
 	// Fetch the DesignateAPI instance
@@ -139,6 +150,10 @@ func (r *DesignateAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -190,6 +205,7 @@ func (r *DesignateAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		condition.UnknownCondition(condition.CreateServiceReadyCondition, condition.InitReason, condition.CreateServiceReadyInitMessage),
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
 		// right now we have no dedicated KeystoneServiceReadyInitMessage
 		condition.UnknownCondition(condition.KeystoneServiceReadyCondition, condition.InitReason, ""),
@@ -197,6 +213,12 @@ func (r *DesignateAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 	)
+	// the read-only replicas are optional
+	if instance.Spec.ReadOnly != nil {
+		cl.Set(condition.UnknownCondition(designatev1beta1.DesignateAPIReadOnlyReadyCondition, condition.InitReason, designatev1beta1.DesignateAPIReadOnlyReadyInitMessage))
+	} else {
+		instance.Status.Conditions.Remove(designatev1beta1.DesignateAPIReadOnlyReadyCondition)
+	}
 
 	instance.Status.Conditions.Init(&cl)
 	instance.Status.ObservedGeneration = instance.Generation
@@ -306,6 +328,18 @@ func (r *DesignateAPIReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 		return err
 	}
 
+	// index apiPolicyConfigMapField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &designatev1beta1.DesignateAPI{}, apiPolicyConfigMapField, func(rawObj client.Object) []string {
+		// Extract the policy ConfigMap name from the spec, if one is provided
+		cr := rawObj.(*designatev1beta1.DesignateAPI)
+		if cr.Spec.APIPolicyOverride == nil || cr.Spec.APIPolicyOverride.ConfigMapName == "" {
+			return nil
+		}
+		return []string{cr.Spec.APIPolicyOverride.ConfigMapName}
+	}); err != nil {
+		return err
+	}
+
 	svcSecretFn := func(ctx context.Context, o client.Object) []reconcile.Request {
 		var namespace = o.GetNamespace()
 		var secretName = o.GetName()
@@ -388,10 +422,15 @@ func (r *DesignateAPIReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&designatev1beta1.DesignateAPI{}).
+		WithOptions(controllerOptions()).
 		Owns(&keystonev1.KeystoneService{}).
 		Owns(&keystonev1.KeystoneEndpoint{}).
+		// the generated config Secrets, to detect their out of band modifications
+		Owns(&corev1.Secret{}).
 		Owns(&corev1.Service{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
 		Watches(&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(svcSecretFn)).
 		Watches(
@@ -399,6 +438,11 @@ func (r *DesignateAPIReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Watches(&networkv1.NetworkAttachmentDefinition{},
 			handler.EnqueueRequestsFromMapFunc(nadFn)).
 		Watches(&topologyv1.Topology{},
@@ -422,6 +466,7 @@ func (r *DesignateAPIReconciler) findObjectsForSrc(ctx context.Context, src clie
 		tlsAPIPublicField,
 		topologyField,
 		authAppCredSecretField,
+		apiPolicyConfigMapField,
 	}
 
 	for _, field := range allWatchFields {
@@ -546,6 +591,30 @@ func (r *DesignateAPIReconciler) reconcileInit(
 
 	Log.Info(fmt.Sprintf("Reconciling Service '%s' init", instance.Name))
 
+	// the services and the keystone service of the API are named after the
+	// namespace, a second API would overwrite them whatever its region
+	keystoneAPI, err := keystonev1.GetKeystoneAPI(ctx, helper, instance.Namespace, map[string]string{})
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.CreateServiceReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.CreateServiceReadyErrorMessage,
+			err.Error()))
+
+		return ctrl.Result{}, err
+	}
+	if err = r.checkRegionConflict(ctx, instance, keystoneAPI.GetRegion()); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.CreateServiceReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.CreateServiceReadyErrorMessage,
+			err.Error()))
+
+		return ctrl.Result{}, err
+	}
+
 	//
 	// expose the service (create service, route and return the created endpoint URLs)
 	//
@@ -563,6 +632,21 @@ func (r *DesignateAPIReconciler) reconcileInit(
 		service.EndpointPublic:   publicEndpointData,
 		service.EndpointInternal: internalEndpointData,
 	}
+	if instance.Spec.InternalOnly {
+		delete(designateEndpoints, service.EndpointPublic)
+		// remove the public service left over from before the switch
+		err := r.deletePublicService(ctx, helper, instance)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.CreateServiceReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.CreateServiceReadyErrorMessage,
+				err.Error()))
+
+			return ctrl.Result{}, err
+		}
+	}
 
 	apiEndpoints := make(map[string]string)
 
@@ -793,8 +877,13 @@ func (r *DesignateAPIReconciler) reconcileNormal(ctx context.Context, instance *
 		if hash != "" {
 			configMapVars[tls.CABundleKey] = env.SetValue(hash)
 		}
+	}
 
-		// Validate API service certs secrets
+	// Validate API service certs secrets. This is independent of the CA bundle
+	// so that the pods are restarted when any of the certificates is rotated.
+	if instance.Spec.TLS.CaBundleSecretName != "" ||
+		instance.Spec.TLS.API.Enabled(service.EndpointInternal) ||
+		instance.Spec.TLS.API.Enabled(service.EndpointPublic) {
 		certsHash, err := instance.Spec.TLS.API.ValidateCertSecrets(ctx, helper, instance.Namespace)
 		if err != nil {
 			if k8s_errors.IsNotFound(err) {
@@ -823,21 +912,16 @@ func (r *DesignateAPIReconciler) reconcileNormal(ctx context.Context, instance *
 	instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, condition.InputReadyMessage)
 
 	//
-	// TODO(beagles 03/2025): this block of code repeats throughout designate. It appears that the idea here was to allow custom
-	// config snippets to be stored in secrets and used later on. This was not used anywhere however. Maybe this
-	// was intended to be part of supporting alternate backends? I will comment out for now and add a warning if the
-	// parameter is used.
+	// check for the Secrets holding CustomServiceConfig snippets, the init
+	// container merges their content into custom.conf
 	//
-	// for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
-	//	ctrlResult, err = r.getSecret(ctx, helper, instance, secretName, &configMapVars, "secret-")
-	//	if err != nil {
-	//		return ctrlResult, err
-	//	}
-	//}
-	// run check service secrets - end
-	if len(instance.Spec.CustomServiceConfigSecrets) > 0 {
-		Log.Info("warning: CustomServiceConfigSecrets is not supported.")
+	for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
+		ctrlResult, err = getSecret(ctx, helper, instance.Namespace, &instance.Status.Conditions, secretName, &configMapVars, "secret-")
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
 	}
+	// run check service secrets - end
 
 	//
 	// check for required Designate config maps that should have been created by parent Designate CR
@@ -1011,7 +1095,17 @@ func (r *DesignateAPIReconciler) reconcileNormal(ctx context.Context, instance *
 			err.Error()))
 		return ctrl.Result{}, err
 	}
-	depl := deployment.NewDeployment(
+	err = designate.ReconcileAutoscaling(ctx, helper, deplDef, instance.Spec.Autoscaling)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	depl := designate.NewDeployment(
 		deplDef,
 		time.Duration(5)*time.Second,
 	)
@@ -1033,14 +1127,32 @@ func (r *DesignateAPIReconciler) reconcileNormal(ctx context.Context, instance *
 			condition.DeploymentReadyRunningMessage))
 		return ctrlResult, nil
 	}
+	// the workload is only rendered into the preview ConfigMap
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
+	ctrlResult, err = designate.ReconcilePodDisruptionBudget(
+		ctx,
+		helper,
+		instance.Name,
+		instance.Namespace,
+		serviceLabels,
+		*deplDef.Spec.Replicas,
+		instance.Spec.PodDisruptionBudget,
+	)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
 	deploy := depl.GetDeployment()
 	if deploy.Generation == deploy.Status.ObservedGeneration {
 		instance.Status.ReadyCount = deploy.Status.ReadyReplicas
+		instance.Status.Selector = metav1.FormatLabelSelector(deploy.Spec.Selector)
 
 		// verify if network attachment matches expectations
 		networkReady := false
 		networkAttachmentStatus := map[string][]string{}
-		if *(instance.Spec.Replicas) > 0 && len(instance.Spec.NetworkAttachments) > 0 {
+		if *deplDef.Spec.Replicas > 0 && len(instance.Spec.NetworkAttachments) > 0 {
 			networkReady, networkAttachmentStatus, err = nad.VerifyNetworkStatusFromAnnotation(
 				ctx,
 				helper,
@@ -1088,6 +1200,13 @@ func (r *DesignateAPIReconciler) reconcileNormal(ctx context.Context, instance *
 	}
 	// create Deployment - end
 
+	ctrlResult, err = r.reconcileReadOnly(ctx, helper, instance, inputHash, serviceAnnotations, topology)
+	if err != nil {
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+
 	// We reached the end of the Reconcile, update the Ready condition based on
 	// the sub conditions
 	if instance.Status.Conditions.AllSubConditionIsTrue() {
@@ -1139,10 +1258,6 @@ func (r *DesignateAPIReconciler) generateServiceConfigMaps(
 
 	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(designate.ServiceName), map[string]string{})
 
-	db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
-	if err != nil {
-		return err
-	}
 	var tlsCfg *tls.Service
 	if instance.Spec.TLS.CaBundleSecretName != "" {
 		tlsCfg = &tls.Service{}
@@ -1152,7 +1267,14 @@ func (r *DesignateAPIReconciler) generateServiceConfigMaps(
 	// custom.conf is going to be merged into /etc/designate/designate.conf.d/custom.conf
 	customData := map[string]string{
 		common.CustomServiceConfigFileName: instance.Spec.CustomServiceConfig,
-		"my.cnf":                           db.GetDatabaseClientConfig(tlsCfg), //(oschwart) for now just get the default my.cnf
+	}
+	// with an external database, the my.cnf of the Designate CR is used
+	if !instance.Spec.ExternalDatabase {
+		db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
+		if err != nil {
+			return err
+		}
+		customData["my.cnf"] = db.GetDatabaseClientConfig(tlsCfg) //(oschwart) for now just get the default my.cnf
 	}
 
 	keystoneAPI, err := keystonev1.GetKeystoneAPI(ctx, h, instance.Namespace, map[string]string{})
@@ -1170,24 +1292,31 @@ func (r *DesignateAPIReconciler) generateServiceConfigMaps(
 
 	customData[common.CustomServiceConfigFileName] = instance.Spec.CustomServiceConfig
 
-	// Get region from KeystoneAPI, defaulting to "regionOne" if empty
-	region := keystoneAPI.GetRegion()
-	if region == "" {
-		region = "regionOne"
-	}
+	region := apiRegion(instance, keystoneAPI.GetRegion())
 
 	templateParameters := map[string]any{
 		"KeystoneInternalURL": keystoneInternalURL,
 		"KeystonePublicURL":   keystonePublicURL,
 		"TimeOut":             instance.Spec.APITimeout,
 		"Region":              region,
+		"Logging":             designate.LoggingTemplateParameters(instance.Spec.Logging),
 	}
 
 	// create httpd  vhost template parameters
 	httpdVhostConfig := map[string]any{}
-	for _, endpt := range []service.Endpoint{service.EndpointInternal, service.EndpointPublic} {
+	endpts := []service.Endpoint{service.EndpointInternal, service.EndpointPublic}
+	if instance.Spec.InternalOnly {
+		endpts = []service.Endpoint{service.EndpointInternal}
+	}
+	for _, endpt := range endpts {
 		endptConfig := map[string]any{}
-		endptConfig["ServerName"] = fmt.Sprintf("%s-%s.%s.svc", designate.ServiceName, endpt.String(), instance.Namespace)
+		serverName := fmt.Sprintf("%s-%s.%s.svc", designate.ServiceName, endpt.String(), instance.Namespace)
+		endptConfig["ServerName"] = serverName
+		serverAlias, err := apiVhostServerAlias(instance.Spec.Override.Service[endpt].EndpointURL, serverName)
+		if err != nil {
+			return err
+		}
+		endptConfig["ServerAlias"] = serverAlias
 		endptConfig["TLS"] = false // default TLS to false, and set it bellow to true if enabled
 		if instance.Spec.TLS.API.Enabled(endpt) {
 			endptConfig["TLS"] = true
@@ -1245,6 +1374,21 @@ func (r *DesignateAPIReconciler) generateServiceConfigMaps(
 		Log.Info("Using ApplicationCredentials auth", "secret", instance.Spec.Auth.ApplicationCredentialSecret)
 	}
 
+	templateParameters["CORS"] = apiCORSParameters(instance.Spec.CORS)
+	templateParameters["MaxRequestBodySize"] = instance.Spec.MaxRequestBodySize
+	templateParameters["WSGI"] = apiWSGIParameters(instance.Spec.WSGI)
+
+	// The policy file is part of the config, a change of the rules restarts the pods
+	templateParameters["PolicyFile"] = ""
+	if instance.Spec.APIPolicyOverride != nil {
+		policy, err := r.getAPIPolicy(ctx, h, instance)
+		if err != nil {
+			return err
+		}
+		customData[designateapi.PolicyFileName] = policy
+		templateParameters["PolicyFile"] = designateapi.PolicyFilePath
+	}
+
 	cms := []util.Template{
 		// Custom ConfigMap
 		{
@@ -1266,7 +1410,7 @@ func (r *DesignateAPIReconciler) generateServiceConfigMaps(
 		},
 	}
 
-	err = oko_secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+	err = ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
 
 	if err != nil {
 		Log.Error(err, "unable to process config map")
@@ -1277,6 +1421,279 @@ func (r *DesignateAPIReconciler) generateServiceConfigMaps(
 	return nil
 }
 
+// apiRegion returns the keystone region of api: the one of its spec, else the
+// one of the KeystoneAPI, else regionOne
+func apiRegion(api *designatev1beta1.DesignateAPI, keystoneRegion string) string {
+	if api.Spec.Region != "" {
+		return api.Spec.Region
+	}
+	if keystoneRegion != "" {
+		return keystoneRegion
+	}
+	return "regionOne"
+}
+
+// checkRegionConflict returns an error if another DesignateAPI of the
+// namespace was created before the instance. Only the first one is served,
+// the services and the keystone service are shared by the namespace.
+func (r *DesignateAPIReconciler) checkRegionConflict(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateAPI,
+	keystoneRegion string,
+) error {
+	apis := &designatev1beta1.DesignateAPIList{}
+	if err := r.Client.List(ctx, apis, client.InNamespace(instance.Namespace)); err != nil {
+		return err
+	}
+	region := apiRegion(instance, keystoneRegion)
+	for _, api := range apis.Items {
+		if api.Name == instance.Name || !api.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if instance.CreationTimestamp.Before(&api.CreationTimestamp) ||
+			(instance.CreationTimestamp.Equal(&api.CreationTimestamp) && instance.Name < api.Name) {
+			continue
+		}
+		if apiRegion(&api, keystoneRegion) == region {
+			return fmt.Errorf("%w: %s", ErrAPIRegionConflict, api.Name)
+		}
+		return fmt.Errorf("%w: %s", ErrAPINamespaceConflict, api.Name)
+	}
+	return nil
+}
+
+// deletePublicService deletes the public service of the API, if any
+func (r *DesignateAPIReconciler) deletePublicService(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.DesignateAPI,
+) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      designate.ServiceName + "-" + string(service.EndpointPublic),
+			Namespace: instance.Namespace,
+		},
+	}
+	if err := helper.GetClient().Delete(ctx, svc); err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// reconcileReadOnly deploys the read-only API replicas of instance and their
+// Service, or removes them when instance has none. Their pods have their own
+// component label, the API Services don't send them any request.
+func (r *DesignateAPIReconciler) reconcileReadOnly(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.DesignateAPI,
+	inputHash string,
+	annotations map[string]string,
+	topology *topologyv1.Topology,
+) (ctrl.Result, error) {
+	name := designateapi.ReadOnlyName(instance)
+	// the Deployment and the Service were named after the namespace before
+	legacyNames := []string{}
+	for _, legacyName := range []string{designateapi.ReadOnlyComponent, designateapi.LegacyReadOnlyServiceName} {
+		if legacyName != name {
+			legacyNames = append(legacyNames, legacyName)
+		}
+	}
+	if instance.Spec.ReadOnly == nil {
+		instance.Status.ReadOnlyReadyCount = 0
+		return ctrl.Result{}, r.deleteReadOnly(ctx, helper, instance, append(legacyNames, name)...)
+	}
+	if err := r.deleteReadOnly(ctx, helper, instance, legacyNames...); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	readOnlyLabels := map[string]string{
+		common.AppSelector:       designate.ServiceName,
+		common.ComponentSelector: designateapi.ReadOnlyComponent,
+	}
+	svc, err := service.NewService(
+		service.GenericService(&service.GenericServiceDetails{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    readOnlyLabels,
+			// the pods of the other DesignateAPIs have the same component
+			Selector: util.MergeStringMaps(readOnlyLabels, map[string]string{
+				designate.InstanceLabel: instance.Name,
+			}),
+			Port: service.GenericServicePort{
+				Name:     name,
+				Port:     designate.DesignateInternalPort,
+				Protocol: corev1.ProtocolTCP,
+			},
+		}),
+		5,
+		&service.OverrideSpec{},
+	)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateAPIReadOnlyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateAPIReadOnlyReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	svc.AddAnnotation(map[string]string{
+		service.AnnotationIngressCreateKey: "false",
+	})
+	ctrlResult, err := svc.CreateOrPatch(ctx, helper)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateAPIReadOnlyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateAPIReadOnlyReadyErrorMessage,
+			err.Error()))
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, nil
+	}
+
+	deplDef, err := designateapi.ReadOnlyDeployment(instance, inputHash, readOnlyLabels, annotations, topology)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateAPIReadOnlyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateAPIReadOnlyReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	depl := designate.NewDeployment(
+		deplDef,
+		time.Duration(5)*time.Second,
+	)
+	ctrlResult, err = depl.CreateOrPatch(ctx, helper)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateAPIReadOnlyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateAPIReadOnlyReadyErrorMessage,
+			err.Error()))
+		return ctrlResult, err
+	} else if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateAPIReadOnlyReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateAPIReadOnlyReadyRunningMessage))
+		return ctrlResult, nil
+	}
+
+	deploy := depl.GetDeployment()
+	if deploy.Generation == deploy.Status.ObservedGeneration {
+		instance.Status.ReadOnlyReadyCount = deploy.Status.ReadyReplicas
+		if deployment.IsReady(deploy) {
+			instance.Status.Conditions.MarkTrue(
+				designatev1beta1.DesignateAPIReadOnlyReadyCondition,
+				designatev1beta1.DesignateAPIReadOnlyReadyMessage)
+		} else {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				designatev1beta1.DesignateAPIReadOnlyReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				designatev1beta1.DesignateAPIReadOnlyReadyRunningMessage))
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// deleteReadOnly deletes the Deployment and the Service of the read-only API
+// replicas of instance named names, if any. The ones of the other
+// DesignateAPIs are kept.
+func (r *DesignateAPIReconciler) deleteReadOnly(
+	ctx context.Context,
+	helper *helper.Helper,
+	instance *designatev1beta1.DesignateAPI,
+	names ...string,
+) error {
+	for _, name := range names {
+		key := types.NamespacedName{Name: name, Namespace: instance.Namespace}
+		for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}} {
+			err := helper.GetClient().Get(ctx, key, obj)
+			if k8s_errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if !metav1.IsControlledBy(obj, instance) {
+				continue
+			}
+			if err := helper.GetClient().Delete(ctx, obj); err != nil && !k8s_errors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// apiCORSParameters returns the options of the cors middleware of the API
+// pipeline, nil when cross-origin requests aren't allowed
+func apiCORSParameters(cors *designatev1beta1.APICORSSpec) map[string]any {
+	if cors == nil {
+		return nil
+	}
+	return map[string]any{
+		"AllowedOrigins":   strings.Join(cors.AllowedOrigins, ","),
+		"AllowCredentials": cors.AllowCredentials,
+		"MaxAge":           cors.MaxAge,
+	}
+}
+
+// apiWSGIParameters returns the options of the mod_wsgi daemon processes of
+// the API vhosts, the defaults when the spec doesn't set them
+func apiWSGIParameters(wsgi *designatev1beta1.APIWSGISpec) map[string]any {
+	params := map[string]any{
+		"Processes":      int32(designateapi.WSGIProcesses),
+		"Threads":        int32(designateapi.WSGIThreads),
+		"RequestTimeout": int32(0),
+	}
+	if wsgi == nil {
+		return params
+	}
+	if wsgi.Processes > 0 {
+		params["Processes"] = wsgi.Processes
+	}
+	if wsgi.Threads > 0 {
+		params["Threads"] = wsgi.Threads
+	}
+	params["RequestTimeout"] = wsgi.RequestTimeout
+	return params
+}
+
+// getAPIPolicy returns the policy.yaml rules overriding the defaults of the API, inline or from a ConfigMap
+func (r *DesignateAPIReconciler) getAPIPolicy(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.DesignateAPI,
+) (string, error) {
+	override := instance.Spec.APIPolicyOverride
+	policy := override.Policy
+	if override.ConfigMapName != "" {
+		configMap := &corev1.ConfigMap{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: override.ConfigMapName, Namespace: instance.Namespace}, configMap)
+		if err != nil {
+			return "", err
+		}
+		var ok bool
+		if policy, ok = configMap.Data[override.Key]; !ok {
+			return "", fmt.Errorf("%w: %s not found in ConfigMap %s", ErrAPIPolicyInvalid, override.Key, override.ConfigMapName)
+		}
+	}
+	// oslo.policy expects a map of rule names to rules
+	rules := map[string]string{}
+	if err := yaml.Unmarshal([]byte(policy), &rules); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrAPIPolicyInvalid, err)
+	}
+	return policy, nil
+}
+
 // createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
 // if any of the input resources change, like configs, passwords, ...
 //
@@ -1291,6 +1708,7 @@ func (r *DesignateAPIReconciler) createHashOfInputHashes(
 	var hashMap map[string]string
 	changed := false
 	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	instance.Status.InputHashes = inputHashes(mergedMapVars)
 	hash, err := util.ObjectHash(mergedMapVars)
 	if err != nil {
 		return hash, changed, err
@@ -1301,3 +1719,21 @@ func (r *DesignateAPIReconciler) createHashOfInputHashes(
 	}
 	return hash, changed, nil
 }
+
+// apiVhostServerAlias returns the hostname of the endpoint URL override when
+// it differs from the vhost ServerName. Clients of an endpoint exposed through
+// a route or a load balancer connect with that hostname, so it has to match
+// the vhost for httpd to select its certificate through SNI.
+func apiVhostServerAlias(endpointURL *string, serverName string) (string, error) {
+	if endpointURL == nil || *endpointURL == "" {
+		return "", nil
+	}
+	u, err := url.Parse(*endpointURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpointURL %s: %w", *endpointURL, err)
+	}
+	if u.Hostname() == serverName {
+		return "", nil
+	}
+	return u.Hostname(), nil
+}
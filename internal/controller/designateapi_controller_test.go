@@ -0,0 +1,158 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_apiVhostServerAlias(t *testing.T) {
+	serverName := "designate-public.openstack.svc"
+	tests := []struct {
+		name        string
+		endpointURL *string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name: "no override",
+			want: "",
+		},
+		{
+			name:        "route hostname",
+			endpointURL: ptr.To("https://designate-public-openstack.apps.example.com"),
+			want:        "designate-public-openstack.apps.example.com",
+		},
+		{
+			name:        "same as server name",
+			endpointURL: ptr.To("https://designate-public.openstack.svc:9001"),
+			want:        "",
+		},
+		{
+			name:        "invalid",
+			endpointURL: ptr.To("https://[::1"),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := apiVhostServerAlias(tt.endpointURL, serverName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apiVhostServerAlias() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("apiVhostServerAlias() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiCORSParameters(t *testing.T) {
+	if got := apiCORSParameters(nil); got != nil {
+		t.Errorf("apiCORSParameters(nil) = %v, want nil", got)
+	}
+
+	got := apiCORSParameters(&designatev1beta1.APICORSSpec{
+		AllowedOrigins:   []string{"https://dashboard.example.org", "https://admin.example.org"},
+		AllowCredentials: true,
+		MaxAge:           3600,
+	})
+	if got["AllowedOrigins"] != "https://dashboard.example.org,https://admin.example.org" {
+		t.Errorf("unexpected allowed origins %v", got["AllowedOrigins"])
+	}
+	if got["AllowCredentials"] != true || got["MaxAge"] != int32(3600) {
+		t.Errorf("unexpected cors options %v", got)
+	}
+}
+
+func Test_apiWSGIParameters(t *testing.T) {
+	got := apiWSGIParameters(nil)
+	if got["Processes"] != int32(5) || got["Threads"] != int32(1) || got["RequestTimeout"] != int32(0) {
+		t.Errorf("unexpected default wsgi options %v", got)
+	}
+
+	got = apiWSGIParameters(&designatev1beta1.APIWSGISpec{
+		Processes:      8,
+		Threads:        4,
+		RequestTimeout: 300,
+	})
+	if got["Processes"] != int32(8) || got["Threads"] != int32(4) || got["RequestTimeout"] != int32(300) {
+		t.Errorf("unexpected wsgi options %v", got)
+	}
+}
+
+func TestDesignateAPIReconciler_checkRegionConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = designatev1beta1.AddToScheme(scheme)
+
+	created := metav1.Now()
+	newAPI := func(name, region string, age time.Duration) *designatev1beta1.DesignateAPI {
+		api := &designatev1beta1.DesignateAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "openstack",
+				CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			},
+		}
+		api.Spec.Region = region
+		return api
+	}
+
+	r := &DesignateAPIReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(newAPI("designate-api", "", time.Hour)).
+			Build(),
+	}
+
+	if err := r.checkRegionConflict(context.TODO(), newAPI("designate-api", "", time.Hour), "regionOne"); err != nil {
+		t.Errorf("unexpected conflict of an API with itself: %v", err)
+	}
+	if err := r.checkRegionConflict(context.TODO(), newAPI("designate-api-old", "regionTwo", 2*time.Hour), "regionOne"); err != nil {
+		t.Errorf("unexpected conflict of the first API: %v", err)
+	}
+	// the region of the KeystoneAPI is the one of the API without a region
+	err := r.checkRegionConflict(context.TODO(), newAPI("designate-api-two", "regionOne", 0), "regionOne")
+	if !errors.Is(err, ErrAPIRegionConflict) {
+		t.Errorf("checkRegionConflict() = %v, want %v", err, ErrAPIRegionConflict)
+	}
+	err = r.checkRegionConflict(context.TODO(), newAPI("designate-api-two", "regionTwo", 0), "regionOne")
+	if !errors.Is(err, ErrAPINamespaceConflict) {
+		t.Errorf("checkRegionConflict() = %v, want %v", err, ErrAPINamespaceConflict)
+	}
+}
+
+func Test_apiRegion(t *testing.T) {
+	api := &designatev1beta1.DesignateAPI{}
+	if got := apiRegion(api, ""); got != "regionOne" {
+		t.Errorf("apiRegion() = %q, want regionOne", got)
+	}
+	if got := apiRegion(api, "RegionOne"); got != "RegionOne" {
+		t.Errorf("apiRegion() = %q, want the region of the KeystoneAPI", got)
+	}
+	api.Spec.Region = "regionTwo"
+	if got := apiRegion(api, "RegionOne"); got != "regionTwo" {
+		t.Errorf("apiRegion() = %q, want regionTwo", got)
+	}
+}
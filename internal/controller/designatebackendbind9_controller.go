@@ -23,15 +23,18 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -84,8 +87,9 @@ func (r *DesignateBackendbind9Reconciler) GetScheme() *runtime.Scheme {
 // DesignateBackendbind9Reconciler reconciles a DesignateBackendbind9 object
 type DesignateBackendbind9Reconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient  kubernetes.Interface
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendbind9s,verbs=get;list;watch;create;update;patch;delete
@@ -95,10 +99,13 @@ type DesignateBackendbind9Reconciler struct {
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;create;update;patch;delete;watch
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -107,6 +114,9 @@ type DesignateBackendbind9Reconciler struct {
 // perform operations to make the cluster state reflect the state specified by
 // the user.
 func (r *DesignateBackendbind9Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateBackendbind9", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
 	Log := r.GetLogger(ctx)
 	Log.Info(fmt.Sprintf("==> Reconcile called for %s/%s", req.Namespace, req.Name))
 
@@ -126,6 +136,10 @@ func (r *DesignateBackendbind9Reconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, err
 	}
 
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -180,8 +194,10 @@ func (r *DesignateBackendbind9Reconciler) Reconcile(ctx context.Context, req ctr
 		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
 		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignatePredictableIPsReadyCondition, condition.InitReason, designatev1beta1.DesignatePredictableIPsReadyInitMessage),
 	)
 
 	instance.Status.Conditions.Init(&cl)
@@ -266,6 +282,16 @@ func (r *DesignateBackendbind9Reconciler) SetupWithManager(ctx context.Context,
 			return nil
 		}
 
+		// reconcile the DesignateBackendbind9 CRs loading their response policy zone from the configmap
+		for _, cr := range apis.Items {
+			if cr.Spec.ResponsePolicyZone != nil && cr.Spec.ResponsePolicyZone.ConfigMapName == o.GetName() {
+				result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{
+					Namespace: o.GetNamespace(),
+					Name:      cr.Name,
+				}})
+			}
+		}
+
 		// reconcile all DesignateBackendbind9 CRs on multipool configmap change
 		if o.GetName() == designate.MultipoolConfigMapName {
 			Log.Info(fmt.Sprintf("Multipool ConfigMap changed, found %d DesignateBackendbind9 CRs to reconcile", len(apis.Items)))
@@ -306,6 +332,26 @@ func (r *DesignateBackendbind9Reconciler) SetupWithManager(ctx context.Context,
 		return nil
 	}
 
+	// watch the rndc key Secret mounted by the pods, owned by the Designate CR
+	secretFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		if o.GetName() != designate.DesignateBindKeySecret {
+			return nil
+		}
+		apis := &designatev1beta1.DesignateBackendbind9List{}
+		if err := r.List(context.Background(), apis, client.InNamespace(o.GetNamespace())); err != nil {
+			Log.Error(err, "Unable to retrieve Backendbind9 CRs %v")
+			return nil
+		}
+		result := []reconcile.Request{}
+		for _, cr := range apis.Items {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{
+				Namespace: o.GetNamespace(),
+				Name:      cr.Name,
+			}})
+		}
+		return result
+	}
+
 	// index topologyField
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &designatev1beta1.DesignateBackendbind9{}, topologyField, func(rawObj client.Object) []string {
 		// Extract the topology name from the spec, if one is provided
@@ -318,6 +364,12 @@ func (r *DesignateBackendbind9Reconciler) SetupWithManager(ctx context.Context,
 		return err
 	}
 
+	// index podOwnerField, the orchestrated updates look up the pods of
+	// each StatefulSet
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podOwnerField, podOwnerIndex); err != nil {
+		return err
+	}
+
 	// Predicate to only reconcile on pod readiness changes or deletions
 	// This avoids excessive reconciliations during pod startup
 	podReadyPredicate := predicate.Funcs{
@@ -342,13 +394,18 @@ func (r *DesignateBackendbind9Reconciler) SetupWithManager(ctx context.Context,
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&designatev1beta1.DesignateBackendbind9{}).
+		WithOptions(controllerOptions()).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Secret{}).
-		Owns(&corev1.Pod{}, builder.WithPredicates(podReadyPredicate)).
+		Owns(&corev1.Pod{}, builder.WithPredicates(
+			componentPodPredicate(designatebackendbind9.Component), podReadyPredicate)).
 		// watch the config CMs we don't own
 		Watches(&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(configMapFn)).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(secretFn)).
 		Watches(&topologyv1.Topology{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
@@ -462,6 +519,59 @@ func (r *DesignateBackendbind9Reconciler) reconcileNormal(ctx context.Context, i
 	// check for required Designate config maps that should have been created by parent Designate CR
 	//
 
+	// The bind9 pods restart to load a changed response policy zone
+	if instance.Spec.ResponsePolicyZone != nil {
+		rpzHash, err := r.getResponsePolicyZoneHash(ctx, helper, instance)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("Response policy zone ConfigMap %s not found", instance.Spec.ResponsePolicyZone.ConfigMapName))
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.InputReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					condition.InputReadyWaitingMessage))
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		configMapVars[designate.ResponsePolicyZoneHash] = env.SetValue(rpzHash)
+	}
+
+	// The TSIG keys of the secondary servers are rendered into the named
+	// configuration, a changed key restarts the pods through its hash
+	secondaryTSIGSecrets, err := r.getSecondaryTSIGSecrets(ctx, helper, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			Log.Info(fmt.Sprintf("Secondary server TSIG key Secret not found: %s", err))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.InputReadyWaitingMessage))
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.InputReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// The rndc keys are mounted from the DesignateBindKeySecret, a changed key
+	// restarts the pods through its hash
+	ctrlResult, err := getSecret(ctx, helper, instance.Namespace, &instance.Status.Conditions, designate.DesignateBindKeySecret, &configMapVars, "")
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
 	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
 
 	serviceLabels := map[string]string{
@@ -475,7 +585,7 @@ func (r *DesignateBackendbind9Reconciler) reconcileNormal(ctx context.Context, i
 	//
 	// create custom Configmap for this designate volume service
 	//
-	err := r.generateServiceConfigMaps(ctx, helper, instance, &configMapVars, serviceLabels)
+	err = r.generateServiceConfigMaps(ctx, helper, instance, &configMapVars, serviceLabels, secondaryTSIGSecrets)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -487,6 +597,36 @@ func (r *DesignateBackendbind9Reconciler) reconcileNormal(ctx context.Context, i
 	}
 	// Create ConfigMaps - end
 
+	// Every phase of an rndc key rotation rolls the pods so the keys mounted
+	// from the DesignateBindKeySecret are picked up
+	rndcRotationState, err := designate.GetRndcRotationStateFromSecret(ctx, helper.GetClient(), instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	configMapVars[designate.RndcRotationHash] = env.SetValue(rndcRotationState)
+
+	// The default pool servers restart with the control TSIG key once it is
+	// registered in designate
+	controlTSIGHash, err := r.reconcileControlTSIG(ctx, instance, helper)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if instance.Spec.ControlTSIG {
+		configMapVars[designate.ControlTSIGHash] = env.SetValue(controlTSIGHash)
+	}
+
 	//
 	// create hash over all the different input resources to identify if any those changed
 	// and a restart/recreate is required.
@@ -564,7 +704,7 @@ func (r *DesignateBackendbind9Reconciler) reconcileNormal(ctx context.Context, i
 	}
 
 	// Handle service init
-	ctrlResult, err := r.reconcileInit(ctx, instance)
+	ctrlResult, err = r.reconcileInit(ctx, instance)
 	if err != nil {
 		return ctrlResult, err
 	} else if (ctrlResult != ctrl.Result{}) {
@@ -612,6 +752,10 @@ func (r *DesignateBackendbind9Reconciler) reconcileNormal(ctx context.Context, i
 	// normal reconcile tasks
 	//
 
+	if err := r.reconcilePredictableIPs(ctx, instance, helper); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Handle multipool vs single-pool mode orchestration
 	ctrlResult, err = r.reconcileByPoolMode(ctx, instance, helper, inputHash, serviceLabels, serviceAnnotations, topology)
 	if err != nil || (ctrlResult != ctrl.Result{}) {
@@ -623,11 +767,26 @@ func (r *DesignateBackendbind9Reconciler) reconcileNormal(ctx context.Context, i
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcileMetrics(ctx, instance, helper, serviceLabels); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctrlResult, err = r.reconcilePodDisruptionBudget(ctx, instance, helper, serviceLabels)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
 	// We reached the end of the Reconcile, update the Ready condition based on
 	// the sub conditions
 	if instance.Status.Conditions.AllSubConditionIsTrue() {
 		instance.Status.Conditions.MarkTrue(
 			condition.ReadyCondition, condition.ReadyMessage)
+		// the pods run with the rndc keys of this rotation state
+		instance.Status.Hash[designate.RndcRotationHash] = rndcRotationState
+	}
+	if instance.Spec.ControlTSIG && controlTSIGHash == "" {
+		Log.Info("Control TSIG pending, requeueing")
+		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
 	}
 	Log.Info("Reconciled Service successfully")
 	return ctrl.Result{}, nil
@@ -650,7 +809,25 @@ func (r *DesignateBackendbind9Reconciler) reconcileSingleStatefulSet(
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	depl := statefulset.NewStatefulSet(
+
+	// The volume claim templates can't be updated, e.g. to add the log volume,
+	// the StatefulSet is recreated when they change
+	existingSts := &appsv1.StatefulSet{}
+	err = helper.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, existingSts)
+	if err == nil {
+		if changed, propagation := claimTemplatesChange(existingSts, deplDef); changed {
+			Log.Info(fmt.Sprintf("VolumeClaimTemplates changed, deleting StatefulSet %s for recreation", instance.Name))
+			err = helper.GetClient().Delete(ctx, existingSts, client.PropagationPolicy(propagation))
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{Requeue: true}, nil
+		}
+	} else if !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	depl := designate.NewStatefulSet(
 		deplDef,
 		time.Duration(5)*time.Second,
 	)
@@ -673,6 +850,10 @@ func (r *DesignateBackendbind9Reconciler) reconcileSingleStatefulSet(
 			condition.DeploymentReadyRunningMessage))
 		return ctrlResult, nil
 	}
+	// the workload is only rendered into the preview ConfigMap
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
 	deploy := depl.GetStatefulSet()
 	if deploy.Generation == deploy.Status.ObservedGeneration {
 		instance.Status.ReadyCount = deploy.Status.ReadyReplicas
@@ -728,20 +909,158 @@ func (r *DesignateBackendbind9Reconciler) reconcileSingleStatefulSet(
 
 	// Handle pod labeling for predictable IPs only when statefulset is ready
 	// This avoids unnecessary reconciliations when pods haven't changed
+	var labelingResult ctrl.Result
 	if statefulset.IsReady(deploy) && !statefulSetUpdated {
 		config := designate.PodLabelingConfig{
 			ConfigMapName: designate.BindPredIPConfigMap,
 			IPKeyPrefix:   "bind_address_",
+			Recorder:      r.Recorder,
 		}
-		err = designate.HandlePodLabeling(ctx, helper, instance.Name, instance.Namespace, config)
+		podIPs, err := designate.HandlePodLabeling(ctx, helper, instance.Name, instance.Namespace, config)
 		if err != nil {
 			Log.Error(err, "Failed to handle pod labeling")
-			// Don't return error as this is not critical for the main reconcile loop
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "PodLabelingFailed", err.Error())
+			// Not critical for the main reconcile loop, the labeling is retried later
+			labelingResult = ctrl.Result{RequeueAfter: podLabelingRetryInterval}
+		} else {
+			instance.Status.PodIPs = podIPs
 		}
 	}
 
+	ctrlResult, err = r.reconcileOrchestratedUpdate(ctx, instance, helper, []appsv1.StatefulSet{deploy})
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
 	Log.Info("Reconciled single StatefulSet successfully")
-	return ctrl.Result{}, nil
+	return labelingResult, nil
+}
+
+// reconcilePodDisruptionBudget keeps the bind9 pods of all the pools covered
+// by a single PodDisruptionBudget, so maintenance never stops all the DNS
+// servers at once
+func (r *DesignateBackendbind9Reconciler) reconcilePodDisruptionBudget(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+) (ctrl.Result, error) {
+	replicas := *instance.Spec.Replicas
+	multipoolConfig, err := designate.GetMultipoolConfig(ctx, helper.GetClient(), instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if multipoolConfig != nil {
+		replicas = 0
+		for _, pool := range multipoolConfig.Pools {
+			replicas += pool.BindReplicas
+		}
+	}
+
+	return designate.ReconcilePodDisruptionBudget(
+		ctx,
+		helper,
+		instance.Name,
+		instance.Namespace,
+		serviceLabels,
+		replicas,
+		instance.Spec.PodDisruptionBudget,
+	)
+}
+
+// reconcilePredictableIPs checks that every bind9 pod of all the pools has a
+// predictable IP reserved by the Designate controller
+func (r *DesignateBackendbind9Reconciler) reconcilePredictableIPs(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+	helper *helper.Helper,
+) error {
+	multipoolConfig, err := designate.GetMultipoolConfig(ctx, helper.GetClient(), instance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	config := designate.PodLabelingConfig{
+		ConfigMapName: designate.BindPredIPConfigMap,
+		IPKeyPrefix:   "bind_address_",
+	}
+	var missing []string
+	if multipoolConfig == nil {
+		missing, err = designate.MissingPredictableIPs(ctx, helper, instance.Name, instance.Namespace, config, int(*instance.Spec.Replicas))
+		if err != nil {
+			return err
+		}
+	} else {
+		// each pool has its own ConfigMap with remapped IP indexes
+		for poolIdx, pool := range multipoolConfig.Pools {
+			statefulSetName := instance.Name
+			if poolIdx > 0 {
+				config.ConfigMapName = fmt.Sprintf("%s-pool%d", designate.BindPredIPConfigMap, poolIdx)
+				statefulSetName = fmt.Sprintf("%s-pool%d", instance.Name, poolIdx)
+			}
+			poolMissing, err := designate.MissingPredictableIPs(ctx, helper, statefulSetName, instance.Namespace, config, int(pool.BindReplicas))
+			if err != nil {
+				return err
+			}
+			missing = append(missing, poolMissing...)
+		}
+	}
+
+	setPredictableIPsCondition(ctx, helper.GetClient(), r.Recorder, instance, &instance.Status.Conditions, missing)
+	return nil
+}
+
+// reconcileMetrics exposes the bind_exporter sidecars through a Service and a
+// ServiceMonitor when the metrics are enabled, and removes them otherwise
+func (r *DesignateBackendbind9Reconciler) reconcileMetrics(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+	helper *helper.Helper,
+	serviceLabels map[string]string,
+) error {
+	Log := r.GetLogger(ctx)
+
+	metricsName := fmt.Sprintf("%s-metrics", instance.Name)
+	metricsLabels := util.MergeStringMaps(serviceLabels, map[string]string{
+		designate.MetricsServiceLabel: designatebackendbind9.Component,
+	})
+	svc, err := designate.CreateMetricsService(
+		metricsName,
+		instance.Namespace,
+		metricsLabels,
+		serviceLabels,
+		designatebackendbind9.MetricsPort,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !instance.Spec.Metrics.Enabled {
+		if err := designate.DeleteServiceMonitor(ctx, helper, metricsName, instance.Namespace); err != nil {
+			return err
+		}
+		return svc.Delete(ctx, helper)
+	}
+
+	if _, err := svc.CreateOrPatch(ctx, helper); err != nil {
+		return err
+	}
+	installed, err := designate.EnsureServiceMonitor(ctx, helper, designate.ServiceMonitorDetails{
+		Name:        metricsName,
+		Namespace:   instance.Namespace,
+		Labels:      metricsLabels,
+		Selector:    metricsLabels,
+		Port:        designate.MetricsPortName,
+		Interval:    instance.Spec.Metrics.ScrapeInterval,
+		Relabelings: designate.PredictableIPRelabelings(),
+	})
+	if err != nil {
+		return err
+	}
+	if !installed {
+		Log.Info("ServiceMonitor CRD not installed, the bind9 metrics are only exposed through the metrics Service")
+	}
+	return nil
 }
 
 func (r *DesignateBackendbind9Reconciler) reconcileUpdate(ctx context.Context, instance *designatev1beta1.DesignateBackendbind9) (ctrl.Result, error) {
@@ -773,6 +1092,7 @@ func (r *DesignateBackendbind9Reconciler) generateServiceConfigMaps(
 	instance *designatev1beta1.DesignateBackendbind9,
 	envVars *map[string]env.Setter,
 	serviceLabels map[string]string,
+	secondaryTSIGSecrets map[string]string,
 ) error {
 	Log := r.GetLogger(ctx)
 	//
@@ -789,32 +1109,42 @@ func (r *DesignateBackendbind9Reconciler) generateServiceConfigMaps(
 
 	customData[common.CustomServiceConfigFileName] = instance.Spec.CustomServiceConfig
 
-	var nadInfo *designate.NADConfig
-	for _, netAtt := range instance.Spec.NetworkAttachments {
-		nad, err := nad.GetNADWithName(ctx, h, netAtt, instance.Namespace)
-		if err != nil {
-			if k8s_errors.IsNotFound(err) {
-				// Since the net-attach-def CR should have been manually created by the user and referenced in the spec,
-				// we treat this as a warning because it means that the service will not be able to start.
-				Log.Info(fmt.Sprintf("network-attachment-definition %s not found, cannot configure pod", netAtt))
+	templateParameters := make(map[string]any)
+	if instance.Spec.HostNetwork || instance.Spec.PodNetwork {
+		// named listens on the addresses of the node or the pod, the
+		// notifies and the rndc commands can't be restricted to the control
+		// network
+		templateParameters["IPVersion"] = "dual"
+		templateParameters["AllowCIDR"] = "any"
+	} else {
+		var nadInfo, queryInfo *designate.NADConfig
+		for _, netAtt := range instance.Spec.NetworkAttachments {
+			nad, err := nad.GetNADWithName(ctx, h, netAtt, instance.Namespace)
+			if err != nil {
+				if k8s_errors.IsNotFound(err) {
+					// Since the net-attach-def CR should have been manually created by the user and referenced in the spec,
+					// we treat this as a warning because it means that the service will not be able to start.
+					Log.Info(fmt.Sprintf("network-attachment-definition %s not found, cannot configure pod", netAtt))
+					instance.Status.Conditions.Set(condition.FalseCondition(
+						condition.NetworkAttachmentsReadyCondition,
+						condition.ErrorReason,
+						condition.SeverityWarning, // Severity is just warning because while we expect it, we will retry.
+						condition.NetworkAttachmentsReadyErrorMessage,
+						netAtt))
+					return nil
+				}
 				instance.Status.Conditions.Set(condition.FalseCondition(
 					condition.NetworkAttachmentsReadyCondition,
 					condition.ErrorReason,
-					condition.SeverityWarning, // Severity is just warning because while we expect it, we will retry.
+					condition.SeverityError, // We cannot proceed with a broken network attachment.
 					condition.NetworkAttachmentsReadyErrorMessage,
-					netAtt))
-				return nil
+					err.Error()))
+				return err
 			}
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.NetworkAttachmentsReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityError, // We cannot proceed with a broken network attachment.
-				condition.NetworkAttachmentsReadyErrorMessage,
-				err.Error()))
-			return err
-		}
-		if nad.Name == instance.Spec.ControlNetworkName {
-			nadInfo, err = designate.GetNADConfig(nad)
+			if nad.Name != instance.Spec.ControlNetworkName && nad.Name != instance.Spec.QueryNetworkName {
+				continue
+			}
+			nadConfig, err := designate.GetNADConfig(nad)
 			if err != nil {
 				instance.Status.Conditions.Set(condition.FalseCondition(
 					condition.NetworkAttachmentsReadyCondition,
@@ -824,34 +1154,106 @@ func (r *DesignateBackendbind9Reconciler) generateServiceConfigMaps(
 					err.Error()))
 				return err
 			}
-			break
+			if nad.Name == instance.Spec.ControlNetworkName {
+				nadInfo = nadConfig
+			} else {
+				queryInfo = nadConfig
+			}
+			if nadInfo != nil && (instance.Spec.QueryNetworkName == "" || queryInfo != nil) {
+				break
+			}
+		}
+		if nadInfo == nil {
+			return fmt.Errorf("%w: %s", designate.ErrNetworkAttachmentNotFound, instance.Spec.ControlNetworkName)
 		}
+
+		// On dual stack networks the NAD has a range per IP version
+		var cidrs []string
+		for _, ipRange := range nadInfo.IPAM.Ranges() {
+			cidrs = append(cidrs, ipRange.CIDR.String())
+		}
+		cidr := strings.Join(cidrs, "; ")
+		if cidr == "" {
+			err := designate.ErrControlNetworkNotConfigured
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityError,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err))
+			return err
+		}
+		hasIPv4, hasIPv6 := nadInfo.IPAM.HasIPv4(), nadInfo.IPAM.HasIPv6()
+		// the notifies are sent to the servers on the query network
+		if queryInfo != nil {
+			for _, ipRange := range queryInfo.IPAM.Ranges() {
+				cidrs = append(cidrs, ipRange.CIDR.String())
+			}
+			cidr = strings.Join(cidrs, "; ")
+			hasIPv4 = hasIPv4 || queryInfo.IPAM.HasIPv4()
+			hasIPv6 = hasIPv6 || queryInfo.IPAM.HasIPv6()
+		}
+		switch {
+		case hasIPv4 && hasIPv6:
+			templateParameters["IPVersion"] = "dual"
+		case hasIPv4:
+			templateParameters["IPVersion"] = "4"
+		default:
+			templateParameters["IPVersion"] = "6"
+		}
+		templateParameters["AllowCIDR"] = cidr
 	}
-	if nadInfo == nil {
-		return fmt.Errorf("%w: %s", designate.ErrNetworkAttachmentNotFound, instance.Spec.ControlNetworkName)
+	templateParameters["DNSPort"] = designatebackendbind9.DNSPort(&instance.Spec.DesignateBackendbind9SpecBase)
+	// This will need to be replaced by custom config for named.
+	templateParameters["EnableQueryLogging"] = instance.Spec.QueryLogging
+	templateParameters["RateLimit"] = instance.Spec.RateLimit
+	templateParameters["ResponsePolicyZone"] = ""
+	if instance.Spec.ResponsePolicyZone != nil {
+		templateParameters["ResponsePolicyZone"] = instance.Spec.ResponsePolicyZone.Name
 	}
-
-	cidr := nadInfo.IPAM.CIDR.String()
-	if cidr == "" {
-		err := designate.ErrControlNetworkNotConfigured
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.NetworkAttachmentsReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityError,
-			condition.NetworkAttachmentsReadyErrorMessage,
-			err))
-		return err
+	// all the zones are defined in the views once they are set, the zones
+	// managed by designate are added to the view of the pool targets
+	templateParameters["Views"] = instance.Spec.Views
+	templateParameters["GeoIPDirectory"] = ""
+	if instance.Spec.GeoIP != nil {
+		templateParameters["GeoIPDirectory"] = designatebackendbind9.GeoIPDirectory
 	}
-	templateParameters := make(map[string]any)
-	if nadInfo.IPAM.CIDR.Addr().Is4() {
-		templateParameters["IPVersion"] = "4"
-	} else {
-		templateParameters["IPVersion"] = "6"
+	templateParameters["DebugLogging"] = designate.DebugLogging(instance.Spec.Logging)
+	// named logs to the container output unless the files of /var/log/bind are requested,
+	// the logging statement is only honoured as named doesn't run with -g
+	templateParameters["LogToFile"] = instance.Spec.LogTarget == "file"
+	templateParameters["LogRotation"] = ""
+	if instance.Spec.LogTarget == "file" && instance.Spec.LogVolume != nil {
+		// named rotates the files of the persistent log volume itself
+		templateParameters["LogRotation"] = fmt.Sprintf(" versions %d size %s",
+			instance.Spec.LogVolume.Versions, instance.Spec.LogVolume.MaxFileSize)
 	}
-	templateParameters["AllowCIDR"] = cidr
-	// This will need to be replaced by custom config for named.
-	templateParameters["EnableQueryLogging"] = false
 	templateParameters["CustomBindOptions"] = instance.Spec.CustomBindOptions
+	templateParameters["StatisticsEnabled"] = instance.Spec.Metrics.Enabled
+	templateParameters["StatisticsPort"] = designatebackendbind9.StatisticsPort
+
+	// The catalog zone is transferred from the mdns servers, init.sh sets
+	// the name of the catalog zone of the pool
+	templateParameters["CatalogZoneMasters"] = ""
+	if instance.Spec.CatalogZone != nil {
+		mdnsIPs, err := r.getMdnsIPsForTSIG(ctx, h, instance.Namespace)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+		if len(mdnsIPs) == 0 {
+			Log.Info("mdns addresses not allocated yet, catalog zone pending")
+		}
+		var masters []string
+		for _, mdnsIP := range mdnsIPs {
+			masters = append(masters, fmt.Sprintf("%s port %d;", mdnsIP, designatebackendbind9.MdnsPort(&instance.Spec.DesignateBackendbind9SpecBase)))
+		}
+		templateParameters["CatalogZoneMasters"] = strings.Join(masters, " ")
+	}
+
+	// The secondary servers transfer the zones from named, by their TSIG key
+	// or otherwise by their address
+	templateParameters["AllowTransfer"] = designatebackendbind9.AllowTransferACL(instance.Spec.SecondaryServers)
+	templateParameters["SecondaryTSIGKeys"] = designatebackendbind9.SecondaryTSIGConfig(instance.Spec.SecondaryServers, secondaryTSIGSecrets)
 
 	// TODO: we need the rndc key value and pod addr but those are going to be supplied by the init container and
 	// information mounted into the init container.
@@ -898,7 +1300,7 @@ func (r *DesignateBackendbind9Reconciler) generateServiceConfigMaps(
 		},
 	}
 
-	return secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+	return ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
 }
 
 // createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
@@ -914,6 +1316,7 @@ func (r *DesignateBackendbind9Reconciler) createHashOfInputHashes(
 	var hashMap map[string]string
 	changed := false
 	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	instance.Status.InputHashes = inputHashes(mergedMapVars)
 	hash, err := util.ObjectHash(mergedMapVars)
 	if err != nil {
 		return hash, changed, err
@@ -947,6 +1350,49 @@ func (r *DesignateBackendbind9Reconciler) hasMapChanged(
 	return updated, nil
 }
 
+// getResponsePolicyZoneHash returns the hash of the ConfigMap holding the response policy zone
+func (r *DesignateBackendbind9Reconciler) getResponsePolicyZoneHash(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.DesignateBackendbind9,
+) (string, error) {
+	rpz := instance.Spec.ResponsePolicyZone
+	configMap := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: rpz.ConfigMapName, Namespace: instance.GetNamespace()}, configMap)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := configMap.Data[rpz.Key]; !ok {
+		return "", fmt.Errorf("%w: %s not found in ConfigMap %s", ErrResponsePolicyZoneMissing, rpz.Key, rpz.ConfigMapName)
+	}
+	return configmap.Hash(configMap)
+}
+
+// getSecondaryTSIGSecrets returns the secret of the TSIG key of each secondary
+// server using one, by server name
+func (r *DesignateBackendbind9Reconciler) getSecondaryTSIGSecrets(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.DesignateBackendbind9,
+) (map[string]string, error) {
+	secrets := map[string]string{}
+	for _, server := range instance.Spec.SecondaryServers {
+		if !server.HasTSIGKey() {
+			continue
+		}
+		keySecret, _, err := secret.GetSecret(ctx, h, server.TSIGKeySecret, instance.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		key, ok := keySecret.Data[server.TSIGKeySecretKey]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s not found in Secret %s", ErrSecondaryTSIGKeyMissing, server.TSIGKeySecretKey, server.TSIGKeySecret)
+		}
+		secrets[server.Name] = string(key)
+	}
+	return secrets, nil
+}
+
 func (r *DesignateBackendbind9Reconciler) hasSecretChanged(
 	ctx context.Context,
 	h *helper.Helper,
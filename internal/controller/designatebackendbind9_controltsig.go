@@ -0,0 +1,168 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// controlTSIGMdnsHashAnnotation - hash of the mdns addresses the TSIG
+// configuration of the default pool servers was rendered for
+const controlTSIGMdnsHashAnnotation = "designate.openstack.org/mdns-ips-hash"
+
+// reconcileControlTSIG renders the TSIG configuration signing the SOA queries
+// and zone transfers of the default pool servers to designate-mdns into the
+// control TSIG Secret. The key is registered in designate scoped to the default
+// pool, so mdns only serves the zones of that pool to the signed requests.
+//
+// It returns the hash of the configuration, empty if it is disabled or can not
+// be rendered yet because the mdns addresses or the designate API are missing.
+func (r *DesignateBackendbind9Reconciler) reconcileControlTSIG(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+	helper *helper.Helper,
+) (string, error) {
+	Log := r.GetLogger(ctx)
+
+	secretName := instance.Name + designate.ControlTSIGSecretSuffix
+	tsigSecret := &corev1.Secret{}
+	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: secretName, Namespace: instance.Namespace}, tsigSecret)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return "", err
+	}
+	secretFound := err == nil
+
+	if !instance.Spec.ControlTSIG {
+		if secretFound {
+			Log.Info("Control TSIG disabled, deleting its Secret and key")
+			if err := r.deleteControlTSIGKey(ctx, instance, helper); err != nil {
+				// the key is useless without the Secret, do not block on it
+				Log.Error(err, "Failed to delete the control TSIG key from Designate")
+			}
+			if err := helper.GetClient().Delete(ctx, tsigSecret); err != nil && !k8s_errors.IsNotFound(err) {
+				return "", err
+			}
+		}
+		return "", nil
+	}
+
+	mdnsIPs, err := r.getMdnsIPsForTSIG(ctx, helper, instance.Namespace)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			Log.Info("mdns-ip-map ConfigMap not found yet, control TSIG pending")
+			return "", nil
+		}
+		return "", err
+	}
+	mdnsHash, err := util.ObjectHash(mdnsIPs)
+	if err != nil {
+		return "", err
+	}
+
+	// avoid querying the designate API when nothing changed
+	if secretFound && tsigSecret.Annotations[controlTSIGMdnsHashAnnotation] == mdnsHash {
+		return util.ObjectHash(string(tsigSecret.Data["tsigkeys.conf"]))
+	}
+
+	tsigKey, err := r.ensureControlTSIGKey(ctx, instance, helper)
+	if err != nil {
+		Log.Info(fmt.Sprintf("Control TSIG key not available yet: %s", err))
+		return "", nil
+	}
+	tsigConfig := r.generateTSIGConfig(tsigKey, mdnsIPs)
+
+	tsigSecret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), tsigSecret, func() error {
+		tsigSecret.Labels = util.MergeStringMaps(tsigSecret.Labels, map[string]string{
+			"service":   "designate-backendbind9",
+			"component": "designate-backendbind9",
+		})
+		tsigSecret.Annotations = util.MergeStringMaps(tsigSecret.Annotations, map[string]string{
+			controlTSIGMdnsHashAnnotation: mdnsHash,
+		})
+		tsigSecret.Type = corev1.SecretTypeOpaque
+		tsigSecret.Data = map[string][]byte{
+			"tsigkeys.conf": []byte(tsigConfig),
+		}
+		return controllerutil.SetControllerReference(instance, tsigSecret, r.Scheme)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create/update control TSIG secret: %w", err)
+	}
+	Log.Info(fmt.Sprintf("Control TSIG secret %s reconciled", secretName))
+
+	return util.ObjectHash(tsigConfig)
+}
+
+// ensureControlTSIGKey retrieves or creates the TSIG key of the default pool
+func (r *DesignateBackendbind9Reconciler) ensureControlTSIGKey(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+	helper *helper.Helper,
+) (*designate.TSIGKey, error) {
+	osclient, err := designate.GetOpenstackClient(ctx, instance.Namespace, helper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenStack client: %w", err)
+	}
+
+	tsigKey, err := designate.GetTSIGKeyByName(ctx, osclient, designate.ControlTSIGKeyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TSIG key: %w", err)
+	}
+	if tsigKey != nil {
+		return tsigKey, nil
+	}
+
+	secret, err := generateTSIGSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TSIG secret: %w", err)
+	}
+	return designate.CreateTSIGKey(ctx, osclient, designate.CreateTSIGKeyOpts{
+		Name:       designate.ControlTSIGKeyName,
+		Algorithm:  "hmac-sha256",
+		Secret:     secret,
+		Scope:      "POOL",
+		ResourceID: designate.DefaultPoolID,
+	})
+}
+
+// deleteControlTSIGKey removes the TSIG key of the default pool from designate
+func (r *DesignateBackendbind9Reconciler) deleteControlTSIGKey(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+	helper *helper.Helper,
+) error {
+	osclient, err := designate.GetOpenstackClient(ctx, instance.Namespace, helper)
+	if err != nil {
+		return err
+	}
+	return designate.DeleteTSIGKeyByName(ctx, osclient, designate.ControlTSIGKeyName)
+}
@@ -50,7 +50,6 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/statefulset"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 )
@@ -81,6 +80,68 @@ func extractPoolNameFromStatefulSetName(stsName, instanceName string) string {
 // ConfigMap Management
 // ============================================================================
 
+// bindConfigMapLayout describes the content of one bind IP ConfigMap: the
+// pool-local IP holders stored in it, the global bind IP holders they stand
+// for, and the rndc key name of every pod of the pool.
+type bindConfigMapLayout struct {
+	ConfigMapName string
+	LocalNames    []string
+	GlobalNames   []string
+	RNDCKeys      map[string]string
+}
+
+// getBindConfigMapLayouts returns the layout of designate-bind-ip-map, and of
+// the designate-bind-ip-map-poolN ConfigMaps in multipool mode
+func getBindConfigMapLayouts(multipoolConfig *designate.MultipoolConfig, totalBinds int) []bindConfigMapLayout {
+	if multipoolConfig == nil {
+		// In single-pool mode the pod index maps directly to the RNDC key
+		layout := bindConfigMapLayout{
+			ConfigMapName: designate.BindPredIPConfigMap,
+			RNDCKeys:      make(map[string]string),
+		}
+		for i := range totalBinds {
+			name := fmt.Sprintf("bind_address_%d", i)
+			layout.LocalNames = append(layout.LocalNames, name)
+			layout.GlobalNames = append(layout.GlobalNames, name)
+			layout.RNDCKeys[fmt.Sprintf("rndc_key_%d", i)] = fmt.Sprintf("rndc-key-%d", i)
+		}
+		return []bindConfigMapLayout{layout}
+	}
+
+	var layouts []bindConfigMapLayout
+	bindIndex := 0
+	for poolIdx, pool := range multipoolConfig.Pools {
+		// Pool 0 (default pool) uses the main ConfigMap name for backward compatibility
+		// Pool 1+ use numbered suffixes
+		layout := bindConfigMapLayout{
+			ConfigMapName: designate.BindPredIPConfigMap,
+			RNDCKeys:      make(map[string]string),
+		}
+		if poolIdx > 0 {
+			layout.ConfigMapName = fmt.Sprintf("%s-pool%d", designate.BindPredIPConfigMap, poolIdx)
+		}
+		// bind_address_0 in pool1 maps to bind_address_N in global map (where N = total replicas in previous pools)
+		for i := 0; i < int(pool.BindReplicas); i++ {
+			layout.LocalNames = append(layout.LocalNames, fmt.Sprintf("bind_address_%d", i))
+			layout.GlobalNames = append(layout.GlobalNames, fmt.Sprintf("bind_address_%d", bindIndex))
+			// pool-local index i maps to global rndc-key-{bindIndex}
+			layout.RNDCKeys[fmt.Sprintf("rndc_key_%d", i)] = fmt.Sprintf("rndc-key-%d", bindIndex)
+			bindIndex++
+		}
+		layouts = append(layouts, layout)
+	}
+	return layouts
+}
+
+// configMapData returns the ConfigMap content for the global bind IP map
+func (l bindConfigMapLayout) configMapData(updatedBindMap map[string]string) map[string]string {
+	data := maps.Clone(l.RNDCKeys)
+	for i, localName := range l.LocalNames {
+		data[localName] = updatedBindMap[l.GlobalNames[i]]
+	}
+	return data
+}
+
 // reconcileBindConfigMaps manages all bind IP ConfigMaps lifecycle:
 // - designate-bind-ip-map: represents the default pool (pool 0)
 //
@@ -125,27 +186,9 @@ func (r *DesignateReconciler) reconcileMultipoolBindConfigMaps(
 	expectedConfigMaps := make(map[string]bool)
 
 	// Create per-pool ConfigMaps with pool-local indexing
-	bindIndex := 0
-	for poolIdx, pool := range multipoolConfig.Pools {
-		poolBindMap := make(map[string]string)
-
-		// Map pool-local bind indexes to global bind IPs and RNDC keys
-		for i := 0; i < int(pool.BindReplicas); i++ {
-			// bind_address_0 in pool1 maps to bind_address_N in global map (where N = total replicas in previous pools)
-			poolBindMap[fmt.Sprintf("bind_address_%d", i)] = updatedBindMap[fmt.Sprintf("bind_address_%d", bindIndex)]
-			// Add RNDC key name mapping (pool-local index i maps to global rndc-key-{bindIndex})
-			poolBindMap[fmt.Sprintf("rndc_key_%d", i)] = fmt.Sprintf("rndc-key-%d", bindIndex)
-			bindIndex++
-		}
-
-		// Pool 0 (default pool) uses the main ConfigMap name for backward compatibility
-		// Pool 1+ use numbered suffixes
-		var poolConfigMapName string
-		if poolIdx == 0 {
-			poolConfigMapName = designate.BindPredIPConfigMap // "designate-bind-ip-map"
-		} else {
-			poolConfigMapName = fmt.Sprintf("%s-pool%d", designate.BindPredIPConfigMap, poolIdx)
-		}
+	for poolIdx, layout := range getBindConfigMapLayouts(multipoolConfig, len(updatedBindMap)) {
+		poolBindMap := layout.configMapData(updatedBindMap)
+		poolConfigMapName := layout.ConfigMapName
 		expectedConfigMaps[poolConfigMapName] = true
 
 		poolBindConfigMap := &corev1.ConfigMap{
@@ -156,16 +199,19 @@ func (r *DesignateReconciler) reconcileMultipoolBindConfigMaps(
 		}
 
 		_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), poolBindConfigMap, func() error {
+			if err := designate.AdoptControllerReference(ctx, helper.GetClient(), instance, poolBindConfigMap, bindLabels, helper.GetScheme()); err != nil {
+				return err
+			}
 			poolBindConfigMap.Labels = util.MergeStringMaps(poolBindConfigMap.Labels, bindLabels)
 			poolBindConfigMap.Data = poolBindMap
-			return controllerutil.SetControllerReference(instance, poolBindConfigMap, helper.GetScheme())
+			return nil
 		})
 
 		if err != nil {
 			Log.Error(err, fmt.Sprintf("Unable to create ConfigMap for pool%d bind IPs", poolIdx))
 			return ctrl.Result{}, err
 		}
-		Log.Info(fmt.Sprintf("Pool%d bind ConfigMap (%s) reconciled successfully with %d bind instances", poolIdx, poolConfigMapName, pool.BindReplicas))
+		Log.Info(fmt.Sprintf("Pool%d bind ConfigMap (%s) reconciled successfully with %d bind instances", poolIdx, poolConfigMapName, len(layout.LocalNames)))
 	}
 
 	// Clean up orphaned numbered pool ConfigMaps (pools removed from multipool config)
@@ -206,16 +252,7 @@ func (r *DesignateReconciler) reconcileSinglePoolBindConfigMap(
 	Log := r.GetLogger(ctx)
 
 	// In single-pool mode, all binds go into the default ConfigMap with RNDC key mappings
-	// Create the ConfigMap data with both IPs and RNDC keys
-	singlePoolMap := make(map[string]string)
-	for _, key := range slices.Sorted(maps.Keys(updatedBindMap)) {
-		singlePoolMap[key] = updatedBindMap[key]
-	}
-
-	// Add RNDC key mappings (in single-pool, pod index maps directly to RNDC key)
-	for i := 0; i < len(updatedBindMap); i++ {
-		singlePoolMap[fmt.Sprintf("rndc_key_%d", i)] = fmt.Sprintf("rndc-key-%d", i)
-	}
+	singlePoolMap := getBindConfigMapLayouts(nil, len(updatedBindMap))[0].configMapData(updatedBindMap)
 
 	// Create/update the default pool ConfigMap
 	bindConfigMap := &corev1.ConfigMap{
@@ -226,9 +263,12 @@ func (r *DesignateReconciler) reconcileSinglePoolBindConfigMap(
 	}
 
 	_, err := controllerutil.CreateOrPatch(ctx, helper.GetClient(), bindConfigMap, func() error {
+		if err := designate.AdoptControllerReference(ctx, helper.GetClient(), instance, bindConfigMap, bindLabels, helper.GetScheme()); err != nil {
+			return err
+		}
 		bindConfigMap.Labels = util.MergeStringMaps(bindConfigMap.Labels, bindLabels)
 		bindConfigMap.Data = singlePoolMap
-		return controllerutil.SetControllerReference(instance, bindConfigMap, helper.GetScheme())
+		return nil
 	})
 
 	if err != nil {
@@ -295,17 +335,15 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolServices(
 			expectedServices[serviceName] = true
 
 			// Get override spec for this service (use empty if not configured)
-			var overrideSpec service.OverrideSpec
-			if serviceIdx < len(instance.Spec.Override.Services) {
-				overrideSpec = instance.Spec.Override.Services[serviceIdx]
-			}
+			overrideSpec := designate.DNSServiceOverride(
+				instance.Spec.Override.Service, instance.Spec.Override.Services, serviceIdx)
 
 			svc, err := designate.CreateDNSService(
 				serviceName,
 				instance.Namespace,
 				&overrideSpec,
 				serviceLabels,
-				53,
+				designatebackendbind9.DNSPort(&instance.Spec.DesignateBackendbind9SpecBase),
 			)
 			if err != nil {
 				instance.Status.Conditions.Set(condition.FalseCondition(
@@ -385,6 +423,8 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolStatefulSets(
 
 	// Track expected StatefulSet names for cleanup
 	expectedStatefulSets := make(map[string]bool)
+	// The StatefulSets of all the pools take part in an orchestrated update
+	poolStatefulSets := []appsv1.StatefulSet{}
 
 	// Get TSIG secret resourceVersion to include in pod annotations for automatic restarts
 	// This ensures non-default pool pods restart when TSIG keys change
@@ -406,6 +446,9 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolStatefulSets(
 		// Create a modified instance for this pool with pool-specific replicas
 		poolInstance := instance.DeepCopy()
 		poolInstance.Spec.Replicas = &pool.BindReplicas
+		if poolInstance.Spec.CatalogZone != nil {
+			poolInstance.Spec.CatalogZone.FQDN = designate.CatalogZoneFQDN(instance.Spec.CatalogZone.FQDN, pool.Name)
+		}
 		// Pool 0 (default) uses instance.Name for backwards compatibility
 		// Pool 1+ use instance.Name-pool1, pool2, etc.
 		var poolStatefulSetName string
@@ -450,25 +493,23 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolStatefulSets(
 		err = helper.GetClient().Get(ctx, types.NamespacedName{Name: poolStatefulSetName, Namespace: poolInstance.Namespace}, existingSts)
 		if err == nil {
 			// Check if VolumeClaimTemplates differ (immutable field)
-			if len(existingSts.Spec.VolumeClaimTemplates) > 0 && len(deplDef.Spec.VolumeClaimTemplates) > 0 {
-				if existingSts.Spec.VolumeClaimTemplates[0].Name != deplDef.Spec.VolumeClaimTemplates[0].Name {
-					Log.Info(fmt.Sprintf("VolumeClaimTemplate name changed, deleting StatefulSet %s for recreation", poolStatefulSetName))
-					err = helper.GetClient().Delete(ctx, existingSts)
-					if err != nil {
-						Log.Error(err, "Failed to delete StatefulSet for recreation")
-						return ctrl.Result{}, err
-					}
-					// Mark that we need to requeue, but continue processing other pools
-					requeueNeeded = true
-					requeueResult = ctrl.Result{Requeue: true}
-					continue
+			if changed, propagation := claimTemplatesChange(existingSts, deplDef); changed {
+				Log.Info(fmt.Sprintf("VolumeClaimTemplates changed, deleting StatefulSet %s for recreation", poolStatefulSetName))
+				err = helper.GetClient().Delete(ctx, existingSts, client.PropagationPolicy(propagation))
+				if err != nil {
+					Log.Error(err, "Failed to delete StatefulSet for recreation")
+					return ctrl.Result{}, err
 				}
+				// Mark that we need to requeue, but continue processing other pools
+				requeueNeeded = true
+				requeueResult = ctrl.Result{Requeue: true}
+				continue
 			}
 		} else if !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
 
-		depl := statefulset.NewStatefulSet(
+		depl := designate.NewStatefulSet(
 			deplDef,
 			time.Duration(5)*time.Second,
 		)
@@ -492,8 +533,13 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolStatefulSets(
 			requeueNeeded = true
 			requeueResult = ctrlResult
 		}
+		if designate.IsPreview(instance) {
+			// only rendered into the preview ConfigMap
+			continue
+		}
 
 		deploy := depl.GetStatefulSet()
+		poolStatefulSets = append(poolStatefulSets, deploy)
 
 		// If generation doesn't match, StatefulSet is still being updated
 		if deploy.Generation != deploy.Status.ObservedGeneration {
@@ -510,6 +556,18 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolStatefulSets(
 			allDeploymentsReady = false
 		}
 	}
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	rolloutResult, err := r.reconcileOrchestratedUpdate(ctx, instance, helper, poolStatefulSets)
+	if err != nil {
+		return ctrl.Result{}, err
+	} else if (rolloutResult != ctrl.Result{}) {
+		allDeploymentsReady = false
+		requeueNeeded = true
+		requeueResult = rolloutResult
+	}
 
 	// Cleanup orphaned StatefulSets (pools that were removed from config)
 	// This always runs, even if pools are still being created/updated
@@ -542,6 +600,7 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolStatefulSets(
 	// Handle pod labeling for predictable IPs only when all deployments are ready
 	// In multipool mode, each pool has its own ConfigMap with remapped IP indexes
 	if allDeploymentsReady {
+		podIPs := map[string]string{}
 		for poolIdx, pool := range multipoolConfig.Pools {
 			// Determine ConfigMap name for this pool
 			var poolConfigMapName string
@@ -562,13 +621,21 @@ func (r *DesignateBackendbind9Reconciler) reconcileMultipoolStatefulSets(
 			config := designate.PodLabelingConfig{
 				ConfigMapName: poolConfigMapName,
 				IPKeyPrefix:   "bind_address_",
+				Recorder:      r.Recorder,
 			}
-			err = designate.HandlePodLabeling(ctx, helper, poolStatefulSetName, instance.Namespace, config)
+			poolPodIPs, err := designate.HandlePodLabeling(ctx, helper, poolStatefulSetName, instance.Namespace, config)
 			if err != nil {
 				Log.Error(err, fmt.Sprintf("Failed to handle pod labeling for pool %s", pool.Name))
-				// Don't return error as this is not critical for the main reconcile loop
+				r.Recorder.Event(instance, corev1.EventTypeWarning, "PodLabelingFailed", err.Error())
+				// Not critical for the main reconcile loop, the labeling is retried later
+				if !requeueNeeded {
+					requeueNeeded = true
+					requeueResult = ctrl.Result{RequeueAfter: podLabelingRetryInterval}
+				}
 			}
+			maps.Copy(podIPs, poolPodIPs)
 		}
+		instance.Status.PodIPs = podIPs
 	}
 
 	Log.Info(fmt.Sprintf("Reconciled multipool StatefulSets successfully, total ready: %d", totalReadyCount))
@@ -855,17 +922,24 @@ func (r *DesignateBackendbind9Reconciler) getMdnsIPsForTSIG(
 		return nil, fmt.Errorf("failed to get mdns IP ConfigMap: %w", err)
 	}
 
-	// Extract IPs from ConfigMap data (mdns_address_0, mdns_address_1, etc.)
+	mdnsIPs := getMdnsIPsFromMap(mdnsIPMap.Data)
+
+	Log.Info(fmt.Sprintf("Found %d mdns IPs for TSIG server blocks", len(mdnsIPs)))
+	return mdnsIPs, nil
+}
+
+// getMdnsIPsFromMap extracts the IPs from the mdns ConfigMap data
+// (mdns_address_0, mdns_address_1, etc.). On dual stack networks every mdns
+// pod gets a server block per address.
+func getMdnsIPsFromMap(data map[string]string) []string {
 	var mdnsIPs []string
-	for _, key := range slices.Sorted(maps.Keys(mdnsIPMap.Data)) {
-		if strings.HasPrefix(key, "mdns_address_") && mdnsIPMap.Data[key] != "" {
-			mdnsIPs = append(mdnsIPs, mdnsIPMap.Data[key])
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		if strings.HasPrefix(key, "mdns_address_") && data[key] != "" {
+			mdnsIPs = append(mdnsIPs, designate.SplitPredictableIPs(data[key])...)
 		}
 	}
 	sort.Strings(mdnsIPs) // Sort for consistent ordering
-
-	Log.Info(fmt.Sprintf("Found %d mdns IPs for TSIG server blocks", len(mdnsIPs)))
-	return mdnsIPs, nil
+	return mdnsIPs
 }
 
 // ensureSharedTSIGKey retrieves or creates a shared TSIG key for all non-default pools
@@ -1030,30 +1104,22 @@ func (r *DesignateBackendbind9Reconciler) getDesignateCR(
 	helper *helper.Helper,
 	instance *designatev1beta1.DesignateBackendbind9,
 ) (*designatev1beta1.Designate, error) {
-	// Find the Designate owner reference
-	var designateName string
-	for _, ownerRef := range instance.GetOwnerReferences() {
-		if ownerRef.Kind == "Designate" {
-			designateName = ownerRef.Name
-			break
-		}
-	}
-
+	designateName := designate.GetOwningDesignateName(instance)
 	if designateName == "" {
 		return nil, ErrNoDesignateCRFound
 	}
 
 	// Get the Designate CR by name
-	designate := &designatev1beta1.Designate{}
+	designateCR := &designatev1beta1.Designate{}
 	err := helper.GetClient().Get(ctx, types.NamespacedName{
 		Name:      designateName,
 		Namespace: instance.Namespace,
-	}, designate)
+	}, designateCR)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Designate CR %s: %w", designateName, err)
 	}
 
-	return designate, nil
+	return designateCR, nil
 }
 
 // checkPoolHasZones checks if a pool has any active DNS zones using gophercloud
@@ -1497,17 +1563,15 @@ func (r *DesignateBackendbind9Reconciler) createSinglePoolServices(
 	serviceLabels map[string]string,
 ) error {
 	for i := 0; i < int(*instance.Spec.Replicas); i++ {
-		var overrideSpec service.OverrideSpec
-		if i < len(instance.Spec.Override.Services) {
-			overrideSpec = instance.Spec.Override.Services[i]
-		}
+		overrideSpec := designate.DNSServiceOverride(
+			instance.Spec.Override.Service, instance.Spec.Override.Services, i)
 
 		svc, err := designate.CreateDNSService(
 			fmt.Sprintf("designate-backendbind9-%d", i),
 			instance.Namespace,
 			&overrideSpec,
 			serviceLabels,
-			53,
+			designatebackendbind9.DNSPort(&instance.Spec.DesignateBackendbind9SpecBase),
 		)
 		if err != nil {
 			instance.Status.Conditions.Set(condition.FalseCondition(
@@ -1532,3 +1596,26 @@ func (r *DesignateBackendbind9Reconciler) createSinglePoolServices(
 	instance.Status.Conditions.MarkTrue(condition.CreateServiceReadyCondition, condition.CreateServiceReadyMessage)
 	return nil
 }
+
+// claimTemplatesChange returns whether the volume claim templates of a StatefulSet, which can't be updated,
+// changed and how its deletion must propagate. The pods and their PVCs are orphaned, to be adopted by the
+// recreated StatefulSet, unless the zone data claim itself was renamed.
+func claimTemplatesChange(existing *appsv1.StatefulSet, desired *appsv1.StatefulSet) (bool, metav1.DeletionPropagation) {
+	existingNames := claimTemplateNames(existing)
+	desiredNames := claimTemplateNames(desired)
+	if slices.Equal(existingNames, desiredNames) {
+		return false, ""
+	}
+	if len(existingNames) > 0 && len(desiredNames) > 0 && existingNames[0] == desiredNames[0] {
+		return true, metav1.DeletePropagationOrphan
+	}
+	return true, metav1.DeletePropagationBackground
+}
+
+func claimTemplateNames(sts *appsv1.StatefulSet) []string {
+	names := make([]string, len(sts.Spec.VolumeClaimTemplates))
+	for i, claim := range sts.Spec.VolumeClaimTemplates {
+		names[i] = claim.Name
+	}
+	return names
+}
@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_getBindConfigMapLayouts(t *testing.T) {
+	updatedBindMap := map[string]string{
+		"bind_address_0": "172.28.0.40",
+		"bind_address_1": "172.28.0.41",
+		"bind_address_2": "172.28.0.42",
+	}
+
+	t.Run("single pool", func(t *testing.T) {
+		layouts := getBindConfigMapLayouts(nil, 3)
+		if len(layouts) != 1 || layouts[0].ConfigMapName != designate.BindPredIPConfigMap {
+			t.Fatalf("unexpected layouts %v", layouts)
+		}
+		want := map[string]string{
+			"bind_address_0": "172.28.0.40",
+			"bind_address_1": "172.28.0.41",
+			"bind_address_2": "172.28.0.42",
+			"rndc_key_0":     "rndc-key-0",
+			"rndc_key_1":     "rndc-key-1",
+			"rndc_key_2":     "rndc-key-2",
+		}
+		if got := layouts[0].configMapData(updatedBindMap); !reflect.DeepEqual(got, want) {
+			t.Errorf("configMapData() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("multipool", func(t *testing.T) {
+		multipoolConfig := &designate.MultipoolConfig{
+			Pools: []designate.PoolConfig{
+				{Name: "default", BindReplicas: 1},
+				{Name: "pool1", BindReplicas: 2},
+			},
+		}
+		layouts := getBindConfigMapLayouts(multipoolConfig, 3)
+		if len(layouts) != 2 {
+			t.Fatalf("expected a layout per pool, got %v", layouts)
+		}
+		if layouts[1].ConfigMapName != designate.BindPredIPConfigMap+"-pool1" {
+			t.Errorf("unexpected pool1 ConfigMap name %s", layouts[1].ConfigMapName)
+		}
+		want := map[string]string{
+			"bind_address_0": "172.28.0.41",
+			"bind_address_1": "172.28.0.42",
+			"rndc_key_0":     "rndc-key-1",
+			"rndc_key_1":     "rndc-key-2",
+		}
+		if got := layouts[1].configMapData(updatedBindMap); !reflect.DeepEqual(got, want) {
+			t.Errorf("configMapData() = %v, want %v", got, want)
+		}
+	})
+}
+
+func Test_getMdnsIPsFromMap(t *testing.T) {
+	data := map[string]string{
+		"mdns_address_0": "172.28.0.31,fd00:bbbb::31",
+		"mdns_address_1": "172.28.0.32,fd00:bbbb::32",
+	}
+	want := []string{"172.28.0.31", "172.28.0.32", "fd00:bbbb::31", "fd00:bbbb::32"}
+	if got := getMdnsIPsFromMap(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("getMdnsIPsFromMap() = %v, want %v", got, want)
+	}
+}
+
+func Test_claimTemplatesChange(t *testing.T) {
+	sts := func(names ...string) *appsv1.StatefulSet {
+		s := &appsv1.StatefulSet{}
+		for _, name := range names {
+			s.Spec.VolumeClaimTemplates = append(s.Spec.VolumeClaimTemplates, corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+			})
+		}
+		return s
+	}
+
+	if changed, _ := claimTemplatesChange(sts("data"), sts("data")); changed {
+		t.Errorf("expected unchanged claims")
+	}
+	changed, propagation := claimTemplatesChange(sts("data"), sts("data", "log"))
+	if !changed || propagation != metav1.DeletePropagationOrphan {
+		t.Errorf("expected the PVCs to be kept when adding a claim, got %v %v", changed, propagation)
+	}
+	changed, propagation = claimTemplatesChange(sts("data", "log"), sts("data"))
+	if !changed || propagation != metav1.DeletePropagationOrphan {
+		t.Errorf("expected the PVCs to be kept when removing a claim, got %v %v", changed, propagation)
+	}
+	changed, propagation = claimTemplatesChange(sts("data"), sts("pool-data"))
+	if !changed || propagation != metav1.DeletePropagationBackground {
+		t.Errorf("expected the PVCs to be deleted when renaming the data claim, got %v %v", changed, propagation)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rolloutPollInterval is how often an orchestrated update checks the pods
+// while one of them is being replaced
+const rolloutPollInterval = 5 * time.Second
+
+// reconcileOrchestratedUpdate replaces the outdated pods of the bind9
+// StatefulSets, which use the OnDelete update strategy, one at a time.
+// The StatefulSets are the ones of all the pools of the CR, so at most one
+// bind9 server is down at any time.
+func (r *DesignateBackendbind9Reconciler) reconcileOrchestratedUpdate(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+	helper *helper.Helper,
+	statefulSets []appsv1.StatefulSet,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	if !instance.Spec.OrchestratedUpdate || len(statefulSets) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	var pods []corev1.Pod
+	for _, sts := range statefulSets {
+		podList := &corev1.PodList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(instance.Namespace),
+			client.MatchingFields{podOwnerField: sts.Name},
+		}
+		if err := helper.GetClient().List(ctx, podList, listOpts...); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to list bind9 pods: %w", err)
+		}
+		pods = append(pods, podList.Items...)
+	}
+
+	pod, wait := nextPodToUpdate(statefulSets, pods)
+	if wait {
+		Log.Info("Orchestrated update waiting for all bind9 pods to be ready")
+		return ctrl.Result{RequeueAfter: rolloutPollInterval}, nil
+	}
+	if pod == nil {
+		return ctrl.Result{}, nil
+	}
+
+	// The preStop hook lets the zone transfers of the pod complete before named stops
+	Log.Info(fmt.Sprintf("Orchestrated update replacing bind9 pod %s", pod.Name))
+	if err := helper.GetClient().Delete(ctx, pod); err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to delete bind9 pod %s: %w", pod.Name, err)
+	}
+	return ctrl.Result{RequeueAfter: rolloutPollInterval}, nil
+}
+
+// nextPodToUpdate returns the pod an orchestrated update replaces next: the
+// outdated pod with the highest ordinal of the first StatefulSet having one.
+// wait is true while a pod of the StatefulSets is missing, terminating or not
+// ready, nothing is replaced until it recovers.
+func nextPodToUpdate(statefulSets []appsv1.StatefulSet, pods []corev1.Pod) (*corev1.Pod, bool) {
+	owned := map[string][]*corev1.Pod{}
+	for i := range pods {
+		owner := metav1.GetControllerOf(&pods[i])
+		if owner == nil || owner.Kind != "StatefulSet" {
+			continue
+		}
+		owned[owner.Name] = append(owned[owner.Name], &pods[i])
+	}
+
+	var next *corev1.Pod
+	for i := range statefulSets {
+		sts := &statefulSets[i]
+		if sts.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType {
+			continue
+		}
+		if sts.Status.ObservedGeneration != sts.Generation {
+			return nil, true
+		}
+		if sts.Spec.Replicas != nil && len(owned[sts.Name]) < int(*sts.Spec.Replicas) {
+			return nil, true
+		}
+		var outdated *corev1.Pod
+		for _, pod := range owned[sts.Name] {
+			if !pod.DeletionTimestamp.IsZero() || !isPodReady(pod) {
+				return nil, true
+			}
+			if sts.Status.UpdateRevision == "" || pod.Labels[appsv1.StatefulSetRevisionLabel] == sts.Status.UpdateRevision {
+				continue
+			}
+			if outdated == nil || podOrdinal(pod.Name) > podOrdinal(outdated.Name) {
+				outdated = pod
+			}
+		}
+		if next == nil {
+			next = outdated
+		}
+	}
+	return next, false
+}
+
+// podOrdinal returns the ordinal of a StatefulSet pod from its name
+func podOrdinal(name string) int {
+	ordinal, err := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+	if err != nil {
+		return -1
+	}
+	return ordinal
+}
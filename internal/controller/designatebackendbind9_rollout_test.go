@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_nextPodToUpdate(t *testing.T) {
+	newSts := func(name string, replicas int32) appsv1.StatefulSet {
+		return appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:       ptr.To(replicas),
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+			},
+			Status: appsv1.StatefulSetStatus{UpdateRevision: name + "-new"},
+		}
+	}
+	newPod := func(sts string, ordinal int, revision string, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("%s-%d", sts, ordinal),
+				Labels:          map[string]string{appsv1.StatefulSetRevisionLabel: sts + "-" + revision},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: sts, Controller: ptr.To(true)}},
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+	statefulSets := []appsv1.StatefulSet{newSts("bind9", 2), newSts("bind9-pool1", 2)}
+
+	tests := []struct {
+		name     string
+		pods     []corev1.Pod
+		wantPod  string
+		wantWait bool
+	}{
+		{
+			name: "up to date",
+			pods: []corev1.Pod{
+				newPod("bind9", 0, "new", true), newPod("bind9", 1, "new", true),
+				newPod("bind9-pool1", 0, "new", true), newPod("bind9-pool1", 1, "new", true),
+			},
+		},
+		{
+			name: "highest ordinal of the first pool first",
+			pods: []corev1.Pod{
+				newPod("bind9", 0, "old", true), newPod("bind9", 1, "old", true),
+				newPod("bind9-pool1", 0, "old", true), newPod("bind9-pool1", 1, "old", true),
+			},
+			wantPod: "bind9-1",
+		},
+		{
+			name: "next pool once the first one is updated",
+			pods: []corev1.Pod{
+				newPod("bind9", 0, "new", true), newPod("bind9", 1, "new", true),
+				newPod("bind9-pool1", 0, "old", true), newPod("bind9-pool1", 1, "new", true),
+			},
+			wantPod: "bind9-pool1-0",
+		},
+		{
+			name: "pod not ready in another pool",
+			pods: []corev1.Pod{
+				newPod("bind9", 0, "old", true), newPod("bind9", 1, "old", true),
+				newPod("bind9-pool1", 0, "new", true), newPod("bind9-pool1", 1, "new", false),
+			},
+			wantWait: true,
+		},
+		{
+			name: "pod missing",
+			pods: []corev1.Pod{
+				newPod("bind9", 0, "old", true),
+				newPod("bind9-pool1", 0, "new", true), newPod("bind9-pool1", 1, "new", true),
+			},
+			wantWait: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod, wait := nextPodToUpdate(statefulSets, tt.pods)
+			if wait != tt.wantWait {
+				t.Errorf("nextPodToUpdate() wait = %v, want %v", wait, tt.wantWait)
+			}
+			got := ""
+			if pod != nil {
+				got = pod.Name
+			}
+			if got != tt.wantPod {
+				t.Errorf("nextPodToUpdate() pod = %q, want %q", got, tt.wantPod)
+			}
+		})
+	}
+}
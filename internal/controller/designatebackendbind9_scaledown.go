@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deployedBindReplicas returns the number of bind9 servers currently
+// deployed by the DesignateBackendbind9 CR of the instance. While a scale
+// down is in progress this is more than the requested replicas, the servers
+// being removed keep their predictable IPs and RNDC keys until their pods
+// are gone. Multipool deployments renumber the servers of the following pools
+// when a pool shrinks, they are not held and 0 is returned.
+func (r *DesignateReconciler) deployedBindReplicas(
+	ctx context.Context,
+	instance *designatev1beta1.Designate,
+	multipoolConfig *designate.MultipoolConfig,
+) (int, error) {
+	if multipoolConfig != nil {
+		return 0, nil
+	}
+
+	bind9 := &designatev1beta1.DesignateBackendbind9{}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      fmt.Sprintf("%s-backendbind9", instance.Name),
+		Namespace: instance.Namespace,
+	}, bind9)
+	if k8s_errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	deployed := int(bind9.Status.ReadyCount)
+	if bind9.Spec.Replicas != nil {
+		deployed = max(deployed, int(*bind9.Spec.Replicas))
+	}
+	return deployed, nil
+}
+
+// activeBindAddresses returns the addresses of the bind9 servers to keep in
+// pools.yaml, the servers of bindMap numbered count or more are being
+// removed.
+func activeBindAddresses(bindMap map[string]string, count int) map[string]string {
+	active := make(map[string]string)
+	for name, address := range bindMap {
+		index, err := strconv.Atoi(strings.TrimPrefix(name, "bind_address_"))
+		if err == nil && index >= count {
+			continue
+		}
+		active[name] = address
+	}
+	return active
+}
@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_activeBindAddresses(t *testing.T) {
+	bindMap := map[string]string{
+		"bind_address_0": "172.28.0.40",
+		"bind_address_1": "172.28.0.41",
+		"bind_address_2": "172.28.0.42",
+	}
+
+	tests := []struct {
+		name  string
+		count int
+		want  map[string]string
+	}{
+		{
+			name:  "no scale down",
+			count: 3,
+			want:  bindMap,
+		},
+		{
+			name:  "highest servers removed",
+			count: 1,
+			want:  map[string]string{"bind_address_0": "172.28.0.40"},
+		},
+		{
+			name:  "all servers removed",
+			count: 0,
+			want:  map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activeBindAddresses(bindMap, tt.count); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("activeBindAddresses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
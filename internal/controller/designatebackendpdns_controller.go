@@ -0,0 +1,851 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/go-logr/logr"
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	designatebackendpdns "github.com/openstack-k8s-operators/designate-operator/internal/designatebackendpdns"
+	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/backup"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/statefulset"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+)
+
+// GetClient -
+func (r *DesignateBackendPDNSReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetKClient -
+func (r *DesignateBackendPDNSReconciler) GetKClient() kubernetes.Interface {
+	return r.Kclient
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *DesignateBackendPDNSReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("DesignateBackendPDNS")
+}
+
+// GetScheme -
+func (r *DesignateBackendPDNSReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// DesignateBackendPDNSReconciler reconciles a DesignateBackendPDNS object
+type DesignateBackendPDNSReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendpdnses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendpdnses/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatebackendpdnses/finalizers,verbs=update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;create;update;patch;delete;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+// +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *DesignateBackendPDNSReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateBackendPDNS", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
+	Log := r.GetLogger(ctx)
+
+	// Fetch the DesignateBackendPDNS instance
+	instance := &designatev1beta1.DesignateBackendPDNS{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers. Return and don't requeue.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// initialize status if Conditions is nil, but do not reset if it already
+	// exists
+	isNewInstance := instance.Status.Conditions == nil
+	if isNewInstance {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Always patch the instance status when exiting this function so we can
+	// persist any changes.
+	defer func() {
+		// Don't update the status, if Reconciler Panics
+		if rc := recover(); rc != nil {
+			Log.Info(fmt.Sprintf("Panic during reconcile %v\n", rc))
+			panic(rc)
+		}
+		condition.RestoreLastTransitionTimes(
+			&instance.Status.Conditions, savedConditions)
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	//
+	// initialize status
+	//
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
+		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
+	)
+
+	// Init Topology condition if there's a reference
+	if instance.Spec.TopologyRef != nil {
+		c := condition.UnknownCondition(condition.TopologyReadyCondition, condition.InitReason, condition.TopologyReadyInitMessage)
+		cl.Set(c)
+	}
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) {
+		return ctrl.Result{}, nil
+	}
+
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = map[string]string{}
+	}
+	if instance.Status.NetworkAttachments == nil {
+		instance.Status.NetworkAttachments = map[string][]string{}
+	}
+
+	// Handle service delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	// Handle non-deleted clusters
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DesignateBackendPDNSReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	Log := r.GetLogger(ctx)
+
+	// watch for configmap where the CM owner label AND the CR.Spec.ManagingCrName label matches
+	configMapFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		result := []reconcile.Request{}
+
+		// get all BackendPDNS CRs
+		apis := &designatev1beta1.DesignateBackendPDNSList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(o.GetNamespace()),
+		}
+		if err := r.List(context.Background(), apis, listOpts...); err != nil {
+			Log.Error(err, "Unable to retrieve BackendPDNS CRs %v")
+			return nil
+		}
+
+		label := o.GetLabels()
+		if l, ok := label[labels.GetOwnerNameLabelSelector(labels.GetGroupLabel(designate.ServiceName))]; ok {
+			for _, cr := range apis.Items {
+				// return reconcil event for the CR where the CM owner label AND
+				// the parentDesignateName matches
+				if l == designate.GetOwningDesignateName(&cr) {
+					// return namespace and Name of CR
+					name := client.ObjectKey{
+						Namespace: o.GetNamespace(),
+						Name:      cr.Name,
+					}
+					Log.Info(fmt.Sprintf("ConfigMap object %s and CR %s marked with label: %s", o.GetName(), cr.Name, l))
+					result = append(result, reconcile.Request{NamespacedName: name})
+				}
+			}
+		}
+		if len(result) > 0 {
+			return result
+		}
+		return nil
+	}
+
+	// watch the API key Secret mounted by the pods, owned by the Designate CR
+	secretFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		apis := &designatev1beta1.DesignateBackendPDNSList{}
+		if err := r.List(context.Background(), apis, client.InNamespace(o.GetNamespace())); err != nil {
+			Log.Error(err, "Unable to retrieve BackendPDNS CRs %v")
+			return nil
+		}
+		result := []reconcile.Request{}
+		for _, cr := range apis.Items {
+			if cr.Spec.APIKeySecret == o.GetName() {
+				result = append(result, reconcile.Request{NamespacedName: client.ObjectKey{
+					Namespace: o.GetNamespace(),
+					Name:      cr.Name,
+				}})
+			}
+		}
+		return result
+	}
+
+	// index topologyField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &designatev1beta1.DesignateBackendPDNS{}, topologyField, func(rawObj client.Object) []string {
+		// Extract the topology name from the spec, if one is provided
+		cr := rawObj.(*designatev1beta1.DesignateBackendPDNS)
+		if cr.Spec.TopologyRef == nil {
+			return nil
+		}
+		return []string{cr.Spec.TopologyRef.Name}
+	}); err != nil {
+		return err
+	}
+
+	// Predicate to only reconcile on pod readiness changes or deletions
+	podReadyPredicate := predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, oldOk := e.ObjectOld.(*corev1.Pod)
+			newPod, newOk := e.ObjectNew.(*corev1.Pod)
+			if !oldOk || !newOk {
+				return false
+			}
+			return isPodReady(oldPod) != isPodReady(newPod)
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return true
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&designatev1beta1.DesignateBackendPDNS{}).
+		WithOptions(controllerOptions()).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.Pod{}, builder.WithPredicates(
+			componentPodPredicate(designatebackendpdns.Component), podReadyPredicate)).
+		// watch the config CMs we don't own
+		Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(configMapFn)).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(secretFn)).
+		Watches(&topologyv1.Topology{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+func (r *DesignateBackendPDNSReconciler) findObjectsForSrc(ctx context.Context, src client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+
+	Log := r.GetLogger(ctx)
+
+	allWatchFields := []string{
+		topologyField,
+	}
+
+	for _, field := range allWatchFields {
+		crList := &designatev1beta1.DesignateBackendPDNSList{}
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(field, src.GetName()),
+			Namespace:     src.GetNamespace(),
+		}
+		err := r.List(context.TODO(), crList, listOps)
+		if err != nil {
+			Log.Error(err, fmt.Sprintf("listing %s for field: %s - %s", crList.GroupVersionKind().Kind, field, src.GetNamespace()))
+			return requests
+		}
+
+		for _, item := range crList.Items {
+			Log.Info(fmt.Sprintf("input source %s changed, reconcile: %s - %s", src.GetName(), item.GetName(), item.GetNamespace()))
+
+			requests = append(requests,
+				reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      item.GetName(),
+						Namespace: item.GetNamespace(),
+					},
+				},
+			)
+		}
+	}
+	return requests
+}
+
+func (r *DesignateBackendPDNSReconciler) reconcileDelete(ctx context.Context, instance *designatev1beta1.DesignateBackendPDNS, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	Log.Info(fmt.Sprintf("Reconciling Service '%s' delete", instance.Name))
+
+	// Remove finalizer on the Topology CR
+	if ctrlResult, err := topologyv1.EnsureDeletedTopologyRef(
+		ctx,
+		helper,
+		instance.Status.LastAppliedTopology,
+		instance.Name,
+	); err != nil {
+		return ctrlResult, err
+	}
+	// We did all the cleanup on the objects we created so we can remove the
+	// finalizer from ourselves to allow the deletion
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info(fmt.Sprintf("Reconciled Service '%s' delete successfully", instance.Name))
+
+	return ctrl.Result{}, nil
+}
+
+func (r *DesignateBackendPDNSReconciler) reconcileNormal(ctx context.Context, instance *designatev1beta1.DesignateBackendPDNS, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	Log.Info("Reconciling Service")
+
+	configMapVars := make(map[string]env.Setter)
+
+	if len(instance.Spec.CustomServiceConfigSecrets) > 0 {
+		Log.Info("warning: CustomServiceConfigSecrets is not supported.")
+	}
+
+	// The API key is mounted from its Secret, a changed key restarts the pods
+	// through its hash
+	ctrlResult, err := getSecret(ctx, helper, instance.Namespace, &instance.Status.Conditions, instance.Spec.APIKeySecret, &configMapVars, "secret-")
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	serviceLabels := map[string]string{
+		common.AppSelector:       instance.Name,
+		common.ComponentSelector: designatebackendpdns.Component,
+	}
+
+	//
+	// create custom Configmap for this designate backend service
+	//
+	err = r.generateServiceConfigMaps(ctx, helper, instance, &configMapVars, serviceLabels)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	//
+	// create hash over all the different input resources to identify if any those changed
+	// and a restart/recreate is required.
+	//
+	inputHash, hashChanged, err := r.createHashOfInputHashes(ctx, instance, configMapVars)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	} else if hashChanged {
+		// Hash changed and instance status should be updated (which will be done by main defer func),
+		// so we need to return and reconcile again
+		return ctrl.Result{}, nil
+	}
+
+	pdnsIPsUpdated, err := r.hasMapChanged(ctx, helper, instance, designate.PDNSPredIPConfigMap, designate.PDNSPredictableIPHash)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	apiKeyUpdated, err := r.hasSecretChanged(ctx, helper, instance, instance.Spec.APIKeySecret, designate.PDNSAPIKeyHash)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if pdnsIPsUpdated || apiKeyUpdated {
+		// Predictable IPs and/or the API key have been updated, we need to update the statefulset.
+		return ctrl.Result{}, nil
+	}
+
+	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+
+	// networks to attach to
+	nadList := []networkv1.NetworkAttachmentDefinition{}
+	for _, netAtt := range instance.Spec.NetworkAttachments {
+		nad, err := nad.GetNADWithName(ctx, helper, netAtt, instance.Namespace)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.NetworkAttachmentsReadyWaitingMessage,
+					netAtt))
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		if nad != nil {
+			nadList = append(nadList, *nad)
+		}
+	}
+
+	serviceAnnotations, err := nad.EnsureNetworksAnnotation(nadList)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
+			instance.Spec.NetworkAttachments, err)
+	}
+
+	//
+	// Handle Topology
+	//
+	topology, err := ensureTopology(
+		ctx,
+		helper,
+		instance,      // topologyHandler
+		instance.Name, // finalizer
+		&instance.Status.Conditions,
+		labels.GetLabelSelector(serviceLabels),
+	)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.TopologyReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.TopologyReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, fmt.Errorf("waiting for Topology requirements: %w", err)
+	}
+
+	ctrlResult, err = r.reconcileStatefulSet(ctx, instance, helper, inputHash, serviceLabels, serviceAnnotations, topology)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
+	// Ensure backup/restore labels on existing PVCs
+	if err := r.reconcilePVCLabels(ctx, instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// We reached the end of the Reconcile, update the Ready condition based on
+	// the sub conditions
+	if instance.Status.Conditions.AllSubConditionIsTrue() {
+		instance.Status.Conditions.MarkTrue(
+			condition.ReadyCondition, condition.ReadyMessage)
+	}
+	Log.Info("Reconciled Service successfully")
+	return ctrl.Result{}, nil
+}
+
+func (r *DesignateBackendPDNSReconciler) reconcileStatefulSet(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendPDNS,
+	helper *helper.Helper,
+	inputHash string,
+	serviceLabels map[string]string,
+	serviceAnnotations map[string]string,
+	topology *topologyv1.Topology,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	deplDef, err := designatebackendpdns.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations, topology)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	depl := designate.NewStatefulSet(
+		deplDef,
+		time.Duration(5)*time.Second,
+	)
+
+	ctrlResult, err := depl.CreateOrPatch(ctx, helper)
+	statefulSetUpdated := (ctrlResult != ctrl.Result{})
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrlResult, err
+	} else if statefulSetUpdated {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DeploymentReadyRunningMessage))
+		return ctrlResult, nil
+	}
+	// the workload is only rendered into the preview ConfigMap
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
+	ctrlResult, err = designate.ReconcilePodDisruptionBudget(
+		ctx,
+		helper,
+		instance.Name,
+		instance.Namespace,
+		serviceLabels,
+		*deplDef.Spec.Replicas,
+		instance.Spec.PodDisruptionBudget,
+	)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
+	deploy := depl.GetStatefulSet()
+	if deploy.Generation == deploy.Status.ObservedGeneration {
+		instance.Status.ReadyCount = deploy.Status.ReadyReplicas
+
+		// verify if network attachment matches expectations
+		networkReady := false
+		networkAttachmentStatus := map[string][]string{}
+		if *(instance.Spec.Replicas) > 0 {
+			networkReady, networkAttachmentStatus, err = nad.VerifyNetworkStatusFromAnnotation(
+				ctx,
+				helper,
+				instance.Spec.NetworkAttachments,
+				serviceLabels,
+				instance.Status.ReadyCount,
+			)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		} else {
+			networkReady = true
+		}
+
+		instance.Status.NetworkAttachments = networkAttachmentStatus
+		if networkReady {
+			instance.Status.Conditions.MarkTrue(condition.NetworkAttachmentsReadyCondition, condition.NetworkAttachmentsReadyMessage)
+		} else {
+			err := fmt.Errorf("%w: %s", designate.ErrNetworkAttachmentConfig, instance.Spec.NetworkAttachments)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		if statefulset.IsReady(deploy) {
+			instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
+		} else {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.DeploymentReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.DeploymentReadyRunningMessage))
+		}
+	}
+
+	// Handle pod labeling for predictable IPs only when statefulset is ready
+	var labelingResult ctrl.Result
+	if statefulset.IsReady(deploy) && !statefulSetUpdated {
+		config := designate.PodLabelingConfig{
+			ConfigMapName: designate.PDNSPredIPConfigMap,
+			IPKeyPrefix:   "pdns_address_",
+		}
+		_, err = designate.HandlePodLabeling(ctx, helper, instance.Name, instance.Namespace, config)
+		if err != nil {
+			Log.Error(err, "Failed to handle pod labeling")
+			// Not critical for the main reconcile loop, the labeling is retried later
+			labelingResult = ctrl.Result{RequeueAfter: podLabelingRetryInterval}
+		}
+	}
+
+	return labelingResult, nil
+}
+
+// generateServiceConfigMaps - create custom configmap to hold service-specific config
+func (r *DesignateBackendPDNSReconciler) generateServiceConfigMaps(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.DesignateBackendPDNS,
+	envVars *map[string]env.Setter,
+	serviceLabels map[string]string,
+) error {
+	Log := r.GetLogger(ctx)
+
+	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), serviceLabels)
+
+	// custom.conf is appended to pdns.conf by the init container
+	customData := map[string]string{common.CustomServiceConfigFileName: instance.Spec.CustomServiceConfig}
+
+	var nadInfo *designate.NADConfig
+	for _, netAtt := range instance.Spec.NetworkAttachments {
+		nad, err := nad.GetNADWithName(ctx, h, netAtt, instance.Namespace)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("network-attachment-definition %s not found, cannot configure pod", netAtt))
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.NetworkAttachmentsReadyErrorMessage,
+					netAtt))
+				return nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityError,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return err
+		}
+		if nad.Name == instance.Spec.ControlNetworkName {
+			nadInfo, err = designate.GetNADConfig(nad)
+			if err != nil {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityError,
+					condition.NetworkAttachmentsReadyErrorMessage,
+					err.Error()))
+				return err
+			}
+			break
+		}
+	}
+	if nadInfo == nil {
+		return fmt.Errorf("%w: %s", designate.ErrNetworkAttachmentNotFound, instance.Spec.ControlNetworkName)
+	}
+
+	var cidrs []string
+	for _, ipRange := range nadInfo.IPAM.Ranges() {
+		cidrs = append(cidrs, ipRange.CIDR.String())
+	}
+	if len(cidrs) == 0 {
+		err := designate.ErrControlNetworkNotConfigured
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.NetworkAttachmentsReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			condition.NetworkAttachmentsReadyErrorMessage,
+			err))
+		return err
+	}
+	templateParameters := make(map[string]any)
+	switch {
+	case nadInfo.IPAM.HasIPv4() && nadInfo.IPAM.HasIPv6():
+		templateParameters["LocalAddress"] = "0.0.0.0, ::"
+		templateParameters["WebserverAddress"] = "::"
+	case nadInfo.IPAM.HasIPv4():
+		templateParameters["LocalAddress"] = "0.0.0.0"
+		templateParameters["WebserverAddress"] = "0.0.0.0"
+	default:
+		templateParameters["LocalAddress"] = "::"
+		templateParameters["WebserverAddress"] = "::"
+	}
+	templateParameters["AllowCIDR"] = strings.Join(cidrs, ",")
+	templateParameters["APIPort"] = designate.PDNSAPIPort
+	templateParameters["DebugLogging"] = designate.DebugLogging(instance.Spec.Logging)
+
+	cms := []util.Template{
+		// ScriptsConfigMap
+		{
+			Name:         fmt.Sprintf("%s-scripts", instance.Name),
+			Namespace:    instance.Namespace,
+			Type:         util.TemplateTypeScripts,
+			InstanceType: instance.Kind,
+			AdditionalTemplate: map[string]string{
+				"common.sh":     "/common/common.sh",
+				"setipalias.py": "/common/setipalias.py",
+			},
+			Labels: cmLabels,
+		},
+		// Custom ConfigMap
+		{
+			Name:          fmt.Sprintf("%s-config-data", instance.Name),
+			Namespace:     instance.Namespace,
+			Type:          util.TemplateTypeConfig,
+			InstanceType:  instance.Kind,
+			CustomData:    customData,
+			ConfigOptions: templateParameters,
+			Labels:        cmLabels,
+		},
+	}
+
+	return ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
+}
+
+// createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
+// if any of the input resources change, like configs, passwords, ...
+//
+// returns the hash, whether the hash changed (as a bool) and any error
+func (r *DesignateBackendPDNSReconciler) createHashOfInputHashes(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendPDNS,
+	envVars map[string]env.Setter,
+) (string, bool, error) {
+	Log := r.GetLogger(ctx)
+	var hashMap map[string]string
+	changed := false
+	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	instance.Status.InputHashes = inputHashes(mergedMapVars)
+	hash, err := util.ObjectHash(mergedMapVars)
+	if err != nil {
+		return hash, changed, err
+	}
+	if hashMap, changed = util.SetHash(instance.Status.Hash, common.InputHashName, hash); changed {
+		instance.Status.Hash = hashMap
+		Log.Info(fmt.Sprintf("Input maps hash %s - %s", common.InputHashName, hash))
+	}
+	return hash, changed, nil
+}
+
+func (r *DesignateBackendPDNSReconciler) hasMapChanged(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.DesignateBackendPDNS,
+	mapName string,
+	hashKey string,
+) (bool, error) {
+	Log := r.GetLogger(ctx)
+	configMap := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: mapName, Namespace: instance.GetNamespace()}, configMap)
+	if err != nil {
+		Log.Error(err, fmt.Sprintf("Unable to check config map %s for changes", mapName))
+		return false, err
+	}
+	hashValue, err := configmap.Hash(configMap)
+	if err != nil {
+		return false, err
+	}
+	_, updated := util.SetHash(instance.Status.Hash, hashKey, hashValue)
+	return updated, nil
+}
+
+func (r *DesignateBackendPDNSReconciler) hasSecretChanged(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.DesignateBackendPDNS,
+	secretName string,
+	hashKey string,
+) (bool, error) {
+	Log := r.GetLogger(ctx)
+	found := &corev1.Secret{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: secretName, Namespace: instance.GetNamespace()}, found)
+	if err != nil {
+		Log.Error(err, fmt.Sprintf("Unable to check secret %s for changes", secretName))
+		return false, err
+	}
+	hashValue, err := secret.Hash(found)
+	if err != nil {
+		return false, err
+	}
+	_, updated := util.SetHash(instance.Status.Hash, hashKey, hashValue)
+	return updated, nil
+}
+
+// reconcilePVCLabels ensures backup/restore labels are set on existing PVCs
+func (r *DesignateBackendPDNSReconciler) reconcilePVCLabels(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendPDNS,
+) error {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			common.AppSelector:       instance.Name,
+			common.ComponentSelector: designatebackendpdns.Component,
+		},
+	}
+	if err := r.List(ctx, pvcList, listOpts...); err != nil {
+		return fmt.Errorf("listing PVCs for %s: %w", instance.Name, err)
+	}
+	for i := range pvcList.Items {
+		if _, err := backup.EnsureBackupLabels(ctx, r.Client, &pvcList.Items[i],
+			util.MergeMaps(
+				backup.GetBackupLabels(backup.CategoryControlPlane),
+				backup.GetRestoreLabels(backup.RestoreOrder00, backup.CategoryControlPlane),
+			)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -19,15 +19,18 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,7 +52,6 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
-	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/statefulset"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
@@ -74,8 +76,9 @@ func (r *DesignateMdnsReconciler) GetScheme() *runtime.Scheme {
 // DesignateMdnsReconciler reconciles a DesignateMdns object
 type DesignateMdnsReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient  kubernetes.Interface
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
@@ -90,9 +93,11 @@ func (r *DesignateMdnsReconciler) GetLogger(ctx context.Context) logr.Logger {
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -104,6 +109,9 @@ func (r *DesignateMdnsReconciler) GetLogger(ctx context.Context) logr.Logger {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
 func (r *DesignateMdnsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateMdns", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
 	Log := r.GetLogger(ctx)
 	// Fetch the DesignateMdns instance
 	instance := &designatev1beta1.DesignateMdns{}
@@ -119,6 +127,10 @@ func (r *DesignateMdnsReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -169,9 +181,11 @@ func (r *DesignateMdnsReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
 		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignatePredictableIPsReadyCondition, condition.InitReason, designatev1beta1.DesignatePredictableIPsReadyInitMessage),
 	)
 
 	instance.Status.Conditions.Init(&cl)
@@ -330,9 +344,13 @@ func (r *DesignateMdnsReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&designatev1beta1.DesignateMdns{}).
+		WithOptions(controllerOptions()).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		// the generated config Secrets, to detect their out of band modifications
+		Owns(&corev1.Secret{}).
 		Owns(&corev1.Service{}).
-		Owns(&corev1.Pod{}).
+		Owns(&corev1.Pod{}, builder.WithPredicates(componentPodPredicate(designatemdns.Component))).
 		// watch the secrets we don't own
 		Watches(&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(svcSecretFn)).
@@ -448,21 +466,16 @@ func (r *DesignateMdnsReconciler) reconcileNormal(ctx context.Context, instance
 	// run check TransportURL secret - end
 
 	//
-	// TODO(beagles 03/2025): this block of code repeats throughout designate. It appears that the idea here was to allow custom
-	// config snippets to be stored in secrets and used later on. This was not used anywhere however. Maybe this
-	// was intended to be part of supporting alternate backends? I will comment out for now and add a warning if the
-	// parameter is used.
+	// check for the Secrets holding CustomServiceConfig snippets, the init
+	// container merges their content into custom.conf
 	//
-	// for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
-	//	ctrlResult, err = r.getSecret(ctx, helper, instance, secretName, &configMapVars, "secret-")
-	//	if err != nil {
-	//		return ctrlResult, err
-	//	}
-	//}
-	// run check service secrets - end
-	if len(instance.Spec.CustomServiceConfigSecrets) > 0 {
-		Log.Info("CustomServiceConfigSecrets is not supported.")
+	for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
+		ctrlResult, err = getSecret(ctx, helper, instance.Namespace, &instance.Status.Conditions, secretName, &configMapVars, "secret-")
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
 	}
+	// run check service secrets - end
 
 	//
 	// check for required Designate config maps that should have been created by parent Designate CR
@@ -534,14 +547,21 @@ func (r *DesignateMdnsReconciler) reconcileNormal(ctx context.Context, instance
 		common.ComponentSelector: designatemdns.Component,
 	}
 
+	// Without an override for all the replicas, Services are only created for
+	// the replicas with their own override
 	serviceCount := min(int(*instance.Spec.Replicas), len(instance.Spec.Override.Services))
+	if instance.Spec.Override.Service != nil {
+		serviceCount = int(*instance.Spec.Replicas)
+	}
 	for i := range serviceCount {
+		overrideSpec := designate.DNSServiceOverride(
+			instance.Spec.Override.Service, instance.Spec.Override.Services, i)
 		svc, err := designate.CreateDNSService(
 			fmt.Sprintf("designate-mdns-%d", i),
 			instance.Namespace,
-			&instance.Spec.Override.Services[i],
+			&overrideSpec,
 			serviceLabels,
-			5354,
+			designatemdns.Port(&instance.Spec.DesignateMdnsSpecBase),
 		)
 
 		if err != nil {
@@ -696,9 +716,29 @@ func (r *DesignateMdnsReconciler) reconcileNormal(ctx context.Context, instance
 	// normal reconcile tasks
 	//
 
+	predictableIPConfig := designate.PodLabelingConfig{
+		ConfigMapName: designate.MdnsPredIPConfigMap,
+		IPKeyPrefix:   "mdns_address_",
+		Recorder:      r.Recorder,
+	}
+	missingIPs, err := designate.MissingPredictableIPs(ctx, helper, instance.Name, instance.Namespace, predictableIPConfig, int(*instance.Spec.Replicas))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	setPredictableIPsCondition(ctx, helper.GetClient(), r.Recorder, instance, &instance.Status.Conditions, missingIPs)
+
 	// Define a new Mdns StatefulSet object
-	statefulSetDef := designatemdns.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations, topology)
-	statefulSet := statefulset.NewStatefulSet(
+	statefulSetDef, err := designatemdns.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations, topology)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	statefulSet := designate.NewStatefulSet(
 		statefulSetDef,
 		time.Duration(5)*time.Second,
 	)
@@ -721,6 +761,23 @@ func (r *DesignateMdnsReconciler) reconcileNormal(ctx context.Context, instance
 			condition.DeploymentReadyRunningMessage))
 		return ctrlResult, nil
 	}
+	// the workload is only rendered into the preview ConfigMap
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
+	ctrlResult, err = designate.ReconcilePodDisruptionBudget(
+		ctx,
+		helper,
+		instance.Name,
+		instance.Namespace,
+		serviceLabels,
+		*statefulSetDef.Spec.Replicas,
+		instance.Spec.PodDisruptionBudget,
+	)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
 	deploy := statefulSet.GetStatefulSet()
 	if deploy.Generation == deploy.Status.ObservedGeneration {
 		instance.Status.ReadyCount = deploy.Status.ReadyReplicas
@@ -772,15 +829,16 @@ func (r *DesignateMdnsReconciler) reconcileNormal(ctx context.Context, instance
 
 	// Handle pod labeling for predictable IPs only when statefulset is ready
 	// This avoids unnecessary reconciliations when pods haven't changed
+	var labelingResult ctrl.Result
 	if statefulset.IsReady(deploy) && !statefulSetUpdated {
-		config := designate.PodLabelingConfig{
-			ConfigMapName: designate.MdnsPredIPConfigMap,
-			IPKeyPrefix:   "mdns_address_",
-		}
-		err = designate.HandlePodLabeling(ctx, helper, instance.Name, instance.Namespace, config)
+		podIPs, err := designate.HandlePodLabeling(ctx, helper, instance.Name, instance.Namespace, predictableIPConfig)
 		if err != nil {
 			Log.Error(err, "Failed to handle pod labeling")
-			// Don't return error as this is not critical for the main reconcile loop
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "PodLabelingFailed", err.Error())
+			// Not critical for the main reconcile loop, the labeling is retried later
+			labelingResult = ctrl.Result{RequeueAfter: podLabelingRetryInterval}
+		} else {
+			instance.Status.PodIPs = podIPs
 		}
 	}
 
@@ -793,7 +851,7 @@ func (r *DesignateMdnsReconciler) reconcileNormal(ctx context.Context, instance
 		Log.Info("Not all conditions are ready for Mdns controller")
 	}
 	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
+	return labelingResult, nil
 }
 
 func (r *DesignateMdnsReconciler) reconcileUpdate(ctx context.Context, instance *designatev1beta1.DesignateMdns) (ctrl.Result, error) {
@@ -818,6 +876,30 @@ func (r *DesignateMdnsReconciler) reconcileUpgrade(ctx context.Context, instance
 	return ctrl.Result{}, nil
 }
 
+// mdnsTuningParameters returns the worker, thread and zone transfer settings
+// of the mdns servers, falling back to the defaults for fields left empty
+func mdnsTuningParameters(spec *designatev1beta1.DesignateMdnsSpecBase) map[string]any {
+	params := map[string]any{
+		"Workers":    int32(designatemdns.DefaultWorkers),
+		"Threads":    int32(designatemdns.DefaultThreads),
+		"TCPBacklog": int32(designatemdns.DefaultTCPBacklog),
+		"XFRTimeout": int32(designatemdns.DefaultXFRTimeout),
+	}
+	if spec.Workers > 0 {
+		params["Workers"] = spec.Workers
+	}
+	if spec.Threads > 0 {
+		params["Threads"] = spec.Threads
+	}
+	if spec.TCPBacklog > 0 {
+		params["TCPBacklog"] = spec.TCPBacklog
+	}
+	if spec.XFRTimeout > 0 {
+		params["XFRTimeout"] = spec.XFRTimeout
+	}
+	return params
+}
+
 // generateServiceConfigMaps - create custom configmap to hold service-specific config
 func (r *DesignateMdnsReconciler) generateServiceConfigMaps(
 	ctx context.Context,
@@ -832,10 +914,6 @@ func (r *DesignateMdnsReconciler) generateServiceConfigMaps(
 
 	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})
 
-	db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
-	if err != nil {
-		return err
-	}
 	var tlsCfg *tls.Service
 	if instance.Spec.TLS.CaBundleSecretName != "" {
 		tlsCfg = &tls.Service{}
@@ -846,8 +924,21 @@ func (r *DesignateMdnsReconciler) generateServiceConfigMaps(
 	// TODO: make sure custom.conf can not be overwritten
 	customData := map[string]string{
 		common.CustomServiceConfigFileName: instance.Spec.CustomServiceConfig,
-		"my.cnf":                           db.GetDatabaseClientConfig(tlsCfg), //(oschwart) for now just get the default my.cnf
 	}
+	// with an external database, the my.cnf of the Designate CR is used
+	if !instance.Spec.ExternalDatabase {
+		db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
+		if err != nil {
+			return err
+		}
+		customData["my.cnf"] = db.GetDatabaseClientConfig(tlsCfg) //(oschwart) for now just get the default my.cnf
+	}
+
+	templateParameters := map[string]any{
+		"Logging": designate.LoggingTemplateParameters(instance.Spec.Logging),
+		"Port":    designatemdns.Port(&instance.Spec.DesignateMdnsSpecBase),
+	}
+	maps.Copy(templateParameters, mdnsTuningParameters(&instance.Spec.DesignateMdnsSpecBase))
 
 	cms := []util.Template{
 		// ScriptsConfigMap
@@ -870,7 +961,7 @@ func (r *DesignateMdnsReconciler) generateServiceConfigMaps(
 			Type:          util.TemplateTypeConfig,
 			InstanceType:  instance.Kind,
 			CustomData:    customData,
-			ConfigOptions: make(map[string]any),
+			ConfigOptions: templateParameters,
 			Labels:        cmLabels,
 		},
 		{
@@ -883,7 +974,7 @@ func (r *DesignateMdnsReconciler) generateServiceConfigMaps(
 		},
 	}
 
-	return oko_secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+	return ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
 }
 
 // createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
@@ -900,6 +991,7 @@ func (r *DesignateMdnsReconciler) createHashOfInputHashes(
 	changed := false
 
 	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	instance.Status.InputHashes = inputHashes(mergedMapVars)
 	hash, err := util.ObjectHash(mergedMapVars)
 	if err != nil {
 		return hash, changed, err
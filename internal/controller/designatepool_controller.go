@@ -0,0 +1,570 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GetClient -
+func (r *DesignatePoolReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetKClient -
+func (r *DesignatePoolReconciler) GetKClient() kubernetes.Interface {
+	return r.Kclient
+}
+
+// GetScheme -
+func (r *DesignatePoolReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// DesignatePoolReconciler reconciles a DesignatePool object
+type DesignatePoolReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *DesignatePoolReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("DesignatePool")
+}
+
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatepools,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatepools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatepools/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;create;update;patch;delete;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;update;patch;delete;watch
+
+// Reconcile renders the pool of a DesignatePool into its own pools.yaml and
+// applies it with a designate-manage pool update job whenever it changes.
+func (r *DesignatePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignatePool", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
+	Log := r.GetLogger(ctx)
+
+	// Fetch the DesignatePool instance
+	instance := &designatev1beta1.DesignatePool{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// initialize status if Conditions is nil, but do not reset if it already
+	// exists
+	isNewInstance := instance.Status.Conditions == nil
+	if isNewInstance {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Always patch the instance status when exiting this function so we can
+	// persist any changes.
+	defer func() {
+		// Don't update the status, if Reconciler Panics
+		if r := recover(); r != nil {
+			Log.Info(fmt.Sprintf("Panic during reconcile %v\n", r))
+			panic(r)
+		}
+		condition.RestoreLastTransitionTimes(
+			&instance.Status.Conditions, savedConditions)
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	//
+	// initialize status
+	//
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignatePoolUpdateReadyCondition, condition.InitReason, designatev1beta1.DesignatePoolUpdateReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) || isNewInstance {
+		return ctrl.Result{}, nil
+	}
+
+	if instance.Status.Hash == nil {
+		instance.Status.Hash = map[string]string{}
+	}
+
+	// Handle pool delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DesignatePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	Log := r.GetLogger(ctx)
+
+	// index designateNameField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &designatev1beta1.DesignatePool{}, designateNameField, func(rawObj client.Object) []string {
+		cr := rawObj.(*designatev1beta1.DesignatePool)
+		return []string{cr.Spec.DesignateName}
+	}); err != nil {
+		return err
+	}
+
+	// reconcile the pools of a Designate CR when it changes, e.g. once
+	// designate-central is ready
+	designateFn := func(ctx context.Context, o client.Object) []reconcile.Request {
+		return r.findPoolsForDesignate(ctx, o.GetNamespace(), o.GetName())
+	}
+
+	// the default masters of the targets are the mdns predictable IPs
+	configMapFn := func(ctx context.Context, o client.Object) []reconcile.Request {
+		if o.GetName() != designate.MdnsPredIPConfigMap {
+			return nil
+		}
+		owner := metav1.GetControllerOf(o)
+		if owner == nil || owner.Kind != "Designate" {
+			return nil
+		}
+		Log.Info(fmt.Sprintf("ConfigMap %s of Designate %s changed", o.GetName(), owner.Name))
+		return r.findPoolsForDesignate(ctx, o.GetNamespace(), owner.Name)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&designatev1beta1.DesignatePool{}).
+		WithOptions(controllerOptions()).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&batchv1.Job{}).
+		Watches(&designatev1beta1.Designate{},
+			handler.EnqueueRequestsFromMapFunc(designateFn)).
+		Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(configMapFn)).
+		Complete(r)
+}
+
+func (r *DesignatePoolReconciler) findPoolsForDesignate(ctx context.Context, namespace string, designateName string) []reconcile.Request {
+	Log := r.GetLogger(ctx)
+	requests := []reconcile.Request{}
+
+	crList := &designatev1beta1.DesignatePoolList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(designateNameField, designateName),
+		Namespace:     namespace,
+	}
+	if err := r.List(ctx, crList, listOps); err != nil {
+		Log.Error(err, fmt.Sprintf("listing %s for Designate %s - %s", crList.GroupVersionKind().Kind, designateName, namespace))
+		return requests
+	}
+
+	for _, item := range crList.Items {
+		requests = append(requests,
+			reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			},
+		)
+	}
+	return requests
+}
+
+func (r *DesignatePoolReconciler) reconcileNormal(ctx context.Context, instance *designatev1beta1.DesignatePool, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info(fmt.Sprintf("Reconciling DesignatePool '%s'", instance.Name))
+
+	//
+	// the pool is applied with the configuration and image of its Designate CR
+	//
+	designateInstance := &designatev1beta1.Designate{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.DesignateName, Namespace: instance.Namespace}, designateInstance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			Log.Info(fmt.Sprintf("Designate %s not found", instance.Spec.DesignateName))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.InputReadyWaitingMessage))
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.InputReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	mdnsConfigMap := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{Name: designate.MdnsPredIPConfigMap, Namespace: instance.Namespace}, mdnsConfigMap)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.InputReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if len(mdnsConfigMap.Data) == 0 && needsDefaultMasters(instance) {
+		Log.Info("Waiting for the mdns predictable IPs")
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.InputReadyWaitingMessage))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	//
+	// render the pools.yaml of this pool
+	//
+	pool, err := designate.GeneratePoolFromCR(instance, mdnsConfigMap.Data)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	poolsYaml, poolsYamlHash, err := designate.GeneratePoolsYaml([]designate.Pool{pool})
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	if isPreviewed(Log, &instance.Status.Conditions, condition.ServiceConfigReadyCondition, instance) {
+		// pools.yaml is only rendered into the preview ConfigMap, the pool
+		// update job doesn't run
+		return ctrl.Result{}, designate.StorePreview(ctx, helper, designate.DesignatePoolsYamlPath, poolsYaml)
+	}
+	err = designate.DeletePreview(ctx, helper, instance, nil)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	poolLabels := labels.GetLabels(instance, labels.GetGroupLabel(designate.ServiceName), map[string]string{})
+	poolsYamlConfigMap := &corev1.ConfigMap{}
+	poolsYamlConfigMap.Name = designate.PoolConfigMapName(instance.Name)
+	poolsYamlConfigMap.Namespace = instance.Namespace
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), poolsYamlConfigMap, func() error {
+		poolsYamlConfigMap.Labels = util.MergeStringMaps(poolsYamlConfigMap.Labels, poolLabels)
+		poolsYamlConfigMap.Data = map[string]string{
+			designate.PoolsYamlContent: poolsYaml,
+		}
+		return controllerutil.SetControllerReference(instance, poolsYamlConfigMap, helper.GetScheme())
+	})
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	instance.Status.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+
+	//
+	// apply the pool, designate-manage talks to designate-central
+	//
+	if designateInstance.Status.DesignateCentralReadyCount == 0 {
+		Log.Info("Waiting for designate-central to apply the pool")
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePoolUpdateReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignatePoolUpdateReadyWaitingMessage,
+			"designate-central"))
+		return ctrl.Result{}, nil
+	}
+
+	jobDef := designate.DesignatePoolUpdateJob(designateInstance, instance, poolsYamlHash, poolLabels, map[string]string{})
+	poolUpdateJob := job.NewJob(
+		jobDef,
+		designatev1beta1.PoolUpdateHash,
+		designateInstance.Spec.PreserveJobs,
+		time.Duration(15)*time.Second,
+		instance.Status.Hash[designatev1beta1.PoolUpdateHash],
+	)
+	ctrlResult, err := poolUpdateJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePoolUpdateReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignatePoolUpdateReadyRunningMessage))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePoolUpdateReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignatePoolUpdateReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if poolUpdateJob.HasChanged() {
+		instance.Status.Hash[designatev1beta1.PoolUpdateHash] = poolUpdateJob.GetHash()
+		Log.Info(fmt.Sprintf("Pool %s updated - Job %s hash added - %s", pool.Name, jobDef.Name, instance.Status.Hash[designatev1beta1.PoolUpdateHash]))
+	}
+	instance.Status.Conditions.MarkTrue(designatev1beta1.DesignatePoolUpdateReadyCondition, designatev1beta1.DesignatePoolUpdateReadyMessage)
+
+	// We reached the end of the Reconcile, update the Ready condition based on
+	// the sub conditions
+	if instance.Status.Conditions.AllSubConditionIsTrue() {
+		instance.Status.Conditions.MarkTrue(
+			condition.ReadyCondition, condition.ReadyMessage)
+	}
+	Log.Info(fmt.Sprintf("Reconciled DesignatePool '%s' successfully", instance.Name))
+	return ctrl.Result{}, nil
+}
+
+func (r *DesignatePoolReconciler) reconcileDelete(ctx context.Context, instance *designatev1beta1.DesignatePool, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info(fmt.Sprintf("Reconciling DesignatePool '%s' delete", instance.Name))
+
+	remainingPoolsYaml, designateInstance, err := r.getRemainingPoolsYaml(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Nothing to remove from designate if the pool was never applied, if
+	// designate is gone, or if the Designate CR takes the pool back. Never run
+	// --delete without any pool left as it would remove all of them.
+	if instance.Status.Hash[designatev1beta1.PoolUpdateHash] == "" || designateInstance == nil || remainingPoolsYaml == "" {
+		controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+		Log.Info(fmt.Sprintf("Reconciled DesignatePool '%s' delete successfully", instance.Name))
+		return ctrl.Result{}, nil
+	}
+
+	poolLabels := labels.GetLabels(instance, labels.GetGroupLabel(designate.ServiceName), map[string]string{})
+	deleteConfigMap := &corev1.ConfigMap{}
+	deleteConfigMap.Name = designate.PoolDeleteConfigMapName(instance.Name)
+	deleteConfigMap.Namespace = instance.Namespace
+	_, err = controllerutil.CreateOrPatch(ctx, helper.GetClient(), deleteConfigMap, func() error {
+		deleteConfigMap.Labels = util.MergeStringMaps(deleteConfigMap.Labels, poolLabels)
+		deleteConfigMap.Data = map[string]string{
+			designate.PoolsYamlContent: remainingPoolsYaml,
+		}
+		return controllerutil.SetControllerReference(instance, deleteConfigMap, helper.GetScheme())
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	remainingPoolsHash, err := util.ObjectHash(remainingPoolsYaml)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	jobDef := designate.DesignatePoolDeleteJob(designateInstance, instance, remainingPoolsHash, poolLabels, map[string]string{})
+	poolDeleteJob := job.NewJob(
+		jobDef,
+		designatev1beta1.PoolDeleteHash,
+		designateInstance.Spec.PreserveJobs,
+		time.Duration(15)*time.Second,
+		instance.Status.Hash[designatev1beta1.PoolDeleteHash],
+	)
+	ctrlResult, err := poolDeleteJob.DoJob(ctx, helper)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePoolUpdateReadyCondition,
+			condition.DeletingReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignatePoolUpdateReadyDeletingMessage))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignatePoolUpdateReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignatePoolUpdateReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if poolDeleteJob.HasChanged() {
+		instance.Status.Hash[designatev1beta1.PoolDeleteHash] = poolDeleteJob.GetHash()
+	}
+
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info(fmt.Sprintf("Reconciled DesignatePool '%s' delete successfully", instance.Name))
+
+	return ctrl.Result{}, nil
+}
+
+// getRemainingPoolsYaml returns the pools.yaml of all the pools of the
+// Designate CR of instance, except the one of instance. The returned Designate
+// is nil if it does not exist anymore or is being deleted.
+func (r *DesignatePoolReconciler) getRemainingPoolsYaml(
+	ctx context.Context,
+	instance *designatev1beta1.DesignatePool,
+) (string, *designatev1beta1.Designate, error) {
+	designateInstance := &designatev1beta1.Designate{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.DesignateName, Namespace: instance.Namespace}, designateInstance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+	if !designateInstance.DeletionTimestamp.IsZero() {
+		return "", nil, nil
+	}
+
+	// The pools of the Designate CR itself. A pool it defines is handed back
+	// to it and must not be deleted.
+	ownPools := []string{designate.DefaultPoolName}
+	multipoolConfig, err := designate.GetMultipoolConfig(ctx, r.Client, instance.Namespace)
+	if err != nil {
+		return "", nil, err
+	}
+	if multipoolConfig != nil {
+		ownPools = []string{}
+		for _, pool := range multipoolConfig.Pools {
+			ownPools = append(ownPools, pool.Name)
+		}
+	}
+	if slices.Contains(ownPools, instance.GetPoolName()) {
+		return "", designateInstance, nil
+	}
+
+	var poolsYamls []string
+	configMapNames := []string{designate.PoolsYamlConfigMap}
+
+	poolList := &designatev1beta1.DesignatePoolList{}
+	listOpts := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(designateNameField, instance.Spec.DesignateName),
+		Namespace:     instance.Namespace,
+	}
+	if err := r.List(ctx, poolList, listOpts); err != nil {
+		return "", nil, err
+	}
+	for _, pool := range poolList.Items {
+		if pool.Name == instance.Name || !pool.DeletionTimestamp.IsZero() ||
+			pool.Status.Hash[designatev1beta1.PoolUpdateHash] == "" {
+			continue
+		}
+		configMapNames = append(configMapNames, designate.PoolConfigMapName(pool.Name))
+	}
+
+	for _, name := range configMapNames {
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: instance.Namespace}, configMap)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				continue
+			}
+			return "", nil, err
+		}
+		poolsYamls = append(poolsYamls, configMap.Data[designate.PoolsYamlContent])
+	}
+
+	remainingPoolsYaml, err := designate.MergePoolsYaml(poolsYamls...)
+	if err != nil {
+		return "", nil, err
+	}
+	return remainingPoolsYaml, designateInstance, nil
+}
+
+// needsDefaultMasters returns true if a target of the pool relies on the mdns
+// servers of the Designate CR
+func needsDefaultMasters(instance *designatev1beta1.DesignatePool) bool {
+	for _, t := range instance.Spec.Targets {
+		if len(t.Masters) == 0 {
+			return true
+		}
+	}
+	return false
+}
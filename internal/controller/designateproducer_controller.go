@@ -34,13 +34,15 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -92,6 +94,8 @@ func (r *DesignateProducerReconciler) GetLogger(ctx context.Context) logr.Logger
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -103,6 +107,9 @@ func (r *DesignateProducerReconciler) GetLogger(ctx context.Context) logr.Logger
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
 func (r *DesignateProducerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateProducer", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
 	Log := r.GetLogger(ctx)
 
 	// Fetch the DesignateProducer instance
@@ -119,6 +126,10 @@ func (r *DesignateProducerReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -169,6 +180,7 @@ func (r *DesignateProducerReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
 		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
@@ -331,7 +343,12 @@ func (r *DesignateProducerReconciler) SetupWithManager(ctx context.Context, mgr
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&designatev1beta1.DesignateProducer{}).
+		WithOptions(controllerOptions()).
 		Owns(&appsv1.Deployment{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		// the generated config Secrets, to detect their out of band modifications
+		Owns(&corev1.Secret{}).
 		Owns(&corev1.Service{}).
 		// watch the secrets we don't own
 		Watches(&corev1.Secret{},
@@ -451,21 +468,16 @@ func (r *DesignateProducerReconciler) reconcileNormal(ctx context.Context, insta
 	// run check TransportURL secret - end
 
 	//
-	// TODO(beagles 03/2025): this block of code repeats throughout designate. It appears that the idea here was to allow custom
-	// config snippets to be stored in secrets and used later on. This was not used anywhere however. Maybe this
-	// was intended to be part of supporting alternate backends? I will comment out for now and add a warning if the
-	// parameter is used.
+	// check for the Secrets holding CustomServiceConfig snippets, the init
+	// container merges their content into custom.conf
 	//
-	// for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
-	//	ctrlResult, err = r.getSecret(ctx, helper, instance, secretName, &configMapVars, "secret-")
-	//	if err != nil {
-	//		return ctrlResult, err
-	//	}
-	//}
-	// run check service secrets - end
-	if len(instance.Spec.CustomServiceConfigSecrets) > 0 {
-		Log.Info("warning: CustomServiceConfigSecrets is not supported.")
+	for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
+		ctrlResult, err = getSecret(ctx, helper, instance.Namespace, &instance.Status.Conditions, secretName, &configMapVars, "secret-")
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
 	}
+	// run check service secrets - end
 
 	//
 	// check for required Designate config maps that should have been created by parent Designate CR
@@ -593,6 +605,15 @@ func (r *DesignateProducerReconciler) reconcileNormal(ctx context.Context, insta
 	}
 	// Create ConfigMaps - end
 
+	// The client certificates of the coordination backend
+	coordinationSecretHash, err := designate.GetCoordinationSecretHash(ctx, helper.GetClient(), instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if coordinationSecretHash != "" {
+		configMapVars[designate.DesignateCoordinationSecret] = env.SetValue(coordinationSecretHash)
+	}
+
 	//
 	// create hash over all the different input resources to identify if any those changed
 	// and a restart/recreate is required.
@@ -669,8 +690,27 @@ func (r *DesignateProducerReconciler) reconcileNormal(ctx context.Context, insta
 	//
 
 	// Define a new Deployment object
-	deplDef := designateproducer.Deployment(instance, inputHash, serviceLabels, serviceAnnotations, topology)
-	depl := deployment.NewDeployment(
+	deplDef, err := designateproducer.Deployment(instance, inputHash, serviceLabels, serviceAnnotations, topology)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	err = designate.ReconcileAutoscaling(ctx, helper, deplDef, instance.Spec.Autoscaling)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	depl := designate.NewDeployment(
 		deplDef,
 		time.Duration(5)*time.Second,
 	)
@@ -692,15 +732,33 @@ func (r *DesignateProducerReconciler) reconcileNormal(ctx context.Context, insta
 			condition.DeploymentReadyRunningMessage))
 		return ctrlResult, nil
 	}
+	// the workload is only rendered into the preview ConfigMap
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	ctrlResult, err = designate.ReconcilePodDisruptionBudget(
+		ctx,
+		helper,
+		instance.Name,
+		instance.Namespace,
+		serviceLabels,
+		*deplDef.Spec.Replicas,
+		instance.Spec.PodDisruptionBudget,
+	)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
 
 	deploy := depl.GetDeployment()
 	if deploy.Generation == deploy.Status.ObservedGeneration {
 		instance.Status.ReadyCount = deploy.Status.ReadyReplicas
+		instance.Status.Selector = metav1.FormatLabelSelector(deploy.Spec.Selector)
 
 		// verify if network attachment matches expectations
 		networkReady := false
 		networkAttachmentStatus := map[string][]string{}
-		if *(instance.Spec.Replicas) > 0 {
+		if *deplDef.Spec.Replicas > 0 {
 			networkReady, networkAttachmentStatus, err = nad.VerifyNetworkStatusFromAnnotation(
 				ctx,
 				helper,
@@ -791,10 +849,6 @@ func (r *DesignateProducerReconciler) generateServiceConfigMaps(
 
 	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})
 
-	db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
-	if err != nil {
-		return err
-	}
 	var tlsCfg *tls.Service
 	if instance.Spec.TLS.CaBundleSecretName != "" {
 		tlsCfg = &tls.Service{}
@@ -805,10 +859,26 @@ func (r *DesignateProducerReconciler) generateServiceConfigMaps(
 	// TODO: make sure custom.conf can not be overwritten
 	customData := map[string]string{
 		common.CustomServiceConfigFileName: instance.Spec.CustomServiceConfig,
-		"my.cnf":                           db.GetDatabaseClientConfig(tlsCfg), //(oschwart) for now just get the default my.cnf
+	}
+	// with an external database, the my.cnf of the Designate CR is used
+	if !instance.Spec.ExternalDatabase {
+		db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
+		if err != nil {
+			return err
+		}
+		customData["my.cnf"] = db.GetDatabaseClientConfig(tlsCfg) //(oschwart) for now just get the default my.cnf
 	}
 
-	templateParameters := map[string]any{}
+	templateParameters := map[string]any{
+		"Logging": designate.LoggingTemplateParameters(instance.Spec.Logging),
+	}
+	if instance.Spec.ZonePurge != nil {
+		templateParameters["ZonePurge"] = map[string]any{
+			"Interval":  instance.Spec.ZonePurge.Interval,
+			"Age":       instance.Spec.ZonePurge.Age,
+			"BatchSize": instance.Spec.ZonePurge.BatchSize,
+		}
+	}
 
 	cms := []util.Template{
 		// Custom ConfigMap
@@ -831,7 +901,7 @@ func (r *DesignateProducerReconciler) generateServiceConfigMaps(
 		},
 	}
 
-	return secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+	return ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
 }
 
 // createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
@@ -848,6 +918,7 @@ func (r *DesignateProducerReconciler) createHashOfInputHashes(
 	var hashMap map[string]string
 	changed := false
 	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	instance.Status.InputHashes = inputHashes(mergedMapVars)
 	hash, err := util.ObjectHash(mergedMapVars)
 	if err != nil {
 		return hash, changed, err
@@ -0,0 +1,359 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GetClient -
+func (r *DesignateRecordSetReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetKClient -
+func (r *DesignateRecordSetReconciler) GetKClient() kubernetes.Interface {
+	return r.Kclient
+}
+
+// GetScheme -
+func (r *DesignateRecordSetReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// DesignateRecordSetReconciler reconciles a DesignateRecordSet object
+type DesignateRecordSetReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *DesignateRecordSetReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("DesignateRecordSet")
+}
+
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designaterecordsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designaterecordsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designaterecordsets/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatezones,verbs=get;list;watch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designates,verbs=get;list;watch
+
+// Reconcile creates, updates and deletes the recordset of a
+// DesignateRecordSet in the zone of its DesignateZone through the Designate API.
+func (r *DesignateRecordSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateRecordSet", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
+	Log := r.GetLogger(ctx)
+
+	// Fetch the DesignateRecordSet instance
+	instance := &designatev1beta1.DesignateRecordSet{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// initialize status if Conditions is nil, but do not reset if it already
+	// exists
+	isNewInstance := instance.Status.Conditions == nil
+	if isNewInstance {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Always patch the instance status when exiting this function so we can
+	// persist any changes.
+	defer func() {
+		// Don't update the status, if Reconciler Panics
+		if r := recover(); r != nil {
+			Log.Info(fmt.Sprintf("Panic during reconcile %v\n", r))
+			panic(r)
+		}
+		condition.RestoreLastTransitionTimes(
+			&instance.Status.Conditions, savedConditions)
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	//
+	// initialize status
+	//
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateRecordSetReadyCondition, condition.InitReason, designatev1beta1.DesignateRecordSetReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) || isNewInstance {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle recordset delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DesignateRecordSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// index zoneRefField
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &designatev1beta1.DesignateRecordSet{}, zoneRefField, func(rawObj client.Object) []string {
+		cr := rawObj.(*designatev1beta1.DesignateRecordSet)
+		return []string{cr.Spec.ZoneRef}
+	}); err != nil {
+		return err
+	}
+
+	// the recordsets are created once the zone has an ID
+	zoneFn := func(ctx context.Context, o client.Object) []reconcile.Request {
+		crList := &designatev1beta1.DesignateRecordSetList{}
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(zoneRefField, o.GetName()),
+			Namespace:     o.GetNamespace(),
+		}
+		if err := r.List(ctx, crList, listOps); err != nil {
+			r.GetLogger(ctx).Error(err, fmt.Sprintf("listing DesignateRecordSets for DesignateZone %s", o.GetName()))
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, item := range crList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+			})
+		}
+		return requests
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&designatev1beta1.DesignateRecordSet{}).
+		WithOptions(controllerOptions()).
+		Watches(&designatev1beta1.DesignateZone{},
+			handler.EnqueueRequestsFromMapFunc(zoneFn)).
+		Complete(r)
+}
+
+func (r *DesignateRecordSetReconciler) reconcileNormal(ctx context.Context, instance *designatev1beta1.DesignateRecordSet, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info(fmt.Sprintf("Reconciling DesignateRecordSet '%s'", instance.Name))
+
+	zone := &designatev1beta1.DesignateZone{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.ZoneRef, Namespace: instance.Namespace}, zone)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.InputReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	var osclient *openstack.OpenStack
+	waitingFor := ""
+	switch {
+	case k8s_errors.IsNotFound(err) || !zone.DeletionTimestamp.IsZero() || zone.Status.ZoneID == "":
+		waitingFor = fmt.Sprintf("DesignateZone %s", instance.Spec.ZoneRef)
+	default:
+		osclient, waitingFor, err = getDesignateDNSClient(ctx, helper, instance.Namespace, zone.Spec.DesignateName)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+	if waitingFor != "" {
+		Log.Info(fmt.Sprintf("Waiting for %s", waitingFor))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.InputReadyWaitingMessage))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateRecordSetReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateRecordSetReadyWaitingMessage,
+			waitingFor))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	// a recreated zone gets a new ID, the recordset has to be created again
+	recordSetID := instance.Status.RecordSetID
+	if instance.Status.ZoneID != zone.Status.ZoneID {
+		recordSetID = ""
+	}
+
+	rrsetOpts := designate.RecordSetOpts{
+		Name:        instance.Spec.Name,
+		Type:        instance.Spec.Type,
+		Records:     instance.Spec.Records,
+		Description: instance.Spec.Description,
+	}
+	if instance.Spec.TTL != nil {
+		rrsetOpts.TTL = int(*instance.Spec.TTL)
+	}
+
+	rrset, err := designate.EnsureRecordSet(ctx, osclient, zone.Status.ZoneID, recordSetID, rrsetOpts)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateRecordSetReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateRecordSetReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	instance.Status.ZoneID = zone.Status.ZoneID
+	instance.Status.RecordSetID = rrset.ID
+	instance.Status.RecordSetStatus = rrset.Status
+
+	switch rrset.Status {
+	case dnsStatusActive:
+		instance.Status.Conditions.MarkTrue(designatev1beta1.DesignateRecordSetReadyCondition, designatev1beta1.DesignateRecordSetReadyMessage)
+	case dnsStatusError:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateRecordSetReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateRecordSetReadyPendingMessage,
+			rrset.Status))
+		// the backends are retried by designate-producer
+		return ctrl.Result{RequeueAfter: time.Duration(60) * time.Second}, nil
+	default:
+		Log.Info(fmt.Sprintf("RecordSet %s %s is %s", rrset.Name, rrset.Type, rrset.Status))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateRecordSetReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateRecordSetReadyPendingMessage,
+			rrset.Status))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// We reached the end of the Reconcile, update the Ready condition based on
+	// the sub conditions
+	if instance.Status.Conditions.AllSubConditionIsTrue() {
+		instance.Status.Conditions.MarkTrue(
+			condition.ReadyCondition, condition.ReadyMessage)
+	}
+	Log.Info(fmt.Sprintf("Reconciled DesignateRecordSet '%s' successfully", instance.Name))
+	return ctrl.Result{}, nil
+}
+
+func (r *DesignateRecordSetReconciler) reconcileDelete(ctx context.Context, instance *designatev1beta1.DesignateRecordSet, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info(fmt.Sprintf("Reconciling DesignateRecordSet '%s' delete", instance.Name))
+
+	zone := &designatev1beta1.DesignateZone{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.ZoneRef, Namespace: instance.Namespace}, zone)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	// Nothing to delete if the recordset was never created or if its zone
+	// goes away with it
+	zoneGone := k8s_errors.IsNotFound(err) || !zone.DeletionTimestamp.IsZero() ||
+		zone.Status.ZoneID != instance.Status.ZoneID
+	if instance.Status.RecordSetID != "" && !zoneGone {
+		gone, err := isDesignateGone(ctx, helper, instance.Namespace, zone.Spec.DesignateName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !gone {
+			osclient, waitingFor, err := getDesignateDNSClient(ctx, helper, instance.Namespace, zone.Spec.DesignateName)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if waitingFor != "" {
+				Log.Info(fmt.Sprintf("Waiting for %s to delete recordset %s", waitingFor, instance.Spec.Name))
+				return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+			}
+			if err := designate.DeleteRecordSet(ctx, osclient, instance.Status.ZoneID, instance.Status.RecordSetID); err != nil {
+				return ctrl.Result{}, err
+			}
+			Log.Info(fmt.Sprintf("RecordSet %s %s deleted", instance.Spec.Name, instance.Spec.Type))
+		}
+	}
+
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info(fmt.Sprintf("Reconciled DesignateRecordSet '%s' delete successfully", instance.Name))
+
+	return ctrl.Result{}, nil
+}
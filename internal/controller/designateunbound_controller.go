@@ -31,6 +31,7 @@ import (
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,8 +46,8 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/statefulset"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -75,6 +76,13 @@ type StubZoneTmplRec struct {
 	Servers []string
 }
 
+// ForwardZoneTmplRec represents a forward zone template record configuration
+type ForwardZoneTmplRec struct {
+	Name       string
+	Options    map[string]string
+	Forwarders []string
+}
+
 func getCIDRsFromNADs(nadList []networkv1.NetworkAttachmentDefinition) ([]string, error) {
 	cidrs := []string{}
 	for _, nad := range nadList {
@@ -82,7 +90,9 @@ func getCIDRsFromNADs(nadList []networkv1.NetworkAttachmentDefinition) ([]string
 		if err != nil {
 			return nil, err
 		}
-		cidrs = append(cidrs, nadConfig.IPAM.CIDR.String())
+		for _, ipRange := range nadConfig.IPAM.Ranges() {
+			cidrs = append(cidrs, ipRange.CIDR.String())
+		}
 	}
 	return cidrs, nil
 }
@@ -93,9 +103,13 @@ func getCIDRsFromNADs(nadList []networkv1.NetworkAttachmentDefinition) ([]string
 //+kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
 //+kubebuilder:rbac:groups=operator.openshift.io,resources=networks,verbs=get;list;watch
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile implementation for designate's Unbound resolver
 func (r *UnboundReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateUnbound", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
 	Log := r.GetLogger(ctx)
 
 	instance := &designatev1.DesignateUnbound{}
@@ -111,6 +125,10 @@ func (r *UnboundReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, err
 	}
 
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -160,8 +178,10 @@ func (r *UnboundReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	cl := condition.CreateList(
 		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
 		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
+		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 	)
 
 	instance.Status.Conditions.Init(&cl)
@@ -208,12 +228,31 @@ func (r *UnboundReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// index tlsEncryptedDNSField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &designatev1.DesignateUnbound{}, tlsEncryptedDNSField, func(rawObj client.Object) []string {
+		// Extract the secret name from the spec, if one is provided
+		cr := rawObj.(*designatev1.DesignateUnbound)
+		if cr.Spec.EncryptedDNS.SecretName == nil {
+			return nil
+		}
+		return []string{*cr.Spec.EncryptedDNS.SecretName}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&designatev1.DesignateUnbound{}).
+		WithOptions(controllerOptions()).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Watches(&topologyv1.Topology{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
@@ -226,6 +265,7 @@ func (r *UnboundReconciler) findObjectsForSrc(ctx context.Context, src client.Ob
 	Log := r.GetLogger(ctx)
 
 	allWatchFields := []string{
+		tlsEncryptedDNSField,
 		topologyField,
 	}
 
@@ -304,6 +344,7 @@ func (r *UnboundReconciler) reconcileNormal(ctx context.Context, instance *desig
 			&instance.Spec.Override.Services[i],
 			serviceLabels,
 			53,
+			designateunbound.EncryptedDNSPorts(&instance.Spec.EncryptedDNS)...,
 		)
 
 		if err != nil {
@@ -362,6 +403,36 @@ func (r *UnboundReconciler) reconcileNormal(ctx context.Context, instance *desig
 	}
 
 	configMapVars := make(map[string]env.Setter)
+
+	//
+	// TLS input validation
+	//
+	// Validate the certificate secret of the encrypted listeners if enabled
+	if instance.Spec.EncryptedDNS.Enabled() {
+		certsHash, err := instance.Spec.EncryptedDNS.ValidateCertSecret(ctx, helper, instance.Namespace)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				// The certificate is issued by cert-manager, which might not have
+				// created the secret yet.
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.TLSInputReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					condition.TLSInputReadyWaitingMessage, err.Error()))
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		configMapVars[tls.TLSHashName] = env.SetValue(certsHash)
+	}
+	instance.Status.Conditions.MarkTrue(condition.TLSInputReadyCondition, condition.InputReadyMessage)
+
 	err := r.generateServiceConfigMaps(ctx, instance, helper, &configMapVars, nadList)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
@@ -432,8 +503,17 @@ func (r *UnboundReconciler) reconcileNormal(ctx context.Context, instance *desig
 	}
 
 	// Define a new Unbound StatefulSet object
-	statefulSetDef := designateunbound.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations, topology)
-	statefulSet := statefulset.NewStatefulSet(
+	statefulSetDef, err := designateunbound.StatefulSet(instance, inputHash, serviceLabels, serviceAnnotations, topology)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	statefulSet := designate.NewStatefulSet(
 		statefulSetDef,
 		time.Duration(5)*time.Second,
 	)
@@ -457,6 +537,23 @@ func (r *UnboundReconciler) reconcileNormal(ctx context.Context, instance *desig
 			condition.DeploymentReadyRunningMessage))
 		return ctrlResult, nil
 	}
+	// the workload is only rendered into the preview ConfigMap
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	ctrlResult, err = designate.ReconcilePodDisruptionBudget(
+		ctx,
+		helper,
+		instance.Name,
+		instance.Namespace,
+		serviceLabels,
+		*statefulSetDef.Spec.Replicas,
+		instance.Spec.PodDisruptionBudget,
+	)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
 
 	deploy := statefulSet.GetStatefulSet()
 	if deploy.Generation == deploy.Status.ObservedGeneration {
@@ -495,6 +592,17 @@ func (r *UnboundReconciler) reconcileNormal(ctx context.Context, instance *desig
 
 			return ctrl.Result{}, err
 		}
+
+		if len(instance.Spec.NetworkAttachments) > 0 {
+			podIPs, err := designate.GetPodNetworkIPs(ctx, helper, instance.Name, instance.Namespace, instance.Spec.NetworkAttachments[0])
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			instance.Status.PodIPs = podIPs
+		} else {
+			instance.Status.PodIPs = nil
+		}
+
 		// Mark the Deployment as Ready only if the number of Replicas is equals
 		// to the Deployed instances (ReadyCount), and the the Status.Replicas
 		// match Status.ReadyReplicas. If a deployment update is in progress,
@@ -534,6 +642,21 @@ func (r *UnboundReconciler) onIPChange() (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// getStubZoneServers returns the addresses of the bind pods in the bind IP
+// map, in pod order. On dual stack networks every pod is listed with each of
+// its addresses.
+func getStubZoneServers(data map[string]string) []string {
+	var servers []string
+	for i := 0; ; i++ {
+		value, ok := data[fmt.Sprintf("bind_address_%d", i)]
+		if !ok {
+			break
+		}
+		servers = append(servers, designate.SplitPredictableIPs(value)...)
+	}
+	return servers
+}
+
 // Set stub zone configuration defaults if they are net set by CR data.
 func stubZoneDefaults(values map[string]string) map[string]string {
 
@@ -603,6 +726,32 @@ func (r *UnboundReconciler) getClusterJoinSubnets(ctx context.Context) []string
 	return joinSubnets
 }
 
+// unboundTuningParameters returns the thread, cache and rate limit settings
+// of the unbound servers, falling back to the defaults for fields left empty
+func unboundTuningParameters(spec *designatev1.DesignateUnboundSpecBase) map[string]any {
+	params := map[string]any{
+		"NumThreads":     int32(designateunbound.DefaultNumThreads),
+		"MsgCacheSize":   designateunbound.DefaultMsgCacheSize,
+		"RRSetCacheSize": designateunbound.DefaultRRSetCacheSize,
+		"CacheMinTTL":    spec.CacheMinTTL,
+		"CacheMaxTTL":    int32(designateunbound.DefaultCacheMaxTTL),
+		"RateLimit":      spec.RateLimit,
+	}
+	if spec.NumThreads > 0 {
+		params["NumThreads"] = spec.NumThreads
+	}
+	if spec.MsgCacheSize != "" {
+		params["MsgCacheSize"] = spec.MsgCacheSize
+	}
+	if spec.RRSetCacheSize != "" {
+		params["RRSetCacheSize"] = spec.RRSetCacheSize
+	}
+	if spec.CacheMaxTTL > 0 {
+		params["CacheMaxTTL"] = spec.CacheMaxTTL
+	}
+	return params
+}
+
 func (r *UnboundReconciler) generateServiceConfigMaps(
 	ctx context.Context,
 	instance *designatev1.DesignateUnbound,
@@ -637,11 +786,7 @@ func (r *UnboundReconciler) generateServiceConfigMaps(
 			}
 			return err
 		}
-		bindIPs := make([]string, len(bindIPMap.Data))
-		keyTmpl := "bind_address_%d"
-		for i := 0; i < len(bindIPMap.Data); i++ {
-			bindIPs[i] = bindIPMap.Data[fmt.Sprintf(keyTmpl, i)]
-		}
+		bindIPs := getStubZoneServers(bindIPMap.Data)
 
 		for i := 0; i < len(instance.Spec.StubZones); i++ {
 			stubZoneData[i] = StubZoneTmplRec{
@@ -665,6 +810,22 @@ func (r *UnboundReconciler) generateServiceConfigMaps(
 	}
 	allowCidrs = append(allowCidrs, nadCIDRs...)
 	templateParameters["AllowCidrs"] = allowCidrs
+	templateParameters["AccessControl"] = instance.Spec.AccessControl
+	templateParameters["ClientSubnet"] = instance.Spec.ClientSubnet
+
+	forwardZoneData := make([]ForwardZoneTmplRec, len(instance.Spec.ForwardZones))
+	for i, zone := range instance.Spec.ForwardZones {
+		forwardZoneData[i] = ForwardZoneTmplRec{
+			Name:       zone.Name,
+			Options:    zone.Options,
+			Forwarders: zone.Forwarders,
+		}
+	}
+	templateParameters["ForwardZones"] = forwardZoneData
+	templateParameters["DebugLogging"] = designate.DebugLogging(instance.Spec.Logging)
+
+	maps.Copy(templateParameters, unboundTuningParameters(&instance.Spec.DesignateUnboundSpecBase))
+	maps.Copy(templateParameters, designateunbound.EncryptedDNSParameters(&instance.Spec.EncryptedDNS))
 
 	cms := []util.Template{
 		// ConfigMap
@@ -678,7 +839,7 @@ func (r *UnboundReconciler) generateServiceConfigMaps(
 			Labels:        cmLabels,
 		},
 	}
-	err := secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+	err := ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
 
 	if err != nil {
 		Log.Error(err, "unable to process config map")
@@ -698,6 +859,7 @@ func (r *UnboundReconciler) createHashOfInputHashes(
 	var hashMap map[string]string
 	changed := false
 	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	instance.Status.InputHashes = inputHashes(mergedMapVars)
 	hash, err := util.ObjectHash(mergedMapVars)
 	if err != nil {
 		return hash, changed, err
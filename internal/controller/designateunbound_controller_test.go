@@ -23,6 +23,7 @@ import (
 
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/designate-operator/internal/designateunbound"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -74,6 +75,20 @@ func Test_getCIDRsFromNADs(t *testing.T) {
 			want:    []string{"172.20.10.0/24"},
 			wantErr: false,
 		},
+		{
+			name: "dual-stack-nad",
+			args: args{
+				nadList: []networkv1.NetworkAttachmentDefinition{
+					{
+						Spec: networkv1.NetworkAttachmentDefinitionSpec{
+							Config: `{"cniVersion": "0.3.1", "name": "designate-nad", "type": "macvlan", "master": "eth0.26", "ipam": {"type": "whereabouts", "ipRanges": [{"range": "172.20.10.0/24", "range_start": "172.20.10.10", "range_end": "172.20.10.20"}, {"range": "fd00:bbbb::/64", "range_start": "fd00:bbbb::10", "range_end": "fd00:bbbb::20"}]}}`,
+						},
+					},
+				},
+			},
+			want:    []string{"172.20.10.0/24", "fd00:bbbb::/64"},
+			wantErr: false,
+		},
 		{
 			name: "no-nads",
 			args: args{
@@ -236,3 +251,90 @@ func TestUnboundReconciler_getClusterJoinSubnets(t *testing.T) {
 		})
 	}
 }
+
+func Test_getStubZoneServers(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+		want []string
+	}{
+		{
+			name: "single stack",
+			data: map[string]string{
+				"bind_address_0": "172.28.0.40",
+				"bind_address_1": "172.28.0.41",
+				"rndc_key_0":     "rndc-key-0",
+				"rndc_key_1":     "rndc-key-1",
+			},
+			want: []string{"172.28.0.40", "172.28.0.41"},
+		},
+		{
+			name: "dual stack",
+			data: map[string]string{
+				"bind_address_0": "172.28.0.40,fd00:bbbb::40",
+				"rndc_key_0":     "rndc-key-0",
+			},
+			want: []string{"172.28.0.40", "fd00:bbbb::40"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getStubZoneServers(tt.data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getStubZoneServers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unboundTuningParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		spec designatev1.DesignateUnboundSpecBase
+		want map[string]any
+	}{
+		{
+			name: "defaults",
+			spec: designatev1.DesignateUnboundSpecBase{},
+			want: map[string]any{
+				"NumThreads":     int32(1),
+				"MsgCacheSize":   "50m",
+				"RRSetCacheSize": "100m",
+				"CacheMinTTL":    int32(0),
+				"CacheMaxTTL":    int32(86400),
+				"RateLimit":      (*designatev1.UnboundRateLimitSpec)(nil),
+			},
+		},
+		{
+			name: "tuned",
+			spec: designatev1.DesignateUnboundSpecBase{
+				NumThreads:     4,
+				MsgCacheSize:   "128m",
+				RRSetCacheSize: "256m",
+				CacheMinTTL:    60,
+				CacheMaxTTL:    3600,
+				RateLimit: &designatev1.UnboundRateLimitSpec{
+					QueriesPerSecond:   500,
+					IPQueriesPerSecond: 50,
+				},
+			},
+			want: map[string]any{
+				"NumThreads":     int32(4),
+				"MsgCacheSize":   "128m",
+				"RRSetCacheSize": "256m",
+				"CacheMinTTL":    int32(60),
+				"CacheMaxTTL":    int32(3600),
+				"RateLimit": &designatev1.UnboundRateLimitSpec{
+					QueriesPerSecond:   500,
+					IPQueriesPerSecond: 50,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unboundTuningParameters(&tt.spec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unboundTuningParameters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,8 +22,11 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -93,6 +96,8 @@ func (r *DesignateWorkerReconciler) GetLogger(ctx context.Context) logr.Logger {
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;update;patch;delete;watch
 // +kubebuilder:rbac:groups=k8s.cni.cncf.io,resources=network-attachment-definitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=topology.openstack.org,resources=topologies,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -104,6 +109,9 @@ func (r *DesignateWorkerReconciler) GetLogger(ctx context.Context) logr.Logger {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.12.2/pkg/reconcile
 func (r *DesignateWorkerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateWorker", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
 	Log := r.GetLogger(ctx)
 
 	// Fetch the DesignateWorker instance
@@ -120,6 +128,10 @@ func (r *DesignateWorkerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
 	helper, err := helper.NewHelper(
 		instance,
 		r.Client,
@@ -170,6 +182,7 @@ func (r *DesignateWorkerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateConfigInSyncCondition, condition.InitReason, designatev1beta1.DesignateConfigInSyncInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
 		condition.UnknownCondition(condition.NetworkAttachmentsReadyCondition, condition.InitReason, condition.NetworkAttachmentsReadyInitMessage),
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
@@ -331,7 +344,12 @@ func (r *DesignateWorkerReconciler) SetupWithManager(ctx context.Context, mgr ct
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&designatev1beta1.DesignateWorker{}).
+		WithOptions(controllerOptions()).
 		Owns(&appsv1.Deployment{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		// the generated config Secrets, to detect their out of band modifications
+		Owns(&corev1.Secret{}).
 		Owns(&corev1.Service{}).
 		// watch the secrets we don't own
 		Watches(&corev1.Secret{},
@@ -448,21 +466,16 @@ func (r *DesignateWorkerReconciler) reconcileNormal(ctx context.Context, instanc
 	// run check TransportURL secret - end
 
 	//
-	// TODO(beagles 03/2025): this block of code repeats throughout designate. It appears that the idea here was to allow custom
-	// config snippets to be stored in secrets and used later on. This was not used anywhere however. Maybe this
-	// was intended to be part of supporting alternate backends? I will comment out for now and add a warning if the
-	// parameter is used.
+	// check for the Secrets holding CustomServiceConfig snippets, the init
+	// container merges their content into custom.conf
 	//
-	// for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
-	//	ctrlResult, err = r.getSecret(ctx, helper, instance, secretName, &configMapVars, "secret-")
-	//	if err != nil {
-	//		return ctrlResult, err
-	//	}
-	//}
-	// run check service secrets - end
-	if len(instance.Spec.CustomServiceConfigSecrets) > 0 {
-		Log.Info("warning: CustomServiceConfigSecrets is not supported.")
+	for _, secretName := range instance.Spec.CustomServiceConfigSecrets {
+		ctrlResult, err = getSecret(ctx, helper, instance.Namespace, &instance.Status.Conditions, secretName, &configMapVars, "secret-")
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
 	}
+	// run check service secrets - end
 
 	//
 	// check for required Designate config maps that should have been created by parent Designate CR
@@ -554,6 +567,28 @@ func (r *DesignateWorkerReconciler) reconcileNormal(ctx context.Context, instanc
 	}
 	// Create ConfigMaps - end
 
+	// The rndc key rotation state the pods are going to run with
+	rndcRotationState, err := designate.GetRndcRotationStateFromSecret(ctx, helper.GetClient(), instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	configMapVars[designate.RndcRotationHash] = env.SetValue(rndcRotationState)
+
+	// The client certificates of the coordination backend
+	coordinationSecretHash, err := designate.GetCoordinationSecretHash(ctx, helper.GetClient(), instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if coordinationSecretHash != "" {
+		configMapVars[designate.DesignateCoordinationSecret] = env.SetValue(coordinationSecretHash)
+	}
+
 	//
 	// create hash over all the different input resources to identify if any those changed
 	// and a restart/recreate is required.
@@ -668,8 +703,27 @@ func (r *DesignateWorkerReconciler) reconcileNormal(ctx context.Context, instanc
 	//
 
 	// Define a new Deployment object
-	deplDef := designateworker.Deployment(instance, inputHash, serviceLabels, serviceAnnotations, topology)
-	depl := deployment.NewDeployment(
+	deplDef, err := designateworker.Deployment(instance, inputHash, serviceLabels, serviceAnnotations, topology)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	err = designate.ReconcileAutoscaling(ctx, helper, deplDef, instance.Spec.Autoscaling)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	depl := designate.NewDeployment(
 		deplDef,
 		time.Duration(5)*time.Second,
 	)
@@ -691,15 +745,33 @@ func (r *DesignateWorkerReconciler) reconcileNormal(ctx context.Context, instanc
 			condition.DeploymentReadyRunningMessage))
 		return ctrlResult, nil
 	}
+	// the workload is only rendered into the preview ConfigMap
+	if isPreviewed(Log, &instance.Status.Conditions, condition.DeploymentReadyCondition, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	ctrlResult, err = designate.ReconcilePodDisruptionBudget(
+		ctx,
+		helper,
+		instance.Name,
+		instance.Namespace,
+		serviceLabels,
+		*deplDef.Spec.Replicas,
+		instance.Spec.PodDisruptionBudget,
+	)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
 
 	deploy := depl.GetDeployment()
 	if deploy.Generation == deploy.Status.ObservedGeneration {
 		instance.Status.ReadyCount = deploy.Status.ReadyReplicas
+		instance.Status.Selector = metav1.FormatLabelSelector(deploy.Spec.Selector)
 
 		// verify if network attachment matches expectations
 		networkReady := false
 		networkAttachmentStatus := map[string][]string{}
-		if *(instance.Spec.Replicas) > 0 {
+		if *deplDef.Spec.Replicas > 0 {
 			networkReady, networkAttachmentStatus, err = nad.VerifyNetworkStatusFromAnnotation(
 				ctx,
 				helper,
@@ -752,6 +824,8 @@ func (r *DesignateWorkerReconciler) reconcileNormal(ctx context.Context, instanc
 	if instance.Status.Conditions.AllSubConditionIsTrue() {
 		instance.Status.Conditions.MarkTrue(
 			condition.ReadyCondition, condition.ReadyMessage)
+		// the pods run with the rndc keys of this rotation state
+		instance.Status.Hash[designate.RndcRotationHash] = rndcRotationState
 	}
 	Log.Info("Reconciled Service successfully")
 	return ctrl.Result{}, nil
@@ -794,10 +868,6 @@ func (r *DesignateWorkerReconciler) generateServiceConfigMaps(
 
 	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(instance.Name), map[string]string{})
 
-	db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
-	if err != nil {
-		return err
-	}
 	var tlsCfg *tls.Service
 	if instance.Spec.TLS.CaBundleSecretName != "" {
 		tlsCfg = &tls.Service{}
@@ -808,7 +878,18 @@ func (r *DesignateWorkerReconciler) generateServiceConfigMaps(
 	// TODO: make sure custom.conf can not be overwritten
 	customData := map[string]string{
 		common.CustomServiceConfigFileName: instance.Spec.CustomServiceConfig,
-		"my.cnf":                           db.GetDatabaseClientConfig(tlsCfg), //(oschwart) for now just get the default my.cnf
+	}
+	// with an external database, the my.cnf of the Designate CR is used
+	if !instance.Spec.ExternalDatabase {
+		db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, designate.DatabaseName, instance.Spec.DatabaseAccount, instance.Namespace)
+		if err != nil {
+			return err
+		}
+		customData["my.cnf"] = db.GetDatabaseClientConfig(tlsCfg) //(oschwart) for now just get the default my.cnf
+	}
+
+	templateParameters := map[string]any{
+		"Logging": designate.LoggingTemplateParameters(instance.Spec.Logging),
 	}
 
 	cms := []util.Template{
@@ -818,7 +899,7 @@ func (r *DesignateWorkerReconciler) generateServiceConfigMaps(
 			Type:          util.TemplateTypeConfig,
 			InstanceType:  instance.Kind,
 			CustomData:    customData,
-			ConfigOptions: make(map[string]any),
+			ConfigOptions: templateParameters,
 			Labels:        cmLabels,
 		},
 		{
@@ -831,7 +912,7 @@ func (r *DesignateWorkerReconciler) generateServiceConfigMaps(
 		},
 	}
 
-	return secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+	return ensureConfigSecrets(ctx, h, instance, &instance.Status.Conditions, instance.Spec.ConfigDriftPolicy, cms, envVars)
 }
 
 // createHashOfInputHashes - creates a hash of hashes which gets added to the resources which requires a restart
@@ -864,7 +945,24 @@ func (r *DesignateWorkerReconciler) createHashOfInputHashes(
 	}
 
 	envVars[designate.DesignateBindKeySecret] = env.SetValue(secretHash)
+
+	// The rndc keys of the external BIND servers
+	externalRndcSecret := &corev1.Secret{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{
+		Name:      designate.DesignateExternalBindKeySecret,
+		Namespace: instance.Namespace,
+	}, externalRndcSecret)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return "", false, err
+	} else if err == nil {
+		externalSecretHash, err := secret.Hash(externalRndcSecret)
+		if err != nil {
+			return externalSecretHash, changed, err
+		}
+		envVars[designate.DesignateExternalBindKeySecret] = env.SetValue(externalSecretHash)
+	}
 	mergedMapVars := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	instance.Status.InputHashes = inputHashes(mergedMapVars)
 	hash, err := util.ObjectHash(mergedMapVars)
 	if err != nil {
 		return hash, changed, err
@@ -0,0 +1,405 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// zoneRefField - index of the DesignateZone a DesignateRecordSet belongs to
+	zoneRefField = ".spec.zoneRef"
+
+	// dnsStatusActive - status of a zone or recordset provisioned on the backends
+	dnsStatusActive = "ACTIVE"
+	// dnsStatusError - status of a zone or recordset the backends failed to provision
+	dnsStatusError = "ERROR"
+)
+
+// GetClient -
+func (r *DesignateZoneReconciler) GetClient() client.Client {
+	return r.Client
+}
+
+// GetKClient -
+func (r *DesignateZoneReconciler) GetKClient() kubernetes.Interface {
+	return r.Kclient
+}
+
+// GetScheme -
+func (r *DesignateZoneReconciler) GetScheme() *runtime.Scheme {
+	return r.Scheme
+}
+
+// DesignateZoneReconciler reconciles a DesignateZone object
+type DesignateZoneReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+// GetLogger returns a logger object with a prefix of "controller.name" and additional controller context fields
+func (r *DesignateZoneReconciler) GetLogger(ctx context.Context) logr.Logger {
+	return log.FromContext(ctx).WithName("Controllers").WithName("DesignateZone")
+}
+
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatezones,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatezones/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designatezones/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designaterecordsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=designate.openstack.org,resources=designates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=keystone.openstack.org,resources=keystoneapis,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// Reconcile creates, updates and deletes the zone of a DesignateZone through
+// the Designate API and reports its serial and status.
+func (r *DesignateZoneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+	ctx, span := startReconcileSpan(ctx, "DesignateZone", req)
+	defer func() { endReconcileSpan(span, _err) }()
+
+	Log := r.GetLogger(ctx)
+
+	// Fetch the DesignateZone instance
+	instance := &designatev1beta1.DesignateZone{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if isReconcilePaused(Log, instance) {
+		return ctrl.Result{}, nil
+	}
+
+	helper, err := helper.NewHelper(
+		instance,
+		r.Client,
+		r.Kclient,
+		r.Scheme,
+		Log,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// initialize status if Conditions is nil, but do not reset if it already
+	// exists
+	isNewInstance := instance.Status.Conditions == nil
+	if isNewInstance {
+		instance.Status.Conditions = condition.Conditions{}
+	}
+
+	// Save a copy of the condtions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change.
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Always patch the instance status when exiting this function so we can
+	// persist any changes.
+	defer func() {
+		// Don't update the status, if Reconciler Panics
+		if r := recover(); r != nil {
+			Log.Info(fmt.Sprintf("Panic during reconcile %v\n", r))
+			panic(r)
+		}
+		condition.RestoreLastTransitionTimes(
+			&instance.Status.Conditions, savedConditions)
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(
+				instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		err := helper.PatchInstance(ctx, instance)
+		if err != nil {
+			_err = err
+			return
+		}
+	}()
+
+	//
+	// initialize status
+	//
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
+		condition.UnknownCondition(designatev1beta1.DesignateZoneReadyCondition, condition.InitReason, designatev1beta1.DesignateZoneReadyInitMessage),
+	)
+
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	// If we're not deleting this and the service object doesn't have our finalizer, add it.
+	if instance.DeletionTimestamp.IsZero() && controllerutil.AddFinalizer(instance, helper.GetFinalizer()) || isNewInstance {
+		return ctrl.Result{}, nil
+	}
+
+	// Handle zone delete
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, helper)
+	}
+
+	return r.reconcileNormal(ctx, instance, helper)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DesignateZoneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// index designateNameField
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &designatev1beta1.DesignateZone{}, designateNameField, func(rawObj client.Object) []string {
+		cr := rawObj.(*designatev1beta1.DesignateZone)
+		return []string{cr.Spec.DesignateName}
+	}); err != nil {
+		return err
+	}
+
+	// reconcile the zones of a Designate CR when it changes, e.g. once the
+	// API is ready
+	designateFn := func(ctx context.Context, o client.Object) []reconcile.Request {
+		crList := &designatev1beta1.DesignateZoneList{}
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(designateNameField, o.GetName()),
+			Namespace:     o.GetNamespace(),
+		}
+		if err := r.List(ctx, crList, listOps); err != nil {
+			r.GetLogger(ctx).Error(err, fmt.Sprintf("listing DesignateZones for Designate %s", o.GetName()))
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, item := range crList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace},
+			})
+		}
+		return requests
+	}
+
+	// the serial of the zone changes with its recordsets
+	recordSetFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		cr := o.(*designatev1beta1.DesignateRecordSet)
+		return []reconcile.Request{{
+			NamespacedName: types.NamespacedName{Name: cr.Spec.ZoneRef, Namespace: cr.Namespace},
+		}}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&designatev1beta1.DesignateZone{}).
+		WithOptions(controllerOptions()).
+		Watches(&designatev1beta1.Designate{},
+			handler.EnqueueRequestsFromMapFunc(designateFn)).
+		Watches(&designatev1beta1.DesignateRecordSet{},
+			handler.EnqueueRequestsFromMapFunc(recordSetFn)).
+		Complete(r)
+}
+
+func (r *DesignateZoneReconciler) reconcileNormal(ctx context.Context, instance *designatev1beta1.DesignateZone, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info(fmt.Sprintf("Reconciling DesignateZone '%s'", instance.Name))
+
+	osclient, waitingFor, err := getDesignateDNSClient(ctx, helper, instance.Namespace, instance.Spec.DesignateName)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.InputReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if waitingFor != "" {
+		Log.Info(fmt.Sprintf("Waiting for %s", waitingFor))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.InputReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.InputReadyWaitingMessage))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateZoneReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateZoneReadyWaitingMessage,
+			waitingFor))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
+
+	zoneOpts := designate.ZoneOpts{
+		Name:        instance.Spec.ZoneName,
+		Type:        string(instance.Spec.Type),
+		Email:       instance.Spec.Email,
+		Description: instance.Spec.Description,
+		Masters:     instance.Spec.Masters,
+		Attributes:  instance.Spec.Attributes,
+	}
+	if instance.Spec.TTL != nil {
+		zoneOpts.TTL = int(*instance.Spec.TTL)
+	}
+
+	zone, err := designate.EnsureZone(ctx, osclient, instance.Status.ZoneID, zoneOpts)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateZoneReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateZoneReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	instance.Status.ZoneID = zone.ID
+	instance.Status.Serial = int64(zone.Serial)
+	instance.Status.ZoneStatus = zone.Status
+	instance.Status.PoolID = zone.PoolID
+
+	switch zone.Status {
+	case dnsStatusActive:
+		instance.Status.Conditions.MarkTrue(designatev1beta1.DesignateZoneReadyCondition, designatev1beta1.DesignateZoneReadyMessage)
+	case dnsStatusError:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateZoneReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			designatev1beta1.DesignateZoneReadyPendingMessage,
+			zone.Status))
+		// the backends are retried by designate-producer
+		return ctrl.Result{RequeueAfter: time.Duration(60) * time.Second}, nil
+	default:
+		Log.Info(fmt.Sprintf("Zone %s is %s", zone.Name, zone.Status))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			designatev1beta1.DesignateZoneReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			designatev1beta1.DesignateZoneReadyPendingMessage,
+			zone.Status))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// We reached the end of the Reconcile, update the Ready condition based on
+	// the sub conditions
+	if instance.Status.Conditions.AllSubConditionIsTrue() {
+		instance.Status.Conditions.MarkTrue(
+			condition.ReadyCondition, condition.ReadyMessage)
+	}
+	Log.Info(fmt.Sprintf("Reconciled DesignateZone '%s' successfully", instance.Name))
+	return ctrl.Result{}, nil
+}
+
+func (r *DesignateZoneReconciler) reconcileDelete(ctx context.Context, instance *designatev1beta1.DesignateZone, helper *helper.Helper) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	Log.Info(fmt.Sprintf("Reconciling DesignateZone '%s' delete", instance.Name))
+
+	// Nothing to delete if the zone was never created
+	if instance.Status.ZoneID != "" {
+		gone, err := isDesignateGone(ctx, helper, instance.Namespace, instance.Spec.DesignateName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if gone {
+			// the zones go away with the designate database
+			Log.Info(fmt.Sprintf("Designate %s is gone, not deleting zone %s", instance.Spec.DesignateName, instance.Spec.ZoneName))
+		} else {
+			osclient, waitingFor, err := getDesignateDNSClient(ctx, helper, instance.Namespace, instance.Spec.DesignateName)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if waitingFor != "" {
+				Log.Info(fmt.Sprintf("Waiting for %s to delete zone %s", waitingFor, instance.Spec.ZoneName))
+				return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+			}
+			if err := designate.DeleteZone(ctx, osclient, instance.Status.ZoneID); err != nil {
+				return ctrl.Result{}, err
+			}
+			Log.Info(fmt.Sprintf("Zone %s deleted", instance.Spec.ZoneName))
+		}
+	}
+
+	controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+	Log.Info(fmt.Sprintf("Reconciled DesignateZone '%s' delete successfully", instance.Name))
+
+	return ctrl.Result{}, nil
+}
+
+// isDesignateGone returns true if the Designate CR does not exist or is being
+// deleted, the DNS resources it hosts do not need to be cleaned up then
+func isDesignateGone(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	designateName string,
+) (bool, error) {
+	designateInstance := &designatev1beta1.Designate{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: designateName, Namespace: namespace}, designateInstance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return !designateInstance.DeletionTimestamp.IsZero(), nil
+}
+
+// getDesignateDNSClient returns a client authenticated as the designate
+// service user once the API of the Designate CR is ready. If it is not, the
+// client is nil and waitingFor describes what is missing.
+func getDesignateDNSClient(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	designateName string,
+) (*openstack.OpenStack, string, error) {
+	designateInstance := &designatev1beta1.Designate{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: designateName, Namespace: namespace}, designateInstance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil, fmt.Sprintf("Designate %s", designateName), nil
+		}
+		return nil, "", err
+	}
+	if !designateInstance.Status.Conditions.IsTrue(designatev1beta1.DesignateAPIReadyCondition) ||
+		designateInstance.Status.DesignateCentralReadyCount == 0 {
+		return nil, "the Designate API", nil
+	}
+
+	osclient, ctrlResult, err := designate.GetDesignateServiceClient(ctx, h, designateInstance)
+	if err != nil {
+		return nil, "", err
+	}
+	if (ctrlResult != ctrl.Result{}) || osclient == nil {
+		return nil, "the designate service password", nil
+	}
+	return osclient, "", nil
+}
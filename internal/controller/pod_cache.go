@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateapi"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendbind9"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatebackendpdns"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatecentral"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatemdns"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateproducer"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designatesink"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateunbound"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designateworker"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// podOwnerField indexes the pods by the name of the StatefulSet controlling them
+const podOwnerField = ".metadata.controller"
+
+// PodCacheSelector returns the selector of the pods the manager caches, the
+// pods of the designate services. The pods of the other services of the
+// namespaces and the job pods, which are read directly, are not cached.
+func PodCacheSelector() labels.Selector {
+	requirement, err := labels.NewRequirement(common.ComponentSelector, selection.In, []string{
+		designateapi.Component,
+		designatebackendbind9.Component,
+		designatebackendpdns.Component,
+		designatecentral.Component,
+		designatemdns.Component,
+		designateproducer.Component,
+		designatesink.Component,
+		designateunbound.Component,
+		designateworker.Component,
+	})
+	if err != nil {
+		// the components are valid label values
+		panic(err)
+	}
+	return labels.NewSelector().Add(*requirement)
+}
+
+// componentPodPredicate filters the pod events to the pods of a component
+func componentPodPredicate(component string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetLabels()[common.ComponentSelector] == component
+	})
+}
+
+// podOwnerIndex returns the name of the StatefulSet controlling a pod
+func podOwnerIndex(rawObj client.Object) []string {
+	pod := rawObj.(*corev1.Pod)
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.APIVersion != appsv1.SchemeGroupVersion.String() || owner.Kind != "StatefulSet" {
+		return nil
+	}
+	return []string{owner.Name}
+}
+
+// podIPsChangedPredicate filters the pod events to the creations, the
+// deletions and the updates changing the addresses of the pods
+var podIPsChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, oldOk := e.ObjectOld.(*corev1.Pod)
+		newPod, newOk := e.ObjectNew.(*corev1.Pod)
+		if !oldOk || !newOk {
+			return false
+		}
+		return !slices.Equal(oldPod.Status.PodIPs, newPod.Status.PodIPs)
+	},
+}
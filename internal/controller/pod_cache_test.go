@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPodCacheSelector(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels labels.Set
+		want   bool
+	}{
+		{
+			name:   "designate service pod",
+			labels: labels.Set{common.AppSelector: "designate-backendbind9", common.ComponentSelector: "designate-backendbind9"},
+			want:   true,
+		},
+		{
+			name:   "other service pod",
+			labels: labels.Set{common.AppSelector: "nova", common.ComponentSelector: "nova-api"},
+			want:   false,
+		},
+		{
+			name:   "job pod",
+			labels: labels.Set{"job-name": "designate-db-sync"},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PodCacheSelector().Matches(tt.labels); got != tt.want {
+				t.Errorf("PodCacheSelector().Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_podOwnerIndex(t *testing.T) {
+	isController := true
+	newPod := func(apiVersion string, kind string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "designate-backendbind9-0",
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: apiVersion, Kind: kind, Name: "designate-backendbind9", Controller: &isController},
+				},
+			},
+		}
+	}
+
+	if got := podOwnerIndex(newPod("apps/v1", "StatefulSet")); !reflect.DeepEqual(got, []string{"designate-backendbind9"}) {
+		t.Errorf("podOwnerIndex() = %v, want [designate-backendbind9]", got)
+	}
+	if got := podOwnerIndex(newPod("batch/v1", "Job")); got != nil {
+		t.Errorf("podOwnerIndex() = %v, want nil", got)
+	}
+	if got := podOwnerIndex(&corev1.Pod{}); got != nil {
+		t.Errorf("podOwnerIndex() = %v, want nil", got)
+	}
+}
+
+func Test_podIPsChangedPredicate(t *testing.T) {
+	newPod := func(ips ...string) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "designate-mdns-0"}}
+		for _, ip := range ips {
+			pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name   string
+		oldPod *corev1.Pod
+		newPod *corev1.Pod
+		want   bool
+	}{
+		{name: "address assigned", oldPod: newPod(), newPod: newPod("10.217.0.12"), want: true},
+		{name: "address changed", oldPod: newPod("10.217.0.12"), newPod: newPod("10.217.0.31"), want: true},
+		{name: "second family added", oldPod: newPod("10.217.0.12"), newPod: newPod("10.217.0.12", "fd00::12"), want: true},
+		{name: "same address", oldPod: newPod("10.217.0.12"), newPod: newPod("10.217.0.12"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := event.UpdateEvent{ObjectOld: tt.oldPod, ObjectNew: tt.newPod}
+			if got := podIPsChangedPredicate.Update(e); got != tt.want {
+				t.Errorf("podIPsChangedPredicate.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !podIPsChangedPredicate.Delete(event.DeleteEvent{Object: newPod("10.217.0.12")}) {
+		t.Errorf("podIPsChangedPredicate.Delete() = false, want true")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReconcileOptions - the reconcile concurrency and work queue rate limits of
+// all the controllers
+type ReconcileOptions struct {
+	// MaxConcurrentReconciles - the number of objects of a kind reconciled in parallel
+	MaxConcurrentReconciles int
+	// RateLimiterBaseDelay - the delay of the first retry of a failed reconcile,
+	// doubled at each following failure
+	RateLimiterBaseDelay time.Duration
+	// RateLimiterMaxDelay - the maximum delay between the retries of a failed reconcile
+	RateLimiterMaxDelay time.Duration
+	// RateLimiterQPS - the overall rate of the reconciles of a controller
+	RateLimiterQPS float64
+	// RateLimiterBurst - the burst of the reconciles of a controller above RateLimiterQPS
+	RateLimiterBurst int
+}
+
+// DefaultReconcileOptions returns the controller-runtime defaults: a single
+// worker and the default controller rate limiter
+func DefaultReconcileOptions() ReconcileOptions {
+	return ReconcileOptions{
+		MaxConcurrentReconciles: 1,
+		RateLimiterBaseDelay:    5 * time.Millisecond,
+		RateLimiterMaxDelay:     1000 * time.Second,
+		RateLimiterQPS:          10,
+		RateLimiterBurst:        100,
+	}
+}
+
+// reconcileOptions are used by the SetupWithManager of all the controllers
+var reconcileOptions = DefaultReconcileOptions()
+
+// SetupReconcileOptions sets the reconcile options of the controllers, it
+// must be called before they are set up with the manager
+func SetupReconcileOptions(options ReconcileOptions) {
+	reconcileOptions = options
+}
+
+// controllerOptions returns the controller-runtime options of a controller
+// from the reconcile options
+func controllerOptions() controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: reconcileOptions.MaxConcurrentReconciles,
+		RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](
+				reconcileOptions.RateLimiterBaseDelay, reconcileOptions.RateLimiterMaxDelay),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{
+				Limiter: rate.NewLimiter(rate.Limit(reconcileOptions.RateLimiterQPS), reconcileOptions.RateLimiterBurst),
+			},
+		),
+	}
+}
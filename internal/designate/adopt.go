@@ -0,0 +1,137 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The objects of a deployment migrated under the management of the operator,
+// created by hand or by a previous version of the operator, are adopted when
+// they carry the labels the operator sets on them: a controller reference to
+// an owner which no longer exists, e.g. a Designate CR deleted without its
+// dependents, is dropped so the operator becomes their controller instead of
+// failing to own them. The objects with a live controller are left alone.
+
+// labelsMatch returns if obj carries all the labels
+func labelsMatch(obj client.Object, labels map[string]string) bool {
+	current := obj.GetLabels()
+	for k, v := range labels {
+		if current[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// staleController returns the index in the owner references of obj of its
+// controller when it no longer exists, or -1. A controller which can't be
+// read, e.g. of a kind the operator has no access to, is considered live.
+func staleController(ctx context.Context, c client.Client, obj client.Object) (int, error) {
+	for i, ref := range obj.GetOwnerReferences() {
+		if ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+		owner := &unstructured.Unstructured{}
+		owner.SetAPIVersion(ref.APIVersion)
+		owner.SetKind(ref.Kind)
+		err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: obj.GetNamespace()}, owner)
+		switch {
+		case k8s_errors.IsNotFound(err):
+			return i, nil
+		case k8s_errors.IsForbidden(err) || meta.IsNoMatchError(err):
+			return -1, nil
+		case err != nil:
+			return -1, err
+		case owner.GetUID() != ref.UID:
+			// an owner re-created with the same name
+			return i, nil
+		}
+		return -1, nil
+	}
+	return -1, nil
+}
+
+// releaseStaleController drops the controller reference of obj, the current
+// state of an object, when it carries the labels and its controller no longer
+// exists. It returns the controller which was dropped, or "".
+func releaseStaleController(ctx context.Context, c client.Client, obj client.Object, labels map[string]string) (string, error) {
+	if !labelsMatch(obj, labels) {
+		return "", nil
+	}
+	i, err := staleController(ctx, c, obj)
+	if err != nil || i < 0 {
+		return "", err
+	}
+	refs := obj.GetOwnerReferences()
+	released := fmt.Sprintf("%s %s", refs[i].Kind, refs[i].Name)
+	obj.SetOwnerReferences(slices.Delete(refs, i, i+1))
+	return released, nil
+}
+
+// Adopt releases current, the existing kind object with the name of the one
+// the object of the helper is about to create or patch, from its controller
+// when it carries the labels and its controller no longer exists
+func Adopt(ctx context.Context, h *helper.Helper, kind string, current client.Object, labels map[string]string) error {
+	released, err := releaseStaleController(ctx, h.GetClient(), current, labels)
+	if err != nil || released == "" {
+		return err
+	}
+	// the resource version fails the patch when the owners changed meanwhile
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"ownerReferences": current.GetOwnerReferences(),
+			"resourceVersion": current.GetResourceVersion(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := h.GetClient().Patch(ctx, current, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return err
+	}
+	h.GetLogger().Info(fmt.Sprintf("%s %s adopted, its controller %s no longer exists", kind, current.GetName(), released))
+	return nil
+}
+
+// AdoptControllerReference sets owner as the controller of obj, the current
+// state of an object in the mutate function of a CreateOrPatch, releasing it
+// first from its controller when it carries the labels and its controller no
+// longer exists
+func AdoptControllerReference(
+	ctx context.Context,
+	c client.Client,
+	owner client.Object,
+	obj client.Object,
+	labels map[string]string,
+	scheme *runtime.Scheme,
+) error {
+	if _, err := releaseStaleController(ctx, c, obj, labels); err != nil {
+		return err
+	}
+	return controllerutil.SetControllerReference(owner, obj, scheme)
+}
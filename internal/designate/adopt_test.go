@@ -0,0 +1,111 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func testOwner(name string, uid types.UID) *corev1.ConfigMap {
+	owner := &corev1.ConfigMap{}
+	owner.Name = name
+	owner.Namespace = "openstack"
+	owner.UID = uid
+	return owner
+}
+
+func testOwned(controller *corev1.ConfigMap, labels map[string]string) *corev1.Secret {
+	owned := &corev1.Secret{}
+	owned.Name = "designate-config-data"
+	owned.Namespace = "openstack"
+	owned.Labels = labels
+	owned.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Name:       controller.Name,
+		UID:        controller.UID,
+		Controller: ptr.To(true),
+	}}
+	return owned
+}
+
+func TestAdoptControllerReference(t *testing.T) {
+	labels := map[string]string{"service": "designate"}
+	owner := testOwner("designate", "new-uid")
+
+	tests := []struct {
+		name     string
+		existing []*corev1.ConfigMap
+		labels   map[string]string
+		adopted  bool
+	}{
+		{
+			name:    "controller deleted",
+			labels:  labels,
+			adopted: true,
+		},
+		{
+			name:     "controller re-created",
+			existing: []*corev1.ConfigMap{testOwner("previous", "re-created-uid")},
+			labels:   labels,
+			adopted:  true,
+		},
+		{
+			name:     "live controller",
+			existing: []*corev1.ConfigMap{testOwner("previous", "old-uid")},
+			labels:   labels,
+		},
+		{
+			name:   "labels not matching",
+			labels: map[string]string{"service": "other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			for _, cm := range tt.existing {
+				builder = builder.WithObjects(cm)
+			}
+			c := builder.Build()
+			owned := testOwned(testOwner("previous", "old-uid"), tt.labels)
+
+			err := AdoptControllerReference(context.TODO(), c, owner, owned, labels, scheme.Scheme)
+			if !tt.adopted {
+				var alreadyOwned *controllerutil.AlreadyOwnedError
+				if !errors.As(err, &alreadyOwned) {
+					t.Fatalf("AdoptControllerReference() error = %v, want AlreadyOwnedError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AdoptControllerReference() error = %v", err)
+			}
+			refs := owned.GetOwnerReferences()
+			if len(refs) != 1 || refs[0].UID != owner.UID {
+				t.Errorf("owner references = %v, want only %s", refs, owner.UID)
+			}
+		})
+	}
+}
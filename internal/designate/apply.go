@@ -0,0 +1,151 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// FieldManager is the field manager of the objects the operator applies
+const FieldManager = "designate-operator"
+
+// previousFieldManagers are the managers of the fields the operator set with
+// client side updates, before it used server side apply, and of the fields
+// set by hand on the objects it adopts
+var previousFieldManagers = sets.New(
+	"manager",
+	"kubectl-create",
+	"kubectl-client-side-apply",
+	"kubectl-edit",
+	"kubectl-patch",
+	"kubectl-replace",
+)
+
+// StatefulSet applies a StatefulSet with a server side apply, the fields set
+// by other managers, e.g. injected sidecars, are kept
+type StatefulSet struct {
+	statefulset *appsv1.StatefulSet
+	timeout     time.Duration
+}
+
+// NewStatefulSet returns an initialized StatefulSet
+func NewStatefulSet(statefulset *appsv1.StatefulSet, timeout time.Duration) *StatefulSet {
+	return &StatefulSet{
+		statefulset: statefulset,
+		timeout:     timeout,
+	}
+}
+
+// CreateOrPatch applies the StatefulSet, owned by the object of the helper
+func (s *StatefulSet) CreateOrPatch(ctx context.Context, h *helper.Helper) (ctrl.Result, error) {
+	return apply(ctx, h, "StatefulSet", s.statefulset, &appsv1.StatefulSet{}, s.timeout)
+}
+
+// GetStatefulSet returns the applied StatefulSet, with its current status
+func (s *StatefulSet) GetStatefulSet() appsv1.StatefulSet {
+	return *s.statefulset
+}
+
+// Deployment applies a Deployment with a server side apply, the fields set
+// by other managers, e.g. injected sidecars, are kept
+type Deployment struct {
+	deployment *appsv1.Deployment
+	timeout    time.Duration
+}
+
+// NewDeployment returns an initialized Deployment
+func NewDeployment(deployment *appsv1.Deployment, timeout time.Duration) *Deployment {
+	return &Deployment{
+		deployment: deployment,
+		timeout:    timeout,
+	}
+}
+
+// CreateOrPatch applies the Deployment, owned by the object of the helper
+func (d *Deployment) CreateOrPatch(ctx context.Context, h *helper.Helper) (ctrl.Result, error) {
+	return apply(ctx, h, "Deployment", d.deployment, &appsv1.Deployment{}, d.timeout)
+}
+
+// GetDeployment returns the applied Deployment, with its current status
+func (d *Deployment) GetDeployment() appsv1.Deployment {
+	return *d.deployment
+}
+
+// apply makes obj, a kind object holding all the fields the operator manages,
+// the object of the operator field manager. The server response, with the
+// current status, is left in obj. current is an empty object of the same
+// type, used to migrate the fields of previousFieldManagers. When the object
+// of the helper is in preview, obj is stored in its preview ConfigMap instead.
+func apply(ctx context.Context, h *helper.Helper, kind string, obj client.Object, current client.Object, timeout time.Duration) (ctrl.Result, error) {
+	if err := controllerutil.SetControllerReference(h.GetBeforeObject(), obj, h.GetScheme()); err != nil {
+		return ctrl.Result{}, err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind(kind))
+	if IsPreview(h.GetBeforeObject()) {
+		// the object is only rendered for a review
+		return ctrl.Result{}, storeManifestPreview(ctx, h, kind, obj)
+	}
+
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, current)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	if err == nil {
+		if err := Adopt(ctx, h, kind, current, obj.GetLabels()); err != nil {
+			return ctrl.Result{}, err
+		}
+		// Hand over the fields the operator set with client side updates,
+		// or which were set by hand, so the apply removes the ones it no
+		// longer sets
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, previousFieldManagers, FieldManager)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if patch != nil {
+			if err := h.GetClient().Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	err = h.GetClient().Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			h.GetLogger().Info(fmt.Sprintf("%s %s not found, reconcile in %s", kind, obj.GetName(), timeout))
+			return ctrl.Result{RequeueAfter: timeout}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if obj.GetResourceVersion() != current.GetResourceVersion() {
+		h.GetLogger().Info(fmt.Sprintf("%s %s - applied", kind, obj.GetName()))
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,177 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// HorizontalPodAutoscalerSpec returns the spec of the HorizontalPodAutoscaler
+// scaling the Deployment name as defined by autoscaling
+func HorizontalPodAutoscalerSpec(
+	name string,
+	autoscaling *designatev1beta1.DesignateAutoscalingSpec,
+) autoscalingv2.HorizontalPodAutoscalerSpec {
+	metrics := []autoscalingv2.MetricSpec{}
+	resourceTargets := []struct {
+		name        corev1.ResourceName
+		utilization *int32
+	}{
+		{corev1.ResourceCPU, autoscaling.TargetCPUUtilizationPercentage},
+		{corev1.ResourceMemory, autoscaling.TargetMemoryUtilizationPercentage},
+	}
+	for _, target := range resourceTargets {
+		if target.utilization == nil {
+			continue
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: target.name,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: ptr.To(*target.utilization),
+				},
+			},
+		})
+	}
+	for _, metric := range autoscaling.CustomMetrics {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: metric.Name,
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: ptr.To(metric.TargetAverageValue.DeepCopy()),
+				},
+			},
+		})
+	}
+
+	return autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       name,
+		},
+		MinReplicas: ptr.To(autoscaling.GetMinReplicas()),
+		MaxReplicas: autoscaling.MaxReplicas,
+		Metrics:     metrics,
+	}
+}
+
+// AutoscaledReplicas returns the replicas of the Deployment name chosen by its
+// HorizontalPodAutoscaler, within the limits of autoscaling
+func AutoscaledReplicas(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	namespace string,
+	autoscaling *designatev1beta1.DesignateAutoscalingSpec,
+) (*int32, error) {
+	replicas := autoscaling.GetMinReplicas()
+
+	depl := &appsv1.Deployment{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, depl)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && depl.Spec.Replicas != nil && *depl.Spec.Replicas > replicas {
+		replicas = min(*depl.Spec.Replicas, autoscaling.MaxReplicas)
+	}
+	return &replicas, nil
+}
+
+// CurrentReplicas returns the current replicas of the Deployment name, 1 when
+// it does not exist yet
+func CurrentReplicas(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	namespace string,
+) (*int32, error) {
+	depl := &appsv1.Deployment{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, depl)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && depl.Spec.Replicas != nil {
+		return depl.Spec.Replicas, nil
+	}
+	return ptr.To[int32](1), nil
+}
+
+// ReconcileAutoscaling creates or updates the HorizontalPodAutoscaler of the
+// Deployment deplDef when autoscaling is set, and makes deplDef keep the
+// replicas chosen by the autoscaler. When autoscaling is not set, the
+// HorizontalPodAutoscaler is deleted and deplDef is left unchanged, unless
+// its replicas are not set: the replicas are then managed externally and
+// deplDef keeps the current ones.
+func ReconcileAutoscaling(
+	ctx context.Context,
+	h *helper.Helper,
+	deplDef *appsv1.Deployment,
+	autoscaling *designatev1beta1.DesignateAutoscalingSpec,
+) error {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deplDef.Name,
+			Namespace: deplDef.Namespace,
+		},
+	}
+
+	if autoscaling == nil {
+		err := h.GetClient().Delete(ctx, hpa)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+		if deplDef.Spec.Replicas == nil {
+			replicas, err := CurrentReplicas(ctx, h, deplDef.Name, deplDef.Namespace)
+			if err != nil {
+				return err
+			}
+			deplDef.Spec.Replicas = replicas
+		}
+		return nil
+	}
+
+	replicas, err := AutoscaledReplicas(ctx, h, deplDef.Name, deplDef.Namespace, autoscaling)
+	if err != nil {
+		return err
+	}
+	deplDef.Spec.Replicas = replicas
+
+	_, err = controllerutil.CreateOrPatch(ctx, h.GetClient(), hpa, func() error {
+		hpa.Labels = util.MergeStringMaps(hpa.Labels, deplDef.Labels)
+		hpa.Spec = HorizontalPodAutoscalerSpec(deplDef.Name, autoscaling)
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), hpa, h.GetScheme())
+	})
+	return err
+}
@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestHorizontalPodAutoscalerSpec(t *testing.T) {
+	spec := HorizontalPodAutoscalerSpec("designate-api", &designatev1beta1.DesignateAutoscalingSpec{
+		MaxReplicas:                       5,
+		TargetMemoryUtilizationPercentage: ptr.To[int32](70),
+		TargetCPUUtilizationPercentage:    ptr.To[int32](80),
+		CustomMetrics: []designatev1beta1.DesignateAutoscalingCustomMetric{
+			{Name: "requests_per_second", TargetAverageValue: resource.MustParse("100")},
+		},
+	})
+
+	if spec.ScaleTargetRef.Kind != "Deployment" || spec.ScaleTargetRef.Name != "designate-api" {
+		t.Errorf("unexpected scale target %v", spec.ScaleTargetRef)
+	}
+	if spec.MinReplicas == nil || *spec.MinReplicas != 1 || spec.MaxReplicas != 5 {
+		t.Errorf("unexpected replicas limits %v-%d", spec.MinReplicas, spec.MaxReplicas)
+	}
+	if len(spec.Metrics) != 3 {
+		t.Fatalf("expected 3 metrics, got %d", len(spec.Metrics))
+	}
+	if spec.Metrics[0].Resource.Name != corev1.ResourceCPU || *spec.Metrics[0].Resource.Target.AverageUtilization != 80 {
+		t.Errorf("unexpected cpu metric %v", spec.Metrics[0].Resource)
+	}
+	if spec.Metrics[1].Resource.Name != corev1.ResourceMemory || *spec.Metrics[1].Resource.Target.AverageUtilization != 70 {
+		t.Errorf("unexpected memory metric %v", spec.Metrics[1].Resource)
+	}
+	if spec.Metrics[2].Type != autoscalingv2.PodsMetricSourceType ||
+		spec.Metrics[2].Pods.Metric.Name != "requests_per_second" ||
+		spec.Metrics[2].Pods.Target.AverageValue.Cmp(resource.MustParse("100")) != 0 {
+		t.Errorf("unexpected custom metric %v", spec.Metrics[2].Pods)
+	}
+}
@@ -17,30 +17,131 @@ package designate
 
 import (
 	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
 )
 
-// GetPredictableIPAM returns a struct describing the available IP range. If the
+// PredictableIPSeparator separates the addresses of a dual stack predictable IP holder
+// in the predictable IP ConfigMaps, e.g. "172.28.0.31,fd00:bbbb::31"
+const PredictableIPSeparator = ","
+
+// GetPredictableIPAM returns a struct describing the available IP range. The
+// excluded CIDRs of the network and the exclude CIDRs are skipped, the range
+// is extended so it still holds BindProvPredictablePoolSize addresses. If the
 // IP pool size does not fit in given networkParameters CIDR it will return an
 // error instead.
-func GetPredictableIPAM(networkParameters *NetworkParameters) (*NADIpam, error) {
+func GetPredictableIPAM(networkParameters *NetworkParameters, exclude []netip.Prefix) (*NADIpam, error) {
 	predParams := &NADIpam{}
 	predParams.CIDR = networkParameters.CIDR
+	for _, prefix := range slices.Concat(networkParameters.Exclude, exclude) {
+		if prefix.Overlaps(predParams.CIDR) {
+			predParams.Exclude = append(predParams.Exclude, prefix)
+		}
+	}
 	predParams.RangeStart = networkParameters.ProviderAllocationEnd.Next()
 	endRange := predParams.RangeStart
-	for range BindProvPredictablePoolSize {
+	for available := 0; available < BindProvPredictablePoolSize; endRange = endRange.Next() {
 		if !predParams.CIDR.Contains(endRange) {
 			return nil, fmt.Errorf("%w: %d IP addresses in %s", ErrPredictableIPAllocation, BindProvPredictablePoolSize, predParams.CIDR)
 		}
-		endRange = endRange.Next()
+		if !predParams.IsExcluded(endRange) {
+			available++
+		}
 	}
 	predParams.RangeEnd = endRange
 	return predParams, nil
 }
 
-// GetNextIP returns the next available IP from the given IPAM parameters
+// GetPredictableIPAMs returns the predictable IP ranges for all the
+// networkParameters, one per IP family on a dual stack network.
+func GetPredictableIPAMs(networkParameters []*NetworkParameters, exclude []netip.Prefix) ([]*NADIpam, error) {
+	var predParams []*NADIpam
+	for _, params := range networkParameters {
+		p, err := GetPredictableIPAM(params, exclude)
+		if err != nil {
+			return nil, err
+		}
+		predParams = append(predParams, p)
+	}
+	return predParams, nil
+}
+
+// SplitPredictableIPs returns the addresses stored for a predictable IP holder
+func SplitPredictableIPs(value string) []string {
+	var addresses []string
+	for _, addr := range strings.Split(value, PredictableIPSeparator) {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
+
+// ParseExcludes returns the CIDRs of the addresses and CIDRs the predictable
+// IPs must not be allocated from, an address is returned as a single address
+// CIDR
+func ParseExcludes(exclude []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, value := range exclude {
+		if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPredictableIPExclude, value)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// StaticIPHolders returns the static IPs of a service keyed by the IP holder
+// of the pod index they are pinned to, e.g. with the "mdns_address_%d"
+// holderTemplate. The addresses are normalized so they match the addresses
+// stored in the predictable IP ConfigMaps, invalid keys and addresses are
+// ignored as they are rejected by the webhook.
+func StaticIPHolders(holderTemplate string, staticIPs map[string]string) map[string]string {
+	holders := make(map[string]string)
+	for index, value := range staticIPs {
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			continue
+		}
+		var addresses []string
+		for _, ip := range SplitPredictableIPs(value) {
+			if addr, err := netip.ParseAddr(ip); err == nil {
+				addresses = append(addresses, addr.String())
+			}
+		}
+		if len(addresses) > 0 {
+			holders[fmt.Sprintf(holderTemplate, i)] = JoinPredictableIPs(addresses)
+		}
+	}
+	return holders
+}
+
+// JoinPredictableIPs returns the value stored for a predictable IP holder
+func JoinPredictableIPs(addresses []string) string {
+	return strings.Join(addresses, PredictableIPSeparator)
+}
+
+// PrimaryPredictableIP returns the first address stored for a predictable IP holder
+func PrimaryPredictableIP(value string) string {
+	addresses := SplitPredictableIPs(value)
+	if len(addresses) == 0 {
+		return ""
+	}
+	return addresses[0]
+}
+
+// GetNextIP returns the next available IP from the given IPAM parameters,
+// skipping the excluded addresses
 func GetNextIP(predParams *NADIpam, allocatedIPs map[string]bool) (string, error) {
 	for candidateAddress := predParams.RangeStart; candidateAddress != predParams.RangeEnd; candidateAddress = candidateAddress.Next() {
-		if !allocatedIPs[candidateAddress.String()] {
+		if !allocatedIPs[candidateAddress.String()] && !predParams.IsExcluded(candidateAddress) {
 			allocatedIPs[candidateAddress.String()] = true
 			return candidateAddress.String(), nil
 		}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/gophercloud/gophercloud/v2"
 	gophercloudopenstack "github.com/gophercloud/gophercloud/v2/openstack"
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/endpoint"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
@@ -39,10 +40,19 @@ var (
 	ErrKeystoneNotReady = errors.New("keystone client not ready - password secret unavailable")
 )
 
+// clientCredentials - the keystone user a client authenticates with
+type clientCredentials struct {
+	username       string
+	passwordSecret string
+	passwordKey    string
+	project        string
+}
+
 func getClient(
 	ctx context.Context,
 	h *helper.Helper,
 	keystoneAPI *keystonev1.KeystoneAPI,
+	creds clientCredentials,
 ) (*openstack.OpenStack, ctrl.Result, error) {
 	// get internal endpoint as authurl from keystone instance
 	authURL, err := keystoneAPI.GetEndpoint(endpoint.EndpointInternal)
@@ -77,14 +87,13 @@ func getClient(
 		}
 	}
 
-	// get the password of the admin user from Spec.Secret
-	// using PasswordSelectors.Admin
+	// get the password of the user
 	authPassword, ctrlResult, err := secret.GetDataFromSecret(
 		ctx,
 		h,
-		keystoneAPI.Spec.Secret,
+		creds.passwordSecret,
 		time.Duration(10)*time.Second,
-		keystoneAPI.Spec.PasswordSelectors.Admin)
+		creds.passwordKey)
 	if err != nil {
 		return nil, ctrl.Result{}, err
 	}
@@ -96,11 +105,11 @@ func getClient(
 
 	authOpts := openstack.AuthOpts{
 		AuthURL:  authURL,
-		Username: keystoneAPI.Spec.AdminUser,
+		Username: creds.username,
 		Password: authPassword,
 		// The Domain of the user is always Default
 		DomainName: "Default",
-		TenantName: keystoneAPI.Spec.AdminProject,
+		TenantName: creds.project,
 		Region:     keystoneAPI.Spec.Region,
 		TLS:        tlsConfig,
 	}
@@ -123,7 +132,32 @@ func GetAdminServiceClient(
 	h *helper.Helper,
 	keystoneAPI *keystonev1.KeystoneAPI,
 ) (*openstack.OpenStack, ctrl.Result, error) {
-	return getClient(ctx, h, keystoneAPI)
+	return getClient(ctx, h, keystoneAPI, clientCredentials{
+		username:       keystoneAPI.Spec.AdminUser,
+		passwordSecret: keystoneAPI.Spec.Secret,
+		passwordKey:    keystoneAPI.Spec.PasswordSelectors.Admin,
+		project:        keystoneAPI.Spec.AdminProject,
+	})
+}
+
+// GetDesignateServiceClient - get a client authenticated as the designate
+// service user, with the password of the service Secret of the Designate CR.
+// Resources created with it are owned by the service project.
+func GetDesignateServiceClient(
+	ctx context.Context,
+	h *helper.Helper,
+	instance *designatev1beta1.Designate,
+) (*openstack.OpenStack, ctrl.Result, error) {
+	keystoneAPI, err := keystonev1.GetKeystoneAPI(ctx, h, instance.Namespace, map[string]string{})
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	return getClient(ctx, h, keystoneAPI, clientCredentials{
+		username:       instance.Spec.ServiceUser,
+		passwordSecret: instance.Spec.Secret,
+		passwordKey:    instance.Spec.PasswordSelectors.Service,
+		project:        ServiceProject,
+	})
 }
 
 // GetOpenstackClient returns an openstack admin service client object
@@ -20,16 +20,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 )
 
@@ -43,6 +48,7 @@ var (
 	// Package errors
 	ErrPredictableIPAllocation     = errors.New("predictable IPs: cannot allocate IP addresses")
 	ErrPredictableIPOutOfAddresses = errors.New("predictable IPs: out of available addresses")
+	ErrInvalidPredictableIPExclude = errors.New("predictable IPs: invalid excluded address or CIDR")
 	ErrCannotAllocateIPAddresses   = errors.New("cannot allocate IP addresses")
 )
 
@@ -59,6 +65,13 @@ func GetScriptConfigMapName(crName string) string {
 	return fmt.Sprintf("%s-scripts", crName)
 }
 
+// IsPaused returns true when the reconciliation of obj is paused with the
+// PausedAnnotation
+func IsPaused(obj metav1.Object) bool {
+	paused, err := strconv.ParseBool(obj.GetAnnotations()[PausedAnnotation])
+	return err == nil && paused
+}
+
 // GetServiceConfigConfigMapName returns the name of the ConfigMap used to
 // store the service configuration files
 func GetServiceConfigConfigMapName(crName string) string {
@@ -89,10 +102,19 @@ const (
 	MQCompleted MessageBusStatus = iota
 )
 
-// Database -
+// Database - the database of Designate, the MariaDBDatabase of its
+// MariaDBAccount or an external database when External is set
 type Database struct {
 	Database *mariadbv1.Database
 	Status   DatabaseStatus
+	// External - database managed outside of mariadb-operator
+	External *designatev1beta1.DesignateExternalDatabase
+	// Username, Password - credentials of the external database
+	Username string
+	Password string
+	// CACert - CA certificate of the external database, connections use
+	// TLS when set
+	CACert string
 }
 
 // MessageBus -
@@ -101,14 +123,25 @@ type MessageBus struct {
 	Status     MessageBusStatus
 }
 
-// PodLabelingConfig holds configuration for pod labeling
+// PodLabelingConfig holds configuration for pod labeling. When Recorder is
+// set, the pods get an event when their predictable IP is assigned or cannot
+// be labeled.
 type PodLabelingConfig struct {
 	ConfigMapName string
 	IPKeyPrefix   string
+	Recorder      record.EventRecorder
+}
+
+// PredictableIPLabelValue returns addr in a form usable as a label value,
+// IPv6 colons are replaced by dashes
+func PredictableIPLabelValue(addr string) string {
+	return strings.ReplaceAll(addr, ":", "-")
 }
 
-// HandlePodLabeling handles adding predictableip labels to pods
-func HandlePodLabeling(ctx context.Context, h *helper.Helper, instanceName, namespace string, config PodLabelingConfig) error {
+// HandlePodLabeling handles adding predictableip labels to pods. It returns
+// the predictable IPs of the pods, by pod name, and the errors of the pods
+// which could not be labeled.
+func HandlePodLabeling(ctx context.Context, h *helper.Helper, instanceName, namespace string, config PodLabelingConfig) (map[string]string, error) {
 	// List all pods owned by this instance
 	podList := &corev1.PodList{}
 	listOpts := []client.ListOption{
@@ -120,7 +153,7 @@ func HandlePodLabeling(ctx context.Context, h *helper.Helper, instanceName, name
 
 	err := h.GetClient().List(ctx, podList, listOpts...)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %w", err)
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
 	// Get the IP configmap once for all pods
@@ -128,12 +161,14 @@ func HandlePodLabeling(ctx context.Context, h *helper.Helper, instanceName, name
 	err = h.GetClient().Get(ctx, types.NamespacedName{Name: config.ConfigMapName, Namespace: namespace}, configMap)
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			return nil // Configmap not found, skip labeling
+			return nil, nil // Configmap not found, skip labeling
 		}
-		return err
+		return nil, err
 	}
 
 	// Process each pod
+	podIPs := make(map[string]string)
+	var labelingErrs []error
 	for _, pod := range podList.Items {
 		// Extract pod index from pod name (e.g., "designate-backendbind9-0" -> "0")
 		podName := pod.Name
@@ -145,30 +180,128 @@ func HandlePodLabeling(ctx context.Context, h *helper.Helper, instanceName, name
 
 		// Get the IP for this pod index
 		ipKey := fmt.Sprintf("%s%s", config.IPKeyPrefix, podIndex)
-		predictableIP, exists := configMap.Data[ipKey]
+		predictableIPs, exists := configMap.Data[ipKey]
 		if !exists {
 			continue // No IP found for this pod index, skip labeling
 		}
+		podIPs[podName] = predictableIPs
+
+		// Label values cannot hold a list of addresses nor the colons of an
+		// IPv6 address, the label carries the primary address in a label safe
+		// form and the annotation the complete list.
+		predictableIP := PredictableIPLabelValue(PrimaryPredictableIP(predictableIPs))
 
 		// Check if the label needs to be added or updated
 		currentIP, hasLabel := pod.Labels[networkv1.PredictableIPLabel]
-		if hasLabel && currentIP == predictableIP {
+		if hasLabel && currentIP == predictableIP && pod.Annotations[PredictableIPsAnnotation] == predictableIPs {
 			continue // Label already exists with correct value
 		}
+		patch := client.MergeFrom(pod.DeepCopy())
 
 		// Add or update the predictableip label to the pod
 		if pod.Labels == nil {
 			pod.Labels = make(map[string]string)
 		}
 		pod.Labels[networkv1.PredictableIPLabel] = predictableIP
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[PredictableIPsAnnotation] = predictableIPs
 
-		// Update the pod
-		err = h.GetClient().Update(ctx, &pod)
-		if err != nil {
-			// Log error but continue processing other pods
+		// A merge patch doesn't conflict with the other updates of the pod
+		err = h.GetClient().Patch(ctx, &pod, patch)
+		if client.IgnoreNotFound(err) != nil {
+			// continue processing the other pods, the caller retries later
+			labelingErrs = append(labelingErrs, fmt.Errorf("failed to label pod %s: %w", podName, err))
+			predictableIPLabelingRetries.WithLabelValues(namespace).Inc()
+			if config.Recorder != nil {
+				config.Recorder.Eventf(&pod, corev1.EventTypeWarning, "PredictableIPLabelingFailed",
+					"Failed to label the pod with its predictable IP %s: %s", predictableIPs, err)
+			}
 			continue
 		}
+		if err == nil && config.Recorder != nil {
+			config.Recorder.Eventf(&pod, corev1.EventTypeNormal, "PredictableIPAssigned",
+				"Predictable IP %s assigned from ConfigMap %s", predictableIPs, config.ConfigMapName)
+		}
+	}
+
+	return podIPs, errors.Join(labelingErrs...)
+}
+
+// MissingPredictableIPs returns the names of the first replicas pods of the
+// StatefulSet statefulSetName which have no predictable IP in the ConfigMap of
+// config, e.g. because the IP range is exhausted
+func MissingPredictableIPs(ctx context.Context, h *helper.Helper, statefulSetName, namespace string, config PodLabelingConfig, replicas int) ([]string, error) {
+	configMap := &corev1.ConfigMap{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: config.ConfigMapName, Namespace: namespace}, configMap)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	var missing []string
+	for i := range replicas {
+		if _, exists := configMap.Data[fmt.Sprintf("%s%d", config.IPKeyPrefix, i)]; !exists {
+			missing = append(missing, fmt.Sprintf("%s-%d", statefulSetName, i))
+		}
+	}
+	return missing, nil
+}
+
+// GetPodNetworkIPs returns the addresses the pods of instanceName have on the
+// network attachment networkName, by pod name. Pods without an address on the
+// network yet are left out.
+func GetPodNetworkIPs(ctx context.Context, h *helper.Helper, instanceName, namespace, networkName string) (map[string]string, error) {
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			common.AppSelector: instanceName,
+		},
+	}
+	if err := h.GetClient().List(ctx, podList, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	podIPs := make(map[string]string)
+	for _, pod := range podList.Items {
+		networkStatus, err := nad.GetNetworkStatusFromAnnotation(pod.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range networkStatus {
+			if status.Name == fmt.Sprintf("%s/%s", namespace, networkName) && len(status.IPs) > 0 {
+				podIPs[pod.Name] = strings.Join(status.IPs, PredictableIPSeparator)
+			}
+		}
 	}
+	return podIPs, nil
+}
 
-	return nil
+// GetPodIPs returns the addresses of the pods of instanceName by pod name,
+// the addresses of their nodes for the pods running on the host network.
+// Pods without an address yet are left out.
+func GetPodIPs(ctx context.Context, h *helper.Helper, instanceName, namespace string) (map[string]string, error) {
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			common.AppSelector: instanceName,
+		},
+	}
+	if err := h.GetClient().List(ctx, podList, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	podIPs := make(map[string]string)
+	for _, pod := range podList.Items {
+		var ips []string
+		for _, podIP := range pod.Status.PodIPs {
+			ips = append(ips, podIP.IP)
+		}
+		if len(ips) > 0 {
+			podIPs[pod.Name] = strings.Join(ips, PredictableIPSeparator)
+		}
+	}
+	return podIPs, nil
 }
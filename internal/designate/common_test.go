@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestGetPodNetworkIPs(t *testing.T) {
+	newPod := func(name, networkStatus string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openstack",
+				Labels:    map[string]string{common.AppSelector: "designate-unbound"},
+			},
+		}
+		if networkStatus != "" {
+			pod.Annotations = map[string]string{networkv1.NetworkStatusAnnot: networkStatus}
+		}
+		return pod
+	}
+	c := fake.NewClientBuilder().WithObjects(
+		newPod("designate-unbound-0", `[{"name":"openstack/designateext","ips":["172.50.0.80","fd00:bbbb::80"]}]`),
+		newPod("designate-unbound-1", `[{"name":"openstack/internalapi","ips":["172.17.0.80"]}]`),
+		newPod("designate-unbound-2", ""),
+	).Build()
+	h, err := helper.NewHelper(&corev1.Pod{}, c, nil, nil, log.Log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetPodNetworkIPs(context.TODO(), h, "designate-unbound", "openstack", "designateext")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"designate-unbound-0": "172.50.0.80,fd00:bbbb::80"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPodNetworkIPs() = %v, want %v", got, want)
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{name: "no annotation", annotations: nil, want: false},
+		{name: "paused", annotations: map[string]string{PausedAnnotation: "true"}, want: true},
+		{name: "resumed", annotations: map[string]string{PausedAnnotation: "false"}, want: false},
+		{name: "invalid value", annotations: map[string]string{PausedAnnotation: "yes"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tt.annotations}
+			if got := IsPaused(obj); got != tt.want {
+				t.Errorf("IsPaused() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
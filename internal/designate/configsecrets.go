@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigHashAnnotation is the annotation of a generated config Secret with
+// the hash of the data the operator rendered into it, a Secret whose data no
+// longer has this hash was modified out of band
+const ConfigHashAnnotation = "designate.openstack.org/config-hash"
+
+// EnsureSecrets creates or updates the Secrets of the templates, owned by
+// obj, adopting the existing ones first. It returns the names of the Secrets
+// modified out of band which are kept as they are with the Report
+// driftPolicy, they are rendered again otherwise. When obj is in preview,
+// the templates are rendered into preview Secrets instead, the hashes of
+// envVars are still the ones of the templates.
+func EnsureSecrets(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	templates []util.Template,
+	envVars *map[string]env.Setter,
+	driftPolicy designatev1beta1.ConfigDriftPolicy,
+) ([]string, error) {
+	if IsPreview(obj) {
+		if err := secret.EnsureSecrets(ctx, h, obj, previewTemplates(templates), envVars); err != nil {
+			return nil, err
+		}
+		for _, t := range templates {
+			if hash, ok := (*envVars)[PreviewName(t.Name)]; ok {
+				(*envVars)[t.Name] = hash
+				delete(*envVars, PreviewName(t.Name))
+			}
+		}
+		return nil, nil
+	}
+	if err := DeletePreview(ctx, h, obj, templates); err != nil {
+		return nil, err
+	}
+
+	drifted := []string{}
+	rendered := []util.Template{}
+	renderedHashes := map[string]string{}
+	for _, t := range templates {
+		current := &corev1.Secret{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, current)
+		if k8s_errors.IsNotFound(err) {
+			rendered = append(rendered, t)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if err := Adopt(ctx, h, "Secret", current, t.Labels); err != nil {
+			return nil, err
+		}
+
+		renderedHash := current.Annotations[ConfigHashAnnotation]
+		renderedHashes[t.Name] = renderedHash
+		hash, err := secret.Hash(current)
+		if err != nil {
+			return nil, err
+		}
+		if renderedHash != "" && hash != renderedHash {
+			if driftPolicy == designatev1beta1.ConfigDriftPolicyReport {
+				h.GetLogger().Info(fmt.Sprintf("Secret %s modified out of band, kept with the %s config drift policy", t.Name, driftPolicy))
+				drifted = append(drifted, t.Name)
+				// the pods are not restarted with the modified configuration
+				(*envVars)[t.Name] = env.SetValue(renderedHash)
+				continue
+			}
+			h.GetLogger().Info(fmt.Sprintf("Secret %s modified out of band, rendering it again", t.Name))
+		}
+		rendered = append(rendered, t)
+	}
+
+	if err := secret.EnsureSecrets(ctx, h, obj, rendered, envVars); err != nil {
+		return nil, err
+	}
+	for _, t := range rendered {
+		hash := envValue((*envVars)[t.Name])
+		if hash == "" || hash == renderedHashes[t.Name] {
+			continue
+		}
+		if err := setConfigHash(ctx, h, t, hash); err != nil {
+			return nil, err
+		}
+	}
+	return drifted, nil
+}
+
+// envValue returns the value set by setter, or ""
+func envValue(setter env.Setter) string {
+	if setter == nil {
+		return ""
+	}
+	envVar := corev1.EnvVar{}
+	setter(&envVar)
+	return envVar.Value
+}
+
+// setConfigHash records hash, the hash of the data rendered into the Secret of
+// t, in its ConfigHashAnnotation
+func setConfigHash(ctx context.Context, h *helper.Helper, t util.Template, hash string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{ConfigHashAnnotation: hash},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	rendered := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: t.Name, Namespace: t.Namespace}}
+	return h.GetClient().Patch(ctx, rendered, client.RawPatch(types.MergePatchType, patch))
+}
+
+// PodAnnotations returns the annotations of a pod template with the
+// InputHashAnnotation of inputHash, annotations is left unchanged
+func PodAnnotations(annotations map[string]string, inputHash string) map[string]string {
+	podAnnotations := make(map[string]string, len(annotations)+1)
+	maps.Copy(podAnnotations, annotations)
+	podAnnotations[InputHashAnnotation] = inputHash
+	return podAnnotations
+}
@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+)
+
+func TestPodAnnotations(t *testing.T) {
+	annotations := map[string]string{"k8s.v1.cni.cncf.io/networks": "[]"}
+	got := PodAnnotations(annotations, "n5f4h")
+
+	if got[InputHashAnnotation] != "n5f4h" || got["k8s.v1.cni.cncf.io/networks"] != "[]" {
+		t.Errorf("PodAnnotations() = %v", got)
+	}
+	// the annotations are left unchanged
+	if _, ok := annotations[InputHashAnnotation]; ok {
+		t.Errorf("annotations modified to %v", annotations)
+	}
+
+	if got := PodAnnotations(nil, "n5f4h"); len(got) != 1 || got[InputHashAnnotation] != "n5f4h" {
+		t.Errorf("PodAnnotations(nil) = %v", got)
+	}
+}
+
+func TestEnvValue(t *testing.T) {
+	if got := envValue(env.SetValue("n5f4h")); got != "n5f4h" {
+		t.Errorf("envValue() = %s, want n5f4h", got)
+	}
+	if got := envValue(nil); got != "" {
+		t.Errorf("envValue(nil) = %s, want empty", got)
+	}
+}
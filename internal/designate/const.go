@@ -21,6 +21,9 @@ const (
 	// ServiceName -
 	ServiceName = "designate"
 
+	// ServiceProject - keystone project of the designate service user
+	ServiceProject = "service"
+
 	// DatabaseName -
 	DatabaseName = "designate"
 
@@ -31,6 +34,13 @@ const (
 	// is to be generated, e.g. "designate_e5a4", "designate_78bc", etc
 	DatabaseUsernamePrefix = "designate"
 
+	// DatabaseCAFileName is the file name of the CA certificate of the external database in the
+	// configuration of Designate
+	DatabaseCAFileName = "db-ca.crt"
+
+	// DatabaseCAPath is the path the services read the CA certificate of the external database from
+	DatabaseCAPath = "/etc/designate/db-ca.crt"
+
 	// DesignatePublicPort -
 	DesignatePublicPort int32 = 9001
 	// DesignateInternalPort -
@@ -42,6 +52,52 @@ const (
 	// DesignateRndcKey is the key name for RNDC configuration
 	DesignateRndcKey = "rndc-key"
 
+	// DesignateExternalBindKeySecret is the name of the secret containing the rndc keys of the
+	// external BIND servers
+	DesignateExternalBindKeySecret = "designate-bind-external-secret" // #nosec G101
+
+	// ExternalRndcConfDir is the directory path for the RNDC keys of the external BIND servers
+	ExternalRndcConfDir = "/etc/designate/rndc-keys-external"
+
+	// DesignateCoordinationSecret is the name of the secret containing the client certificates
+	// of the coordination backend
+	DesignateCoordinationSecret = "designate-coordination-secret" // #nosec G101
+
+	// CoordinationCertDir is the directory path for the client certificates of the coordination
+	// backend
+	CoordinationCertDir = "/etc/designate/coordination"
+
+	// CoordinationCAKey is the key of the CA certificate of the coordination backend in its
+	// TLS Secret and in the coordination secret
+	CoordinationCAKey = "ca.crt"
+
+	// CustomConfigSecretsPath is the directory the CustomServiceConfigSecrets are mounted
+	// in, one sub directory per Secret
+	CustomConfigSecretsPath = "/var/lib/config-data/custom-secrets"
+
+	// ExternalTSIGSecretEnvPrefix is the prefix of the pool update job environment variables
+	// holding the TSIG secrets of the external BIND servers
+	ExternalTSIGSecretEnvPrefix = "TSIG_SECRET_"
+
+	// DesignatePDNSAPIKeySecret is the name of the secret containing the key of the PowerDNS API
+	DesignatePDNSAPIKeySecret = "designate-pdns-api-key" // #nosec G101
+
+	// PDNSAPIKeySecretKey is the key of the PowerDNS API key in DesignatePDNSAPIKeySecret
+	PDNSAPIKeySecretKey = "api-key"
+
+	// PDNSAPIKeyPlaceholder is written to pools.yaml instead of the PowerDNS API key, the pool
+	// update job replaces it with the key from DesignatePDNSAPIKeySecret
+	PDNSAPIKeyPlaceholder = "@PDNS_API_KEY@"
+
+	// PDNSPredIPConfigMap is the name of the ConfigMap containing PowerDNS predictable IP mappings
+	PDNSPredIPConfigMap = "designate-pdns-ip-map"
+
+	// PDNSPredictableIPHash key for status hash
+	PDNSPredictableIPHash = "PDNS IP Map"
+
+	// PDNSAPIKeyHash key for status hash
+	PDNSAPIKeyHash = "PDNS API key"
+
 	// MdnsPredIPConfigMap is the name of the ConfigMap containing MDNS predictable IP mappings
 	MdnsPredIPConfigMap = "designate-mdns-ip-map"
 
@@ -54,6 +110,25 @@ const (
 	// BindPredIPConfigMap is the name of the ConfigMap containing bind predictable IP mappings
 	BindPredIPConfigMap = "designate-bind-ip-map"
 
+	// PredictableIPsAnnotation is the pod annotation listing all the predictable IPs of the pod,
+	// comma separated, e.g. "172.28.0.31,fd00:bbbb::31" on dual stack networks
+	PredictableIPsAnnotation = "designate.openstack.org/predictable-ips"
+
+	// PausedAnnotation set to "true" on a Designate CR or on one of its sub-CRs stops
+	// its reconciliation, the resources of the CR are left as they are for a manual
+	// maintenance
+	PausedAnnotation = "designate.openstack.org/paused"
+
+	// PreviewAnnotation set to "true" on a Designate CR, on one of its sub-CRs or
+	// on a DesignatePool renders their configuration, workloads and pools.yaml
+	// into preview objects instead of applying them, for a review
+	PreviewAnnotation = "designate.openstack.org/preview"
+
+	// InputHashAnnotation is the pod template annotation with the hash of all the
+	// inputs of the pods, e.g. their config and mounted Secrets, a changed input
+	// rolls the pods
+	InputHashAnnotation = "designate.openstack.org/input-hash"
+
 	// RndcConfDir is the directory path for RNDC configuration files
 	RndcConfDir = "/etc/designate/rndc-keys"
 
@@ -90,15 +165,47 @@ const (
 	// TsigSecretSuffix is the suffix for TSIG secret names in multipool mode
 	TsigSecretSuffix = "-tsig"
 
+	// ControlTSIGSecretSuffix is the suffix of the TSIG secret of the default pool servers
+	ControlTSIGSecretSuffix = "-control-tsig"
+
 	// MultipoolConfigMapName is the name of the ConfigMap containing multipool configuration
 	MultipoolConfigMapName = "designate-multipool-config"
 
 	// DefaultPoolName is the name of the default pool (pool0)
 	DefaultPoolName = "default"
 
+	// DefaultPoolID is the ID designate gives to the default pool
+	DefaultPoolID = "794ccc2c-d751-44fe-b57f-8894c9f5c842"
+
 	// PoolStatefulSetSuffix is the suffix for numbered pool StatefulSets (pool1, pool2, etc.)
 	PoolStatefulSetSuffix = "-pool"
 
 	// SharedTSIGKeyName is the name of the shared TSIG key used for all non-default pools
 	SharedTSIGKeyName = "multipool-shared-key"
+
+	// ControlTSIGKeyName is the name of the TSIG key of the default pool servers
+	ControlTSIGKeyName = "default-pool-control-key"
+
+	// ResponsePolicyZoneHash key for the input hash, holds the hash of the
+	// ConfigMap of the bind9 response policy zone
+	ResponsePolicyZoneHash = "Response policy zone"
+
+	// ControlTSIGHash key for the input hash, holds the hash of the TSIG
+	// configuration of the default pool servers
+	ControlTSIGHash = "Control TSIG"
+
+	// MetricsPortName is the name of the Service ports exposing Prometheus metrics
+	MetricsPortName = "metrics"
+
+	// MetricsServiceLabel marks the Services exposing Prometheus metrics, its
+	// value is the component the metrics belong to
+	MetricsServiceLabel = "designate.openstack.org/metrics"
+
+	// InstanceLabel is set on the pods whose service label is shared by the
+	// instances of a namespace, its value is the name of the sub-CR of the pod
+	InstanceLabel = "designate.openstack.org/instance"
+
+	// MultiNetworkPolicyForAnnotation is the annotation of a MultiNetworkPolicy
+	// naming the NetworkAttachmentDefinition the policy applies to
+	MultiNetworkPolicyForAnnotation = "k8s.v1.cni.cncf.io/policy-for"
 )
@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RedisSentinelBackendURL returns the tooz backend_url of a Redis Sentinel
+// coordination backend. The first sentinel is queried first, the others are
+// fallbacks.
+func RedisSentinelBackendURL(spec *designatev1beta1.DesignateRedisSentinelSpec, password string) string {
+	query := url.Values{}
+	query.Set("sentinel", spec.MasterName)
+	for _, sentinel := range spec.Sentinels[1:] {
+		query.Add("sentinel_fallback", sentinel)
+	}
+	if spec.TLS {
+		query.Set("ssl", "true")
+	}
+
+	backendURL := url.URL{
+		Scheme:   "redis",
+		Host:     spec.Sentinels[0],
+		Path:     "/",
+		RawQuery: query.Encode(),
+	}
+	if password != "" {
+		backendURL.User = url.UserPassword("", password)
+	}
+	return backendURL.String()
+}
+
+// EtcdBackendURL returns the tooz backend_url of an etcd coordination backend.
+// With TLS, the client certificates are read from the coordination secret
+// mounted in CoordinationCertDir, withCA tells if it holds the CA certificate
+// of etcd.
+func EtcdBackendURL(spec *designatev1beta1.DesignateEtcdSpec, withCA bool) string {
+	if spec.TLSSecretName == "" {
+		return fmt.Sprintf("etcd3+http://%s", spec.Endpoint)
+	}
+
+	query := url.Values{}
+	query.Set("cert_cert", fmt.Sprintf("%s/%s", CoordinationCertDir, corev1.TLSCertKey))
+	query.Set("cert_key", fmt.Sprintf("%s/%s", CoordinationCertDir, corev1.TLSPrivateKeyKey))
+	if withCA {
+		query.Set("ca_cert", fmt.Sprintf("%s/%s", CoordinationCertDir, CoordinationCAKey))
+	}
+	backendURL := url.URL{
+		Scheme:   "etcd3+https",
+		Host:     spec.Endpoint,
+		RawQuery: query.Encode(),
+	}
+	return backendURL.String()
+}
+
+// GetCoordinationSecretHash returns the hash of the coordination secret, or an
+// empty string when it doesn't exist
+func GetCoordinationSecretHash(ctx context.Context, c client.Client, namespace string) (string, error) {
+	coordinationSecret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: DesignateCoordinationSecret, Namespace: namespace}, coordinationSecret)
+	if k8s_errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return secret.Hash(coordinationSecret)
+}
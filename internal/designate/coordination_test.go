@@ -0,0 +1,61 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+func TestRedisSentinelBackendURL(t *testing.T) {
+	spec := &designatev1beta1.DesignateRedisSentinelSpec{
+		Sentinels:  []string{"redis-0.redis:26379"},
+		MasterName: "mymaster",
+	}
+	if got, want := RedisSentinelBackendURL(spec, ""), "redis://redis-0.redis:26379/?sentinel=mymaster"; got != want {
+		t.Errorf("RedisSentinelBackendURL() = %s, want %s", got, want)
+	}
+
+	spec.Sentinels = append(spec.Sentinels, "redis-1.redis:26379", "redis-2.redis:26379")
+	spec.TLS = true
+	want := "redis://:p%40ss@redis-0.redis:26379/?sentinel=mymaster" +
+		"&sentinel_fallback=redis-1.redis%3A26379&sentinel_fallback=redis-2.redis%3A26379&ssl=true"
+	if got := RedisSentinelBackendURL(spec, "p@ss"); got != want {
+		t.Errorf("RedisSentinelBackendURL() = %s, want %s", got, want)
+	}
+}
+
+func TestEtcdBackendURL(t *testing.T) {
+	spec := &designatev1beta1.DesignateEtcdSpec{
+		Endpoint: "etcd.openstack.svc:2379",
+	}
+	if got, want := EtcdBackendURL(spec, false), "etcd3+http://etcd.openstack.svc:2379"; got != want {
+		t.Errorf("EtcdBackendURL() = %s, want %s", got, want)
+	}
+
+	spec.TLSSecretName = "etcd-client-cert"
+	want := "etcd3+https://etcd.openstack.svc:2379?cert_cert=%2Fetc%2Fdesignate%2Fcoordination%2Ftls.crt" +
+		"&cert_key=%2Fetc%2Fdesignate%2Fcoordination%2Ftls.key"
+	if got := EtcdBackendURL(spec, false); got != want {
+		t.Errorf("EtcdBackendURL() = %s, want %s", got, want)
+	}
+
+	want = "etcd3+https://etcd.openstack.svc:2379?ca_cert=%2Fetc%2Fdesignate%2Fcoordination%2Fca.crt" +
+		"&cert_cert=%2Fetc%2Fdesignate%2Fcoordination%2Ftls.crt&cert_key=%2Fetc%2Fdesignate%2Fcoordination%2Ftls.key"
+	if got := EtcdBackendURL(spec, true); got != want {
+		t.Errorf("EtcdBackendURL() = %s, want %s", got, want)
+	}
+}
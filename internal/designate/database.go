@@ -0,0 +1,183 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
+)
+
+var (
+	// ErrExternalDatabaseCredentials - the credentials Secret of the external database misses a key
+	ErrExternalDatabaseCredentials = errors.New("external database credentials not found")
+	// ErrExternalDatabaseCA - the CA Secret of the external database misses its key
+	ErrExternalDatabaseCA = errors.New("external database CA certificate not found")
+)
+
+// GetDatabase returns the database of instance, the MariaDBDatabase of its
+// MariaDBAccount or its external database
+func GetDatabase(ctx context.Context, h *helper.Helper, instance *designatev1beta1.Designate) (*Database, error) {
+	if instance.UsesExternalDatabase() {
+		return GetExternalDatabase(ctx, h, instance.Spec.ExternalDatabase, instance.Namespace)
+	}
+	db, err := mariadbv1.GetDatabaseByNameAndAccount(ctx, h, DatabaseCRName, instance.Spec.DatabaseAccount, instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &Database{Database: db, Status: DBCompleted}, nil
+}
+
+// GetExternalDatabase returns the external database of spec with the
+// credentials and the CA certificate of its Secrets. No MariaDBDatabase is
+// created for it.
+func GetExternalDatabase(
+	ctx context.Context,
+	h *helper.Helper,
+	spec *designatev1beta1.DesignateExternalDatabase,
+	namespace string,
+) (*Database, error) {
+	credentials, _, err := secret.GetSecret(ctx, h, spec.Secret, namespace)
+	if err != nil {
+		return nil, err
+	}
+	db := &Database{
+		External: spec,
+		Status:   DBCompleted,
+		Username: string(credentials.Data[spec.UsernameKey]),
+		Password: string(credentials.Data[spec.PasswordKey]),
+	}
+	if db.Username == "" || db.Password == "" {
+		return nil, fmt.Errorf("%w: keys %s and %s of Secret %s",
+			ErrExternalDatabaseCredentials, spec.UsernameKey, spec.PasswordKey, spec.Secret)
+	}
+
+	if spec.CASecret != "" {
+		ca, _, err := secret.GetSecret(ctx, h, spec.CASecret, namespace)
+		if err != nil {
+			return nil, err
+		}
+		db.CACert = string(ca.Data[spec.CASecretKey])
+		if db.CACert == "" {
+			return nil, fmt.Errorf("%w: key %s of Secret %s", ErrExternalDatabaseCA, spec.CASecretKey, spec.CASecret)
+		}
+	}
+	return db, nil
+}
+
+// GetDatabaseHostname returns the address of the database
+func (d *Database) GetDatabaseHostname() string {
+	if d.External != nil {
+		return d.External.Host
+	}
+	return d.Database.GetDatabaseHostname()
+}
+
+// GetSecretName returns the name of the Secret holding the credentials of
+// the database
+func (d *Database) GetSecretName() string {
+	if d.External != nil {
+		return d.External.Secret
+	}
+	return d.Database.GetSecret().Name
+}
+
+// GetUsername returns the username designate connects to the database with
+func (d *Database) GetUsername() string {
+	if d.External != nil {
+		return d.Username
+	}
+	return d.Database.GetAccount().Spec.UserName
+}
+
+// GetPassword returns the password designate connects to the database with
+func (d *Database) GetPassword() string {
+	if d.External != nil {
+		return d.Password
+	}
+	return string(d.Database.GetSecret().Data[mariadbv1.DatabasePasswordSelector])
+}
+
+// GetConnection returns the SQLAlchemy URL of the database, the client
+// options are read from /etc/my.cnf. The credentials of an external database
+// are escaped, they aren't generated by mariadb-operator.
+func (d *Database) GetConnection() string {
+	return d.connection(d.GetDatabaseHostname())
+}
+
+// GetReadOnlyConnection returns the SQLAlchemy URL of the read replica of the
+// database at hostname, reached with the credentials and the client options
+// of the database
+func (d *Database) GetReadOnlyConnection(hostname string) string {
+	return d.connection(hostname)
+}
+
+func (d *Database) connection(hostname string) string {
+	if d.External == nil {
+		return fmt.Sprintf("mysql+pymysql://%s:%s@%s/%s?read_default_file=/etc/my.cnf",
+			d.GetUsername(), d.GetPassword(), hostname, DatabaseName)
+	}
+	connection := url.URL{
+		Scheme:   "mysql+pymysql",
+		User:     url.UserPassword(d.Username, d.Password),
+		Host:     net.JoinHostPort(hostname, strconv.Itoa(int(d.External.Port))),
+		Path:     "/" + d.External.DatabaseName,
+		RawQuery: "read_default_file=/etc/my.cnf",
+	}
+	return connection.String()
+}
+
+// GetDatabaseClientConfig returns the content of my.cnf. The CA certificate
+// of an external database is shipped in the configuration of Designate as
+// DatabaseCAFileName and copied to DatabaseCAPath by the services.
+func (d *Database) GetDatabaseClientConfig(s *tls.Service) string {
+	if d.External == nil {
+		return d.Database.GetDatabaseClientConfig(s)
+	}
+	if d.CACert == "" {
+		return ""
+	}
+	return fmt.Sprintf("[client]\nssl=1\nssl-ca=%s\n", DatabaseCAPath)
+}
+
+// DatabaseTuningOptions returns the oslo.db options of spec which are set, by
+// option name, for the [database] and [storage:sqlalchemy] sections
+func DatabaseTuningOptions(spec *designatev1beta1.DesignateDatabaseTuningSpec) map[string]int32 {
+	options := map[string]int32{}
+	if spec == nil {
+		return options
+	}
+	for name, value := range map[string]*int32{
+		"max_pool_size":     spec.MaxPoolSize,
+		"max_overflow":      spec.MaxOverflow,
+		"pool_timeout":      spec.PoolTimeout,
+		"db_max_retries":    spec.DBMaxRetries,
+		"db_retry_interval": spec.DBRetryInterval,
+	} {
+		if value != nil {
+			options[name] = *value
+		}
+	}
+	return options
+}
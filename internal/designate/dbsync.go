@@ -16,8 +16,13 @@ limitations under the License.
 package designate
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,7 +38,7 @@ func DbSyncJob(
 	instance *designatev1beta1.Designate,
 	labels map[string]string,
 	annotations map[string]string,
-) *batchv1.Job {
+) (*batchv1.Job, error) {
 	runAsUser := int64(0)
 
 	volumeDefs := []VolumeMapping{
@@ -97,19 +102,70 @@ func DbSyncJob(
 		},
 	}
 
-	initContainerDetails := APIDetails{
-		ContainerImage:       instance.Spec.DesignateAPI.ContainerImage,
-		DatabaseHost:         instance.Status.DatabaseHostname,
-		DatabaseName:         DatabaseName,
-		OSPSecret:            instance.Spec.Secret,
-		UserPasswordSelector: instance.Spec.PasswordSelectors.Service,
-		VolumeMounts:         initVolumeMounts,
+	initContainerDetails := InitContainerDetails{
+		ContainerImage: instance.Spec.DesignateAPI.ContainerImage,
+		Resources: InitResources{
+			VolumeMounts: map[InitCapability][]corev1.VolumeMount{
+				NeedsServiceConfig: initVolumeMounts,
+			},
+		},
+		Needs: []InitCapability{NeedsServiceConfig},
+	}
+	job.Spec.Template.Spec.InitContainers = []corev1.Container{
+		InitContainer(initContainerDetails),
 	}
-	job.Spec.Template.Spec.InitContainers = InitContainer(initContainerDetails)
 
 	if instance.Spec.NodeSelector != nil {
 		job.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
+	ApplyImagePullOptions(&job.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+
+	err := ApplyExtraMounts(&job.Spec.Template.Spec, instance.Spec.ExtraMounts, DBSync)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
 
-	return job
+// DbSyncPendingImage returns the container image the db sync job waits to be
+// approved for with the Manual dbSyncPolicy, or "" when it can run. The
+// initial sync and a sync with the last synced image always run.
+func DbSyncPendingImage(instance *designatev1beta1.Designate) string {
+	image := instance.Spec.DesignateAPI.ContainerImage
+	if instance.Spec.DBSyncPolicy != designatev1beta1.DBSyncPolicyManual ||
+		instance.Status.DBSyncImage == "" ||
+		image == instance.Status.DBSyncImage ||
+		image == instance.Spec.DBSyncApprovedImage {
+		return ""
+	}
+	return image
+}
+
+// DbSyncRevision returns the schema revision bootstrap.sh writes to the
+// termination message of the db sync pod, or "" if no pod reported one
+func DbSyncRevision(
+	ctx context.Context,
+	h *helper.Helper,
+	jobDef *batchv1.Job,
+) (string, error) {
+	// the job pods are not in the cache of the manager
+	podList, err := h.GetKClient().CoreV1().Pods(jobDef.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", jobDef.Name),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods for job %s: %w", jobDef.Name, err)
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return strings.TrimSpace(status.State.Terminated.Message), nil
+			}
+		}
+	}
+	return "", nil
 }
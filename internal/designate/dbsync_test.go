@@ -0,0 +1,50 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+func TestDbSyncPendingImage(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        string
+		approvedImage string
+		syncedImage   string
+		want          string
+	}{
+		{name: "auto", policy: designatev1beta1.DBSyncPolicyAuto, syncedImage: "designate:1"},
+		{name: "manual initial sync", policy: designatev1beta1.DBSyncPolicyManual},
+		{name: "manual same image", policy: designatev1beta1.DBSyncPolicyManual, syncedImage: "designate:2"},
+		{name: "manual new image", policy: designatev1beta1.DBSyncPolicyManual, syncedImage: "designate:1", want: "designate:2"},
+		{name: "manual approved image", policy: designatev1beta1.DBSyncPolicyManual, approvedImage: "designate:2", syncedImage: "designate:1"},
+		{name: "manual other approved image", policy: designatev1beta1.DBSyncPolicyManual, approvedImage: "designate:3", syncedImage: "designate:1", want: "designate:2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &designatev1beta1.Designate{}
+			instance.Spec.DesignateAPI.ContainerImage = "designate:2"
+			instance.Spec.DBSyncPolicy = tt.policy
+			instance.Spec.DBSyncApprovedImage = tt.approvedImage
+			instance.Status.DBSyncImage = tt.syncedImage
+			if got := DbSyncPendingImage(instance); got != tt.want {
+				t.Errorf("DbSyncPendingImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
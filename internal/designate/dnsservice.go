@@ -16,17 +16,22 @@ limitations under the License.
 package designate
 
 import (
+	"maps"
+
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 )
 
 // CreateDNSService - helper function for creating a new serv
+// extraPorts are exposed in addition to the dns ports, e.g. encrypted listeners
 func CreateDNSService(
 	name string,
 	namespace string,
 	details *service.OverrideSpec,
 	labels map[string]string,
 	port int32,
+	extraPorts ...corev1.ServicePort,
 ) (*service.Service, error) {
 	if details.EmbeddedLabelsAnnotations == nil {
 		details.EmbeddedLabelsAnnotations = &service.EmbeddedLabelsAnnotations{}
@@ -34,18 +39,22 @@ func CreateDNSService(
 	selector := make(map[string]string)
 	// Service name is expected to match the name of the pod.
 	selector["statefulset.kubernetes.io/pod-name"] = name
+	// the appProtocol selects how a service mesh proxies the DNS traffic
 	ports := []corev1.ServicePort{
 		{
-			Name:     "dns",
-			Port:     port,
-			Protocol: corev1.ProtocolUDP,
+			Name:        "dns",
+			Port:        port,
+			Protocol:    corev1.ProtocolUDP,
+			AppProtocol: ptr.To("udp"),
 		},
 		{
-			Name:     "dns-tcp",
-			Port:     port,
-			Protocol: corev1.ProtocolTCP,
+			Name:        "dns-tcp",
+			Port:        port,
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: ptr.To("tcp"),
 		},
 	}
+	ports = append(ports, extraPorts...)
 
 	svc, err := service.NewService(
 		service.GenericService(
@@ -65,3 +74,74 @@ func CreateDNSService(
 	}
 	return svc, nil
 }
+
+// CreateMetricsService - helper function for creating a Service exposing the
+// metrics port of the pods matching selector
+func CreateMetricsService(
+	name string,
+	namespace string,
+	labels map[string]string,
+	selector map[string]string,
+	port int32,
+) (*service.Service, error) {
+	return service.NewService(
+		service.GenericService(
+			&service.GenericServiceDetails{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    labels,
+				Selector:  selector,
+				Ports: []corev1.ServicePort{
+					{
+						Name:     MetricsPortName,
+						Port:     port,
+						Protocol: corev1.ProtocolTCP,
+					},
+				},
+			},
+		),
+		5,
+		nil,
+	)
+}
+
+// DNSServiceOverride - returns the override of the Service of replica i: the
+// per replica override merged on top of the override of all the replicas
+func DNSServiceOverride(
+	common *service.OverrideSpec,
+	replicas []service.OverrideSpec,
+	i int,
+) service.OverrideSpec {
+	override := service.OverrideSpec{}
+	if common != nil {
+		common.DeepCopyInto(&override)
+	}
+	if i >= len(replicas) {
+		return override
+	}
+
+	replica := replicas[i].DeepCopy()
+	if replica.EmbeddedLabelsAnnotations != nil {
+		if override.EmbeddedLabelsAnnotations == nil {
+			override.EmbeddedLabelsAnnotations = &service.EmbeddedLabelsAnnotations{}
+		}
+		override.Labels = mergeOverrideMaps(override.Labels, replica.Labels)
+		override.Annotations = mergeOverrideMaps(override.Annotations, replica.Annotations)
+	}
+	if replica.Spec != nil {
+		override.Spec = replica.Spec
+	}
+	return override
+}
+
+// mergeOverrideMaps returns base with the entries of extra added, extra
+// winning on conflicts
+func mergeOverrideMaps(base map[string]string, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(extra))
+	maps.Copy(merged, base)
+	maps.Copy(merged, extra)
+	return merged
+}
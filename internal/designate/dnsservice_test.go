@@ -0,0 +1,66 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDNSServiceOverride(t *testing.T) {
+	if override := DNSServiceOverride(nil, nil, 0); override.EmbeddedLabelsAnnotations != nil || override.Spec != nil {
+		t.Errorf("expected an empty override, got %v", override)
+	}
+
+	common := &service.OverrideSpec{
+		EmbeddedLabelsAnnotations: &service.EmbeddedLabelsAnnotations{
+			Annotations: map[string]string{
+				"metallb.universe.tf/address-pool":    "designateext",
+				"metallb.universe.tf/allow-shared-ip": "designateext",
+			},
+		},
+		Spec: &service.OverrideServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}
+	replicas := []service.OverrideSpec{
+		{
+			EmbeddedLabelsAnnotations: &service.EmbeddedLabelsAnnotations{
+				Annotations: map[string]string{
+					"metallb.universe.tf/loadBalancerIPs": "172.17.0.80",
+					"metallb.universe.tf/address-pool":    "internalapi",
+				},
+			},
+		},
+	}
+
+	override := DNSServiceOverride(common, replicas, 0)
+	if override.Annotations["metallb.universe.tf/loadBalancerIPs"] != "172.17.0.80" ||
+		override.Annotations["metallb.universe.tf/address-pool"] != "internalapi" ||
+		override.Annotations["metallb.universe.tf/allow-shared-ip"] != "designateext" {
+		t.Errorf("expected the replica annotations merged on top of the common ones, got %v", override.Annotations)
+	}
+	if override.Spec == nil || override.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		t.Errorf("expected the common spec, got %v", override.Spec)
+	}
+	if common.Annotations["metallb.universe.tf/address-pool"] != "designateext" {
+		t.Errorf("expected the common override to be left untouched, got %v", common.Annotations)
+	}
+
+	override = DNSServiceOverride(common, replicas, 1)
+	if override.Annotations["metallb.universe.tf/address-pool"] != "designateext" || len(override.Annotations) != 2 {
+		t.Errorf("expected the common annotations for a replica without override, got %v", override.Annotations)
+	}
+}
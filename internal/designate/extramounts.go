@@ -0,0 +1,71 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Designate propagates the extra mounts to all the designate services
+	Designate storage.PropagationType = "Designate"
+	// DBSync propagates the extra mounts to the db sync job
+	DBSync storage.PropagationType = "DBSync"
+)
+
+// ExtraVolumes returns the volumes and the mounts of the extra mounts
+// propagated to one of the propagation types of a service
+func ExtraVolumes(
+	extraMounts []designatev1beta1.DesignateExtraVolMounts,
+	propagation ...storage.PropagationType,
+) ([]corev1.Volume, []corev1.VolumeMount, error) {
+	volumes := []corev1.Volume{}
+	mounts := []corev1.VolumeMount{}
+	for _, extraMount := range extraMounts {
+		for _, volMounts := range extraMount.Propagate(propagation) {
+			for _, volume := range volMounts.Volumes {
+				volumeSource, err := volume.ToCoreVolumeSource()
+				if err != nil {
+					return nil, nil, err
+				}
+				volumes = append(volumes, corev1.Volume{
+					Name:         volume.Name,
+					VolumeSource: *volumeSource,
+				})
+			}
+			mounts = append(mounts, volMounts.Mounts...)
+		}
+	}
+	return volumes, mounts, nil
+}
+
+// ApplyExtraMounts adds the extra mounts propagated to a service to its pod,
+// they are mounted in the service container
+func ApplyExtraMounts(
+	spec *corev1.PodSpec,
+	extraMounts []designatev1beta1.DesignateExtraVolMounts,
+	propagation ...storage.PropagationType,
+) error {
+	volumes, mounts, err := ExtraVolumes(extraMounts, propagation...)
+	if err != nil {
+		return err
+	}
+	spec.Volumes = append(spec.Volumes, volumes...)
+	spec.Containers[0].VolumeMounts = append(spec.Containers[0].VolumeMounts, mounts...)
+	return nil
+}
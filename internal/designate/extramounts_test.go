@@ -0,0 +1,82 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestVolMounts(name string, propagation ...storage.PropagationType) storage.VolMounts {
+	return storage.VolMounts{
+		Propagation: propagation,
+		Volumes: []storage.Volume{
+			{
+				Name: name,
+				VolumeSource: storage.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: name},
+				},
+			},
+		},
+		Mounts: []corev1.VolumeMount{
+			{Name: name, MountPath: "/etc/" + name, ReadOnly: true},
+		},
+	}
+}
+
+func TestApplyExtraMounts(t *testing.T) {
+	extraMounts := []designatev1beta1.DesignateExtraVolMounts{
+		{
+			VolMounts: []storage.VolMounts{
+				newTestVolMounts("all", Designate),
+				newTestVolMounts("bind9", "DesignateBackendbind9"),
+				newTestVolMounts("dbsync", DBSync),
+			},
+		},
+	}
+
+	spec := corev1.PodSpec{
+		Volumes:    []corev1.Volume{{Name: "config"}},
+		Containers: []corev1.Container{{Name: "service"}, {Name: "exporter"}},
+	}
+	if err := ApplyExtraMounts(&spec, extraMounts, Designate, "DesignateBackendbind9"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(spec.Volumes) != 3 || spec.Volumes[1].Name != "all" || spec.Volumes[2].Name != "bind9" {
+		t.Errorf("expected the all and bind9 volumes to be added, got %v", spec.Volumes)
+	}
+	if spec.Volumes[2].Secret == nil || spec.Volumes[2].Secret.SecretName != "bind9" {
+		t.Errorf("expected the bind9 Secret volume, got %v", spec.Volumes[2].VolumeSource)
+	}
+	if len(spec.Containers[0].VolumeMounts) != 2 || spec.Containers[0].VolumeMounts[1].MountPath != "/etc/bind9" {
+		t.Errorf("expected the service container to mount the volumes, got %v", spec.Containers[0].VolumeMounts)
+	}
+	if len(spec.Containers[1].VolumeMounts) != 0 {
+		t.Errorf("expected the other containers to be unchanged")
+	}
+
+	volumes, mounts, err := ExtraVolumes(extraMounts, Designate, "DesignateCentral")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(volumes) != 1 || len(mounts) != 1 || volumes[0].Name != "all" {
+		t.Errorf("expected only the volume propagated to all the services, got %v", volumes)
+	}
+}
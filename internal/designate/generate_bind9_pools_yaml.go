@@ -19,11 +19,16 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"gopkg.in/yaml.v2"
@@ -51,6 +56,14 @@ var (
 	ErrMultipoolConfigKeyMissing = errors.New("multipool ConfigMap missing pools key")
 	// ErrPoolMissingNSRecords is returned when a pool doesn't define NS records in multipool mode
 	ErrPoolMissingNSRecords = errors.New("pool missing NS records in multipool mode")
+	// ErrInvalidTargetOption is returned when a typed target option has an invalid value
+	ErrInvalidTargetOption = errors.New("invalid target option")
+	// ErrExternalBindPoolNotFound is returned when an external BIND server references an unknown pool
+	ErrExternalBindPoolNotFound = errors.New("pool of external BIND server not found")
+	// ErrSecondaryServerPoolNotFound is returned when a secondary server references an unknown pool
+	ErrSecondaryServerPoolNotFound = errors.New("pool of secondary server not found")
+	// ErrHiddenPrimaryPoolWithoutSecondaries is returned when a pool of hidden primaries has no secondary server
+	ErrHiddenPrimaryPoolWithoutSecondaries = errors.New("pool of hidden primaries without secondary servers")
 )
 
 const (
@@ -58,6 +71,8 @@ const (
 	MultipoolConfigMapKey = "pools"
 	// bindAddressKeyTemplate is the template for bind address keys in ConfigMaps
 	bindAddressKeyTemplate = "bind_address_%d"
+	// PDNSAddressKeyTemplate is the template for PowerDNS address keys in ConfigMaps
+	PDNSAddressKeyTemplate = "pdns_address_%d"
 
 	// MdnsMasterPort is the port used by mDNS masters
 	MdnsMasterPort = 5354
@@ -65,17 +80,20 @@ const (
 	DNSPort = 53
 	// RNDCPort is the port used by RNDC for BIND9 control operations
 	RNDCPort = 953
+	// PDNSAPIPort is the port of the PowerDNS API
+	PDNSAPIPort = 8081
 )
 
 // Pool represents a designate pool configuration
 type Pool struct {
-	Name        string                          `yaml:"name"`
-	Description string                          `yaml:"description"`
-	Attributes  map[string]string               `yaml:"attributes"`
-	NSRecords   []designatev1.DesignateNSRecord `yaml:"ns_records"`
-	Nameservers []Nameserver                    `yaml:"nameservers"`
-	Targets     []Target                        `yaml:"targets"`
-	CatalogZone *CatalogZone                    `yaml:"catalog_zone,omitempty"` // it is a pointer because it is optional
+	Name         string                          `yaml:"name"`
+	Description  string                          `yaml:"description"`
+	Attributes   map[string]string               `yaml:"attributes"`
+	NSRecords    []designatev1.DesignateNSRecord `yaml:"ns_records"`
+	Nameservers  []Nameserver                    `yaml:"nameservers"`
+	Targets      []Target                        `yaml:"targets"`
+	AlsoNotifies []AlsoNotify                    `yaml:"also_notifies,omitempty"`
+	CatalogZone  *CatalogZone                    `yaml:"catalog_zone,omitempty"` // it is a pointer because it is optional
 }
 
 // We have the same defined in the API now
@@ -104,13 +122,22 @@ type Master struct {
 	Port int    `yaml:"port"`
 }
 
-// Options represents designate target options
+// AlsoNotify represents an additional server notified of zone changes
+type AlsoNotify struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+}
+
+// Options represents designate target options. The bind9 options of the
+// managed backends are typed, Extra holds the options of any other target.
 type Options struct {
-	Host        string `yaml:"host"`
-	Port        int    `yaml:"port"`
-	RNDCHost    string `yaml:"rndc_host"`
-	RNDCPort    int    `yaml:"rndc_port"`
-	RNDCKeyFile string `yaml:"rndc_key_file"`
+	Host        string            `yaml:"host,omitempty"`
+	Port        int               `yaml:"port,omitempty"`
+	RNDCHost    string            `yaml:"rndc_host,omitempty"`
+	RNDCPort    int               `yaml:"rndc_port,omitempty"`
+	RNDCKeyFile string            `yaml:"rndc_key_file,omitempty"`
+	View        string            `yaml:"view,omitempty"`
+	Extra       map[string]string `yaml:",inline"`
 }
 
 // CatalogZone represents a designate catalog zone configuration
@@ -215,28 +242,377 @@ func validateMultipoolConfig(config *MultipoolConfig) error {
 
 // GeneratePoolsYamlDataAndHash sorts all pool resources to get the correct hash every time
 func GeneratePoolsYamlDataAndHash(BindMap, MdnsMap map[string]string, nsRecords []designatev1.DesignateNSRecord, multipoolConfig *MultipoolConfig) (string, string, error) {
+	pools, err := GeneratePools(BindMap, MdnsMap, nsRecords, multipoolConfig)
+	if err != nil {
+		return "", "", err
+	}
+	return GeneratePoolsYaml(pools)
+}
+
+// GetMasterHosts returns the sorted mdns addresses of the mdns predictable IP map
+func GetMasterHosts(MdnsMap map[string]string) []string {
+	// On dual stack networks every mdns pod is a master on each of its addresses
 	masterHosts := make([]string, 0, len(MdnsMap))
-	for _, host := range MdnsMap {
-		masterHosts = append(masterHosts, host)
+	for _, hosts := range MdnsMap {
+		masterHosts = append(masterHosts, SplitPredictableIPs(hosts)...)
 	}
 	sort.Strings(masterHosts)
+	return masterHosts
+}
 
-	var pools []Pool
+// GeneratePools returns the pools of the operator managed bind9 backends
+func GeneratePools(BindMap, MdnsMap map[string]string, nsRecords []designatev1.DesignateNSRecord, multipoolConfig *MultipoolConfig) ([]Pool, error) {
+	masterHosts := GetMasterHosts(MdnsMap)
 
 	if multipoolConfig == nil {
 		pool, err := generateDefaultPool(BindMap, masterHosts, nsRecords)
 		if err != nil {
-			return "", "", err
+			return nil, err
+		}
+		return []Pool{pool}, nil
+	}
+	return generateMultiplePools(BindMap, masterHosts, multipoolConfig)
+}
+
+// CatalogZoneFQDN returns the name of the catalog zone of the bind9 pool
+// poolName. The default pool uses fqdn, the other pools get a catalog zone
+// named after them below it.
+func CatalogZoneFQDN(fqdn string, poolName string) string {
+	if poolName == DefaultPoolName {
+		return fqdn
+	}
+	return fmt.Sprintf("%s.%s", poolName, fqdn)
+}
+
+// SetBind9Port sets the port the bind9 servers of the pools generated by
+// GeneratePools answer DNS queries and notifies on, when it isn't the standard
+// DNS port.
+func SetBind9Port(pools []Pool, port int) {
+	for i := range pools {
+		for j := range pools[i].Targets {
+			pools[i].Targets[j].Options.Port = port
+		}
+		for j := range pools[i].Nameservers {
+			pools[i].Nameservers[j].Port = port
+		}
+	}
+}
+
+// SetBind9QueryHosts moves the DNS queries and the notifies of the bind9
+// servers of the pools generated by GeneratePools to their addresses in
+// queryMap, rndc stays on their addresses in bindMap. Both maps are keyed by
+// the bind IP holders, the servers without a query address are left as is.
+func SetBind9QueryHosts(pools []Pool, bindMap map[string]string, queryMap map[string]string) {
+	hosts := make(map[string]string, len(queryMap))
+	for holder, address := range queryMap {
+		if address != "" && bindMap[holder] != "" {
+			hosts[PrimaryPredictableIP(bindMap[holder])] = PrimaryPredictableIP(address)
+		}
+	}
+	for i := range pools {
+		for j := range pools[i].Targets {
+			if host, ok := hosts[pools[i].Targets[j].Options.RNDCHost]; ok {
+				pools[i].Targets[j].Options.Host = host
+			}
+		}
+		for j := range pools[i].Nameservers {
+			if host, ok := hosts[pools[i].Nameservers[j].Host]; ok {
+				pools[i].Nameservers[j].Host = host
+			}
+		}
+	}
+}
+
+// SetMdnsPort sets the port of the mdns servers the targets of the pools
+// transfer the zones from.
+func SetMdnsPort(pools []Pool, port int) {
+	for i := range pools {
+		for j := range pools[i].Targets {
+			for k := range pools[i].Targets[j].Masters {
+				pools[i].Targets[j].Masters[k].Port = port
+			}
+		}
+	}
+}
+
+// SetCatalogZones adds the catalog zone of every pool generated by
+// GeneratePools, the bind9 servers of the pool consume it.
+func SetCatalogZones(pools []Pool, catalogZone *designatev1.Bind9CatalogZoneSpec) {
+	if catalogZone == nil {
+		return
+	}
+	for i := range pools {
+		pools[i].CatalogZone = &CatalogZone{
+			FQDN:    CatalogZoneFQDN(catalogZone.FQDN, pools[i].Name),
+			Refresh: int(catalogZone.Refresh),
+		}
+	}
+}
+
+// SetBind9Views adds the zones of the bind9 targets of the pools generated by
+// GeneratePools to the view of the managed zones, when named defines views.
+func SetBind9Views(pools []Pool, views []designatev1.Bind9ViewSpec) {
+	for _, view := range views {
+		if !view.ManagedZones {
+			continue
+		}
+		for i := range pools {
+			for j := range pools[i].Targets {
+				pools[i].Targets[j].Options.View = view.Name
+			}
+		}
+		return
+	}
+}
+
+// ExternalRndcKeyName returns the name of the rndc key of an external BIND server
+func ExternalRndcKeyName(serverName string) string {
+	return fmt.Sprintf("%s-%s", DesignateRndcKey, serverName)
+}
+
+// ExternalTSIGSecretEnvVar returns the name of the pool update job environment
+// variable holding the TSIG secret of an external BIND server
+func ExternalTSIGSecretEnvVar(serverName string) string {
+	return ExternalTSIGSecretEnvPrefix + strings.ToUpper(strings.ReplaceAll(serverName, "-", "_"))
+}
+
+// ExternalTSIGSecretPlaceholder is written to pools.yaml instead of the TSIG
+// secret of an external BIND server, the pool update job replaces it with the
+// secret from the TSIGKeySecret of the server
+func ExternalTSIGSecretPlaceholder(serverName string) string {
+	return fmt.Sprintf("@%s@", ExternalTSIGSecretEnvVar(serverName))
+}
+
+// AddExternalBindServers adds the external BIND servers as targets,
+// nameservers and also notifies of their pools. The TSIG key of a server is
+// set in the options of its target, designate has no per server TSIG
+// settings for also_notifies.
+func AddExternalBindServers(pools []Pool, servers []designatev1.DesignateExternalBindServer, MdnsMap map[string]string) ([]Pool, error) {
+	masters := createMasters(GetMasterHosts(MdnsMap))
+	for _, server := range servers {
+		idx := slices.IndexFunc(pools, func(p Pool) bool { return p.Name == server.Pool })
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: %s references pool %s", ErrExternalBindPoolNotFound, server.Name, server.Pool)
+		}
+		pool := &pools[idx]
+		options := Options{
+			Host:        server.Host,
+			Port:        int(server.Port),
+			RNDCHost:    server.GetRNDCHost(),
+			RNDCPort:    int(server.RNDCPort),
+			RNDCKeyFile: fmt.Sprintf("%s/%s", ExternalRndcConfDir, ExternalRndcKeyName(server.Name)),
+		}
+		if server.HasTSIGKey() {
+			options.Extra = map[string]string{
+				"tsig_key_name":      server.GetTSIGKeyName(),
+				"tsig_key_algorithm": server.TSIGKeyAlgorithm,
+				"tsig_key_secret":    ExternalTSIGSecretPlaceholder(server.Name),
+			}
+		}
+		pool.Targets = append(pool.Targets, Target{
+			Type:        "bind9",
+			Description: fmt.Sprintf("External BIND9 Server %s (%s)", server.Name, server.Host),
+			Masters:     masters,
+			Options:     options,
+		})
+		pool.Nameservers = append(pool.Nameservers, Nameserver{
+			Host: server.Host,
+			Port: int(server.Port),
+		})
+		pool.AlsoNotifies = append(pool.AlsoNotifies, AlsoNotify{
+			Host: server.Host,
+			Port: int(server.Port),
+		})
+	}
+	return pools, nil
+}
+
+// AddSecondaryServers adds the external secondary servers as also notifies of
+// their pools. The servers transfer the zones from the bind9 servers, they are
+// not targets of the pools.
+func AddSecondaryServers(pools []Pool, servers []designatev1.Bind9SecondaryServerSpec) ([]Pool, error) {
+	for _, server := range servers {
+		idx := slices.IndexFunc(pools, func(p Pool) bool { return p.Name == server.Pool })
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: %s references pool %s", ErrSecondaryServerPoolNotFound, server.Name, server.Pool)
+		}
+		pools[idx].AlsoNotifies = append(pools[idx].AlsoNotifies, AlsoNotify{
+			Host: server.Host,
+			Port: int(server.Port),
+		})
+	}
+	return pools, nil
+}
+
+// SetHiddenPrimary replaces the bind9 servers of the pools generated by
+// GeneratePools with the secondary servers of the pools as nameservers. The
+// bind9 servers stay the targets of the pools, designate provisions the zones
+// on them and checks on the secondaries that the zone changes are published.
+func SetHiddenPrimary(pools []Pool, servers []designatev1.Bind9SecondaryServerSpec) error {
+	for i := range pools {
+		var nameservers []Nameserver
+		for _, server := range servers {
+			if server.Pool == pools[i].Name {
+				nameservers = append(nameservers, Nameserver{
+					Host: server.Host,
+					Port: int(server.Port),
+				})
+			}
+		}
+		if len(nameservers) == 0 {
+			return fmt.Errorf("%w: %s", ErrHiddenPrimaryPoolWithoutSecondaries, pools[i].Name)
+		}
+		pools[i].Nameservers = nameservers
+	}
+	return nil
+}
+
+// PoolsStatus returns the description of the pools reported in the status of
+// Designate, hiddenPrimary tells whether the bind9 servers are hidden primaries
+func PoolsStatus(pools []Pool, hiddenPrimary bool) []designatev1.DesignateAppliedPool {
+	status := make([]designatev1.DesignateAppliedPool, 0, len(pools))
+	for _, pool := range pools {
+		applied := designatev1.DesignateAppliedPool{
+			Name:          pool.Name,
+			HiddenPrimary: hiddenPrimary,
+		}
+		for _, nsRecord := range pool.NSRecords {
+			applied.NSRecords = append(applied.NSRecords, nsRecord.Hostname)
+		}
+		for _, nameserver := range pool.Nameservers {
+			applied.Nameservers = append(applied.Nameservers, net.JoinHostPort(nameserver.Host, strconv.Itoa(nameserver.Port)))
+		}
+		for _, target := range pool.Targets {
+			applied.Targets = append(applied.Targets, net.JoinHostPort(target.Options.Host, strconv.Itoa(target.Options.Port)))
+		}
+		for _, alsoNotify := range pool.AlsoNotifies {
+			applied.AlsoNotifies = append(applied.AlsoNotifies, net.JoinHostPort(alsoNotify.Host, strconv.Itoa(alsoNotify.Port)))
+		}
+		status = append(status, applied)
+	}
+	return status
+}
+
+// AddPDNSServers adds the PowerDNS servers of PDNSMap as pdns4 targets and
+// nameservers of the default pool. The API key is rendered as
+// PDNSAPIKeyPlaceholder so it is not stored in the pools.yaml ConfigMap.
+func AddPDNSServers(pools []Pool, PDNSMap map[string]string, MdnsMap map[string]string) ([]Pool, error) {
+	if len(PDNSMap) == 0 {
+		return pools, nil
+	}
+	idx := slices.IndexFunc(pools, func(p Pool) bool { return p.Name == DefaultPoolName })
+	if idx < 0 {
+		return nil, ErrDefaultPoolMissing
+	}
+	pool := &pools[idx]
+	masters := createMasters(GetMasterHosts(MdnsMap))
+	for i := range len(PDNSMap) {
+		pdnsIP := PrimaryPredictableIP(PDNSMap[fmt.Sprintf(PDNSAddressKeyTemplate, i)])
+		pool.Targets = append(pool.Targets, Target{
+			Type:        "pdns4",
+			Description: fmt.Sprintf("PowerDNS Server %d (%s)", i, pdnsIP),
+			Masters:     masters,
+			Options: Options{
+				Host: pdnsIP,
+				Port: DNSPort,
+				Extra: map[string]string{
+					"api_endpoint": fmt.Sprintf("http://%s", net.JoinHostPort(pdnsIP, strconv.Itoa(PDNSAPIPort))),
+					"api_token":    PDNSAPIKeyPlaceholder,
+				},
+			},
+		})
+		pool.Nameservers = append(pool.Nameservers, Nameserver{
+			Host: pdnsIP,
+			Port: DNSPort,
+		})
+	}
+	return pools, nil
+}
+
+// GeneratePoolFromCR returns the pool defined by a DesignatePool CR. Targets
+// without masters get the mdns servers of MdnsMap.
+func GeneratePoolFromCR(instance *designatev1.DesignatePool, MdnsMap map[string]string) (Pool, error) {
+	nsRecords := make([]designatev1.DesignateNSRecord, len(instance.Spec.NSRecords))
+	copy(nsRecords, instance.Spec.NSRecords)
+	sortNSRecords(nsRecords)
+
+	attributes := make(map[string]string)
+	for k, v := range instance.Spec.Attributes {
+		attributes[k] = v
+	}
+
+	nameservers := make([]Nameserver, len(instance.Spec.Nameservers))
+	for i, ns := range instance.Spec.Nameservers {
+		nameservers[i] = Nameserver{Host: ns.Host, Port: int(ns.Port)}
+	}
+
+	defaultMasters := createMasters(GetMasterHosts(MdnsMap))
+	targets := make([]Target, len(instance.Spec.Targets))
+	for i, t := range instance.Spec.Targets {
+		masters := defaultMasters
+		if len(t.Masters) > 0 {
+			masters = make([]Master, len(t.Masters))
+			for j, m := range t.Masters {
+				masters[j] = Master{Host: m.Host, Port: int(m.Port)}
+			}
+		}
+		options, err := newTargetOptions(t.Options)
+		if err != nil {
+			return Pool{}, err
 		}
-		pools = []Pool{pool}
-	} else {
+		targets[i] = Target{
+			Type:        t.Type,
+			Description: t.Description,
+			Masters:     masters,
+			Options:     options,
+		}
+	}
+
+	var alsoNotifies []AlsoNotify
+	for _, an := range instance.Spec.AlsoNotifies {
+		alsoNotifies = append(alsoNotifies, AlsoNotify{Host: an.Host, Port: int(an.Port)})
+	}
+
+	return Pool{
+		Name:         instance.GetPoolName(),
+		Description:  instance.Spec.Description,
+		Attributes:   attributes,
+		NSRecords:    nsRecords,
+		Nameservers:  nameservers,
+		Targets:      targets,
+		AlsoNotifies: alsoNotifies,
+	}, nil
+}
+
+// newTargetOptions splits the options of a DesignatePool target into the typed
+// Options fields and Extra. yaml can't inline a map key matching a field.
+func newTargetOptions(targetOptions map[string]string) (Options, error) {
+	options := Options{Extra: map[string]string{}}
+	for k, v := range targetOptions {
 		var err error
-		pools, err = generateMultiplePools(BindMap, masterHosts, multipoolConfig)
+		switch k {
+		case "host":
+			options.Host = v
+		case "port":
+			options.Port, err = strconv.Atoi(v)
+		case "rndc_host":
+			options.RNDCHost = v
+		case "rndc_port":
+			options.RNDCPort, err = strconv.Atoi(v)
+		case "rndc_key_file":
+			options.RNDCKeyFile = v
+		default:
+			options.Extra[k] = v
+		}
 		if err != nil {
-			return "", "", err
+			return Options{}, fmt.Errorf("%w: %s: %q", ErrInvalidTargetOption, k, v)
 		}
 	}
+	return options, nil
+}
 
+// GeneratePoolsYaml renders the pools.yaml content of pools and its hash
+func GeneratePoolsYaml(pools []Pool) (string, string, error) {
 	poolBytes, err := yaml.Marshal(pools)
 	if err != nil {
 		return "", "", fmt.Errorf("error marshalling pools for hash: %w", err)
@@ -254,7 +630,7 @@ func GeneratePoolsYamlDataAndHash(BindMap, MdnsMap map[string]string, nsRecords
 	if err != nil {
 		return "", "", err
 	}
-	tmpl, err := template.New("pools").Parse(string(poolsYaml))
+	tmpl, err := template.New("pools").Funcs(template.FuncMap{"quote": quoteYamlString}).Parse(string(poolsYaml))
 	if err != nil {
 		return "", "", err
 	}
@@ -267,6 +643,37 @@ func GeneratePoolsYamlDataAndHash(BindMap, MdnsMap map[string]string, nsRecords
 	return buf.String(), poolHash, nil
 }
 
+// MergePoolsYaml concatenates the pools of several rendered pools.yaml files
+// into a single one
+func MergePoolsYaml(poolsYamls ...string) (string, error) {
+	merged := []yaml.MapSlice{}
+	for _, poolsYaml := range poolsYamls {
+		var pools []yaml.MapSlice
+		if err := yaml.Unmarshal([]byte(poolsYaml), &pools); err != nil {
+			return "", fmt.Errorf("failed to parse pools.yaml: %w", err)
+		}
+		merged = append(merged, pools...)
+	}
+	if len(merged) == 0 {
+		return "", nil
+	}
+	out, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return "---\n" + string(out), nil
+}
+
+// quoteYamlString renders s as a double quoted scalar. JSON strings are valid
+// YAML, so user provided target options cannot break the pools.yaml structure.
+func quoteYamlString(s string) (string, error) {
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(quoted), nil
+}
+
 // sortNSRecords sorts NS records by hostname and then by priority
 func sortNSRecords(nsRecords []designatev1.DesignateNSRecord) {
 	sort.Slice(nsRecords, func(i, j int) bool {
@@ -323,14 +730,9 @@ func generateDefaultPool(BindMap map[string]string, masterHosts []string, nsReco
 	nameservers := make([]Nameserver, len(bindIPs))
 	for i := range bindIPs {
 		description := fmt.Sprintf("BIND9 Server %d (%s)", i, bindIPs[i])
-		targets[i], nameservers[i] = createTargetAndNameserver(bindIPs[i], i, masters, description)
+		targets[i], nameservers[i] = createTargetAndNameserver(PrimaryPredictableIP(bindIPs[i]), i, masters, description)
 	}
 
-	// Catalog zone is an optional section
-	// catalogZone := &CatalogZone{
-	// 	FQDN:    "example.org.",
-	//	Refresh: 60,
-	// }
 	defaultAttributes := make(map[string]string)
 	pool := Pool{
 		Name:        "default",
@@ -339,7 +741,6 @@ func generateDefaultPool(BindMap map[string]string, masterHosts []string, nsReco
 		NSRecords:   nsRecords,
 		Nameservers: nameservers,
 		Targets:     targets,
-		CatalogZone: nil, // set to catalogZone if this section should be presented
 	}
 
 	return pool, nil
@@ -381,7 +782,7 @@ func generateMultiplePools(BindMap map[string]string, masterHosts []string, mult
 		for i := int32(0); i < poolConfig.BindReplicas; i++ {
 			globalIndex := bindIndex - int(poolConfig.BindReplicas) + int(i)
 			description := fmt.Sprintf("BIND9 Server for pool %s (%s)", poolConfig.Name, poolBindIPs[i])
-			targets[i], nameservers[i] = createTargetAndNameserver(poolBindIPs[i], globalIndex, masters, description)
+			targets[i], nameservers[i] = createTargetAndNameserver(PrimaryPredictableIP(poolBindIPs[i]), globalIndex, masters, description)
 		}
 
 		attributes := poolConfig.Attributes
@@ -389,11 +790,6 @@ func generateMultiplePools(BindMap map[string]string, masterHosts []string, mult
 			attributes = make(map[string]string)
 		}
 
-		// Catalog zone is an optional section
-		// catalogZone := &CatalogZone{
-		// 	FQDN:    "example.org.",
-		//	Refresh: 60,
-		// }
 		pool := Pool{
 			Name:        poolConfig.Name,
 			Description: poolConfig.Description,
@@ -401,7 +797,6 @@ func generateMultiplePools(BindMap map[string]string, masterHosts []string, mult
 			NSRecords:   nsRecords,
 			Nameservers: nameservers,
 			Targets:     targets,
-			CatalogZone: nil, // set to catalogZone if this section should be presented
 		}
 
 		pools = append(pools, pool)
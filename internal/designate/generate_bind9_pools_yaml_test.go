@@ -17,9 +17,13 @@ limitations under the License.
 package designate
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
+	"gopkg.in/yaml.v2"
+
 	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 )
 
@@ -208,6 +212,30 @@ func TestGenerateDefaultPool(t *testing.T) {
 	}
 }
 
+func TestGenerateDefaultPoolDualStack(t *testing.T) {
+	bindMap := map[string]string{
+		"bind_address_0": "192.168.1.10,fd00::10",
+	}
+	masterHosts := []string{"192.168.1.20", "fd00::20"}
+
+	pool, err := generateDefaultPool(bindMap, masterHosts, []designatev1.DesignateNSRecord{})
+	if err != nil {
+		t.Fatalf("generateDefaultPool() error = %v", err)
+	}
+
+	if pool.Nameservers[0].Host != "192.168.1.10" {
+		t.Errorf("expected nameserver on the primary address '192.168.1.10', got %s", pool.Nameservers[0].Host)
+	}
+
+	if pool.Targets[0].Options.RNDCHost != "192.168.1.10" {
+		t.Errorf("expected rndc host on the primary address '192.168.1.10', got %s", pool.Targets[0].Options.RNDCHost)
+	}
+
+	if len(pool.Targets[0].Masters) != 2 {
+		t.Errorf("expected a master per mdns address, got %d", len(pool.Targets[0].Masters))
+	}
+}
+
 func TestGenerateMultiplePools(t *testing.T) {
 	bindMap := map[string]string{
 		"bind_address_0": "192.168.1.10",
@@ -365,3 +393,454 @@ func TestSinglePoolModeUsesCRNSRecords(t *testing.T) {
 		t.Errorf("expected single-pool mode to use CR NS record 'ns2-cr-single.example.org.', got %s", pool.NSRecords[1].Hostname)
 	}
 }
+
+func TestGeneratePoolFromCR(t *testing.T) {
+	instance := &designatev1.DesignatePool{}
+	instance.Name = "external"
+	instance.Spec = designatev1.DesignatePoolSpec{
+		Description: "External PowerDNS",
+		Attributes:  map[string]string{"type": "external"},
+		NSRecords: []designatev1.DesignateNSRecord{
+			{Hostname: "ns2.example.org.", Priority: 2},
+			{Hostname: "ns1.example.org.", Priority: 1},
+		},
+		Nameservers: []designatev1.DesignatePoolServer{{Host: "192.0.2.10", Port: 53}},
+		Targets: []designatev1.DesignatePoolTarget{
+			{
+				Type:    "pdns4",
+				Options: map[string]string{"host": "192.0.2.10", "api_endpoint": "http://192.0.2.10:8081"},
+			},
+			{
+				Type:    "bind9",
+				Masters: []designatev1.DesignatePoolMaster{{Host: "192.0.2.1", Port: 5354}},
+			},
+		},
+		AlsoNotifies: []designatev1.DesignatePoolServer{{Host: "192.0.2.20", Port: 53}},
+	}
+	mdnsMap := map[string]string{
+		"mdns_address_0": "172.28.0.31",
+		"mdns_address_1": "172.28.0.32",
+	}
+
+	pool, err := GeneratePoolFromCR(instance, mdnsMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pool.Name != "external" {
+		t.Errorf("expected pool name to default to the CR name, got %s", pool.Name)
+	}
+	if pool.NSRecords[0].Hostname != "ns1.example.org." {
+		t.Errorf("expected sorted NS records, got %v", pool.NSRecords)
+	}
+	if len(pool.Targets[0].Masters) != 2 || pool.Targets[0].Masters[0].Host != "172.28.0.31" {
+		t.Errorf("expected the mdns servers as default masters, got %v", pool.Targets[0].Masters)
+	}
+	if pool.Targets[0].Options.Extra["api_endpoint"] != "http://192.0.2.10:8081" {
+		t.Errorf("expected target options to be passed through, got %v", pool.Targets[0].Options)
+	}
+	if pool.Targets[0].Options.Host != "192.0.2.10" || pool.Targets[0].Options.Extra["host"] != "" {
+		t.Errorf("expected the host option to be typed, got %v", pool.Targets[0].Options)
+	}
+	if len(pool.Targets[1].Masters) != 1 || pool.Targets[1].Masters[0].Host != "192.0.2.1" {
+		t.Errorf("expected explicit masters to be kept, got %v", pool.Targets[1].Masters)
+	}
+	if len(pool.AlsoNotifies) != 1 || pool.AlsoNotifies[0].Host != "192.0.2.20" {
+		t.Errorf("expected also notifies, got %v", pool.AlsoNotifies)
+	}
+
+	if _, _, err := GeneratePoolsYaml([]Pool{pool}); err != nil {
+		t.Errorf("unexpected error rendering the pool: %v", err)
+	}
+
+	instance.Spec.PoolName = "default"
+	if pool, _ := GeneratePoolFromCR(instance, mdnsMap); pool.Name != "default" {
+		t.Errorf("expected pool name 'default', got %s", pool.Name)
+	}
+
+	instance.Spec.Targets[0].Options["port"] = "fifty-three"
+	if _, err := GeneratePoolFromCR(instance, mdnsMap); !errors.Is(err, ErrInvalidTargetOption) {
+		t.Errorf("expected ErrInvalidTargetOption, got %v", err)
+	}
+}
+
+func TestGeneratePoolsYamlQuotesTargetOptions(t *testing.T) {
+	options := map[string]string{
+		"api_token":    `se"cret: {x}`,
+		"api_endpoint": "http://192.0.2.10:8081\n- name: injected",
+	}
+	pools := []Pool{{
+		Name: "external",
+		Targets: []Target{{
+			Type:    "pdns4",
+			Options: Options{Extra: options},
+		}},
+	}}
+
+	poolsYaml, _, err := GeneratePoolsYaml(pools)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var rendered []struct {
+		Name    string `yaml:"name"`
+		Targets []struct {
+			Options map[string]string `yaml:"options"`
+		} `yaml:"targets"`
+	}
+	if err := yaml.Unmarshal([]byte(poolsYaml), &rendered); err != nil {
+		t.Fatalf("rendered pools.yaml is not valid YAML: %v\n%s", err, poolsYaml)
+	}
+	if len(rendered) != 1 {
+		t.Fatalf("expected 1 pool, got %d:\n%s", len(rendered), poolsYaml)
+	}
+	for k, v := range options {
+		if got := rendered[0].Targets[0].Options[k]; got != v {
+			t.Errorf("option %s: expected %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestSetCatalogZones(t *testing.T) {
+	pools := []Pool{{Name: DefaultPoolName}, {Name: "pool1"}}
+
+	SetCatalogZones(pools, nil)
+	if pools[0].CatalogZone != nil || pools[1].CatalogZone != nil {
+		t.Fatalf("expected no catalog zones, got %v", pools)
+	}
+
+	SetCatalogZones(pools, &designatev1.Bind9CatalogZoneSpec{FQDN: "catalog.example.org.", Refresh: 60})
+	if pools[0].CatalogZone == nil || pools[0].CatalogZone.FQDN != "catalog.example.org." {
+		t.Errorf("expected the default pool to use the catalog zone, got %v", pools[0].CatalogZone)
+	}
+	if pools[1].CatalogZone == nil || pools[1].CatalogZone.FQDN != "pool1.catalog.example.org." {
+		t.Errorf("expected pool1 to get its own catalog zone, got %v", pools[1].CatalogZone)
+	}
+
+	poolsYaml, _, err := GeneratePoolsYaml(pools)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rendered []struct {
+		Name        string            `yaml:"name"`
+		CatalogZone map[string]string `yaml:"catalog_zone"`
+	}
+	if err := yaml.Unmarshal([]byte(poolsYaml), &rendered); err != nil {
+		t.Fatalf("rendered pools.yaml is not valid YAML: %v\n%s", err, poolsYaml)
+	}
+	if rendered[1].CatalogZone["catalog_zone_fqdn"] != "pool1.catalog.example.org." ||
+		rendered[1].CatalogZone["catalog_zone_refresh"] != "60" {
+		t.Errorf("unexpected catalog zone of pool1: %v", rendered[1].CatalogZone)
+	}
+}
+
+func TestSetBind9Views(t *testing.T) {
+	pools := []Pool{
+		{Name: DefaultPoolName, Targets: []Target{{Type: "bind9"}, {Type: "bind9"}}},
+		{Name: "pool1", Targets: []Target{{Type: "bind9"}}},
+	}
+
+	SetBind9Views(pools, nil)
+	if pools[0].Targets[0].Options.View != "" {
+		t.Fatalf("expected no view without views, got %q", pools[0].Targets[0].Options.View)
+	}
+
+	SetBind9Views(pools, []designatev1.Bind9ViewSpec{
+		{Name: "external", MatchClients: []string{"any"}},
+		{Name: "internal", MatchClients: []string{"10.0.0.0/8"}, ManagedZones: true},
+	})
+	for _, pool := range pools {
+		for _, target := range pool.Targets {
+			if target.Options.View != "internal" {
+				t.Errorf("expected the targets of %s to use the internal view, got %q", pool.Name, target.Options.View)
+			}
+		}
+	}
+
+	poolsYaml, _, err := GeneratePoolsYaml(pools)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rendered []struct {
+		Targets []struct {
+			Options map[string]string `yaml:"options"`
+		} `yaml:"targets"`
+	}
+	if err := yaml.Unmarshal([]byte(poolsYaml), &rendered); err != nil {
+		t.Fatalf("rendered pools.yaml is not valid YAML: %v\n%s", err, poolsYaml)
+	}
+	if rendered[1].Targets[0].Options["view"] != "internal" {
+		t.Errorf("unexpected options of the pool1 target: %v", rendered[1].Targets[0].Options)
+	}
+}
+
+func TestSetPorts(t *testing.T) {
+	bindMap := map[string]string{"bind_address_0": "192.168.1.10"}
+	pool, err := generateDefaultPool(bindMap, []string{"192.168.1.20"}, []designatev1.DesignateNSRecord{
+		{Hostname: "ns1.example.org.", Priority: 1},
+	})
+	if err != nil {
+		t.Fatalf("generateDefaultPool() error = %v", err)
+	}
+	pools := []Pool{pool}
+
+	SetBind9Port(pools, 1053)
+	SetMdnsPort(pools, 5355)
+	if pools[0].Nameservers[0].Port != 1053 || pools[0].Targets[0].Options.Port != 1053 {
+		t.Errorf("expected the bind9 servers on port 1053, got %v and %v", pools[0].Nameservers[0], pools[0].Targets[0].Options)
+	}
+	if pools[0].Targets[0].Options.RNDCPort != RNDCPort {
+		t.Errorf("expected the rndc port to be left untouched, got %d", pools[0].Targets[0].Options.RNDCPort)
+	}
+	if pools[0].Targets[0].Masters[0].Port != 5355 {
+		t.Errorf("expected the mdns servers on port 5355, got %v", pools[0].Targets[0].Masters)
+	}
+}
+
+func TestSetBind9QueryHosts(t *testing.T) {
+	bindMap := map[string]string{"bind_address_0": "192.168.1.10", "bind_address_1": "192.168.1.11"}
+	pool, err := generateDefaultPool(bindMap, []string{"192.168.1.20"}, []designatev1.DesignateNSRecord{
+		{Hostname: "ns1.example.org.", Priority: 1},
+	})
+	if err != nil {
+		t.Fatalf("generateDefaultPool() error = %v", err)
+	}
+	pools := []Pool{pool}
+
+	// the second server has no query address yet
+	SetBind9QueryHosts(pools, bindMap, map[string]string{"bind_address_0": "10.0.0.10,fd00::10", "bind_address_1": ""})
+	target := pools[0].Targets[0].Options
+	if target.Host != "10.0.0.10" || pools[0].Nameservers[0].Host != "10.0.0.10" {
+		t.Errorf("expected the queries of the first server on 10.0.0.10, got %v and %v", target, pools[0].Nameservers[0])
+	}
+	if target.RNDCHost != "192.168.1.10" {
+		t.Errorf("expected rndc to stay on the control network, got %s", target.RNDCHost)
+	}
+	if pools[0].Targets[1].Options.Host != "192.168.1.11" || pools[0].Nameservers[1].Host != "192.168.1.11" {
+		t.Errorf("expected the second server to stay on the control network, got %v and %v",
+			pools[0].Targets[1].Options, pools[0].Nameservers[1])
+	}
+}
+
+func TestMergePoolsYaml(t *testing.T) {
+	first := `---
+- name: default
+  description: Default pool
+  targets:
+    - type: bind9
+`
+	second := `---
+- name: external
+  targets:
+    - type: pdns4
+      options:
+        "api_token": "se\"cret"
+`
+
+	merged, err := MergePoolsYaml(first, "", second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var pools []struct {
+		Name    string `yaml:"name"`
+		Targets []struct {
+			Type    string            `yaml:"type"`
+			Options map[string]string `yaml:"options"`
+		} `yaml:"targets"`
+	}
+	if err := yaml.Unmarshal([]byte(merged), &pools); err != nil {
+		t.Fatalf("merged pools.yaml is not valid YAML: %v\n%s", err, merged)
+	}
+	if len(pools) != 2 || pools[0].Name != "default" || pools[1].Name != "external" {
+		t.Fatalf("expected pools default and external, got %v", pools)
+	}
+	if pools[1].Targets[0].Options["api_token"] != `se"cret` {
+		t.Errorf("expected target options to be kept, got %v", pools[1].Targets[0].Options)
+	}
+
+	if merged, err := MergePoolsYaml(""); err != nil || merged != "" {
+		t.Errorf("expected no pools, got %q, %v", merged, err)
+	}
+}
+
+func TestAddExternalBindServers(t *testing.T) {
+	pools := []Pool{
+		{Name: "default", Targets: []Target{{Type: "bind9"}}},
+		{Name: "pool1"},
+	}
+	servers := []designatev1.DesignateExternalBindServer{
+		{Name: "dc1", Host: "192.0.2.53", Port: 53, RNDCPort: 953, Pool: "default"},
+		{Name: "dc2", Host: "192.0.2.54", Port: 5353, RNDCHost: "198.51.100.54", RNDCPort: 9953, Pool: "pool1",
+			TSIGKeyAlgorithm: "hmac-sha512", TSIGKeySecret: "dc2-tsig", TSIGKeySecretKey: "tsig-key"},
+	}
+	mdnsMap := map[string]string{"mdns_address_0": "172.28.0.31"}
+
+	pools, err := AddExternalBindServers(pools, servers, mdnsMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pools[0].Targets) != 2 {
+		t.Fatalf("expected the external server to be added to the default pool, got %v", pools[0].Targets)
+	}
+	target := pools[0].Targets[1]
+	if target.Options.Host != "192.0.2.53" || target.Options.RNDCHost != "192.0.2.53" ||
+		target.Options.RNDCKeyFile != ExternalRndcConfDir+"/rndc-key-dc1" {
+		t.Errorf("unexpected target options %v", target.Options)
+	}
+	if len(target.Masters) != 1 || target.Masters[0].Host != "172.28.0.31" {
+		t.Errorf("expected the mdns servers as masters, got %v", target.Masters)
+	}
+
+	pool1 := pools[1]
+	if len(pool1.Targets) != 1 || pool1.Targets[0].Options.RNDCHost != "198.51.100.54" || pool1.Targets[0].Options.RNDCPort != 9953 {
+		t.Errorf("unexpected pool1 targets %v", pool1.Targets)
+	}
+	if target.Options.Extra != nil {
+		t.Errorf("expected no TSIG options without a TSIG key, got %v", target.Options.Extra)
+	}
+	tsigOptions := pool1.Targets[0].Options.Extra
+	if tsigOptions["tsig_key_name"] != "dc2" || tsigOptions["tsig_key_algorithm"] != "hmac-sha512" ||
+		tsigOptions["tsig_key_secret"] != "@TSIG_SECRET_DC2@" {
+		t.Errorf("unexpected TSIG options %v", tsigOptions)
+	}
+	if len(pool1.Nameservers) != 1 || pool1.Nameservers[0].Port != 5353 {
+		t.Errorf("expected the external server as nameserver, got %v", pool1.Nameservers)
+	}
+	if len(pool1.AlsoNotifies) != 1 || pool1.AlsoNotifies[0].Host != "192.0.2.54" {
+		t.Errorf("expected the external server in also_notifies, got %v", pool1.AlsoNotifies)
+	}
+
+	servers[0].Pool = "missing"
+	if _, err := AddExternalBindServers(pools, servers, mdnsMap); !errors.Is(err, ErrExternalBindPoolNotFound) {
+		t.Errorf("expected ErrExternalBindPoolNotFound, got %v", err)
+	}
+}
+
+func TestAddSecondaryServers(t *testing.T) {
+	pools := []Pool{
+		{Name: "default"},
+		{Name: "pool1", AlsoNotifies: []AlsoNotify{{Host: "192.0.2.53", Port: 53}}},
+	}
+	servers := []designatev1.Bind9SecondaryServerSpec{
+		{Name: "corp1", Host: "198.51.100.10", Port: 53, Pool: "default"},
+		{Name: "corp2", Host: "198.51.100.11", Port: 5353, Pool: "pool1", TSIGKeySecret: "corp2-tsig"},
+	}
+
+	pools, err := AddSecondaryServers(pools, servers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pools[0].AlsoNotifies) != 1 || pools[0].AlsoNotifies[0] != (AlsoNotify{Host: "198.51.100.10", Port: 53}) {
+		t.Errorf("expected corp1 to be notified by the default pool, got %v", pools[0].AlsoNotifies)
+	}
+	if len(pools[1].AlsoNotifies) != 2 || pools[1].AlsoNotifies[1] != (AlsoNotify{Host: "198.51.100.11", Port: 5353}) {
+		t.Errorf("expected corp2 to be notified by pool1, got %v", pools[1].AlsoNotifies)
+	}
+	if len(pools[0].Targets) != 0 || len(pools[0].Nameservers) != 0 {
+		t.Errorf("secondary servers must not be targets or nameservers of the pool")
+	}
+
+	servers = []designatev1.Bind9SecondaryServerSpec{{Name: "corp3", Host: "198.51.100.12", Port: 53, Pool: "missing"}}
+	if _, err := AddSecondaryServers(pools, servers); !errors.Is(err, ErrSecondaryServerPoolNotFound) {
+		t.Errorf("expected ErrSecondaryServerPoolNotFound, got %v", err)
+	}
+}
+
+func TestSetHiddenPrimary(t *testing.T) {
+	pools := []Pool{
+		{
+			Name:        "default",
+			Nameservers: []Nameserver{{Host: "172.28.0.31", Port: 53}},
+			Targets:     []Target{{Type: "bind9", Options: Options{Host: "172.28.0.31", Port: 53}}},
+		},
+	}
+	servers := []designatev1.Bind9SecondaryServerSpec{
+		{Name: "corp1", Host: "198.51.100.10", Port: 53, Pool: "default"},
+		{Name: "corp2", Host: "2001:db8::10", Port: 5353, Pool: "default"},
+		{Name: "other", Host: "198.51.100.20", Port: 53, Pool: "pool1"},
+	}
+
+	if err := SetHiddenPrimary(pools, servers); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Nameserver{{Host: "198.51.100.10", Port: 53}, {Host: "2001:db8::10", Port: 5353}}
+	if !reflect.DeepEqual(pools[0].Nameservers, expected) {
+		t.Errorf("expected the secondary servers as nameservers, got %v", pools[0].Nameservers)
+	}
+	if len(pools[0].Targets) != 1 || pools[0].Targets[0].Options.Host != "172.28.0.31" {
+		t.Errorf("expected the bind9 servers to stay targets, got %v", pools[0].Targets)
+	}
+
+	pools = append(pools, Pool{Name: "pool2"})
+	if err := SetHiddenPrimary(pools, servers); !errors.Is(err, ErrHiddenPrimaryPoolWithoutSecondaries) {
+		t.Errorf("expected ErrHiddenPrimaryPoolWithoutSecondaries, got %v", err)
+	}
+}
+
+func TestPoolsStatus(t *testing.T) {
+	pools := []Pool{
+		{
+			Name:         "default",
+			NSRecords:    []designatev1.DesignateNSRecord{{Hostname: "ns1.example.com.", Priority: 1}},
+			Nameservers:  []Nameserver{{Host: "2001:db8::10", Port: 53}},
+			Targets:      []Target{{Type: "bind9", Options: Options{Host: "172.28.0.31", Port: 53}}},
+			AlsoNotifies: []AlsoNotify{{Host: "198.51.100.10", Port: 5353}},
+		},
+	}
+
+	status := PoolsStatus(pools, true)
+	expected := []designatev1.DesignateAppliedPool{
+		{
+			Name:          "default",
+			HiddenPrimary: true,
+			NSRecords:     []string{"ns1.example.com."},
+			Nameservers:   []string{"[2001:db8::10]:53"},
+			Targets:       []string{"172.28.0.31:53"},
+			AlsoNotifies:  []string{"198.51.100.10:5353"},
+		},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("expected %v, got %v", expected, status)
+	}
+}
+
+func TestAddPDNSServers(t *testing.T) {
+	pools := []Pool{
+		{Name: "default", Targets: []Target{{Type: "bind9"}}},
+	}
+	mdnsMap := map[string]string{"mdns_address_0": "172.28.0.31"}
+
+	unchanged, err := AddPDNSServers(pools, nil, mdnsMap)
+	if err != nil || len(unchanged[0].Targets) != 1 {
+		t.Fatalf("expected the pools to be unchanged without PowerDNS servers, got %v (%v)", unchanged, err)
+	}
+
+	pdnsMap := map[string]string{"pdns_address_0": "172.28.0.40", "pdns_address_1": "fd00::41"}
+	pools, err = AddPDNSServers(pools, pdnsMap, mdnsMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pools[0].Targets) != 3 || len(pools[0].Nameservers) != 2 {
+		t.Fatalf("expected two PowerDNS targets and nameservers, got %v", pools[0])
+	}
+	target := pools[0].Targets[1]
+	if target.Type != "pdns4" || target.Options.Host != "172.28.0.40" || target.Options.Port != DNSPort {
+		t.Errorf("unexpected target %v", target)
+	}
+	if target.Options.Extra["api_endpoint"] != "http://172.28.0.40:8081" || target.Options.Extra["api_token"] != PDNSAPIKeyPlaceholder {
+		t.Errorf("unexpected target options %v", target.Options.Extra)
+	}
+	if len(target.Masters) != 1 || target.Masters[0].Host != "172.28.0.31" {
+		t.Errorf("expected the mdns servers as masters, got %v", target.Masters)
+	}
+	if pools[0].Targets[2].Options.Extra["api_endpoint"] != "http://[fd00::41]:8081" {
+		t.Errorf("expected a bracketed IPv6 API endpoint, got %v", pools[0].Targets[2].Options.Extra)
+	}
+
+	if _, err := AddPDNSServers([]Pool{{Name: "pool1"}}, pdnsMap, mdnsMap); !errors.Is(err, ErrDefaultPoolMissing) {
+		t.Errorf("expected ErrDefaultPoolMissing, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"fmt"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HookImageEnv - the new image of the stage of a hook
+	HookImageEnv = "DESIGNATE_HOOK_IMAGE"
+
+	// HookStageEnv - the stage of a hook
+	HookStageEnv = "DESIGNATE_HOOK_STAGE"
+)
+
+// HookJob returns the Job of hook for the new image of its stage. The image
+// is set in the environment of the containers, which changes the hash of the
+// Job and runs it again for each image.
+func HookJob(
+	instance *designatev1beta1.Designate,
+	hook designatev1beta1.DesignateHook,
+	image string,
+	labels map[string]string,
+) *batchv1.Job {
+	spec := *hook.Job.DeepCopy()
+	for i := range spec.Template.Spec.Containers {
+		spec.Template.Spec.Containers[i].Env = append(spec.Template.Spec.Containers[i].Env,
+			corev1.EnvVar{Name: HookImageEnv, Value: image},
+			corev1.EnvVar{Name: HookStageEnv, Value: hook.Stage},
+		)
+	}
+	// the Jobs don't accept the Always default of the pods
+	if spec.Template.Spec.RestartPolicy == "" {
+		spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-hook-%s", instance.Name, hook.Name),
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: spec,
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"slices"
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHookJob(t *testing.T) {
+	instance := &designatev1beta1.Designate{
+		ObjectMeta: metav1.ObjectMeta{Name: "designate", Namespace: "openstack"},
+	}
+	hook := designatev1beta1.DesignateHook{
+		Name:  "freeze-zones",
+		Stage: designatev1beta1.HookStagePreDBSync,
+	}
+	hook.Job.Template.Spec.Containers = []corev1.Container{
+		{Name: "freeze", Image: "registry.local:5000/tools:latest", Command: []string{"/scripts/freeze.sh"}},
+	}
+
+	job := HookJob(instance, hook, "openstack-designate-api:18.0.2", map[string]string{"service": "designate"})
+	if job.Name != "designate-hook-freeze-zones" || job.Namespace != "openstack" {
+		t.Errorf("unexpected job %s/%s", job.Namespace, job.Name)
+	}
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("expected the Never restart policy, got %q", job.Spec.Template.Spec.RestartPolicy)
+	}
+	env := job.Spec.Template.Spec.Containers[0].Env
+	expected := []corev1.EnvVar{
+		{Name: HookImageEnv, Value: "openstack-designate-api:18.0.2"},
+		{Name: HookStageEnv, Value: designatev1beta1.HookStagePreDBSync},
+	}
+	if !slices.Equal(env, expected) {
+		t.Errorf("unexpected env %v", env)
+	}
+	// the template of the hook is not modified
+	if len(hook.Job.Template.Spec.Containers[0].Env) != 0 {
+		t.Errorf("the template of the hook was modified")
+	}
+}
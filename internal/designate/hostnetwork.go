@@ -0,0 +1,50 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DNSContainerPorts returns the UDP and TCP container ports of a DNS server
+// listening on port
+func DNSContainerPorts(port int32) []corev1.ContainerPort {
+	return []corev1.ContainerPort{
+		{
+			Name:          "dns",
+			ContainerPort: port,
+			Protocol:      corev1.ProtocolUDP,
+		},
+		{
+			Name:          "dns-tcp",
+			ContainerPort: port,
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+}
+
+// ApplyHostNetwork runs the pods of spec on the network of their nodes and
+// binds the ports of their first container on the nodes. The host ports keep
+// two pods of the same service off the same node.
+func ApplyHostNetwork(spec *corev1.PodSpec, ports []corev1.ContainerPort) {
+	spec.HostNetwork = true
+	// the pods keep resolving the cluster names, e.g. of the database
+	spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	for _, port := range ports {
+		port.HostPort = port.ContainerPort
+		spec.Containers[0].Ports = append(spec.Containers[0].Ports, port)
+	}
+}
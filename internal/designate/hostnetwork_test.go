@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyHostNetwork(t *testing.T) {
+	spec := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "bind9"}, {Name: "exporter"}},
+	}
+
+	ApplyHostNetwork(spec, DNSContainerPorts(1053))
+	if !spec.HostNetwork || spec.DNSPolicy != corev1.DNSClusterFirstWithHostNet {
+		t.Errorf("expected the pod on the host network with the cluster DNS, got %v/%v", spec.HostNetwork, spec.DNSPolicy)
+	}
+	ports := spec.Containers[0].Ports
+	if len(ports) != 2 {
+		t.Fatalf("expected the UDP and TCP ports, got %v", ports)
+	}
+	for _, port := range ports {
+		if port.ContainerPort != 1053 || port.HostPort != 1053 {
+			t.Errorf("expected port 1053 bound on the node, got %v", port)
+		}
+	}
+	if ports[0].Protocol == ports[1].Protocol {
+		t.Errorf("expected both UDP and TCP, got %v", ports)
+	}
+	if len(spec.Containers[1].Ports) != 0 {
+		t.Errorf("expected the sidecar ports to be left untouched, got %v", spec.Containers[1].Ports)
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyImagePullOptions sets the pull secrets of a pod and the pull policy
+// of all its containers, including the init and sidecar containers. Without
+// a policy the containers keep the Kubernetes default.
+func ApplyImagePullOptions(spec *corev1.PodSpec, secrets []corev1.LocalObjectReference, policy corev1.PullPolicy) {
+	spec.ImagePullSecrets = secrets
+	if policy == "" {
+		return
+	}
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].ImagePullPolicy = policy
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].ImagePullPolicy = policy
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplyImagePullOptions(t *testing.T) {
+	spec := newTestPodSpec()
+	secrets := []corev1.LocalObjectReference{{Name: "registry-credentials"}}
+	ApplyImagePullOptions(&spec, secrets, corev1.PullAlways)
+
+	if len(spec.ImagePullSecrets) != 1 || spec.ImagePullSecrets[0].Name != "registry-credentials" {
+		t.Errorf("unexpected pull secrets %v", spec.ImagePullSecrets)
+	}
+	for _, c := range append(spec.InitContainers, spec.Containers...) {
+		if c.ImagePullPolicy != corev1.PullAlways {
+			t.Errorf("expected the Always pull policy on container %s, got %q", c.Name, c.ImagePullPolicy)
+		}
+	}
+}
+
+func TestApplyImagePullOptionsDefault(t *testing.T) {
+	spec := newTestPodSpec()
+	ApplyImagePullOptions(&spec, nil, "")
+
+	if spec.ImagePullSecrets != nil {
+		t.Errorf("unexpected pull secrets %v", spec.ImagePullSecrets)
+	}
+	for _, c := range append(spec.InitContainers, spec.Containers...) {
+		if c.ImagePullPolicy != "" {
+			t.Errorf("expected the default pull policy on container %s, got %q", c.Name, c.ImagePullPolicy)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ImageTag returns the tag of an image reference, e.g. current-podified for
+// quay.io/podified-antelope-centos9/openstack-designate-api:current-podified,
+// or "" when the image is only referenced by digest
+func ImageTag(image string) string {
+	name, _, _ := strings.Cut(image, "@")
+	// the registry host can have a port, the tag is in the last path element
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	_, tag, _ := strings.Cut(name, ":")
+	return tag
+}
+
+// ImageDigest returns the digest of an image reference or of the image ID of
+// a container status, e.g. sha256:..., or "" when it has none
+func ImageDigest(image string) string {
+	if _, digest, ok := strings.Cut(image, "@"); ok {
+		return digest
+	}
+	return ""
+}
+
+// ReleaseVersion returns the release of the images of instance, the tag of
+// the API image, or its digest when it is referenced by digest only. An image
+// without tag nor digest is the latest one.
+func ReleaseVersion(instance *designatev1beta1.Designate) string {
+	image := instance.Spec.DesignateAPI.ContainerImage
+	if tag := ImageTag(image); tag != "" {
+		return tag
+	}
+	if digest := ImageDigest(image); digest != "" {
+		return digest
+	}
+	return "latest"
+}
+
+// ReleaseImages returns the container images of the designate services of
+// instance, which are built from the same release, by service. PowerDNS is a
+// third party image with its own tags, sink is only included when deployed.
+func ReleaseImages(instance *designatev1beta1.Designate) map[string]string {
+	images := map[string]string{
+		"designateAPI":          instance.Spec.DesignateAPI.ContainerImage,
+		"designateCentral":      instance.Spec.DesignateCentral.ContainerImage,
+		"designateWorker":       instance.Spec.DesignateWorker.ContainerImage,
+		"designateMdns":         instance.Spec.DesignateMdns.ContainerImage,
+		"designateProducer":     instance.Spec.DesignateProducer.ContainerImage,
+		"designateBackendbind9": instance.Spec.DesignateBackendbind9.ContainerImage,
+		"designateUnbound":      instance.Spec.DesignateUnbound.ContainerImage,
+	}
+	if instance.IsSinkEnabled() {
+		images["designateSink"] = instance.Spec.DesignateSink.ContainerImage
+	}
+	return images
+}
+
+// InconsistentImageTags returns the tag of each image of images, as
+// service=tag sorted by service, when they don't all share the same tag, or
+// nil. The images referenced by digest only are pinned to a build and are
+// not checked.
+func InconsistentImageTags(images map[string]string) []string {
+	tags := map[string]string{}
+	for service, image := range images {
+		if tag := ImageTag(image); tag != "" {
+			tags[service] = tag
+		}
+	}
+	distinct := slices.Compact(slices.Sorted(maps.Values(tags)))
+	if len(distinct) <= 1 {
+		return nil
+	}
+	inconsistent := []string{}
+	for _, service := range slices.Sorted(maps.Keys(tags)) {
+		inconsistent = append(inconsistent, fmt.Sprintf("%s=%s", service, tags[service]))
+	}
+	return inconsistent
+}
+
+// RunningImageDigests returns the digests of the image the containers started
+// from image run in pods, sorted and without duplicates. There is more than
+// one while the pods are updated to a new build of a tag.
+func RunningImageDigests(pods []corev1.Pod, image string) []string {
+	digests := []string{}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			idx := slices.IndexFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+				return c.Name == status.Name
+			})
+			if idx < 0 || pod.Spec.Containers[idx].Image != image {
+				continue
+			}
+			if digest := ImageDigest(status.ImageID); digest != "" && !slices.Contains(digests, digest) {
+				digests = append(digests, digest)
+			}
+		}
+	}
+	slices.Sort(digests)
+	return digests
+}
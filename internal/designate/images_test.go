@@ -0,0 +1,109 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+const testDigest = "sha256:4c1d1c8e8f1ab8a62e5b3c1ad0e1d4f0c2f5d1b0a4e0b0e6c2f1e9e5a3d1c0b7"
+
+func TestImageTag(t *testing.T) {
+	tests := []struct {
+		image  string
+		tag    string
+		digest string
+	}{
+		{image: "quay.io/podified-antelope-centos9/openstack-designate-api:current-podified", tag: "current-podified"},
+		{image: "registry.local:5000/openstack-designate-api:18.0.1", tag: "18.0.1"},
+		{image: "registry.local:5000/openstack-designate-api:18.0.1@" + testDigest, tag: "18.0.1", digest: testDigest},
+		{image: "registry.local:5000/openstack-designate-api@" + testDigest, digest: testDigest},
+		{image: "registry.local:5000/openstack-designate-api"},
+	}
+	for _, tt := range tests {
+		if tag := ImageTag(tt.image); tag != tt.tag {
+			t.Errorf("ImageTag(%s) = %q, expected %q", tt.image, tag, tt.tag)
+		}
+		if digest := ImageDigest(tt.image); digest != tt.digest {
+			t.Errorf("ImageDigest(%s) = %q, expected %q", tt.image, digest, tt.digest)
+		}
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	for image, version := range map[string]string{
+		"registry.local:5000/openstack-designate-api:18.0.1":               "18.0.1",
+		"registry.local:5000/openstack-designate-api@" + testDigest:        testDigest,
+		"registry.local:5000/openstack-designate-api:18.0.1@" + testDigest: "18.0.1",
+		"repo/designate-api-image":                                         "latest",
+	} {
+		instance := &designatev1beta1.Designate{}
+		instance.Spec.DesignateAPI.ContainerImage = image
+		if v := ReleaseVersion(instance); v != version {
+			t.Errorf("ReleaseVersion(%s) = %q, expected %q", image, v, version)
+		}
+	}
+}
+
+func TestInconsistentImageTags(t *testing.T) {
+	consistent := map[string]string{
+		"designateAPI":     "registry.local:5000/openstack-designate-api:18.0.1",
+		"designateCentral": "registry.local:5000/openstack-designate-central:18.0.1",
+		// pinned to a hotfix build
+		"designateWorker": "registry.local:5000/openstack-designate-worker@" + testDigest,
+	}
+	if tags := InconsistentImageTags(consistent); tags != nil {
+		t.Errorf("unexpected inconsistent tags %v", tags)
+	}
+
+	inconsistent := map[string]string{
+		"designateAPI":     "registry.local:5000/openstack-designate-api:18.0.2",
+		"designateCentral": "registry.local:5000/openstack-designate-central:18.0.1",
+	}
+	expected := []string{"designateAPI=18.0.2", "designateCentral=18.0.1"}
+	if tags := InconsistentImageTags(inconsistent); !slices.Equal(tags, expected) {
+		t.Errorf("InconsistentImageTags() = %v, expected %v", tags, expected)
+	}
+}
+
+func TestRunningImageDigests(t *testing.T) {
+	image := "registry.local:5000/openstack-designate-api:18.0.1"
+	pod := func(digest string) corev1.Pod {
+		return corev1.Pod{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "designate-api", Image: image},
+				{Name: "logs", Image: "registry.local:5000/busybox:latest"},
+			}},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "designate-api", ImageID: "registry.local:5000/openstack-designate-api@" + digest},
+				{Name: "logs", ImageID: "registry.local:5000/busybox@sha256:ffff"},
+			}},
+		}
+	}
+	// one pod still runs the previous build of the tag, one isn't started yet
+	pending := pod(testDigest)
+	pending.Status.ContainerStatuses = nil
+	pods := []corev1.Pod{pod(testDigest), pod("sha256:0000"), pod(testDigest), pending}
+
+	expected := []string{"sha256:0000", testDigest}
+	if digests := RunningImageDigests(pods, image); !slices.Equal(digests, expected) {
+		t.Errorf("RunningImageDigests() = %v, expected %v", digests, expected)
+	}
+}
@@ -16,26 +16,57 @@ limitations under the License.
 package designate
 
 import (
+	"maps"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	corev1 "k8s.io/api/core/v1"
 )
 
-// APIDetails information
-type APIDetails struct {
-	ContainerImage       string
-	DatabaseHost         string
-	DatabaseName         string
-	OSPSecret            string
-	TransportURLSecret   string
-	UserPasswordSelector string
-	VolumeMounts         []corev1.VolumeMount
-	Privileged           bool
+// InitCapability is a part of the pod configuration an init container may need
+type InitCapability int
+
+const (
+	// NeedsServiceConfig - the configuration of the service to merge, for the
+	// designate services it holds the database and messaging credentials
+	NeedsServiceConfig InitCapability = iota
+	// NeedsPodName - the name of the pod, giving its index in the StatefulSet
+	NeedsPodName
+	// NeedsPredictableIPs - the predictable IPs of the pods of the StatefulSet
+	NeedsPredictableIPs
+	// NeedsRndc - the rndc keys of the bind9 servers
+	NeedsRndc
+	// NeedsAPIKey - the API key of the PowerDNS servers
+	NeedsAPIKey
+	// NeedsData - the persistent data of the pod
+	NeedsData
+)
+
+// InitResources holds the volume mounts and the environment of a pod given to
+// its init containers, by capability. The scripts are given to all of them.
+type InitResources struct {
+	Scripts      []corev1.VolumeMount
+	VolumeMounts map[InitCapability][]corev1.VolumeMount
+	EnvVars      map[InitCapability]map[string]env.Setter
+}
+
+// Select returns the volume mounts and the environment of the capabilities
+// an init container needs
+func (r InitResources) Select(needs ...InitCapability) ([]corev1.VolumeMount, []corev1.EnvVar) {
+	mounts := append([]corev1.VolumeMount{}, r.Scripts...)
+	envVars := map[string]env.Setter{}
+	for _, need := range needs {
+		mounts = append(mounts, r.VolumeMounts[need]...)
+		maps.Copy(envVars, r.EnvVars[need])
+	}
+	return mounts, env.MergeEnvs([]corev1.EnvVar{}, envVars)
 }
 
 // InitContainerDetails contains configuration for init containers
 type InitContainerDetails struct {
 	ContainerImage string
-	VolumeMounts   []corev1.VolumeMount
-	EnvVars        []corev1.EnvVar
+	Resources      InitResources
+	// Needs - the capabilities of Resources given to the init container
+	Needs []InitCapability
 }
 
 const (
@@ -43,8 +74,9 @@ const (
 	InitContainerCommand = "/usr/local/bin/container-scripts/init.sh"
 )
 
-// SimpleInitContainer creates a simple init container with the provided details
-func SimpleInitContainer(init InitContainerDetails) corev1.Container {
+// InitContainer creates the init container rendering the configuration of a
+// pod, only the resources of the capabilities it needs are given to it
+func InitContainer(init InitContainerDetails) corev1.Container {
 	runAsUser := int64(0)
 
 	args := []string{
@@ -52,7 +84,8 @@ func SimpleInitContainer(init InitContainerDetails) corev1.Container {
 		InitContainerCommand,
 	}
 
-	return corev1.Container{
+	mounts, envVars := init.Resources.Select(init.Needs...)
+	container := corev1.Container{
 		Name:  "init",
 		Image: init.ContainerImage,
 		SecurityContext: &corev1.SecurityContext{
@@ -62,32 +95,10 @@ func SimpleInitContainer(init InitContainerDetails) corev1.Container {
 			"/bin/bash",
 		},
 		Args:         args,
-		Env:          init.EnvVars,
-		VolumeMounts: init.VolumeMounts,
-	}
-}
-
-// InitContainer - init container for designate api pods
-func InitContainer(init APIDetails) []corev1.Container {
-	runAsUser := int64(0)
-
-	args := []string{
-		"-c",
-		InitContainerCommand,
+		VolumeMounts: mounts,
 	}
-
-	return []corev1.Container{
-		{
-			Name:  "init",
-			Image: init.ContainerImage,
-			SecurityContext: &corev1.SecurityContext{
-				RunAsUser: &runAsUser,
-			},
-			Command: []string{
-				"/bin/bash",
-			},
-			Args:         args,
-			VolumeMounts: init.VolumeMounts,
-		},
+	if len(envVars) > 0 {
+		container.Env = envVars
 	}
+	return container
 }
@@ -0,0 +1,106 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func mountNames(mounts []corev1.VolumeMount) []string {
+	names := []string{}
+	for _, mount := range mounts {
+		names = append(names, mount.Name)
+	}
+	return names
+}
+
+func envNames(envVars []corev1.EnvVar) []string {
+	names := []string{}
+	for _, envVar := range envVars {
+		names = append(names, envVar.Name)
+	}
+	return names
+}
+
+func TestInitContainers(t *testing.T) {
+	resources := InitResources{
+		Scripts: []corev1.VolumeMount{{Name: "scripts"}},
+		VolumeMounts: map[InitCapability][]corev1.VolumeMount{
+			NeedsServiceConfig:  {{Name: "config"}, {Name: "merged"}},
+			NeedsRndc:           {{Name: "rndc-keys"}},
+			NeedsPredictableIPs: {{Name: "ips"}},
+		},
+		EnvVars: map[InitCapability]map[string]env.Setter{
+			NeedsServiceConfig:  {"CustomConf": env.SetValue("custom.conf")},
+			NeedsPodName:        {"POD_NAME": env.DownwardAPI("metadata.name")},
+			NeedsPredictableIPs: {"MAP_PREFIX": env.SetValue("bind_address_")},
+			NeedsRndc:           {"RNDC_PREFIX": env.SetValue("rndc-key")},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		container  corev1.Container
+		wantMounts []string
+		wantEnv    []string
+	}{
+		{
+			name:       "config only",
+			container:  InitContainer(InitContainerDetails{Resources: resources, Needs: []InitCapability{NeedsServiceConfig}}),
+			wantMounts: []string{"scripts", "config", "merged"},
+			wantEnv:    []string{"CustomConf"},
+		},
+		{
+			name: "config and rndc",
+			container: InitContainer(InitContainerDetails{
+				Resources: resources,
+				Needs:     []InitCapability{NeedsServiceConfig, NeedsPodName, NeedsRndc},
+			}),
+			wantMounts: []string{"scripts", "config", "merged", "rndc-keys"},
+			wantEnv:    []string{"CustomConf", "POD_NAME", "RNDC_PREFIX"},
+		},
+		{
+			name:       "predictable IPs",
+			container:  PredictableIPContainer(PredIPContainerDetails{Resources: resources}),
+			wantMounts: []string{"scripts", "ips"},
+			wantEnv:    []string{"MAP_PREFIX", "POD_NAME"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mountNames(tt.container.VolumeMounts); !equalStrings(got, tt.wantMounts) {
+				t.Errorf("VolumeMounts = %v, want %v", got, tt.wantMounts)
+			}
+			if got := envNames(tt.container.Env); !equalStrings(got, tt.wantEnv) {
+				t.Errorf("Env = %v, want %v", got, tt.wantEnv)
+			}
+		})
+	}
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// allocationLocks holds one mutex per namespace so the reconcilers of this
+// operator never run two predictable IP allocations for a namespace at once.
+// Writes from other processes are caught by the resourceVersion checks.
+var allocationLocks sync.Map
+
+func lockAllocations(namespace string) func() {
+	lock, _ := allocationLocks.LoadOrStore(namespace, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+// PredictableIPRequest lists the IP holders which need an address stored in
+// the ConfigMap ConfigMapName. Holders which are in the ConfigMap but not in
+// IPHolders are released. Extra entries, e.g. the rndc key names of the bind
+// pods, are stored next to the addresses so the ConfigMap is written with its
+// final content. A request without holders and extra entries only releases
+// the addresses of an existing ConfigMap, it is not created. StaticIPs pins
+// addresses to IP holders, they are never handed out to another holder.
+type PredictableIPRequest struct {
+	ConfigMapName string
+	IPHolders     []string
+	Extra         map[string]string
+	StaticIPs     map[string]string
+}
+
+// AllocatePredictableIPs allocates the addresses for all the requests in one
+// transaction. The ConfigMaps are read, the missing addresses are picked from
+// predParams while skipping every address used by any of the ConfigMaps, and
+// the ConfigMaps are written back with their resourceVersion. On a conflict
+// the whole transaction is retried with fresh data, so an address is never
+// handed out twice. An address stored for more than one holder, e.g. by a
+// release which was missed, stays with the first holder in request order and
+// the others get a new one. The static IPs are taken from any holder they
+// were handed out to before. mutate is called on every ConfigMap before it is written
+// and can be used to set labels and owner references. The returned map is
+// keyed by ConfigMap name.
+//
+// Every IP holder gets one address per range in predParams, so on a dual stack
+// network the stored value is a list like "172.28.0.31,fd00:bbbb::31".
+func AllocatePredictableIPs(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	predParams []*NADIpam,
+	requests []PredictableIPRequest,
+	mutate func(*corev1.ConfigMap) error,
+) (map[string]map[string]string, error) {
+	unlock := lockAllocations(namespace)
+	defer unlock()
+
+	var result map[string]map[string]string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		result, err = allocatePredictableIPs(ctx, c, namespace, predParams, requests, mutate)
+		if k8s_errors.IsConflict(err) {
+			predictableIPAllocationConflicts.WithLabelValues(namespace).Inc()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func allocatePredictableIPs(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	predParams []*NADIpam,
+	requests []PredictableIPRequest,
+	mutate func(*corev1.ConfigMap) error,
+) (map[string]map[string]string, error) {
+	configMaps := make([]*corev1.ConfigMap, len(requests))
+	allocatedIPs := make(map[string]bool)
+	// owners maps the stored addresses to the first requested holder using
+	// them, the static IPs belong to the holder they are pinned to
+	owners := make(map[string]string)
+	for _, req := range requests {
+		for _, ipHolder := range req.IPHolders {
+			for _, ip := range SplitPredictableIPs(req.StaticIPs[ipHolder]) {
+				allocatedIPs[ip] = true
+				owners[ip] = holderID(req.ConfigMapName, ipHolder)
+			}
+		}
+	}
+
+	for i, req := range requests {
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, types.NamespacedName{Name: req.ConfigMapName, Namespace: namespace}, cm)
+		if err != nil {
+			if !k8s_errors.IsNotFound(err) {
+				return nil, err
+			}
+			cm.Name = req.ConfigMapName
+			cm.Namespace = namespace
+		}
+		for key, value := range cm.Data {
+			if _, ok := req.Extra[key]; ok {
+				continue
+			}
+			for _, ip := range SplitPredictableIPs(value) {
+				allocatedIPs[ip] = true
+			}
+		}
+		for _, ipHolder := range req.IPHolders {
+			for _, ip := range SplitPredictableIPs(cm.Data[ipHolder]) {
+				if _, ok := owners[ip]; !ok {
+					owners[ip] = holderID(req.ConfigMapName, ipHolder)
+				}
+			}
+		}
+		configMaps[i] = cm
+	}
+
+	result := make(map[string]map[string]string)
+	for i, req := range requests {
+		cm := configMaps[i]
+		updated := make(map[string]string)
+		for _, ipHolder := range req.IPHolders {
+			var existing []string
+			for _, ip := range SplitPredictableIPs(cm.Data[ipHolder]) {
+				if owners[ip] == holderID(req.ConfigMapName, ipHolder) {
+					existing = append(existing, ip)
+				}
+			}
+			value, err := allocateHolder(predParams, SplitPredictableIPs(req.StaticIPs[ipHolder]), existing, allocatedIPs)
+			if err != nil {
+				return nil, err
+			}
+			updated[ipHolder] = value
+		}
+		result[req.ConfigMapName] = updated
+	}
+
+	for i, req := range requests {
+		cm := configMaps[i]
+		if cm.ResourceVersion == "" && len(req.IPHolders) == 0 && len(req.Extra) == 0 {
+			// nothing to release
+			continue
+		}
+		cm.Data = make(map[string]string)
+		for k, v := range req.Extra {
+			cm.Data[k] = v
+		}
+		for k, v := range result[req.ConfigMapName] {
+			cm.Data[k] = v
+		}
+		if mutate != nil {
+			if err := mutate(cm); err != nil {
+				return nil, err
+			}
+		}
+		if cm.ResourceVersion == "" {
+			err := c.Create(ctx, cm)
+			if k8s_errors.IsAlreadyExists(err) {
+				// created by someone else since we looked, start over
+				return nil, k8s_errors.NewConflict(corev1.Resource("configmaps"), cm.Name, err)
+			}
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := c.Update(ctx, cm); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func holderID(configMapName string, ipHolder string) string {
+	return fmt.Sprintf("%s/%s", configMapName, ipHolder)
+}
+
+// allocateHolder uses the static addresses of an IP holder, then keeps its
+// existing addresses which are still in one of the ranges and not excluded,
+// and allocates an address from every range which is not covered yet, e.g.
+// when a network becomes dual stack.
+func allocateHolder(predParams []*NADIpam, static []string, existing []string, allocatedIPs map[string]bool) (string, error) {
+	var addresses []string
+	for _, params := range predParams {
+		found := addressInRange(params, static)
+		if found == "" {
+			found = addressInRange(params, existing)
+			if addr, err := netip.ParseAddr(found); err == nil && params.IsExcluded(addr) {
+				found = ""
+			}
+		}
+		if found == "" {
+			ip, err := GetNextIP(params, allocatedIPs)
+			if err != nil {
+				return "", err
+			}
+			found = ip
+		}
+		addresses = append(addresses, found)
+	}
+	return JoinPredictableIPs(addresses), nil
+}
+
+// addressInRange returns the first of addresses in the subnet of params
+func addressInRange(params *NADIpam, addresses []string) string {
+	for _, ip := range addresses {
+		addr, err := netip.ParseAddr(ip)
+		if err == nil && params.CIDR.Contains(addr) {
+			return ip
+		}
+	}
+	return ""
+}
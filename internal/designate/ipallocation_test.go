@@ -0,0 +1,378 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func testIPAM() []*NADIpam {
+	return []*NADIpam{
+		{
+			CIDR:       netip.MustParsePrefix("172.28.0.0/24"),
+			RangeStart: netip.MustParseAddr("172.28.0.31"),
+			RangeEnd:   netip.MustParseAddr("172.28.0.56"),
+		},
+	}
+}
+
+func testDualStackIPAM() []*NADIpam {
+	return append(testIPAM(), &NADIpam{
+		CIDR:       netip.MustParsePrefix("fd00:bbbb::/64"),
+		RangeStart: netip.MustParseAddr("fd00:bbbb::31"),
+		RangeEnd:   netip.MustParseAddr("fd00:bbbb::56"),
+	})
+}
+
+func checkUnique(t *testing.T, allocations ...map[string]string) {
+	t.Helper()
+	seen := make(map[string]string)
+	for _, m := range allocations {
+		for holder, value := range m {
+			for _, ip := range SplitPredictableIPs(value) {
+				if other, ok := seen[ip]; ok {
+					t.Errorf("IP %s allocated to both %s and %s", ip, other, holder)
+				}
+				seen[ip] = holder
+			}
+		}
+	}
+}
+
+func TestAllocatePredictableIPsKeepsExisting(t *testing.T) {
+	existing := &corev1.ConfigMap{}
+	existing.Name = MdnsPredIPConfigMap
+	existing.Namespace = "openstack"
+	existing.Data = map[string]string{
+		"mdns_address_0": "172.28.0.31",
+		"mdns_address_5": "172.28.0.40",
+	}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testIPAM(),
+		[]PredictableIPRequest{
+			{ConfigMapName: MdnsPredIPConfigMap, IPHolders: []string{"mdns_address_0", "mdns_address_1"}},
+			{ConfigMapName: BindPredIPConfigMap, IPHolders: []string{"bind_address_0"}},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+
+	mdns := result[MdnsPredIPConfigMap]
+	if mdns["mdns_address_0"] != "172.28.0.31" {
+		t.Errorf("existing allocation changed: %v", mdns)
+	}
+	if _, ok := mdns["mdns_address_5"]; ok {
+		t.Errorf("mdns_address_5 should have been released: %v", mdns)
+	}
+	checkUnique(t, mdns, result[BindPredIPConfigMap])
+
+	stored := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: BindPredIPConfigMap, Namespace: "openstack"}, stored); err != nil {
+		t.Fatalf("bind ConfigMap not created: %v", err)
+	}
+	if stored.Data["bind_address_0"] != result[BindPredIPConfigMap]["bind_address_0"] {
+		t.Errorf("stored bind ConfigMap %v does not match result %v", stored.Data, result[BindPredIPConfigMap])
+	}
+}
+
+func TestAllocatePredictableIPsDualStack(t *testing.T) {
+	// an IPv4 only allocation made before the network became dual stack
+	existing := &corev1.ConfigMap{}
+	existing.Name = MdnsPredIPConfigMap
+	existing.Namespace = "openstack"
+	existing.Data = map[string]string{"mdns_address_0": "172.28.0.31"}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testDualStackIPAM(),
+		[]PredictableIPRequest{
+			{ConfigMapName: MdnsPredIPConfigMap, IPHolders: []string{"mdns_address_0", "mdns_address_1"}},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+
+	mdns := result[MdnsPredIPConfigMap]
+	if mdns["mdns_address_0"] != "172.28.0.31,fd00:bbbb::31" {
+		t.Errorf("unexpected mdns_address_0 allocation %s", mdns["mdns_address_0"])
+	}
+	if mdns["mdns_address_1"] != "172.28.0.32,fd00:bbbb::32" {
+		t.Errorf("unexpected mdns_address_1 allocation %s", mdns["mdns_address_1"])
+	}
+	checkUnique(t, mdns)
+}
+
+// TestAllocatePredictableIPsConflict simulates another writer allocating an
+// address between our read and our write.
+func TestAllocatePredictableIPsConflict(t *testing.T) {
+	existing := &corev1.ConfigMap{}
+	existing.Name = MdnsPredIPConfigMap
+	existing.Namespace = "openstack"
+	existing.Data = map[string]string{}
+
+	interfered := false
+	c := fake.NewClientBuilder().WithObjects(existing).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if !interfered {
+				interfered = true
+				other := &corev1.ConfigMap{}
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), other); err != nil {
+					return err
+				}
+				other.Data = map[string]string{"mdns_address_9": "172.28.0.31"}
+				if err := cl.Update(ctx, other); err != nil {
+					return err
+				}
+			}
+			return cl.Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testIPAM(),
+		[]PredictableIPRequest{
+			{ConfigMapName: MdnsPredIPConfigMap, IPHolders: []string{"mdns_address_0", "mdns_address_9"}},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+	mdns := result[MdnsPredIPConfigMap]
+	if mdns["mdns_address_9"] != "172.28.0.31" {
+		t.Errorf("allocation of the concurrent writer was lost: %v", mdns)
+	}
+	checkUnique(t, mdns)
+}
+
+// TestAllocatePredictableIPsConcurrent runs the same allocation from several
+// goroutines, they all have to end up with the same unique addresses.
+func TestAllocatePredictableIPsConcurrent(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+
+	var holders []string
+	for i := range 10 {
+		holders = append(holders, fmt.Sprintf("bind_address_%d", i))
+	}
+
+	const workers = 10
+	var wg sync.WaitGroup
+	results := make([]map[string]string, workers)
+	errs := make([]error, workers)
+	for i := range workers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testIPAM(),
+				[]PredictableIPRequest{{ConfigMapName: BindPredIPConfigMap, IPHolders: holders}}, nil)
+			errs[i] = err
+			results[i] = result[BindPredIPConfigMap]
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range workers {
+		if errs[i] != nil {
+			t.Fatalf("AllocatePredictableIPs() error = %v", errs[i])
+		}
+		if !reflect.DeepEqual(results[i], results[0]) {
+			t.Errorf("worker %d got %v, worker 0 got %v", i, results[i], results[0])
+		}
+	}
+	if len(results[0]) != len(holders) {
+		t.Errorf("expected %d allocations, got %v", len(holders), results[0])
+	}
+	checkUnique(t, results[0])
+}
+
+// TestAllocatePredictableIPsExtra checks the extra entries are written along
+// with the addresses and are not mistaken for allocations.
+func TestAllocatePredictableIPsExtra(t *testing.T) {
+	existing := &corev1.ConfigMap{}
+	existing.Name = BindPredIPConfigMap
+	existing.Namespace = "openstack"
+	existing.Data = map[string]string{
+		"bind_address_0": "172.28.0.40",
+		"rndc_key_0":     "rndc-key-0",
+	}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	extra := map[string]string{"rndc_key_0": "rndc-key-0", "rndc_key_1": "rndc-key-1"}
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testIPAM(),
+		[]PredictableIPRequest{
+			{ConfigMapName: BindPredIPConfigMap, IPHolders: []string{"bind_address_0", "bind_address_1"}, Extra: extra},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+	if _, ok := result[BindPredIPConfigMap]["rndc_key_0"]; ok {
+		t.Errorf("extra entries should not be returned as allocations: %v", result[BindPredIPConfigMap])
+	}
+
+	stored := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: BindPredIPConfigMap, Namespace: "openstack"}, stored); err != nil {
+		t.Fatalf("bind ConfigMap not found: %v", err)
+	}
+	expected := map[string]string{
+		"bind_address_0": "172.28.0.40",
+		"bind_address_1": result[BindPredIPConfigMap]["bind_address_1"],
+		"rndc_key_0":     "rndc-key-0",
+		"rndc_key_1":     "rndc-key-1",
+	}
+	if !reflect.DeepEqual(stored.Data, expected) {
+		t.Errorf("stored bind ConfigMap = %v, want %v", stored.Data, expected)
+	}
+}
+
+// TestAllocatePredictableIPsReleaseOnly checks a request without holders
+// empties an existing ConfigMap and does not create a missing one.
+func TestAllocatePredictableIPsReleaseOnly(t *testing.T) {
+	existing := &corev1.ConfigMap{}
+	existing.Name = PDNSPredIPConfigMap
+	existing.Namespace = "openstack"
+	existing.Data = map[string]string{"pdns_address_0": "172.28.0.32"}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testIPAM(),
+		[]PredictableIPRequest{
+			{ConfigMapName: BindPredIPConfigMap, IPHolders: []string{"bind_address_0", "bind_address_1"}},
+			{ConfigMapName: PDNSPredIPConfigMap},
+			{ConfigMapName: MdnsPredIPConfigMap},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+	if len(result[PDNSPredIPConfigMap]) != 0 {
+		t.Errorf("unexpected pdns allocations %v", result[PDNSPredIPConfigMap])
+	}
+
+	stored := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: PDNSPredIPConfigMap, Namespace: "openstack"}, stored); err != nil {
+		t.Fatalf("pdns ConfigMap not found: %v", err)
+	}
+	if len(stored.Data) != 0 {
+		t.Errorf("pdns addresses not released: %v", stored.Data)
+	}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: MdnsPredIPConfigMap, Namespace: "openstack"}, stored)
+	if !k8s_errors.IsNotFound(err) {
+		t.Errorf("mdns ConfigMap should not have been created, got %v", err)
+	}
+}
+
+// TestAllocatePredictableIPsDuplicate checks an address stored for two holders
+// stays with the first one and the other one gets a new address.
+func TestAllocatePredictableIPsDuplicate(t *testing.T) {
+	mdns := &corev1.ConfigMap{}
+	mdns.Name = MdnsPredIPConfigMap
+	mdns.Namespace = "openstack"
+	mdns.Data = map[string]string{"mdns_address_0": "172.28.0.31"}
+	pdns := &corev1.ConfigMap{}
+	pdns.Name = PDNSPredIPConfigMap
+	pdns.Namespace = "openstack"
+	pdns.Data = map[string]string{"pdns_address_0": "172.28.0.31"}
+	c := fake.NewClientBuilder().WithObjects(mdns, pdns).Build()
+
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testIPAM(),
+		[]PredictableIPRequest{
+			{ConfigMapName: MdnsPredIPConfigMap, IPHolders: []string{"mdns_address_0"}},
+			{ConfigMapName: PDNSPredIPConfigMap, IPHolders: []string{"pdns_address_0"}},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+	if result[MdnsPredIPConfigMap]["mdns_address_0"] != "172.28.0.31" {
+		t.Errorf("mdns_address_0 should keep its address: %v", result[MdnsPredIPConfigMap])
+	}
+	if result[PDNSPredIPConfigMap]["pdns_address_0"] != "172.28.0.32" {
+		t.Errorf("pdns_address_0 should get a new address: %v", result[PDNSPredIPConfigMap])
+	}
+}
+
+func TestAllocatePredictableIPsStatic(t *testing.T) {
+	// the address pinned to bind_address_0 was handed out to mdns_address_0
+	existing := &corev1.ConfigMap{}
+	existing.Name = MdnsPredIPConfigMap
+	existing.Namespace = "openstack"
+	existing.Data = map[string]string{"mdns_address_0": "172.28.0.31"}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", testDualStackIPAM(),
+		[]PredictableIPRequest{
+			{ConfigMapName: MdnsPredIPConfigMap, IPHolders: []string{"mdns_address_0", "mdns_address_1"}},
+			{
+				ConfigMapName: BindPredIPConfigMap,
+				IPHolders:     []string{"bind_address_0", "bind_address_1"},
+				StaticIPs: map[string]string{
+					"bind_address_0": "172.28.0.31",
+					"bind_address_1": "172.28.0.200,fd00:bbbb::200",
+				},
+			},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+
+	bind := result[BindPredIPConfigMap]
+	if bind["bind_address_0"] != "172.28.0.31,fd00:bbbb::33" {
+		t.Errorf("unexpected bind_address_0 allocation %s", bind["bind_address_0"])
+	}
+	if bind["bind_address_1"] != "172.28.0.200,fd00:bbbb::200" {
+		t.Errorf("unexpected bind_address_1 allocation %s", bind["bind_address_1"])
+	}
+	mdns := result[MdnsPredIPConfigMap]
+	if mdns["mdns_address_0"] != "172.28.0.32,fd00:bbbb::31" {
+		t.Errorf("mdns_address_0 should get a new IPv4 address: %s", mdns["mdns_address_0"])
+	}
+	checkUnique(t, mdns, bind)
+}
+
+func TestAllocatePredictableIPsExcluded(t *testing.T) {
+	// an address allocated before it was excluded
+	existing := &corev1.ConfigMap{}
+	existing.Name = MdnsPredIPConfigMap
+	existing.Namespace = "openstack"
+	existing.Data = map[string]string{
+		"mdns_address_0": "172.28.0.31",
+		"mdns_address_1": "172.28.0.32",
+	}
+	c := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	ipam := testIPAM()
+	ipam[0].Exclude = []netip.Prefix{netip.MustParsePrefix("172.28.0.31/32")}
+	result, err := AllocatePredictableIPs(context.TODO(), c, "openstack", ipam,
+		[]PredictableIPRequest{
+			{ConfigMapName: MdnsPredIPConfigMap, IPHolders: []string{"mdns_address_0", "mdns_address_1"}},
+		}, nil)
+	if err != nil {
+		t.Fatalf("AllocatePredictableIPs() error = %v", err)
+	}
+
+	mdns := result[MdnsPredIPConfigMap]
+	if mdns["mdns_address_0"] != "172.28.0.33" {
+		t.Errorf("mdns_address_0 should be moved out of the excluded address: %s", mdns["mdns_address_0"])
+	}
+	if mdns["mdns_address_1"] != "172.28.0.32" {
+		t.Errorf("mdns_address_1 should keep its address: %s", mdns["mdns_address_1"])
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"net/netip"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The predictable IP metrics are exported on the metrics endpoint of the
+// operator, so an exhausted range can be alerted on before pods fail to get
+// an address. The reconcile durations of the controllers are exported by
+// controller-runtime as controller_runtime_reconcile_time_seconds.
+var (
+	predictableIPsAllocated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "designate_predictable_ips_allocated",
+			Help: "Predictable IPs allocated on a network, per IP family",
+		},
+		[]string{"namespace", "designate", "network", "family"},
+	)
+	predictableIPsAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "designate_predictable_ips_available",
+			Help: "Predictable IPs left in the range of a network, per IP family",
+		},
+		[]string{"namespace", "designate", "network", "family"},
+	)
+	predictableIPAllocationConflicts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "designate_predictable_ip_allocation_conflicts_total",
+			Help: "Predictable IP allocations retried because a ConfigMap was updated concurrently",
+		},
+		[]string{"namespace"},
+	)
+	predictableIPLabelingRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "designate_predictable_ip_labeling_retries_total",
+			Help: "Pods which could not be labeled with their predictable IP and are retried",
+		},
+		[]string{"namespace"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		predictableIPsAllocated,
+		predictableIPsAvailable,
+		predictableIPAllocationConflicts,
+		predictableIPLabelingRetries,
+	)
+}
+
+// PredictableIPUsage returns how many addresses of the CIDR of params are
+// allocated in allocations, keyed by ConfigMap name like the result of
+// AllocatePredictableIPs, and how many addresses of the predictable range are
+// still free
+func PredictableIPUsage(params *NADIpam, allocations map[string]map[string]string) (int, int) {
+	used := make(map[netip.Addr]bool)
+	for _, holders := range allocations {
+		for _, value := range holders {
+			for _, ip := range SplitPredictableIPs(value) {
+				addr, err := netip.ParseAddr(ip)
+				if err == nil && params.CIDR.Contains(addr) {
+					used[addr] = true
+				}
+			}
+		}
+	}
+
+	available := 0
+	for addr := params.RangeStart; addr.IsValid() && addr != params.RangeEnd; addr = addr.Next() {
+		if !params.IsExcluded(addr) && !used[addr] {
+			available++
+		}
+	}
+	return len(used), available
+}
+
+// RecordPredictableIPUsage exports the usage of each range of predParams, one
+// per IP family, for the allocations of the Designate instance on network
+func RecordPredictableIPUsage(namespace, instance, network string, predParams []*NADIpam, allocations map[string]map[string]string) {
+	for _, params := range predParams {
+		family := "IPv4"
+		if params.CIDR.Addr().Is6() {
+			family = "IPv6"
+		}
+		seriesLabels := prometheus.Labels{
+			"namespace": namespace,
+			"designate": instance,
+			"network":   network,
+			"family":    family,
+		}
+		allocated, available := PredictableIPUsage(params, allocations)
+		predictableIPsAllocated.With(seriesLabels).Set(float64(allocated))
+		predictableIPsAvailable.With(seriesLabels).Set(float64(available))
+	}
+}
+
+// DeletePredictableIPUsage drops the predictable IP usage series of the
+// Designate instance, e.g. when it's deleted or its addresses aren't
+// allocated from ConfigMaps anymore
+func DeletePredictableIPUsage(namespace, instance string) {
+	seriesLabels := prometheus.Labels{"namespace": namespace, "designate": instance}
+	predictableIPsAllocated.DeletePartialMatch(seriesLabels)
+	predictableIPsAvailable.DeletePartialMatch(seriesLabels)
+}
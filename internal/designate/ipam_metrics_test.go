@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestPredictableIPUsage(t *testing.T) {
+	allocations := map[string]map[string]string{
+		MdnsPredIPConfigMap: {
+			"mdns_address_0": "172.28.0.31,fd00:bbbb::31",
+			"mdns_address_1": "172.28.0.32,fd00:bbbb::32",
+		},
+		BindPredIPConfigMap: {
+			"bind_address_0": "172.28.0.33,fd00:bbbb::33",
+			// a static IP outside of the range
+			"bind_address_1": "172.28.0.200,fd00:bbbb::200",
+		},
+	}
+
+	params := testDualStackIPAM()
+	params[0].Exclude = []netip.Prefix{netip.MustParsePrefix("172.28.0.40/30")}
+	tests := []struct {
+		params    *NADIpam
+		allocated int
+		available int
+	}{
+		{params: params[0], allocated: 4, available: 18},
+		{params: params[1], allocated: 4, available: 34},
+	}
+	for _, tt := range tests {
+		allocated, available := PredictableIPUsage(tt.params, allocations)
+		if allocated != tt.allocated || available != tt.available {
+			t.Errorf("PredictableIPUsage(%s) = %d, %d, expected %d, %d",
+				tt.params.CIDR, allocated, available, tt.allocated, tt.available)
+		}
+	}
+}
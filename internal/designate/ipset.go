@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+)
+
+const (
+	// IPSetHolderLabel is the label on the IPSet CRs storing the predictable IP holder
+	// (e.g. mdns_address_0) the reservation belongs to
+	IPSetHolderLabel = "designate.openstack.org/ip-holder"
+
+	// DefaultIPSetSubnetName is the NetConfig subnet used when none is configured
+	DefaultIPSetSubnetName = "subnet1"
+)
+
+// ErrIPv6SubnetRequired is returned when IPSet mode is used on a dual stack
+// network without an IPv6 subnet
+var ErrIPv6SubnetRequired = errors.New("predictableIPs.ipv6SubnetName is required in IPSet mode on dual stack networks")
+
+// IPSetName returns the name of the IPSet CR reserving the predictable IP of
+// ipHolder. Holder names use underscores which are not valid in object names.
+func IPSetName(instanceName string, ipHolder string) string {
+	return fmt.Sprintf("%s-%s", instanceName, strings.ReplaceAll(ipHolder, "_", "-"))
+}
+
+// GetIPSetAddress returns the address infra-operator reserved for the IPSet on
+// the given network and subnet. The second return value is false until the
+// reservation has been made.
+func GetIPSetAddress(ipSet *networkv1.IPSet, networkName string, subnetName string) (string, bool) {
+	for _, res := range ipSet.Status.Reservation {
+		if strings.EqualFold(string(res.Network), networkName) &&
+			strings.EqualFold(string(res.Subnet), subnetName) &&
+			res.Address != "" {
+			return res.Address, true
+		}
+	}
+	return "", false
+}
+
+// IPSetNetworks returns the networks of the IPSet CR reserving the predictable
+// IP of a holder, one per subnet. The static IP of the holder, if any, is
+// requested as the fixed IP of the subnet of its family. On dual stack
+// networks the first subnet is the IPv4 one and the second the IPv6 one.
+func IPSetNetworks(networkName string, subnetNames []string, staticIPs string) []networkv1.IPSetNetwork {
+	networks := make([]networkv1.IPSetNetwork, len(subnetNames))
+	addresses := SplitPredictableIPs(staticIPs)
+	for i, subnetName := range subnetNames {
+		networks[i] = networkv1.IPSetNetwork{
+			Name:       networkv1.NetNameStr(networkName),
+			SubnetName: networkv1.NetNameStr(subnetName),
+		}
+		for _, ip := range addresses {
+			addr, err := netip.ParseAddr(ip)
+			if err != nil {
+				continue
+			}
+			if len(subnetNames) == 1 || addr.Is6() == (i == 1) {
+				networks[i].FixedIP = &ip
+				break
+			}
+		}
+	}
+	return networks
+}
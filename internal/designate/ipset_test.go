@@ -0,0 +1,145 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+)
+
+func TestIPSetName(t *testing.T) {
+	tests := []struct {
+		holder   string
+		expected string
+	}{
+		{holder: "mdns_address_0", expected: "designate-mdns-address-0"},
+		{holder: "bind_address_12", expected: "designate-bind-address-12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.holder, func(t *testing.T) {
+			if got := IPSetName("designate", tt.holder); got != tt.expected {
+				t.Errorf("IPSetName() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetIPSetAddress(t *testing.T) {
+	tests := []struct {
+		name          string
+		reservations  []networkv1.IPSetReservation
+		expectedAddr  string
+		expectedFound bool
+	}{
+		{
+			name:          "no reservation yet",
+			expectedFound: false,
+		},
+		{
+			name: "reservation on the requested subnet",
+			reservations: []networkv1.IPSetReservation{
+				{Network: "designate", Subnet: "subnet1", Address: "172.28.0.30"},
+			},
+			expectedAddr:  "172.28.0.30",
+			expectedFound: true,
+		},
+		{
+			name: "network name is matched case insensitively",
+			reservations: []networkv1.IPSetReservation{
+				{Network: "Designate", Subnet: "subnet1", Address: "172.28.0.31"},
+			},
+			expectedAddr:  "172.28.0.31",
+			expectedFound: true,
+		},
+		{
+			name: "reservation on another subnet is ignored",
+			reservations: []networkv1.IPSetReservation{
+				{Network: "designate", Subnet: "subnet2", Address: "172.29.0.30"},
+			},
+			expectedFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ipSet := &networkv1.IPSet{}
+			ipSet.Status.Reservation = tt.reservations
+
+			addr, found := GetIPSetAddress(ipSet, "designate", "subnet1")
+			if found != tt.expectedFound {
+				t.Fatalf("GetIPSetAddress() found = %v, want %v", found, tt.expectedFound)
+			}
+			if addr != tt.expectedAddr {
+				t.Errorf("GetIPSetAddress() = %s, want %s", addr, tt.expectedAddr)
+			}
+		})
+	}
+}
+
+func TestIPSetNetworks(t *testing.T) {
+	tests := []struct {
+		name        string
+		subnetNames []string
+		staticIPs   string
+		expected    []string
+	}{
+		{
+			name:        "no static IP",
+			subnetNames: []string{"subnet1"},
+			expected:    []string{""},
+		},
+		{
+			name:        "static IP",
+			subnetNames: []string{"subnet1"},
+			staticIPs:   "172.28.0.200",
+			expected:    []string{"172.28.0.200"},
+		},
+		{
+			name:        "dual stack static IPs",
+			subnetNames: []string{"subnet1", "subnet2"},
+			staticIPs:   "fd00:bbbb::200,172.28.0.200",
+			expected:    []string{"172.28.0.200", "fd00:bbbb::200"},
+		},
+		{
+			name:        "dual stack static IPv6 only",
+			subnetNames: []string{"subnet1", "subnet2"},
+			staticIPs:   "fd00:bbbb::200",
+			expected:    []string{"", "fd00:bbbb::200"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			networks := IPSetNetworks("designate", tt.subnetNames, tt.staticIPs)
+			if len(networks) != len(tt.expected) {
+				t.Fatalf("IPSetNetworks() returned %d networks, want %d", len(networks), len(tt.expected))
+			}
+			for i, network := range networks {
+				if string(network.Name) != "designate" || string(network.SubnetName) != tt.subnetNames[i] {
+					t.Errorf("unexpected network %s/%s", network.Name, network.SubnetName)
+				}
+				fixedIP := ""
+				if network.FixedIP != nil {
+					fixedIP = *network.FixedIP
+				}
+				if fixedIP != tt.expected[i] {
+					t.Errorf("fixed IP of %s = %q, want %q", network.SubnetName, fixedIP, tt.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+// LoggingTemplateParameters returns the oslo.log options rendered in the designate.conf of a
+// service, nil when the service keeps the default logging
+func LoggingTemplateParameters(logging *designatev1beta1.DesignateLoggingSpec) map[string]any {
+	if logging == nil {
+		return nil
+	}
+	return map[string]any{
+		"Debug":             DebugLogging(logging),
+		"JSON":              logging.JSON,
+		"RateLimitInterval": logging.RateLimitInterval,
+		"RateLimitBurst":    logging.RateLimitBurst,
+	}
+}
+
+// DebugLogging returns whether a service logs the debug records
+func DebugLogging(logging *designatev1beta1.DesignateLoggingSpec) bool {
+	return logging != nil && logging.Level == "debug"
+}
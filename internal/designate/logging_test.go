@@ -0,0 +1,48 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+func TestLoggingTemplateParameters(t *testing.T) {
+	if params := LoggingTemplateParameters(nil); params != nil {
+		t.Fatalf("expected no parameters without logging, got %v", params)
+	}
+	if DebugLogging(nil) {
+		t.Fatalf("expected no debug logging without logging")
+	}
+
+	params := LoggingTemplateParameters(&designatev1beta1.DesignateLoggingSpec{
+		Level:             "debug",
+		JSON:              true,
+		RateLimitInterval: 30,
+		RateLimitBurst:    100,
+	})
+	if params["Debug"] != true || params["JSON"] != true {
+		t.Errorf("expected debug JSON logging, got %v", params)
+	}
+	if params["RateLimitInterval"] != int32(30) || params["RateLimitBurst"] != int32(100) {
+		t.Errorf("expected the rate limiting to be rendered, got %v", params)
+	}
+
+	params = LoggingTemplateParameters(&designatev1beta1.DesignateLoggingSpec{Level: "info"})
+	if params["Debug"] != false {
+		t.Errorf("expected no debug logging at the info level, got %v", params)
+	}
+}
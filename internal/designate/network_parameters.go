@@ -27,6 +27,7 @@ type NetworkParameters struct {
 	CIDR                    netip.Prefix
 	ProviderAllocationStart netip.Addr
 	ProviderAllocationEnd   netip.Addr
+	Exclude                 []netip.Prefix
 }
 
 // NADConfig - IPAM parameters of the NAD
@@ -39,6 +40,78 @@ type NADIpam struct {
 	CIDR       netip.Prefix `json:"range"`
 	RangeStart netip.Addr   `json:"range_start"`
 	RangeEnd   netip.Addr   `json:"range_end"`
+	// Exclude - CIDRs of the range whereabouts doesn't hand out, the
+	// predictable IPs are never allocated from them either
+	Exclude []netip.Prefix `json:"exclude,omitempty"`
+	// IPRanges - additional ranges of a whereabouts IPAM, used for dual stack
+	IPRanges []NADIpam `json:"ipRanges,omitempty"`
+}
+
+// Ranges returns the top level range, if set, followed by the ipRanges
+func (ipam NADIpam) Ranges() []NADIpam {
+	var ranges []NADIpam
+	if ipam.CIDR.IsValid() {
+		ranges = append(ranges, NADIpam{
+			CIDR:       ipam.CIDR,
+			RangeStart: ipam.RangeStart,
+			RangeEnd:   ipam.RangeEnd,
+			Exclude:    ipam.Exclude,
+		})
+	}
+	for _, r := range ipam.IPRanges {
+		if r.CIDR.IsValid() {
+			ranges = append(ranges, NADIpam{
+				CIDR:       r.CIDR,
+				RangeStart: r.RangeStart,
+				RangeEnd:   r.RangeEnd,
+				Exclude:    r.Exclude,
+			})
+		}
+	}
+	return ranges
+}
+
+// HasIPv4 returns true if one of the ranges is an IPv4 range
+func (ipam NADIpam) HasIPv4() bool {
+	for _, r := range ipam.Ranges() {
+		if r.CIDR.Addr().Is4() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIPv6 returns true if one of the ranges is an IPv6 range
+func (ipam NADIpam) HasIPv6() bool {
+	for _, r := range ipam.Ranges() {
+		if r.CIDR.Addr().Is6() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExcluded returns true if addr is in one of the excluded CIDRs
+func (ipam NADIpam) IsExcluded(addr netip.Addr) bool {
+	for _, prefix := range ipam.Exclude {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAddresses returns true if the range from RangeStart to RangeEnd holds at
+// least count addresses
+func (ipam NADIpam) HasAddresses(count int32) bool {
+	addr := ipam.RangeStart
+	for range count {
+		if !addr.IsValid() || addr.Compare(ipam.RangeEnd) > 0 {
+			return false
+		}
+		addr = addr.Next()
+	}
+	return true
 }
 
 // GetNADConfig parses and returns the NAD configuration from a NetworkAttachmentDefinition
@@ -55,26 +128,57 @@ func GetNADConfig(
 }
 
 // GetNetworkParametersFromNAD - Extract network information from the Network Attachment Definition
+// for the first range of the NAD
 func GetNetworkParametersFromNAD(
 	nad *networkv1.NetworkAttachmentDefinition,
 ) (*NetworkParameters, error) {
-	networkParameters := &NetworkParameters{}
+	networkParameters, err := GetAllNetworkParametersFromNAD(nad)
+	if err != nil {
+		return nil, err
+	}
+	return networkParameters[0], nil
+}
 
+// GetAllNetworkParametersFromNAD - Extract network information for every range of the Network Attachment
+// Definition, e.g. one for IPv4 and one for IPv6 on a dual stack network
+func GetAllNetworkParametersFromNAD(
+	nad *networkv1.NetworkAttachmentDefinition,
+) ([]*NetworkParameters, error) {
 	nadConfig, err := GetNADConfig(nad)
 	if err != nil {
 		return nil, fmt.Errorf("cannot read network parameters: %w", err)
 	}
 
+	ranges := nadConfig.IPAM.Ranges()
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrControlNetworkNotConfigured, nad.Name)
+	}
+
+	var allParameters []*NetworkParameters
+	for _, ipam := range ranges {
+		networkParameters, err := getNetworkParametersFromRange(ipam)
+		if err != nil {
+			return nil, err
+		}
+		allParameters = append(allParameters, networkParameters)
+	}
+	return allParameters, nil
+}
+
+func getNetworkParametersFromRange(ipam NADIpam) (*NetworkParameters, error) {
+	networkParameters := &NetworkParameters{}
+
 	// Designate CIDR parameters
 	// These are the parameters for Designate's net/subnet
-	networkParameters.CIDR = nadConfig.IPAM.CIDR
+	networkParameters.CIDR = ipam.CIDR
+	networkParameters.Exclude = ipam.Exclude
 
 	// OpenShift allocates IP addresses from IPAM.RangeStart to IPAM.RangeEnd
 	// for the pods.
 	// We're going to use a range of 25 IP addresses that are assigned to
 	// the Neutron allocation pool, the range starts right after OpenShift
 	// RangeEnd.
-	networkParameters.ProviderAllocationStart = nadConfig.IPAM.RangeEnd.Next()
+	networkParameters.ProviderAllocationStart = ipam.RangeEnd.Next()
 	end := networkParameters.ProviderAllocationStart
 	for range BindProvPredictablePoolSize {
 		if !networkParameters.CIDR.Contains(end) {
@@ -84,5 +188,5 @@ func GetNetworkParametersFromNAD(
 	}
 	networkParameters.ProviderAllocationEnd = end
 
-	return networkParameters, err
+	return networkParameters, nil
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package designate
 
 import (
+	"errors"
 	"net/netip"
 	"testing"
 
@@ -399,6 +400,93 @@ func TestGetNetworkParametersFromNAD(t *testing.T) {
 	}
 }
 
+func TestGetAllNetworkParametersFromNAD(t *testing.T) {
+	tests := []struct {
+		name                   string
+		nadConfig              string
+		expectError            bool
+		expectedProviderStarts []string
+	}{
+		{
+			name: "single stack",
+			nadConfig: `{
+				"ipam": {
+					"range": "192.168.1.0/24",
+					"range_start": "192.168.1.10",
+					"range_end": "192.168.1.100"
+				}
+			}`,
+			expectedProviderStarts: []string{"192.168.1.101"},
+		},
+		{
+			name: "dual stack with ipRanges only",
+			nadConfig: `{
+				"ipam": {
+					"ipRanges": [
+						{"range": "192.168.1.0/24", "range_start": "192.168.1.10", "range_end": "192.168.1.100"},
+						{"range": "2001:db8::/64", "range_start": "2001:db8::10", "range_end": "2001:db8::100"}
+					]
+				}
+			}`,
+			expectedProviderStarts: []string{"192.168.1.101", "2001:db8::101"},
+		},
+		{
+			name: "dual stack with range and ipRanges",
+			nadConfig: `{
+				"ipam": {
+					"range": "192.168.1.0/24",
+					"range_start": "192.168.1.10",
+					"range_end": "192.168.1.100",
+					"ipRanges": [
+						{"range": "2001:db8::/64", "range_start": "2001:db8::10", "range_end": "2001:db8::100"}
+					]
+				}
+			}`,
+			expectedProviderStarts: []string{"192.168.1.101", "2001:db8::101"},
+		},
+		{
+			name: "no range",
+			nadConfig: `{
+				"ipam": {}
+			}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nad := &networkv1.NetworkAttachmentDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-nad",
+					Namespace: "test-namespace",
+				},
+				Spec: networkv1.NetworkAttachmentDefinitionSpec{
+					Config: tt.nadConfig,
+				},
+			}
+
+			params, err := GetAllNetworkParametersFromNAD(nad)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(params) != len(tt.expectedProviderStarts) {
+				t.Fatalf("expected %d network parameters, got %d", len(tt.expectedProviderStarts), len(params))
+			}
+			for i, expected := range tt.expectedProviderStarts {
+				if params[i].ProviderAllocationStart.String() != expected {
+					t.Errorf("expected provider start %s, got %s", expected, params[i].ProviderAllocationStart)
+				}
+			}
+		})
+	}
+}
+
 func TestGetNetworkParametersFromNAD_ProviderAllocationSize(t *testing.T) {
 	// Test that the provider allocation pool has exactly BindProvPredictablePoolSize (25) IP addresses
 	nadConfig := `{
@@ -495,3 +583,93 @@ func TestNADConfig_StructFields(t *testing.T) {
 		t.Errorf("expected end %v, got %v", end, config.IPAM.RangeEnd)
 	}
 }
+
+func TestNADIpamHasAddresses(t *testing.T) {
+	ipam := NADIpam{
+		CIDR:       netip.MustParsePrefix("172.28.0.0/24"),
+		RangeStart: netip.MustParseAddr("172.28.0.30"),
+		RangeEnd:   netip.MustParseAddr("172.28.0.32"),
+	}
+	tests := []struct {
+		count    int32
+		expected bool
+	}{
+		{count: 0, expected: true},
+		{count: 3, expected: true},
+		{count: 4, expected: false},
+	}
+	for _, tt := range tests {
+		if got := ipam.HasAddresses(tt.count); got != tt.expected {
+			t.Errorf("HasAddresses(%d) = %v, expected %v", tt.count, got, tt.expected)
+		}
+	}
+
+	if (NADIpam{}).HasAddresses(1) {
+		t.Error("expected a range without addresses to have none")
+	}
+}
+
+func TestGetPredictableIPAMsExclude(t *testing.T) {
+	nad := &networkv1.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-nad",
+			Namespace: "test-namespace",
+		},
+		Spec: networkv1.NetworkAttachmentDefinitionSpec{
+			Config: `{
+				"ipam": {
+					"range": "172.28.0.0/24",
+					"range_start": "172.28.0.30",
+					"range_end": "172.28.0.70",
+					"exclude": ["172.28.0.96/30", "10.0.0.0/8"]
+				}
+			}`,
+		},
+	}
+
+	networkParameters, err := GetAllNetworkParametersFromNAD(nad)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exclude, err := ParseExcludes([]string{"172.28.0.110", "fd00::/64"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	predParams, err := GetPredictableIPAMs(networkParameters, exclude)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ipam := predParams[0]
+	if len(ipam.Exclude) != 2 {
+		t.Errorf("expected the CIDRs of the other subnets to be dropped, got %v", ipam.Exclude)
+	}
+	if ipam.RangeStart.String() != "172.28.0.97" {
+		t.Errorf("expected the range to start at 172.28.0.97, got %s", ipam.RangeStart)
+	}
+	// the 4 excluded addresses of the range extend it
+	if ipam.RangeEnd.String() != "172.28.0.126" {
+		t.Errorf("expected the range to end at 172.28.0.126, got %s", ipam.RangeEnd)
+	}
+
+	allocatedIPs := map[string]bool{}
+	available := 0
+	for {
+		ip, err := GetNextIP(ipam, allocatedIPs)
+		if err != nil {
+			break
+		}
+		addr := netip.MustParseAddr(ip)
+		if ipam.IsExcluded(addr) {
+			t.Errorf("excluded address %s allocated", ip)
+		}
+		available++
+	}
+	if available != BindProvPredictablePoolSize {
+		t.Errorf("expected %d available addresses, got %d", BindProvPredictablePoolSize, available)
+	}
+
+	if _, err := ParseExcludes([]string{"172.28.0"}); !errors.Is(err, ErrInvalidPredictableIPExclude) {
+		t.Errorf("expected ErrInvalidPredictableIPExclude, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NetworkPolicyIngress describes traffic admitted to the pods of a component
+type NetworkPolicyIngress struct {
+	// From - labels of the pods of the same namespace the traffic is
+	// admitted from, any source is admitted when empty
+	From  []map[string]string
+	Ports []networkingv1.NetworkPolicyPort
+}
+
+// TCPPort returns the NetworkPolicyPort matching the TCP port
+func TCPPort(port int32) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: ptr.To(corev1.ProtocolTCP),
+		Port:     ptr.To(intstr.FromInt32(port)),
+	}
+}
+
+// DNSPorts returns the NetworkPolicyPorts matching DNS traffic on port, which
+// uses both UDP and TCP
+func DNSPorts(port int32) []networkingv1.NetworkPolicyPort {
+	return []networkingv1.NetworkPolicyPort{
+		{
+			Protocol: ptr.To(corev1.ProtocolUDP),
+			Port:     ptr.To(intstr.FromInt32(port)),
+		},
+		TCPPort(port),
+	}
+}
+
+// NetworkPolicy returns the NetworkPolicy of the pods matching podLabels
+// which only admits the ingress traffic described by ingress. Without
+// ingress, no traffic is admitted.
+func NetworkPolicy(
+	name string,
+	namespace string,
+	podLabels map[string]string,
+	ingress []NetworkPolicyIngress,
+) *networkingv1.NetworkPolicy {
+	rules := []networkingv1.NetworkPolicyIngressRule{}
+	for _, in := range ingress {
+		rule := networkingv1.NetworkPolicyIngressRule{
+			Ports: in.Ports,
+		}
+		for _, from := range in.From {
+			rule.From = append(rule.From, networkingv1.NetworkPolicyPeer{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: from,
+				},
+			})
+		}
+		rules = append(rules, rule)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    podLabels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: podLabels,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+}
+
+// EnsureNetworkPolicy creates or updates the NetworkPolicy np, owned by the
+// object of the helper
+func EnsureNetworkPolicy(ctx context.Context, h *helper.Helper, np *networkingv1.NetworkPolicy) error {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      np.Name,
+			Namespace: np.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), policy, func() error {
+		policy.Labels = util.MergeStringMaps(policy.Labels, np.Labels)
+		policy.Spec = np.Spec
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), policy, h.GetScheme())
+	})
+	return err
+}
+
+// DeleteNetworkPolicy deletes the NetworkPolicy name, if it exists
+func DeleteNetworkPolicy(ctx context.Context, h *helper.Helper, name string, namespace string) error {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	err := h.GetClient().Delete(ctx, policy)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// MultiNetworkPolicyGVK - the MultiNetworkPolicy kind of the Multus network
+// policies. Like NetworkPolicies, they admit the traffic of the pods on a
+// network attachment. The MultiNetworkPolicy CRD is an optional dependency,
+// so MultiNetworkPolicies are handled as unstructured objects.
+var MultiNetworkPolicyGVK = schema.GroupVersionKind{
+	Group:   "k8s.cni.cncf.io",
+	Version: "v1beta1",
+	Kind:    "MultiNetworkPolicy",
+}
+
+// MultiNetworkPolicy returns the MultiNetworkPolicy of the pods matching
+// podLabels on the network attachment networkName, which only admits the
+// ingress traffic described by ingress
+func MultiNetworkPolicy(
+	name string,
+	namespace string,
+	networkName string,
+	podLabels map[string]string,
+	ingress []NetworkPolicyIngress,
+) (*unstructured.Unstructured, error) {
+	np := NetworkPolicy(name, namespace, podLabels, ingress)
+
+	mnp := &unstructured.Unstructured{}
+	mnp.SetGroupVersionKind(MultiNetworkPolicyGVK)
+	mnp.SetName(name)
+	mnp.SetNamespace(namespace)
+	mnp.SetLabels(podLabels)
+	mnp.SetAnnotations(map[string]string{
+		MultiNetworkPolicyForAnnotation: fmt.Sprintf("%s/%s", namespace, networkName),
+	})
+	// the MultiNetworkPolicy spec has the schema of the NetworkPolicy spec
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&np.Spec)
+	if err != nil {
+		return nil, err
+	}
+	mnp.Object["spec"] = spec
+	return mnp, nil
+}
+
+// EnsureMultiNetworkPolicy creates or updates the MultiNetworkPolicy mnp,
+// owned by the object of the helper. It returns false when the
+// MultiNetworkPolicy CRD is not installed.
+func EnsureMultiNetworkPolicy(ctx context.Context, h *helper.Helper, mnp *unstructured.Unstructured) (bool, error) {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(MultiNetworkPolicyGVK)
+	policy.SetName(mnp.GetName())
+	policy.SetNamespace(mnp.GetNamespace())
+
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), policy, func() error {
+		policy.SetLabels(util.MergeStringMaps(policy.GetLabels(), mnp.GetLabels()))
+		policy.SetAnnotations(util.MergeStringMaps(policy.GetAnnotations(), mnp.GetAnnotations()))
+		policy.Object["spec"] = mnp.Object["spec"]
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), policy, h.GetScheme())
+	})
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteMultiNetworkPolicy deletes the MultiNetworkPolicy name, if it exists
+func DeleteMultiNetworkPolicy(ctx context.Context, h *helper.Helper, name string, namespace string) error {
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(MultiNetworkPolicyGVK)
+	policy.SetName(name)
+	policy.SetNamespace(namespace)
+
+	err := h.GetClient().Delete(ctx, policy)
+	if err != nil && !k8s_errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
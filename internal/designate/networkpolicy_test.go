@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	bind9Labels = map[string]string{
+		common.ComponentSelector: "designate-backendbind9",
+		common.AppSelector:       "designate-backendbind9",
+	}
+	workerLabels = map[string]string{
+		common.ComponentSelector: "designate-worker",
+		common.AppSelector:       "designate-worker",
+	}
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	np := NetworkPolicy("designate-backendbind9", "ns", bind9Labels, []NetworkPolicyIngress{
+		{Ports: DNSPorts(DNSPort)},
+		{From: []map[string]string{workerLabels}, Ports: []networkingv1.NetworkPolicyPort{TCPPort(RNDCPort)}},
+	})
+
+	if np.Spec.PodSelector.MatchLabels[common.ComponentSelector] != "designate-backendbind9" ||
+		np.Spec.PodSelector.MatchLabels[common.AppSelector] != "designate-backendbind9" {
+		t.Errorf("unexpected pod selector %v", np.Spec.PodSelector)
+	}
+	if len(np.Spec.PolicyTypes) != 1 || np.Spec.PolicyTypes[0] != networkingv1.PolicyTypeIngress {
+		t.Errorf("unexpected policy types %v", np.Spec.PolicyTypes)
+	}
+	if len(np.Spec.Ingress) != 2 {
+		t.Fatalf("expected 2 ingress rules, got %d", len(np.Spec.Ingress))
+	}
+
+	dns := np.Spec.Ingress[0]
+	if len(dns.From) != 0 {
+		t.Errorf("expected DNS from any source, got %v", dns.From)
+	}
+	if len(dns.Ports) != 2 || *dns.Ports[0].Protocol != corev1.ProtocolUDP || *dns.Ports[1].Protocol != corev1.ProtocolTCP ||
+		dns.Ports[0].Port.IntValue() != DNSPort || dns.Ports[1].Port.IntValue() != DNSPort {
+		t.Errorf("unexpected DNS ports %v", dns.Ports)
+	}
+
+	rndc := np.Spec.Ingress[1]
+	if len(rndc.From) != 1 || rndc.From[0].PodSelector.MatchLabels[common.ComponentSelector] != "designate-worker" ||
+		rndc.From[0].PodSelector.MatchLabels[common.AppSelector] != "designate-worker" {
+		t.Errorf("expected rndc from the workers only, got %v", rndc.From)
+	}
+	if len(rndc.Ports) != 1 || rndc.Ports[0].Port.IntValue() != RNDCPort {
+		t.Errorf("unexpected rndc ports %v", rndc.Ports)
+	}
+}
+
+func TestNetworkPolicyDenyAll(t *testing.T) {
+	np := NetworkPolicy("designate-central", "ns", map[string]string{common.ComponentSelector: "designate-central"}, nil)
+
+	if np.Spec.Ingress == nil || len(np.Spec.Ingress) != 0 {
+		t.Errorf("expected no ingress rule, got %v", np.Spec.Ingress)
+	}
+	if len(np.Spec.PolicyTypes) != 1 || np.Spec.PolicyTypes[0] != networkingv1.PolicyTypeIngress {
+		t.Errorf("unexpected policy types %v", np.Spec.PolicyTypes)
+	}
+}
+
+func TestMultiNetworkPolicy(t *testing.T) {
+	mnp, err := MultiNetworkPolicy("designate-backendbind9", "ns", "designate", bind9Labels, []NetworkPolicyIngress{
+		{From: []map[string]string{workerLabels}, Ports: []networkingv1.NetworkPolicyPort{TCPPort(RNDCPort)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mnp.GroupVersionKind() != MultiNetworkPolicyGVK {
+		t.Errorf("unexpected kind %v", mnp.GroupVersionKind())
+	}
+	if mnp.GetAnnotations()[MultiNetworkPolicyForAnnotation] != "ns/designate" {
+		t.Errorf("unexpected policy-for annotation %v", mnp.GetAnnotations())
+	}
+
+	matchLabels, _, err := unstructured.NestedStringMap(mnp.Object, "spec", "podSelector", "matchLabels")
+	if err != nil || matchLabels[common.AppSelector] != "designate-backendbind9" {
+		t.Errorf("unexpected pod selector %v: %v", matchLabels, err)
+	}
+	ingress, _, err := unstructured.NestedSlice(mnp.Object, "spec", "ingress")
+	if err != nil || len(ingress) != 1 {
+		t.Fatalf("unexpected ingress %v: %v", ingress, err)
+	}
+	from, _, err := unstructured.NestedSlice(ingress[0].(map[string]any), "from")
+	if err != nil || len(from) != 1 {
+		t.Fatalf("unexpected ingress sources %v: %v", from, err)
+	}
+	fromLabels, _, err := unstructured.NestedStringMap(from[0].(map[string]any), "podSelector", "matchLabels")
+	if err != nil || fromLabels[common.ComponentSelector] != "designate-worker" {
+		t.Errorf("expected rndc from the workers only, got %v: %v", fromLabels, err)
+	}
+}
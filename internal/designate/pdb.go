@@ -0,0 +1,73 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"time"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pdb"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// PodDisruptionBudget returns the PodDisruptionBudget of the pods matching
+// selector as defined by spec. When spec is not set, a budget keeping one pod
+// available is returned if there is more than one replica, otherwise nil as
+// a single pod can't be protected without blocking node drains.
+func PodDisruptionBudget(
+	name string,
+	namespace string,
+	selector map[string]string,
+	replicas int32,
+	spec *designatev1beta1.DesignatePDBSpec,
+) *policyv1.PodDisruptionBudget {
+	var pdbDef *policyv1.PodDisruptionBudget
+	switch {
+	case spec != nil && spec.MaxUnavailable != nil:
+		pdbDef = pdb.MaxUnavailablePodDisruptionBudget(name, namespace, *spec.MaxUnavailable, selector)
+	case spec != nil && spec.MinAvailable != nil:
+		pdbDef = pdb.MinAvailablePodDisruptionBudget(name, namespace, *spec.MinAvailable, selector)
+	case replicas > 1:
+		pdbDef = pdb.MinAvailablePodDisruptionBudget(name, namespace, intstr.FromInt32(1), selector)
+	default:
+		return nil
+	}
+	pdbDef.Labels = selector
+	return pdbDef
+}
+
+// ReconcilePodDisruptionBudget creates or updates the PodDisruptionBudget name
+// of the pods matching selector, owned by the object of the helper, or
+// deletes it when it is not needed anymore
+func ReconcilePodDisruptionBudget(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	namespace string,
+	selector map[string]string,
+	replicas int32,
+	spec *designatev1beta1.DesignatePDBSpec,
+) (ctrl.Result, error) {
+	pdbDef := PodDisruptionBudget(name, namespace, selector, replicas, spec)
+	if pdbDef == nil {
+		return ctrl.Result{}, pdb.DeletePDBWithName(ctx, h, name, namespace)
+	}
+	return pdb.NewPDB(pdbDef, time.Duration(5)*time.Second).CreateOrPatch(ctx, h)
+}
@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+	selector := map[string]string{"component": "designate-backendbind9"}
+
+	if pdb := PodDisruptionBudget("bind9", "ns", selector, 1, nil); pdb != nil {
+		t.Errorf("expected no budget for a single replica, got %v", pdb.Spec)
+	}
+
+	pdb := PodDisruptionBudget("bind9", "ns", selector, 3, nil)
+	if pdb == nil || pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.IntValue() != 1 || pdb.Spec.MaxUnavailable != nil {
+		t.Fatalf("expected minAvailable 1 by default, got %v", pdb)
+	}
+	if pdb.Name != "bind9" || pdb.Namespace != "ns" || pdb.Spec.Selector.MatchLabels["component"] != "designate-backendbind9" {
+		t.Errorf("unexpected budget %v", pdb)
+	}
+
+	pdb = PodDisruptionBudget("bind9", "ns", selector, 1, &designatev1beta1.DesignatePDBSpec{
+		MinAvailable: ptr.To(intstr.FromString("50%")),
+	})
+	if pdb == nil || pdb.Spec.MinAvailable == nil || pdb.Spec.MinAvailable.String() != "50%" {
+		t.Errorf("expected minAvailable 50%%, got %v", pdb)
+	}
+
+	pdb = PodDisruptionBudget("bind9", "ns", selector, 3, &designatev1beta1.DesignatePDBSpec{
+		MaxUnavailable: ptr.To(intstr.FromInt32(2)),
+	})
+	if pdb == nil || pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != 2 || pdb.Spec.MinAvailable != nil {
+		t.Errorf("expected maxUnavailable 2, got %v", pdb)
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// ApplyPodClasses sets the PriorityClass and the RuntimeClass of a pod. Unset,
+// the pod keeps the default priority and the default runtime of its node.
+func ApplyPodClasses(spec *corev1.PodSpec, priorityClassName string, runtimeClassName string) {
+	spec.PriorityClassName = priorityClassName
+	spec.RuntimeClassName = nil
+	if runtimeClassName != "" {
+		spec.RuntimeClassName = ptr.To(runtimeClassName)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+)
+
+func TestApplyPodClasses(t *testing.T) {
+	spec := newTestPodSpec()
+	ApplyPodClasses(&spec, "dns-critical", "kata")
+
+	if spec.PriorityClassName != "dns-critical" {
+		t.Errorf("expected the dns-critical PriorityClass, got %q", spec.PriorityClassName)
+	}
+	if spec.RuntimeClassName == nil || *spec.RuntimeClassName != "kata" {
+		t.Errorf("expected the kata RuntimeClass, got %v", spec.RuntimeClassName)
+	}
+}
+
+func TestApplyPodClassesDefault(t *testing.T) {
+	spec := newTestPodSpec()
+	ApplyPodClasses(&spec, "", "")
+
+	if spec.PriorityClassName != "" {
+		t.Errorf("unexpected PriorityClass %q", spec.PriorityClassName)
+	}
+	if spec.RuntimeClassName != nil {
+		t.Errorf("unexpected RuntimeClass %q", *spec.RuntimeClassName)
+	}
+}
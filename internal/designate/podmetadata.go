@@ -0,0 +1,54 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"maps"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OperatorKeyPrefix is the prefix of the annotations and labels owned by the
+// operator, e.g. the predictable IP labels it sets on the pods
+const OperatorKeyPrefix = "designate.openstack.org/"
+
+// mergeUnowned returns a copy of owned, the annotations or labels set by the
+// operator, with the ones of extra neither set in owned nor prefixed with
+// OperatorKeyPrefix. owned is not modified as it can be shared, e.g. the labels
+// of a pod template are also the selector of its Deployment.
+func mergeUnowned(owned map[string]string, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(owned)+len(extra))
+	maps.Copy(merged, owned)
+	for k, v := range extra {
+		if _, ok := owned[k]; ok || strings.HasPrefix(k, OperatorKeyPrefix) {
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// ApplyPodMetadata adds the annotations and labels of a service spec to the
+// ones of template, without overriding the ones the operator owns
+func ApplyPodMetadata(template *corev1.PodTemplateSpec, annotations map[string]string, labels map[string]string) {
+	if len(annotations) > 0 {
+		template.Annotations = mergeUnowned(template.Annotations, annotations)
+	}
+	if len(labels) > 0 {
+		template.Labels = mergeUnowned(template.Labels, labels)
+	}
+}
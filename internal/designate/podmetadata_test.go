@@ -0,0 +1,58 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"maps"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyPodMetadata(t *testing.T) {
+	selector := map[string]string{"service": "designate-worker"}
+	template := &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      selector,
+			Annotations: map[string]string{InputHashAnnotation: "n5f4h"},
+		},
+	}
+
+	ApplyPodMetadata(template,
+		map[string]string{
+			"downscaler/exclude":         "true",
+			InputHashAnnotation:          "overridden",
+			OperatorKeyPrefix + "paused": "true",
+		},
+		map[string]string{
+			"cost-center":              "dns",
+			"service":                  "overridden",
+			OperatorKeyPrefix + "pool": "pool1",
+		})
+
+	wantAnnotations := map[string]string{InputHashAnnotation: "n5f4h", "downscaler/exclude": "true"}
+	if !maps.Equal(template.Annotations, wantAnnotations) {
+		t.Errorf("annotations = %v, want %v", template.Annotations, wantAnnotations)
+	}
+	wantLabels := map[string]string{"service": "designate-worker", "cost-center": "dns"}
+	if !maps.Equal(template.Labels, wantLabels) {
+		t.Errorf("labels = %v, want %v", template.Labels, wantLabels)
+	}
+	if len(selector) != 1 {
+		t.Errorf("expected the selector to be left unchanged, got %v", selector)
+	}
+}
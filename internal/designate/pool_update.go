@@ -2,8 +2,7 @@ package designate
 
 import (
 	"fmt"
-
-	"time"
+	"strings"
 
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -22,9 +21,66 @@ const (
 	DesignatePoolsYamlPath = "pools.yaml"
 )
 
-// PoolUpdateJob creates a job for updating designate pools
+// PoolUpdateJob creates a job applying the pools.yaml of a Designate.
+// configHash is the hash of the rendered pools.yaml, it re-runs the job when
+// the pools change, e.g. on a change of the mdns or bind9 replicas or of
+// their predictable IPs.
 func PoolUpdateJob(
 	instance *designatev1beta1.Designate,
+	configHash string,
+	labels map[string]string,
+	annotations map[string]string,
+) *batchv1.Job {
+	jobName := fmt.Sprintf("%s-pool-update", instance.Name)
+	return poolUpdateJob(instance, PoolsYamlConfigMap, jobName, "", configHash, labels, annotations)
+}
+
+// PoolConfigMapName returns the name of the ConfigMap holding the pools.yaml
+// of a DesignatePool
+func PoolConfigMapName(poolName string) string {
+	return fmt.Sprintf("%s-pools-yaml", poolName)
+}
+
+// DesignatePoolUpdateJob creates a job applying the pool of a DesignatePool.
+// configHash is the hash of the rendered pools.yaml, it re-runs the job when
+// the pool changes.
+func DesignatePoolUpdateJob(
+	instance *designatev1beta1.Designate,
+	pool *designatev1beta1.DesignatePool,
+	configHash string,
+	labels map[string]string,
+	annotations map[string]string,
+) *batchv1.Job {
+	jobName := fmt.Sprintf("%s-pool-update", pool.Name)
+	return poolUpdateJob(instance, PoolConfigMapName(pool.Name), jobName, "", configHash, labels, annotations)
+}
+
+// PoolDeleteConfigMapName returns the name of the ConfigMap holding the pools
+// remaining once a DesignatePool is deleted
+func PoolDeleteConfigMapName(poolName string) string {
+	return fmt.Sprintf("%s-pools-yaml-delete", poolName)
+}
+
+// DesignatePoolDeleteJob creates a job removing the pool of a DesignatePool.
+// designate-manage has no command deleting a single pool, the job applies the
+// remaining pools with --delete which removes every pool not listed.
+func DesignatePoolDeleteJob(
+	instance *designatev1beta1.Designate,
+	pool *designatev1beta1.DesignatePool,
+	configHash string,
+	labels map[string]string,
+	annotations map[string]string,
+) *batchv1.Job {
+	jobName := fmt.Sprintf("%s-pool-delete", pool.Name)
+	return poolUpdateJob(instance, PoolDeleteConfigMapName(pool.Name), jobName, "--delete", configHash, labels, annotations)
+}
+
+func poolUpdateJob(
+	instance *designatev1beta1.Designate,
+	configMapName string,
+	jobName string,
+	extraArgs string,
+	configHash string,
 	labels map[string]string,
 	annotations map[string]string,
 ) *batchv1.Job {
@@ -43,7 +99,7 @@ func PoolUpdateJob(
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: PoolsYamlConfigMap,
+					Name: configMapName,
 				},
 				Items: []corev1.KeyToPath{
 					{
@@ -84,6 +140,12 @@ func PoolUpdateJob(
 	)
 
 	envVars := []corev1.EnvVar{}
+	if configHash != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "CONFIG_HASH",
+			Value: configHash,
+		})
+	}
 	if instance.Spec.DesignateAPI.TLS.CaBundleSecretName != "" {
 		volumes = append(volumes, instance.Spec.DesignateAPI.TLS.CreateVolume())
 		volumeMounts = append(volumeMounts, instance.Spec.DesignateAPI.TLS.CreateVolumeMounts(nil)...)
@@ -97,12 +159,59 @@ func PoolUpdateJob(
 		})
 	}
 
-	jobName := fmt.Sprintf("%s-pool-update-%d", ServiceName, time.Now().Unix())
-	cmdLine := fmt.Sprintf("/usr/bin/designate-manage --config-file %s --config-file %s pool update --file /tmp/designate-pools/%s",
+	// Fill in the secrets which are kept out of the pools.yaml ConfigMap
+	var sedExprs []string
+	if instance.IsPDNSEnabled() {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "PDNS_API_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: DesignatePDNSAPIKeySecret,
+					},
+					Key: PDNSAPIKeySecretKey,
+				},
+			},
+		})
+		sedExprs = append(sedExprs, fmt.Sprintf("-e \"s|%s|${PDNS_API_KEY}|g\"", PDNSAPIKeyPlaceholder))
+	}
+	for _, server := range instance.Spec.ExternalBindServers {
+		if !server.HasTSIGKey() {
+			continue
+		}
+		envName := ExternalTSIGSecretEnvVar(server.Name)
+		envVars = append(envVars, corev1.EnvVar{
+			Name: envName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: server.TSIGKeySecret,
+					},
+					Key: server.TSIGKeySecretKey,
+				},
+			},
+		})
+		sedExprs = append(sedExprs, fmt.Sprintf("-e \"s|%s|${%s}|g\"", ExternalTSIGSecretPlaceholder(server.Name), envName))
+	}
+
+	poolsYamlFile := fmt.Sprintf("/tmp/designate-pools/%s", DesignatePoolsYamlPath)
+	preCmdLine := ""
+	if len(sedExprs) > 0 {
+		mergedPoolsYamlFile := fmt.Sprintf("/var/lib/config-data/merged/%s", DesignatePoolsYamlPath)
+		preCmdLine = fmt.Sprintf("sed %s %s > %s && ",
+			strings.Join(sedExprs, " "), poolsYamlFile, mergedPoolsYamlFile)
+		poolsYamlFile = mergedPoolsYamlFile
+	}
+
+	cmdLine := fmt.Sprintf("%s/usr/bin/designate-manage --config-file %s --config-file %s pool update --file %s",
+		preCmdLine,
 		"/var/lib/config-data/default/designate.conf",
 		"/etc/designate/designate.conf",
-		DesignatePoolsYamlPath,
+		poolsYamlFile,
 	)
+	if extraArgs != "" {
+		cmdLine = fmt.Sprintf("%s %s", cmdLine, extraArgs)
+	}
 
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
@@ -139,6 +248,7 @@ func PoolUpdateJob(
 			},
 		},
 	}
+	ApplyImagePullOptions(&job.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
 	return job
 }
 
@@ -263,5 +373,6 @@ func PoolListJob(
 			},
 		},
 	}
+	ApplyImagePullOptions(&job.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
 	return job
 }
@@ -28,8 +28,8 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -139,13 +139,11 @@ func ListPoolsFromJob(
 		return nil, ErrPoolListJobNotComplete
 	}
 
-	// Get the pod created by the job to retrieve logs
-	podList := &corev1.PodList{}
-	listOpts := []client.ListOption{
-		client.InNamespace(namespace),
-		client.MatchingLabels(map[string]string{"job-name": jobDef.Name}),
-	}
-	err = h.GetClient().List(ctx, podList, listOpts...)
+	// Get the pod created by the job to retrieve logs, the job pods are not
+	// in the cache of the manager
+	podList, err := h.GetKClient().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", jobDef.Name),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods for job %s: %w", jobDef.Name, err)
 	}
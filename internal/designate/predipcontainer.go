@@ -16,20 +16,36 @@ limitations under the License.
 package designate
 
 import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 )
 
+const (
+	// PredictableIPContainerName is the name of the predictable IP container
+	PredictableIPContainerName = "predictableips"
+
+	// PredictableIPNetworksPath is where the predictable IP maps of the
+	// networks other than the control network are mounted, one directory
+	// per network
+	PredictableIPNetworksPath = "/var/lib/predictableips-networks"
+)
+
 // PredIPContainerDetails contains configuration for predictable IP containers
 type PredIPContainerDetails struct {
 	ContainerImage string
-	VolumeMounts   []corev1.VolumeMount
+	Resources      InitResources
 	Command        string
-	EnvVars        []corev1.EnvVar
 }
 
-// PredictableIPContainer creates a container with predictable IP configuration
+// PredictableIPContainer creates a container with predictable IP configuration,
+// it is only given the name of the pod and the predictable IPs
 func PredictableIPContainer(init PredIPContainerDetails) corev1.Container {
+	mounts, envVars := init.Resources.Select(NeedsPodName, NeedsPredictableIPs)
 
 	args := []string{
 		"-c",
@@ -38,7 +54,7 @@ func PredictableIPContainer(init PredIPContainerDetails) corev1.Container {
 
 	capabilities := []corev1.Capability{"NET_ADMIN", "SYS_ADMIN", "SYS_NICE"}
 	return corev1.Container{
-		Name:  "predictableips",
+		Name:  PredictableIPContainerName,
 		Image: init.ContainerImage,
 		SecurityContext: &corev1.SecurityContext{
 			Capabilities: &corev1.Capabilities{
@@ -51,7 +67,59 @@ func PredictableIPContainer(init PredIPContainerDetails) corev1.Container {
 			"/bin/bash",
 		},
 		Args:         args,
-		Env:          init.EnvVars,
-		VolumeMounts: init.VolumeMounts,
+		Env:          envVars,
+		VolumeMounts: mounts,
+	}
+}
+
+// NetworkPredIPConfigMap returns the name of the ConfigMap holding the
+// predictable IPs on network of the pods whose control network addresses are
+// in the ConfigMap configMapName. The network comes first, the names of the
+// maps of the bind9 pools must keep their prefix.
+func NetworkPredIPConfigMap(configMapName string, network string) string {
+	return fmt.Sprintf("%s-%s", network, configMapName)
+}
+
+// PredictableIPNetworkVolumes returns the volumes and the mounts of the
+// predictable IP maps of networks, for the pods whose control network
+// addresses are in the ConfigMap configMapName. The maps are optional, the
+// predictable IP container waits for the address of its pod.
+func PredictableIPNetworkVolumes(configMapName string, networks []string) ([]corev1.Volume, []corev1.VolumeMount) {
+	volumes := []corev1.Volume{}
+	mounts := []corev1.VolumeMount{}
+	for i, network := range networks {
+		// the network names are not always valid volume names
+		name := fmt.Sprintf("predictableips-net%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: NetworkPredIPConfigMap(configMapName, network),
+					},
+					Optional: ptr.To(true),
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: path.Join(PredictableIPNetworksPath, network),
+			ReadOnly:  true,
+		})
+	}
+	return volumes, mounts
+}
+
+// PredictableIPNetworkEnv returns the environment of the predictable IP
+// container naming the interfaces the addresses are added to, the interface
+// of each network attachment is named after it
+func PredictableIPNetworkEnv(controlNetworkName string, networks []string) map[string]env.Setter {
+	envVars := map[string]env.Setter{}
+	if controlNetworkName != "" {
+		envVars["NAD_NAME"] = env.SetValue(controlNetworkName)
+	}
+	if len(networks) > 0 {
+		envVars["PREDICTABLE_IP_NETWORKS"] = env.SetValue(strings.Join(networks, ","))
 	}
+	return envVars
 }
@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PreflightCommand - the checks run by the pre-flight job, they read the config of the db sync job
+	PreflightCommand = "/usr/bin/python3 /usr/local/bin/container-scripts/preflight.py " +
+		"/var/lib/config-data/default/designate.conf /var/lib/config-data/default/custom.conf"
+
+	// PreflightRndcTargetsEnv - the rndc endpoints of the bind9 servers checked by the pre-flight job
+	PreflightRndcTargetsEnv = "PREFLIGHT_RNDC_TARGETS"
+)
+
+// PreflightRndcTargets returns the rndc endpoints of the bind9 servers at the
+// addresses of bindMap, sorted to keep the hash of the job stable
+func PreflightRndcTargets(bindMap map[string]string) []string {
+	targets := []string{}
+	for _, address := range bindMap {
+		targets = append(targets, net.JoinHostPort(address, strconv.Itoa(RNDCPort)))
+	}
+	slices.Sort(targets)
+	return targets
+}
+
+// PreflightJob returns the job checking that the database, RabbitMQ, the
+// coordination backend and the rndc ports of rndcTargets are reachable. The
+// pod is attached to the control network through annotations to reach the
+// bind9 servers.
+func PreflightJob(
+	instance *designatev1beta1.Designate,
+	labels map[string]string,
+	annotations map[string]string,
+	rndcTargets []string,
+) *batchv1.Job {
+	runAsUser := int64(0)
+
+	volumeDefs := []VolumeMapping{
+		{Name: ScriptsVolumeName(ServiceName), Type: ScriptMount, MountPath: "/usr/local/bin/container-scripts"},
+		{Name: ConfigVolumeName(ServiceName), Type: SecretMount, MountPath: "/var/lib/config-data/default"},
+	}
+
+	volumes, volumeMounts := ProcessVolumes(volumeDefs)
+
+	// the database connection reads the TLS options of the client from my.cnf
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      ConfigVolumeName(ServiceName),
+		MountPath: "/etc/my.cnf",
+		SubPath:   "my.cnf",
+		ReadOnly:  true,
+	})
+
+	envVars := []corev1.EnvVar{
+		{Name: PreflightRndcTargetsEnv, Value: strings.Join(rndcTargets, " ")},
+	}
+	if instance.Spec.DesignateAPI.TLS.CaBundleSecretName != "" {
+		volumes = append(volumes, instance.Spec.DesignateAPI.TLS.CreateVolume())
+		volumeMounts = append(volumeMounts, instance.Spec.DesignateAPI.TLS.CreateVolumeMounts(nil)...)
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "SSL_CERT_FILE",
+			Value: "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+		})
+	}
+
+	jobName := instance.Name + "-preflight"
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: instance.RbacResourceName(),
+					Containers: []corev1.Container{
+						{
+							Name:  jobName,
+							Image: instance.Spec.DesignateAPI.ContainerImage,
+							Env:   envVars,
+							Command: []string{
+								"/bin/bash",
+								"-c",
+								PreflightCommand,
+							},
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: &runAsUser,
+							},
+							VolumeMounts: volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+
+	if instance.Spec.NodeSelector != nil {
+		job.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
+	}
+	ApplyImagePullOptions(&job.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+
+	return job
+}
+
+// PreflightFailures returns the failed checks preflight.py writes to the
+// termination message of the failed pods of the pre-flight job
+func PreflightFailures(
+	ctx context.Context,
+	h *helper.Helper,
+	jobDef *batchv1.Job,
+) (string, error) {
+	// the job pods are not in the cache of the manager
+	podList, err := h.GetKClient().CoreV1().Pods(jobDef.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("job-name=%s", jobDef.Name),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods for job %s: %w", jobDef.Name, err)
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return strings.TrimSpace(status.State.Terminated.Message), nil
+			}
+		}
+	}
+	return "", nil
+}
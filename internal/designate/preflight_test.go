@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"slices"
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+func TestPreflightRndcTargets(t *testing.T) {
+	got := PreflightRndcTargets(map[string]string{
+		"bind_address_1": "172.28.0.32",
+		"bind_address_0": "172.28.0.31",
+		"bind_address_2": "fd00::31",
+	})
+	want := []string{"172.28.0.31:953", "172.28.0.32:953", "[fd00::31]:953"}
+	if !slices.Equal(got, want) {
+		t.Errorf("PreflightRndcTargets() = %v, want %v", got, want)
+	}
+}
+
+func TestPreflightJob(t *testing.T) {
+	instance := &designatev1beta1.Designate{}
+	instance.Name = "designate"
+	instance.Namespace = "openstack"
+	instance.Spec.DesignateAPI.ContainerImage = "designate-api:latest"
+
+	job := PreflightJob(instance, nil, map[string]string{}, []string{"172.28.0.31:953", "172.28.0.32:953"})
+	if job.Name != "designate-preflight" {
+		t.Errorf("unexpected job name %s", job.Name)
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Image != "designate-api:latest" {
+		t.Errorf("unexpected image %s", container.Image)
+	}
+	if container.Env[0].Name != PreflightRndcTargetsEnv || container.Env[0].Value != "172.28.0.31:953 172.28.0.32:953" {
+		t.Errorf("unexpected rndc targets %v", container.Env[0])
+	}
+}
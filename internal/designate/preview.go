@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+// PreviewSuffix is the suffix of the names of the objects holding a preview
+const PreviewSuffix = "-preview"
+
+// IsPreview returns true when obj is in preview with the PreviewAnnotation
+func IsPreview(obj metav1.Object) bool {
+	preview, err := strconv.ParseBool(obj.GetAnnotations()[PreviewAnnotation])
+	return err == nil && preview
+}
+
+// PreviewName returns the name of the preview of the object named name, the
+// ConfigMap holding the manifests of a CR or the Secret of a rendered config
+func PreviewName(name string) string {
+	return name + PreviewSuffix
+}
+
+// PreviewKey returns the key of the manifest of the kind object named name in
+// a preview ConfigMap
+func PreviewKey(kind string, name string) string {
+	return fmt.Sprintf("%s-%s.yaml", kind, name)
+}
+
+// StorePreview stores content, e.g. a manifest the object of the helper would
+// apply, with key in the preview ConfigMap of the object
+func StorePreview(ctx context.Context, h *helper.Helper, key string, content string) error {
+	owner := h.GetBeforeObject()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PreviewName(owner.GetName()),
+			Namespace: owner.GetNamespace(),
+		},
+	}
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), cm, func() error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = content
+		return controllerutil.SetControllerReference(owner, cm, h.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		h.GetLogger().Info(fmt.Sprintf("Preview of %s stored in ConfigMap %s", key, cm.Name))
+	}
+	return nil
+}
+
+// storeManifestPreview stores obj, the kind object the object of the helper
+// would apply, in its preview ConfigMap
+func storeManifestPreview(ctx context.Context, h *helper.Helper, kind string, obj client.Object) error {
+	manifest, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return StorePreview(ctx, h, PreviewKey(strings.ToLower(kind), obj.GetName()), string(manifest))
+}
+
+// DeletePreview deletes the preview ConfigMap of obj and the preview Secrets
+// of the templates, once obj is no longer in preview
+func DeletePreview(ctx context.Context, h *helper.Helper, obj client.Object, templates []util.Template) error {
+	previews := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: PreviewName(obj.GetName()), Namespace: obj.GetNamespace()}},
+	}
+	for _, t := range templates {
+		previews = append(previews, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: PreviewName(t.Name), Namespace: t.Namespace}})
+	}
+	for _, preview := range previews {
+		// only the existing previews are deleted, the cache is read first
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: preview.GetName(), Namespace: preview.GetNamespace()}, preview)
+		if k8s_errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(preview, obj) {
+			continue
+		}
+		if err := h.GetClient().Delete(ctx, preview); err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+		h.GetLogger().Info(fmt.Sprintf("Preview %s deleted", preview.GetName()))
+	}
+	return nil
+}
+
+// previewTemplates returns the templates rendered into preview Secrets
+func previewTemplates(templates []util.Template) []util.Template {
+	previews := make([]util.Template, 0, len(templates))
+	for _, t := range templates {
+		t.Name = PreviewName(t.Name)
+		previews = append(previews, t)
+	}
+	return previews
+}
@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestIsPreview(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		want        bool
+	}{
+		{annotations: nil, want: false},
+		{annotations: map[string]string{PreviewAnnotation: "true"}, want: true},
+		{annotations: map[string]string{PreviewAnnotation: "false"}, want: false},
+		{annotations: map[string]string{PreviewAnnotation: "yes"}, want: false},
+	}
+	for _, tt := range tests {
+		obj := &corev1.ConfigMap{}
+		obj.Annotations = tt.annotations
+		if got := IsPreview(obj); got != tt.want {
+			t.Errorf("IsPreview(%v) = %v, want %v", tt.annotations, got, tt.want)
+		}
+	}
+}
+
+func TestPreviewTemplates(t *testing.T) {
+	templates := []util.Template{
+		{Name: "designate-central-config-data", Type: util.TemplateTypeConfig},
+		{Name: "designate-central-scripts", Type: util.TemplateTypeScripts},
+	}
+	previews := previewTemplates(templates)
+
+	want := []string{"designate-central-config-data-preview", "designate-central-scripts-preview"}
+	for i, preview := range previews {
+		if preview.Name != want[i] || preview.Type != templates[i].Type {
+			t.Errorf("preview template %d = %s %s, want %s %s", i, preview.Name, preview.Type, want[i], templates[i].Type)
+		}
+	}
+	// the templates are left unchanged
+	if templates[0].Name != "designate-central-config-data" {
+		t.Errorf("template renamed to %s", templates[0].Name)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyProbeOverrides applies the probe overrides of a service to its
+// container. A probe the container doesn't define is added with the check of
+// the liveness probe.
+func ApplyProbeOverrides(container *corev1.Container, probes *designatev1beta1.DesignateProbesSpec) {
+	if probes == nil {
+		return
+	}
+	container.LivenessProbe = overrideProbe(container.LivenessProbe, container.LivenessProbe, probes.LivenessProbe)
+	container.ReadinessProbe = overrideProbe(container.ReadinessProbe, container.LivenessProbe, probes.ReadinessProbe)
+	container.StartupProbe = overrideProbe(container.StartupProbe, container.LivenessProbe, probes.StartupProbe)
+}
+
+func overrideProbe(probe *corev1.Probe, liveness *corev1.Probe, override *designatev1beta1.DesignateProbeSpec) *corev1.Probe {
+	if override == nil {
+		return probe
+	}
+	if probe == nil {
+		if liveness == nil {
+			return nil
+		}
+		probe = &corev1.Probe{ProbeHandler: liveness.ProbeHandler}
+	} else {
+		// The probes of a container may share their handler, only the
+		// timings are changed
+		probe = probe.DeepCopy()
+	}
+	if override.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *override.InitialDelaySeconds
+	}
+	if override.PeriodSeconds != nil {
+		probe.PeriodSeconds = *override.PeriodSeconds
+	}
+	if override.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *override.TimeoutSeconds
+	}
+	if override.FailureThreshold != nil {
+		probe.FailureThreshold = *override.FailureThreshold
+	}
+	return probe
+}
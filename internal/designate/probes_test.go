@@ -0,0 +1,66 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestApplyProbeOverrides(t *testing.T) {
+	liveness := &corev1.Probe{
+		TimeoutSeconds: 15,
+		PeriodSeconds:  13,
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"healthcheck.sh"}},
+		},
+	}
+	container := corev1.Container{
+		LivenessProbe:  liveness,
+		ReadinessProbe: liveness,
+	}
+
+	ApplyProbeOverrides(&container, nil)
+	if container.LivenessProbe != liveness || container.StartupProbe != nil {
+		t.Fatalf("expected the probes to be unchanged without overrides")
+	}
+
+	ApplyProbeOverrides(&container, &designatev1beta1.DesignateProbesSpec{
+		LivenessProbe: &designatev1beta1.DesignateProbeSpec{
+			TimeoutSeconds:   ptr.To[int32](30),
+			FailureThreshold: ptr.To[int32](5),
+		},
+		StartupProbe: &designatev1beta1.DesignateProbeSpec{
+			PeriodSeconds:    ptr.To[int32](10),
+			FailureThreshold: ptr.To[int32](30),
+		},
+	})
+
+	if container.LivenessProbe.TimeoutSeconds != 30 || container.LivenessProbe.FailureThreshold != 5 ||
+		container.LivenessProbe.PeriodSeconds != 13 {
+		t.Errorf("unexpected liveness probe %v", container.LivenessProbe)
+	}
+	if container.ReadinessProbe.TimeoutSeconds != 15 || liveness.TimeoutSeconds != 15 {
+		t.Errorf("expected the readiness probe sharing the liveness probe to be unchanged, got %v", container.ReadinessProbe)
+	}
+	startup := container.StartupProbe
+	if startup == nil || startup.Exec == nil || startup.Exec.Command[0] != "healthcheck.sh" ||
+		startup.PeriodSeconds != 10 || startup.FailureThreshold != 30 {
+		t.Errorf("expected a startup probe with the liveness check, got %v", startup)
+	}
+}
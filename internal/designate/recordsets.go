@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/dns/v2/recordsets"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+)
+
+var (
+	// ErrRecordSetNotFound is returned when a recordset is not found
+	ErrRecordSetNotFound = errors.New("recordset not found")
+)
+
+// RecordSetOpts - the desired state of a recordset
+type RecordSetOpts struct {
+	Name        string
+	Type        string
+	Records     []string
+	TTL         int
+	Description string
+}
+
+// GetRecordSet retrieves a recordset of a zone by ID
+func GetRecordSet(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+	zoneID string,
+	recordSetID string,
+) (*recordsets.RecordSet, error) {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	rrset, err := recordsets.Get(ctx, dnsClient, zoneID, recordSetID).Extract()
+	if err != nil {
+		if gophercloud.ResponseCodeIs(err, 404) {
+			return nil, fmt.Errorf("%w: %s", ErrRecordSetNotFound, recordSetID)
+		}
+		return nil, fmt.Errorf("failed to get recordset %s: %w", recordSetID, err)
+	}
+	return rrset, nil
+}
+
+// FindRecordSet returns the recordset of a zone with the given name and type,
+// nil if it does not exist
+func FindRecordSet(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+	zoneID string,
+	name string,
+	rrType string,
+) (*recordsets.RecordSet, error) {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	listOpts := recordsets.ListOpts{Name: name, Type: rrType}
+	allPages, err := recordsets.ListByZone(dnsClient, zoneID, listOpts).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recordsets: %w", err)
+	}
+	rrsets, err := recordsets.ExtractRecordSets(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract recordsets from response: %w", err)
+	}
+	for _, rrset := range rrsets {
+		if rrset.Name == name && rrset.Type == rrType {
+			return &rrset, nil
+		}
+	}
+	return nil, nil
+}
+
+// EnsureRecordSet creates the recordset if it does not exist yet, and updates
+// its records, TTL and description otherwise
+func EnsureRecordSet(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+	zoneID string,
+	recordSetID string,
+	opts RecordSetOpts,
+) (*recordsets.RecordSet, error) {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var rrset *recordsets.RecordSet
+	if recordSetID != "" {
+		rrset, err = GetRecordSet(ctx, osclient, zoneID, recordSetID)
+		if err != nil && !errors.Is(err, ErrRecordSetNotFound) {
+			return nil, err
+		}
+	}
+	if rrset == nil {
+		rrset, err = FindRecordSet(ctx, osclient, zoneID, opts.Name, opts.Type)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rrset == nil {
+		createOpts := recordsets.CreateOpts{
+			Name:        opts.Name,
+			Type:        opts.Type,
+			Records:     opts.Records,
+			TTL:         opts.TTL,
+			Description: opts.Description,
+		}
+		rrset, err = recordsets.Create(ctx, dnsClient, zoneID, createOpts).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create recordset %s %s: %w", opts.Name, opts.Type, err)
+		}
+		return rrset, nil
+	}
+
+	if recordSetNeedsUpdate(rrset, opts) {
+		updateOpts := recordsets.UpdateOpts{
+			Records:     opts.Records,
+			Description: &opts.Description,
+		}
+		if opts.TTL != 0 {
+			updateOpts.TTL = &opts.TTL
+		}
+		rrset, err = recordsets.Update(ctx, dnsClient, zoneID, rrset.ID, updateOpts).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("failed to update recordset %s %s: %w", opts.Name, opts.Type, err)
+		}
+	}
+	return rrset, nil
+}
+
+// recordSetNeedsUpdate returns true if the records, TTL or description of the
+// recordset differ from opts, the order of the records is not relevant
+func recordSetNeedsUpdate(rrset *recordsets.RecordSet, opts RecordSetOpts) bool {
+	current := slices.Sorted(slices.Values(rrset.Records))
+	desired := slices.Sorted(slices.Values(opts.Records))
+	return !slices.Equal(current, desired) ||
+		(opts.TTL != 0 && rrset.TTL != opts.TTL) ||
+		rrset.Description != opts.Description
+}
+
+// DeleteRecordSet deletes the recordset, a recordset which does not exist is ignored
+func DeleteRecordSet(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+	zoneID string,
+	recordSetID string,
+) error {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	err = recordsets.Delete(ctx, dnsClient, zoneID, recordSetID).ExtractErr()
+	if err != nil && !gophercloud.ResponseCodeIs(err, 404) {
+		return fmt.Errorf("failed to delete recordset %s: %w", recordSetID, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	// DefaultResourceRequests are requested for the service container when
+	// not set, e.g. for the CPU utilization target of the autoscaler
+	DefaultResourceRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+
+	// DefaultInitResourceRequests are requested for the init containers when
+	// not set
+	DefaultInitResourceRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10m"),
+		corev1.ResourceMemory: resource.MustParse("64Mi"),
+	}
+
+	// DefaultSidecarResourceRequests are requested for the sidecar containers
+	// when not set
+	DefaultSidecarResourceRequests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10m"),
+		corev1.ResourceMemory: resource.MustParse("64Mi"),
+	}
+)
+
+// withDefaultRequests returns a copy of resources requesting defaults for the
+// resources it neither requests nor limits. A limit alone is kept, Kubernetes
+// then requests as much as the limit.
+func withDefaultRequests(resources corev1.ResourceRequirements, defaults corev1.ResourceList) corev1.ResourceRequirements {
+	out := *resources.DeepCopy()
+	for name, quantity := range defaults {
+		if _, ok := out.Requests[name]; ok {
+			continue
+		}
+		if _, ok := out.Limits[name]; ok {
+			continue
+		}
+		if out.Requests == nil {
+			out.Requests = corev1.ResourceList{}
+		}
+		out.Requests[name] = quantity.DeepCopy()
+	}
+	return out
+}
+
+// ApplyResources sets the compute resources of the containers of a pod: the
+// ones of main on the service container, the first one, of sidecar on the
+// other containers and of init on the init containers. The operator defaults
+// are requested for the resources they don't set.
+func ApplyResources(spec *corev1.PodSpec, main corev1.ResourceRequirements, init corev1.ResourceRequirements, sidecar corev1.ResourceRequirements) {
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Resources = withDefaultRequests(init, DefaultInitResourceRequests)
+	}
+	for i := range spec.Containers {
+		if i == 0 {
+			spec.Containers[i].Resources = withDefaultRequests(main, DefaultResourceRequests)
+			continue
+		}
+		spec.Containers[i].Resources = withDefaultRequests(sidecar, DefaultSidecarResourceRequests)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestApplyResourcesDefault(t *testing.T) {
+	spec := newTestPodSpec()
+	spec.Containers = append(spec.Containers, corev1.Container{Name: "exporter"})
+	ApplyResources(&spec, corev1.ResourceRequirements{}, corev1.ResourceRequirements{}, corev1.ResourceRequirements{})
+
+	for _, c := range spec.InitContainers {
+		if c.Resources.Requests.Cpu().String() != "10m" || c.Resources.Requests.Memory().String() != "64Mi" {
+			t.Errorf("expected the init defaults on container %s, got %v", c.Name, c.Resources.Requests)
+		}
+	}
+	if r := spec.Containers[0].Resources; r.Requests.Cpu().String() != "100m" || r.Requests.Memory().String() != "256Mi" {
+		t.Errorf("expected the service defaults, got %v", r.Requests)
+	}
+	if r := spec.Containers[1].Resources; r.Requests.Cpu().String() != "10m" || r.Limits != nil {
+		t.Errorf("expected the sidecar defaults, got %v", r)
+	}
+}
+
+func TestApplyResources(t *testing.T) {
+	spec := newTestPodSpec()
+	main := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	init := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+	}
+	ApplyResources(&spec, main, init, corev1.ResourceRequirements{})
+
+	r := spec.Containers[0].Resources
+	if r.Requests.Cpu().String() != "500m" {
+		t.Errorf("expected the CPU request to be kept, got %v", r.Requests)
+	}
+	if _, ok := r.Requests[corev1.ResourceMemory]; ok {
+		t.Errorf("expected no memory request next to the memory limit, got %v", r.Requests)
+	}
+	r = spec.InitContainers[0].Resources
+	if r.Requests.Cpu().String() != "10m" || r.Requests.Memory().String() != "128Mi" {
+		t.Errorf("expected the init memory request with the default CPU request, got %v", r.Requests)
+	}
+	if len(main.Requests) != 1 || len(init.Requests) != 1 {
+		t.Errorf("expected the spec resources to be left unchanged")
+	}
+}
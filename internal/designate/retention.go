@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PVCRetentionPolicy returns the retention policy of the volume claims of a
+// StatefulSet, the one of the spec or by default deleting them with the
+// StatefulSet and retaining them on scale down. preserveOnDelete retains them
+// with the StatefulSet.
+func PVCRetentionPolicy(
+	policy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy,
+	preserveOnDelete bool,
+) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	retention := &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+	}
+	if policy != nil {
+		if policy.WhenDeleted != "" {
+			retention.WhenDeleted = policy.WhenDeleted
+		}
+		if policy.WhenScaled != "" {
+			retention.WhenScaled = policy.WhenScaled
+		}
+	}
+	if preserveOnDelete {
+		retention.WhenDeleted = appsv1.RetainPersistentVolumeClaimRetentionPolicyType
+	}
+	return retention
+}
+
+// RemoveOwnerReference removes owner from the owners of obj, obj is then kept
+// when owner is deleted
+func RemoveOwnerReference(owner, obj metav1.Object) {
+	obj.SetOwnerReferences(slices.DeleteFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.UID == owner.GetUID()
+	}))
+}
@@ -0,0 +1,61 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPVCRetentionPolicy(t *testing.T) {
+	policy := PVCRetentionPolicy(nil, false)
+	if policy.WhenDeleted != appsv1.DeletePersistentVolumeClaimRetentionPolicyType ||
+		policy.WhenScaled != appsv1.RetainPersistentVolumeClaimRetentionPolicyType {
+		t.Errorf("expected Delete on delete and Retain on scale down by default, got %v", policy)
+	}
+
+	policy = PVCRetentionPolicy(&appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenScaled: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+	}, false)
+	if policy.WhenDeleted != appsv1.DeletePersistentVolumeClaimRetentionPolicyType ||
+		policy.WhenScaled != appsv1.DeletePersistentVolumeClaimRetentionPolicyType {
+		t.Errorf("expected Delete on delete and on scale down, got %v", policy)
+	}
+
+	policy = PVCRetentionPolicy(&appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+	}, true)
+	if policy.WhenDeleted != appsv1.RetainPersistentVolumeClaimRetentionPolicyType {
+		t.Errorf("expected Retain on delete with preserveOnDelete, got %v", policy)
+	}
+}
+
+func TestRemoveOwnerReference(t *testing.T) {
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "designate", UID: "owner"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "designate-bind-secret",
+		OwnerReferences: []metav1.OwnerReference{
+			{Name: "designate", UID: "owner"},
+			{Name: "other", UID: "other"},
+		},
+	}}
+	RemoveOwnerReference(owner, secret)
+	if refs := secret.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "other" {
+		t.Errorf("expected only the other owner to be kept, got %v", refs)
+	}
+}
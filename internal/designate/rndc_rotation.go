@@ -0,0 +1,134 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// An rndc key rotation goes through the following phases, every phase rolls
+// the bind9 pods so the control channel stays usable during the rotation:
+//   - Staged: a new key is added next to every rndc key, the bind9 servers
+//     accept both keys while designate-worker keeps using the current one.
+//   - Promoted: the new keys replace the current ones which are kept as the
+//     previous keys until designate-worker has been restarted with the new
+//     keys.
+//   - the previous keys are removed and the rotation is complete.
+const (
+	// RndcRotateAnnotation requests an rndc key rotation when set on the
+	// Designate CR to a value which has not been handled yet
+	RndcRotateAnnotation = "designate.openstack.org/rotate-rndc-keys"
+
+	// RndcRotationPhaseAnnotation holds the phase of the ongoing rndc key
+	// rotation on the DesignateBindKeySecret
+	RndcRotationPhaseAnnotation = "designate.openstack.org/rndc-rotation-phase"
+
+	// RndcRotationTimeAnnotation holds the start time of the last rndc key
+	// rotation on the DesignateBindKeySecret
+	RndcRotationTimeAnnotation = "designate.openstack.org/rndc-rotation-time"
+
+	// RndcRotationRequestAnnotation holds the last handled value of
+	// RndcRotateAnnotation on the DesignateBindKeySecret
+	RndcRotationRequestAnnotation = "designate.openstack.org/rndc-rotation-request"
+
+	// RndcRotationPhaseStaged - the new keys are deployed next to the current keys
+	RndcRotationPhaseStaged = "Staged"
+
+	// RndcRotationPhasePromoted - the new keys replaced the current keys
+	RndcRotationPhasePromoted = "Promoted"
+
+	// RndcNextKeySuffix is the suffix of the new keys of a rotation
+	RndcNextKeySuffix = "-next"
+
+	// RndcPreviousKeySuffix is the suffix of the replaced keys of a rotation
+	RndcPreviousKeySuffix = "-previous"
+
+	// RndcRotationHash key for status hash, holds the rndc key rotation
+	// state deployed by the service
+	RndcRotationHash = "Rndc rotation"
+)
+
+// GetRndcRotationState returns a value which changes with every phase of an
+// rndc key rotation
+func GetRndcRotationState(rndcSecret *corev1.Secret) string {
+	return fmt.Sprintf("%s/%s",
+		rndcSecret.Annotations[RndcRotationPhaseAnnotation],
+		rndcSecret.Annotations[RndcRotationTimeAnnotation])
+}
+
+// GetRndcRotationStateFromSecret returns the rndc key rotation state of the
+// DesignateBindKeySecret of namespace
+func GetRndcRotationStateFromSecret(ctx context.Context, c client.Client, namespace string) (string, error) {
+	rndcSecret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Name: DesignateBindKeySecret, Namespace: namespace}, rndcSecret)
+	if err != nil {
+		return "", err
+	}
+	return GetRndcRotationState(rndcSecret), nil
+}
+
+func isRotationKey(keyName string) bool {
+	return strings.HasSuffix(keyName, RndcNextKeySuffix) || strings.HasSuffix(keyName, RndcPreviousKeySuffix)
+}
+
+// StageRndcKeys adds a new key, called rndc-key-<unix time of now>, next to
+// every rndc key of data
+func StageRndcKeys(data map[string][]byte, now time.Time) error {
+	newKeyName := fmt.Sprintf("%s-%d", DesignateRndcKey, now.Unix())
+	for keyName := range data {
+		if isRotationKey(keyName) {
+			continue
+		}
+		content, err := CreateNamedRndcKeySecret(newKeyName)
+		if err != nil {
+			return err
+		}
+		data[keyName+RndcNextKeySuffix] = []byte(content)
+	}
+	return nil
+}
+
+// PromoteRndcKeys replaces the rndc keys of data by their staged keys, the
+// replaced keys are kept as the previous keys
+func PromoteRndcKeys(data map[string][]byte) {
+	for keyName, next := range data {
+		if !strings.HasSuffix(keyName, RndcNextKeySuffix) {
+			continue
+		}
+		current := strings.TrimSuffix(keyName, RndcNextKeySuffix)
+		if key, ok := data[current]; ok {
+			data[current+RndcPreviousKeySuffix] = key
+		}
+		data[current] = next
+		delete(data, keyName)
+	}
+}
+
+// CleanupRndcKeys removes the previous keys of a rotation from data
+func CleanupRndcKeys(data map[string][]byte) {
+	for keyName := range data {
+		if isRotationKey(keyName) {
+			delete(data, keyName)
+		}
+	}
+}
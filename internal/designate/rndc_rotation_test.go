@@ -0,0 +1,58 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRndcKeyRotation(t *testing.T) {
+	data := map[string][]byte{
+		"rndc-key-0": []byte(`key "rndc-key" {};`),
+		"rndc-key-1": []byte(`key "rndc-key" {};`),
+	}
+
+	if err := StageRndcKeys(data, time.Unix(1700000000, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 4 {
+		t.Fatalf("expected a staged key for every key, got %v", data)
+	}
+	staged := string(data["rndc-key-0-next"])
+	if !strings.HasPrefix(staged, `key "rndc-key-1700000000" {`) {
+		t.Errorf("unexpected staged key %s", staged)
+	}
+	if string(data["rndc-key-0"]) != `key "rndc-key" {};` {
+		t.Errorf("the current key must not change when staging, got %s", data["rndc-key-0"])
+	}
+
+	PromoteRndcKeys(data)
+	if string(data["rndc-key-0"]) != staged {
+		t.Errorf("expected the staged key to be promoted, got %s", data["rndc-key-0"])
+	}
+	if string(data["rndc-key-0-previous"]) != `key "rndc-key" {};` {
+		t.Errorf("expected the replaced key to be kept, got %s", data["rndc-key-0-previous"])
+	}
+	if _, ok := data["rndc-key-1-next"]; ok {
+		t.Errorf("expected the staged keys to be removed, got %v", data)
+	}
+
+	CleanupRndcKeys(data)
+	if len(data) != 2 || string(data["rndc-key-0"]) != staged {
+		t.Errorf("expected only the new keys to be left, got %v", data)
+	}
+}
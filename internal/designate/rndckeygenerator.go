@@ -47,6 +47,11 @@ func genword(length int) (string, error) {
 
 // CreateRndcKeySecret creates the rndc key secret
 func CreateRndcKeySecret() (string, error) {
+	return CreateNamedRndcKeySecret(DesignateRndcKey)
+}
+
+// CreateNamedRndcKeySecret creates the rndc key secret for a key called keyName
+func CreateNamedRndcKeySecret(keyName string) (string, error) {
 	// Generate random strings
 	key, err := genword(MinPasswordSize)
 	if err != nil {
@@ -69,10 +74,15 @@ func CreateRndcKeySecret() (string, error) {
 	secret := base64.StdEncoding.EncodeToString(digest)
 
 	// Format the key content according to the required structure
-	rndcKeyContent := fmt.Sprintf(`key "rndc-key" {
+	rndcKeyContent := fmt.Sprintf(`key "%s" {
 		algorithm hmac-sha256;
 		secret "%s";
-	};`, secret)
+	};`, keyName, secret)
 
 	return rndcKeyContent, nil
 }
+
+// CreatePDNSAPIKey generates the key of the PowerDNS API used by designate-worker
+func CreatePDNSAPIKey() (string, error) {
+	return genword(MinPasswordSize)
+}
@@ -0,0 +1,82 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	common "github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AntiAffinityRequired never runs two pods of a service on the same node
+	AntiAffinityRequired = "required"
+)
+
+// DistributePods spreads the pods of serviceName when no Topology is applied.
+// Two pods of the service preferably don't run on the same node, or never with
+// a required anti-affinity, and they are spread across the domains of the
+// topology spread constraints.
+func DistributePods(
+	template *corev1.PodTemplateSpec,
+	serviceName string,
+	antiAffinity string,
+	constraints []designatev1beta1.DesignateTopologySpreadConstraint,
+) {
+	selector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      common.AppSelector,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{serviceName},
+			},
+		},
+	}
+
+	if antiAffinity == AntiAffinityRequired {
+		template.Spec.Affinity = &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+					{
+						LabelSelector: selector,
+						TopologyKey:   corev1.LabelHostname,
+					},
+				},
+			},
+		}
+	} else {
+		template.Spec.Affinity = affinity.DistributePods(
+			common.AppSelector,
+			[]string{
+				serviceName,
+			},
+			corev1.LabelHostname,
+		)
+	}
+
+	template.Spec.TopologySpreadConstraints = nil
+	for _, constraint := range constraints {
+		template.Spec.TopologySpreadConstraints = append(template.Spec.TopologySpreadConstraints,
+			corev1.TopologySpreadConstraint{
+				MaxSkew:           constraint.MaxSkew,
+				TopologyKey:       constraint.TopologyKey,
+				WhenUnsatisfiable: constraint.WhenUnsatisfiable,
+				LabelSelector:     selector,
+			})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDistributePods(t *testing.T) {
+	template := &corev1.PodTemplateSpec{}
+
+	DistributePods(template, "designate-mdns", "", nil)
+	antiAffinity := template.Spec.Affinity.PodAntiAffinity
+	if len(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 ||
+		len(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) != 0 {
+		t.Errorf("expected a preferred anti-affinity by default, got %v", antiAffinity)
+	}
+	if template.Spec.TopologySpreadConstraints != nil {
+		t.Errorf("expected no topology spread constraints, got %v", template.Spec.TopologySpreadConstraints)
+	}
+
+	DistributePods(template, "designate-mdns", AntiAffinityRequired, []designatev1beta1.DesignateTopologySpreadConstraint{
+		{
+			TopologyKey:       corev1.LabelTopologyZone,
+			MaxSkew:           1,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+		},
+	})
+	antiAffinity = template.Spec.Affinity.PodAntiAffinity
+	if len(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 0 ||
+		len(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) != 1 ||
+		antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey != corev1.LabelHostname {
+		t.Errorf("expected a required anti-affinity, got %v", antiAffinity)
+	}
+	constraints := template.Spec.TopologySpreadConstraints
+	if len(constraints) != 1 || constraints[0].TopologyKey != corev1.LabelTopologyZone ||
+		constraints[0].LabelSelector.MatchExpressions[0].Values[0] != "designate-mdns" {
+		t.Errorf("unexpected topology spread constraints %v", constraints)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// SecurityProfileBaseline only sets the RuntimeDefault seccomp profile
+	SecurityProfileBaseline = "baseline"
+	// SecurityProfileRestricted also drops the privileges of the containers
+	SecurityProfileRestricted = "restricted"
+
+	// DesignateUID is the uid of the designate user of the service images
+	DesignateUID int64 = 42411
+
+	// runVolume is the writable run directory of the containers with a read
+	// only root filesystem
+	runVolume = "run"
+)
+
+// SecurityProfile describes what a service needs with the restricted profile
+type SecurityProfile struct {
+	// User - the non root uid the containers run as, nil when the service
+	// starts as root and drops its privileges itself
+	User *int64
+	// Capabilities - the capabilities the containers keep
+	Capabilities []corev1.Capability
+	// RunDir - the directory the service container writes to, with a read only
+	// root filesystem. Empty when the service writes to its root filesystem,
+	// e.g. the kolla services copying their configuration.
+	RunDir string
+}
+
+// ApplySecurityProfile sets the security contexts of a pod for the security
+// profile of its service. The predictable IP container, configuring the
+// network of the pod, keeps running as root with its capabilities.
+func ApplySecurityProfile(spec *corev1.PodSpec, profileName string, profile SecurityProfile) {
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	spec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+		Type: corev1.SeccompProfileTypeRuntimeDefault,
+	}
+	if profileName != SecurityProfileRestricted {
+		return
+	}
+
+	if profile.User != nil {
+		spec.SecurityContext.RunAsNonRoot = ptr.To(true)
+		spec.SecurityContext.RunAsUser = profile.User
+		spec.SecurityContext.RunAsGroup = profile.User
+		if spec.SecurityContext.FSGroup == nil {
+			spec.SecurityContext.FSGroup = profile.User
+		}
+	}
+
+	for i := range spec.InitContainers {
+		restrictContainer(&spec.InitContainers[i], profile)
+	}
+	for i := range spec.Containers {
+		restrictContainer(&spec.Containers[i], profile)
+	}
+
+	if profile.RunDir != "" && len(spec.Containers) > 0 {
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: runVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+			},
+		})
+		service := &spec.Containers[0]
+		service.VolumeMounts = append(service.VolumeMounts, corev1.VolumeMount{
+			Name:      runVolume,
+			MountPath: profile.RunDir,
+		})
+		service.SecurityContext.ReadOnlyRootFilesystem = ptr.To(true)
+	}
+}
+
+func restrictContainer(container *corev1.Container, profile SecurityProfile) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	} else {
+		container.SecurityContext = container.SecurityContext.DeepCopy()
+	}
+	securityContext := container.SecurityContext
+
+	if container.Name == PredictableIPContainerName {
+		if profile.User != nil {
+			securityContext.RunAsNonRoot = ptr.To(false)
+		}
+		return
+	}
+
+	if profile.User != nil {
+		securityContext.RunAsUser = profile.User
+	}
+	securityContext.AllowPrivilegeEscalation = ptr.To(false)
+	securityContext.Capabilities = &corev1.Capabilities{
+		Drop: []corev1.Capability{"ALL"},
+		Add:  profile.Capabilities,
+	}
+}
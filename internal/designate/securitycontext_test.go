@@ -0,0 +1,109 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newTestPodSpec() corev1.PodSpec {
+	return corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "init", SecurityContext: &corev1.SecurityContext{RunAsUser: ptr.To(int64(0))}},
+			{Name: PredictableIPContainerName, SecurityContext: &corev1.SecurityContext{RunAsUser: ptr.To(int64(0))}},
+		},
+		Containers: []corev1.Container{
+			{Name: "service", SecurityContext: &corev1.SecurityContext{RunAsUser: ptr.To(int64(0))}},
+		},
+	}
+}
+
+func TestApplySecurityProfileBaseline(t *testing.T) {
+	spec := newTestPodSpec()
+	ApplySecurityProfile(&spec, "", SecurityProfile{User: ptr.To(DesignateUID)})
+
+	if spec.SecurityContext.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("expected the RuntimeDefault seccomp profile")
+	}
+	if spec.SecurityContext.RunAsNonRoot != nil {
+		t.Errorf("expected the baseline profile to keep the users of the containers")
+	}
+	if *spec.Containers[0].SecurityContext.RunAsUser != 0 || spec.Containers[0].SecurityContext.Capabilities != nil {
+		t.Errorf("expected the service container to be unchanged")
+	}
+}
+
+func TestApplySecurityProfileRestricted(t *testing.T) {
+	spec := newTestPodSpec()
+	ApplySecurityProfile(&spec, SecurityProfileRestricted, SecurityProfile{
+		User:         ptr.To(DesignateUID),
+		Capabilities: []corev1.Capability{"NET_BIND_SERVICE"},
+		RunDir:       "/run/service",
+	})
+
+	if !*spec.SecurityContext.RunAsNonRoot || *spec.SecurityContext.FSGroup != DesignateUID {
+		t.Errorf("expected the pod to run as the designate user")
+	}
+	for _, container := range []corev1.Container{spec.InitContainers[0], spec.Containers[0]} {
+		securityContext := container.SecurityContext
+		if *securityContext.RunAsUser != DesignateUID || *securityContext.AllowPrivilegeEscalation {
+			t.Errorf("expected %s to run as the designate user without privilege escalation", container.Name)
+		}
+		if securityContext.Capabilities.Drop[0] != "ALL" || securityContext.Capabilities.Add[0] != "NET_BIND_SERVICE" {
+			t.Errorf("expected %s to only keep NET_BIND_SERVICE, got %v", container.Name, securityContext.Capabilities)
+		}
+	}
+
+	predIP := spec.InitContainers[1].SecurityContext
+	if *predIP.RunAsUser != 0 || *predIP.RunAsNonRoot || predIP.Capabilities != nil {
+		t.Errorf("expected the predictable IP container to keep running as root")
+	}
+
+	service := spec.Containers[0]
+	if !*service.SecurityContext.ReadOnlyRootFilesystem {
+		t.Errorf("expected a read only root filesystem")
+	}
+	if len(service.VolumeMounts) != 1 || service.VolumeMounts[0].MountPath != "/run/service" || len(spec.Volumes) != 1 {
+		t.Errorf("expected the run directory to be mounted, got %v", service.VolumeMounts)
+	}
+	if spec.InitContainers[0].SecurityContext.ReadOnlyRootFilesystem != nil {
+		t.Errorf("expected the init container to keep a writable root filesystem")
+	}
+}
+
+func TestApplySecurityProfileRestrictedRoot(t *testing.T) {
+	spec := newTestPodSpec()
+	ApplySecurityProfile(&spec, SecurityProfileRestricted, SecurityProfile{
+		Capabilities: []corev1.Capability{"SETGID", "SETUID"},
+	})
+
+	if spec.SecurityContext.RunAsNonRoot != nil {
+		t.Errorf("expected a service dropping its privileges itself to start as root")
+	}
+	securityContext := spec.Containers[0].SecurityContext
+	if *securityContext.RunAsUser != 0 || len(securityContext.Capabilities.Add) != 2 {
+		t.Errorf("expected the service to start as root with its capabilities")
+	}
+	if spec.InitContainers[1].SecurityContext.RunAsNonRoot != nil {
+		t.Errorf("expected the predictable IP container to be unchanged")
+	}
+	if securityContext.ReadOnlyRootFilesystem != nil || len(spec.Volumes) != 0 {
+		t.Errorf("expected a writable root filesystem without a run directory")
+	}
+}
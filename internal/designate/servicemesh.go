@@ -0,0 +1,77 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// IstioInjectAnnotation requests or prevents the injection of the Istio sidecar
+	IstioInjectAnnotation = "sidecar.istio.io/inject"
+
+	// LinkerdInjectAnnotation requests or prevents the injection of the Linkerd proxy
+	LinkerdInjectAnnotation = "linkerd.io/inject"
+
+	// LinkerdOpaquePortsAnnotation lists the ports Linkerd proxies as plain TCP,
+	// without detecting their protocol
+	LinkerdOpaquePortsAnnotation = "config.linkerd.io/opaque-ports"
+)
+
+// ApplyServiceMesh sets on template the annotations of mesh: the ones passed
+// through, which don't override the ones already set by the operator, and the
+// injection annotation of its provider. ports, e.g. the DNS ports the service
+// listens on, are declared on the first container when missing and the TCP
+// ones are proxied by Linkerd as opaque ports.
+func ApplyServiceMesh(template *corev1.PodTemplateSpec, mesh *designatev1beta1.DesignateServiceMeshSpec, ports []corev1.ContainerPort) {
+	if mesh == nil {
+		return
+	}
+	template.Annotations = mergeUnowned(template.Annotations, mesh.PodAnnotations)
+
+	container := &template.Spec.Containers[0]
+	opaquePorts := []string{}
+	for _, port := range ports {
+		if !slices.ContainsFunc(container.Ports, func(p corev1.ContainerPort) bool { return p.Name == port.Name }) {
+			container.Ports = append(container.Ports, port)
+		}
+		if port.Protocol == corev1.ProtocolTCP {
+			opaquePorts = append(opaquePorts, strconv.Itoa(int(port.ContainerPort)))
+		}
+	}
+
+	if mesh.Inject == nil {
+		return
+	}
+	switch mesh.Provider {
+	case designatev1beta1.ServiceMeshProviderIstio:
+		template.Annotations[IstioInjectAnnotation] = strconv.FormatBool(*mesh.Inject)
+	case designatev1beta1.ServiceMeshProviderLinkerd:
+		if !*mesh.Inject {
+			template.Annotations[LinkerdInjectAnnotation] = "disabled"
+			return
+		}
+		template.Annotations[LinkerdInjectAnnotation] = "enabled"
+		if len(opaquePorts) > 0 {
+			template.Annotations[LinkerdOpaquePortsAnnotation] = strings.Join(opaquePorts, ",")
+		}
+	}
+}
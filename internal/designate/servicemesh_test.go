@@ -0,0 +1,107 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"maps"
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestApplyServiceMesh(t *testing.T) {
+	tests := []struct {
+		name string
+		mesh *designatev1beta1.DesignateServiceMeshSpec
+		want map[string]string
+	}{
+		{
+			name: "no mesh",
+			want: map[string]string{InputHashAnnotation: "n5f4h"},
+		},
+		{
+			name: "istio injection",
+			mesh: &designatev1beta1.DesignateServiceMeshSpec{
+				Provider: designatev1beta1.ServiceMeshProviderIstio,
+				Inject:   ptr.To(true),
+			},
+			want: map[string]string{InputHashAnnotation: "n5f4h", IstioInjectAnnotation: "true"},
+		},
+		{
+			name: "linkerd injection",
+			mesh: &designatev1beta1.DesignateServiceMeshSpec{
+				Provider: designatev1beta1.ServiceMeshProviderLinkerd,
+				Inject:   ptr.To(true),
+			},
+			want: map[string]string{
+				InputHashAnnotation:          "n5f4h",
+				LinkerdInjectAnnotation:      "enabled",
+				LinkerdOpaquePortsAnnotation: "5354",
+			},
+		},
+		{
+			name: "linkerd injection prevented",
+			mesh: &designatev1beta1.DesignateServiceMeshSpec{
+				Provider: designatev1beta1.ServiceMeshProviderLinkerd,
+				Inject:   ptr.To(false),
+			},
+			want: map[string]string{InputHashAnnotation: "n5f4h", LinkerdInjectAnnotation: "disabled"},
+		},
+		{
+			name: "custom injector",
+			mesh: &designatev1beta1.DesignateServiceMeshSpec{
+				PodAnnotations: map[string]string{
+					"sidecar.example.com/inject": "dns-proxy",
+					InputHashAnnotation:          "overridden",
+				},
+			},
+			want: map[string]string{InputHashAnnotation: "n5f4h", "sidecar.example.com/inject": "dns-proxy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &corev1.PodTemplateSpec{}
+			template.Annotations = map[string]string{InputHashAnnotation: "n5f4h"}
+			template.Spec.Containers = []corev1.Container{{Name: "designate-mdns"}}
+
+			ApplyServiceMesh(template, tt.mesh, DNSContainerPorts(5354))
+			if !maps.Equal(template.Annotations, tt.want) {
+				t.Errorf("annotations = %v, want %v", template.Annotations, tt.want)
+			}
+			wantPorts := 2
+			if tt.mesh == nil {
+				wantPorts = 0
+			}
+			if len(template.Spec.Containers[0].Ports) != wantPorts {
+				t.Errorf("ports = %v, want %d", template.Spec.Containers[0].Ports, wantPorts)
+			}
+		})
+	}
+}
+
+func TestApplyServiceMeshDeclaredPorts(t *testing.T) {
+	spec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "designate-backendbind9"}}}
+	ApplyHostNetwork(spec, DNSContainerPorts(53))
+	template := &corev1.PodTemplateSpec{Spec: *spec}
+
+	ApplyServiceMesh(template, &designatev1beta1.DesignateServiceMeshSpec{}, DNSContainerPorts(53))
+	ports := template.Spec.Containers[0].Ports
+	if len(ports) != 2 || ports[0].HostPort != 53 {
+		t.Errorf("expected the host ports to be kept, got %v", ports)
+	}
+}
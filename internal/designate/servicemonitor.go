@@ -0,0 +1,155 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"strings"
+
+	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ServiceMonitorGVK - the Prometheus Operator ServiceMonitor kind. The
+// Prometheus Operator is an optional dependency, so ServiceMonitors are
+// handled as unstructured objects.
+var ServiceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// ServiceMonitorDetails describes a ServiceMonitor scraping a port of the
+// Services matching Selector
+type ServiceMonitorDetails struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Selector    map[string]string
+	Port        string
+	Interval    string
+	Relabelings []ServiceMonitorRelabeling
+}
+
+// ServiceMonitorRelabeling copies the SourceLabel of the scraped targets to
+// their TargetLabel
+type ServiceMonitorRelabeling struct {
+	SourceLabel string
+	TargetLabel string
+}
+
+// PredictableIPRelabelings returns the relabelings adding the predictable IP
+// of the scraped pods, which is the address of their interface on the
+// designate control network, to their metrics
+func PredictableIPRelabelings() []ServiceMonitorRelabeling {
+	return []ServiceMonitorRelabeling{
+		{
+			SourceLabel: "__meta_kubernetes_pod_label_" + prometheusLabelName(networkv1.PredictableIPLabel),
+			TargetLabel: "predictable_ip",
+		},
+	}
+}
+
+// prometheusLabelName returns the name Prometheus gives to the meta label of
+// a Kubernetes label key, any character which isn't valid in a Prometheus
+// label name is replaced with an underscore
+func prometheusLabelName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// setServiceMonitorSpec sets the spec of sm to scrape the port of the
+// Services described by details
+func setServiceMonitorSpec(sm *unstructured.Unstructured, details ServiceMonitorDetails) {
+	// unstructured content only holds JSON compatible types
+	matchLabels := map[string]any{}
+	for k, v := range details.Selector {
+		matchLabels[k] = v
+	}
+	endpoint := map[string]any{
+		"port": details.Port,
+	}
+	if details.Interval != "" {
+		endpoint["interval"] = details.Interval
+	}
+	if len(details.Relabelings) > 0 {
+		relabelings := []any{}
+		for _, relabeling := range details.Relabelings {
+			relabelings = append(relabelings, map[string]any{
+				"sourceLabels": []any{relabeling.SourceLabel},
+				"targetLabel":  relabeling.TargetLabel,
+				"action":       "replace",
+			})
+		}
+		endpoint["relabelings"] = relabelings
+	}
+	sm.Object["spec"] = map[string]any{
+		"selector": map[string]any{
+			"matchLabels": matchLabels,
+		},
+		"namespaceSelector": map[string]any{
+			"matchNames": []any{details.Namespace},
+		},
+		"endpoints": []any{endpoint},
+	}
+}
+
+// EnsureServiceMonitor creates or updates the ServiceMonitor described by
+// details, owned by the object of the helper. It returns false when the
+// Prometheus Operator CRDs are not installed.
+func EnsureServiceMonitor(ctx context.Context, h *helper.Helper, details ServiceMonitorDetails) (bool, error) {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(ServiceMonitorGVK)
+	sm.SetName(details.Name)
+	sm.SetNamespace(details.Namespace)
+
+	_, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), sm, func() error {
+		sm.SetLabels(util.MergeStringMaps(sm.GetLabels(), details.Labels))
+		setServiceMonitorSpec(sm, details)
+		return controllerutil.SetControllerReference(h.GetBeforeObject(), sm, h.GetScheme())
+	})
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteServiceMonitor deletes the ServiceMonitor name, if it exists
+func DeleteServiceMonitor(ctx context.Context, h *helper.Helper, name string, namespace string) error {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(ServiceMonitorGVK)
+	sm.SetName(name)
+	sm.SetNamespace(namespace)
+
+	err := h.GetClient().Delete(ctx, sm)
+	if err != nil && !k8s_errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
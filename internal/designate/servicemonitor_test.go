@@ -0,0 +1,80 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSetServiceMonitorSpec(t *testing.T) {
+	sm := &unstructured.Unstructured{Object: map[string]any{}}
+	setServiceMonitorSpec(sm, ServiceMonitorDetails{
+		Name:        "designate-backendbind9-metrics",
+		Namespace:   "openstack",
+		Selector:    map[string]string{"service": "designate-backendbind9-metrics"},
+		Port:        "metrics",
+		Interval:    "30s",
+		Relabelings: PredictableIPRelabelings(),
+	})
+
+	matchLabels, found, err := unstructured.NestedStringMap(sm.Object, "spec", "selector", "matchLabels")
+	if err != nil || !found {
+		t.Fatalf("selector not set: %v", err)
+	}
+	if matchLabels["service"] != "designate-backendbind9-metrics" {
+		t.Errorf("unexpected selector %v", matchLabels)
+	}
+
+	namespaces, _, err := unstructured.NestedStringSlice(sm.Object, "spec", "namespaceSelector", "matchNames")
+	if err != nil || len(namespaces) != 1 || namespaces[0] != "openstack" {
+		t.Errorf("unexpected namespaceSelector %v: %v", namespaces, err)
+	}
+
+	endpoints, _, err := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+	if err != nil || len(endpoints) != 1 {
+		t.Fatalf("unexpected endpoints %v: %v", endpoints, err)
+	}
+	endpoint := endpoints[0].(map[string]any)
+	if endpoint["port"] != "metrics" || endpoint["interval"] != "30s" {
+		t.Errorf("unexpected endpoint %v", endpoint)
+	}
+	relabelings := endpoint["relabelings"].([]any)
+	if len(relabelings) != 1 {
+		t.Fatalf("unexpected relabelings %v", relabelings)
+	}
+	relabeling := relabelings[0].(map[string]any)
+	if relabeling["targetLabel"] != "predictable_ip" ||
+		relabeling["sourceLabels"].([]any)[0] != "__meta_kubernetes_pod_label_predictableip" {
+		t.Errorf("unexpected relabeling %v", relabeling)
+	}
+
+	// the spec must be usable by the unstructured helpers which deep copy it
+	_ = sm.DeepCopy()
+}
+
+func TestPrometheusLabelName(t *testing.T) {
+	for key, expected := range map[string]string{
+		"predictableip":                   "predictableip",
+		"designate.openstack.org/metrics": "designate_openstack_org_metrics",
+		"app.kubernetes.io/part-of":       "app_kubernetes_io_part_of",
+	} {
+		if name := prometheusLabelName(key); name != expected {
+			t.Errorf("expected %s for %s, got %s", expected, key, name)
+		}
+	}
+}
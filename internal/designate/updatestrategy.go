@@ -0,0 +1,43 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+)
+
+// StatefulSetUpdateStrategy returns the update strategy of a StatefulSet as
+// defined by spec, RollingUpdate of all the pods when spec is not set
+func StatefulSetUpdateStrategy(spec *designatev1beta1.DesignateUpdateStrategySpec) appsv1.StatefulSetUpdateStrategy {
+	strategy := appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+	}
+	if spec == nil {
+		return strategy
+	}
+	if spec.Type == string(appsv1.OnDeleteStatefulSetStrategyType) {
+		strategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+		return strategy
+	}
+	if spec.Partition != nil {
+		strategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: ptr.To(*spec.Partition),
+		}
+	}
+	return strategy
+}
@@ -0,0 +1,44 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestStatefulSetUpdateStrategy(t *testing.T) {
+	strategy := StatefulSetUpdateStrategy(nil)
+	if strategy.Type != appsv1.RollingUpdateStatefulSetStrategyType || strategy.RollingUpdate != nil {
+		t.Errorf("expected a RollingUpdate of all the pods by default, got %v", strategy)
+	}
+
+	strategy = StatefulSetUpdateStrategy(&designatev1beta1.DesignateUpdateStrategySpec{
+		Type:      "RollingUpdate",
+		Partition: ptr.To[int32](2),
+	})
+	if strategy.Type != appsv1.RollingUpdateStatefulSetStrategyType || strategy.RollingUpdate == nil ||
+		*strategy.RollingUpdate.Partition != 2 {
+		t.Errorf("expected a RollingUpdate with partition 2, got %v", strategy)
+	}
+
+	strategy = StatefulSetUpdateStrategy(&designatev1beta1.DesignateUpdateStrategySpec{Type: "OnDelete"})
+	if strategy.Type != appsv1.OnDeleteStatefulSetStrategyType || strategy.RollingUpdate != nil {
+		t.Errorf("expected OnDelete, got %v", strategy)
+	}
+}
@@ -16,6 +16,8 @@ limitations under the License.
 package designate
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -52,6 +54,22 @@ func GetStandardVolumeMapping(instance client.Object) []VolumeMapping {
 	}
 }
 
+// GetCustomServiceConfigSecretsVolumeMapping returns the volume mappings of the
+// CustomServiceConfigSecrets of a designate instance. The init container merges
+// the content of the Secrets into the custom config file.
+func GetCustomServiceConfigSecretsVolumeMapping(secretNames []string) []VolumeMapping {
+	mappings := make([]VolumeMapping, len(secretNames))
+	for i, secretName := range secretNames {
+		mappings[i] = VolumeMapping{
+			Name:      fmt.Sprintf("custom-config-secret-%d", i),
+			Type:      SecretMount,
+			MountPath: fmt.Sprintf("%s/%s", CustomConfigSecretsPath, secretName),
+			Source:    secretName,
+		}
+	}
+	return mappings
+}
+
 // ProcessVolumes takes a slice of VolumeMapping and creates corresponding slices of Volumes and Mounts. This
 // helps keep naming and matching of volumes and mounts in sync and consistent.
 func ProcessVolumes(volumeDefs []VolumeMapping) ([]corev1.Volume, []corev1.VolumeMount) {
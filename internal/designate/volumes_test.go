@@ -0,0 +1,36 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"testing"
+)
+
+func TestGetCustomServiceConfigSecretsVolumeMapping(t *testing.T) {
+	if mappings := GetCustomServiceConfigSecretsVolumeMapping(nil); len(mappings) != 0 {
+		t.Errorf("expected no mappings, got %v", mappings)
+	}
+
+	volumes, mounts := ProcessVolumes(GetCustomServiceConfigSecretsVolumeMapping([]string{"pool-targets", "sink-creds"}))
+	if len(volumes) != 2 || len(mounts) != 2 {
+		t.Fatalf("expected two volumes and mounts, got %v %v", volumes, mounts)
+	}
+	if volumes[1].Name != "custom-config-secret-1" || volumes[1].Secret == nil || volumes[1].Secret.SecretName != "sink-creds" {
+		t.Errorf("unexpected volume %v", volumes[1])
+	}
+	if mounts[0].MountPath != "/var/lib/config-data/custom-secrets/pool-targets" || !mounts[0].ReadOnly {
+		t.Errorf("unexpected mount %v", mounts[0])
+	}
+}
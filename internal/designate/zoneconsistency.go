@@ -0,0 +1,185 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/dns/v2/zones"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// SOAQueryTimeout is the maximum time to wait for the answer of a DNS server
+// to a SOA query
+const SOAQueryTimeout = 5 * time.Second
+
+// ZoneSerialsQueryTimeout is the maximum time to query the serials of the
+// zones on all the DNS servers
+const ZoneSerialsQueryTimeout = 15 * time.Second
+
+var (
+	// ErrZoneNotServed is returned when a DNS server has no SOA record for a zone
+	ErrZoneNotServed = errors.New("zone not served")
+)
+
+// ListActiveZones returns the active primary zones of all the projects, the
+// zones whose serial the DNS servers are expected to serve
+func ListActiveZones(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+) ([]zones.Zone, error) {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+	dnsClient.MoreHeaders = map[string]string{"X-Auth-All-Projects": "true"}
+
+	allPages, err := zones.List(dnsClient, zones.ListOpts{Status: "ACTIVE", Type: "PRIMARY"}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	return zones.ExtractZones(allPages)
+}
+
+// QuerySOASerial returns the serial of the SOA record of zone served by the
+// DNS server at address, queried over TCP
+func QuerySOASerial(ctx context.Context, address string, zone string) (uint32, error) {
+	name, err := dnsmessage.NewName(zone)
+	if err != nil {
+		return 0, err
+	}
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: rand.N(uint16(math.MaxUint16))},
+		Questions: []dnsmessage.Question{
+			{Name: name, Type: dnsmessage.TypeSOA, Class: dnsmessage.ClassINET},
+		},
+	}
+	// DNS over TCP prefixes the messages with their length
+	packed, err := query.AppendPack(make([]byte, 2, 514))
+	if err != nil {
+		return 0, err
+	}
+	binary.BigEndian.PutUint16(packed, uint16(len(packed)-2)) // #nosec G115
+
+	ctx, cancel := context.WithTimeout(ctx, SOAQueryTimeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := conn.Write(packed); err != nil {
+		return 0, err
+	}
+	length := make([]byte, 2)
+	if _, err := io.ReadFull(conn, length); err != nil {
+		return 0, err
+	}
+	answer := make([]byte, binary.BigEndian.Uint16(length))
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return 0, err
+	}
+
+	var response dnsmessage.Message
+	if err := response.Unpack(answer); err != nil {
+		return 0, err
+	}
+	if response.ID != query.ID {
+		return 0, fmt.Errorf("unexpected DNS answer id %d", response.ID)
+	}
+	if response.RCode != dnsmessage.RCodeSuccess {
+		return 0, fmt.Errorf("%w: %s", ErrZoneNotServed, response.RCode)
+	}
+	for _, rr := range response.Answers {
+		if soa, ok := rr.Body.(*dnsmessage.SOAResource); ok {
+			return soa.Serial, nil
+		}
+	}
+	return 0, ErrZoneNotServed
+}
+
+// QueryZoneSerials queries the SOA serial of zoneNames on servers, the
+// addresses of the DNS servers by name, concurrently and within
+// ZoneSerialsQueryTimeout. It returns the serials served by each server by
+// zone, without the zones the server doesn't serve. A server failing to
+// answer isn't queried further, it is left out of the serials and reported
+// in the error.
+func QueryZoneSerials(
+	ctx context.Context,
+	servers map[string]string,
+	zoneNames []string,
+) (map[string]map[string]uint32, error) {
+	ctx, cancel := context.WithTimeout(ctx, ZoneSerialsQueryTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	serials := make(map[string]map[string]uint32, len(servers))
+	errs := []error{}
+	for name, address := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			served := make(map[string]uint32, len(zoneNames))
+			for _, zone := range zoneNames {
+				serial, err := QuerySOASerial(ctx, address, zone)
+				if errors.Is(err, ErrZoneNotServed) {
+					continue
+				} else if err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("failed to query zone %s on %s: %w", zone, name, err))
+					mu.Unlock()
+					return
+				}
+				served[zone] = serial
+			}
+			mu.Lock()
+			serials[name] = served
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return serials, errors.Join(errs...)
+}
+
+// ZoneSerialLag returns how far the serial served by a DNS server is behind
+// the serial of the zone in designate. The serials designate sets are
+// timestamps, the lag is in seconds.
+func ZoneSerialLag(designateSerial uint32, servedSerial uint32) int64 {
+	if servedSerial >= designateSerial {
+		return 0
+	}
+	return int64(designateSerial - servedSerial)
+}
@@ -0,0 +1,165 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designate
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveSOA answers one DNS over TCP query on listener with a SOA record of
+// serial, or with rcode when it is not a success
+func serveSOA(t *testing.T, listener net.Listener, serial uint32, rcode dnsmessage.RCode) {
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	length := make([]byte, 2)
+	if _, err := io.ReadFull(conn, length); err != nil {
+		t.Error(err)
+		return
+	}
+	packed := make([]byte, binary.BigEndian.Uint16(length))
+	if _, err := io.ReadFull(conn, packed); err != nil {
+		t.Error(err)
+		return
+	}
+	var query dnsmessage.Message
+	if err := query.Unpack(packed); err != nil {
+		t.Error(err)
+		return
+	}
+
+	response := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true, RCode: rcode},
+		Questions: query.Questions,
+	}
+	if rcode == dnsmessage.RCodeSuccess {
+		response.Answers = []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{
+				Name:  query.Questions[0].Name,
+				Type:  dnsmessage.TypeSOA,
+				Class: dnsmessage.ClassINET,
+				TTL:   300,
+			},
+			Body: &dnsmessage.SOAResource{
+				NS:     dnsmessage.MustNewName("ns1.example.org."),
+				MBox:   dnsmessage.MustNewName("admin.example.org."),
+				Serial: serial,
+			},
+		}}
+	}
+	answer, err := response.AppendPack(make([]byte, 2, 514))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	binary.BigEndian.PutUint16(answer, uint16(len(answer)-2))
+	if _, err := conn.Write(answer); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuerySOASerial(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go serveSOA(t, listener, 1760000000, dnsmessage.RCodeSuccess)
+	serial, err := QuerySOASerial(context.TODO(), listener.Addr().String(), "example.org.")
+	if err != nil {
+		t.Fatalf("QuerySOASerial() error = %v", err)
+	}
+	if serial != 1760000000 {
+		t.Errorf("QuerySOASerial() = %d, want 1760000000", serial)
+	}
+
+	go serveSOA(t, listener, 0, dnsmessage.RCodeRefused)
+	_, err = QuerySOASerial(context.TODO(), listener.Addr().String(), "example.org.")
+	if !errors.Is(err, ErrZoneNotServed) {
+		t.Errorf("QuerySOASerial() error = %v, want %v", err, ErrZoneNotServed)
+	}
+}
+
+func TestQueryZoneSerials(t *testing.T) {
+	answering, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer answering.Close()
+	// the silent server accepts the queries but never answers them
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+	go func() {
+		conns := []net.Conn{}
+		for {
+			conn, err := silent.Accept()
+			if err != nil {
+				for _, c := range conns {
+					c.Close()
+				}
+				return
+			}
+			conns = append(conns, conn)
+		}
+	}()
+
+	go serveSOA(t, answering, 1760000000, dnsmessage.RCodeSuccess)
+	ctx, cancel := context.WithTimeout(context.TODO(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	serials, err := QueryZoneSerials(ctx, map[string]string{
+		"bind9-0": answering.Addr().String(),
+		"bind9-1": silent.Addr().String(),
+	}, []string{"example.org."})
+
+	if elapsed := time.Since(start); elapsed >= SOAQueryTimeout {
+		t.Errorf("expected the queries to stop at the deadline, took %s", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "bind9-1") {
+		t.Errorf("expected the silent server in the error, got %v", err)
+	}
+	if serials["bind9-0"]["example.org."] != 1760000000 {
+		t.Errorf("expected the serial of the answering server, got %v", serials)
+	}
+	if _, ok := serials["bind9-1"]; ok {
+		t.Errorf("expected no serial of the silent server, got %v", serials["bind9-1"])
+	}
+}
+
+func TestZoneSerialLag(t *testing.T) {
+	if lag := ZoneSerialLag(1760000300, 1760000000); lag != 300 {
+		t.Errorf("ZoneSerialLag() = %d, want 300", lag)
+	}
+	if lag := ZoneSerialLag(1760000000, 1760000300); lag != 0 {
+		t.Errorf("ZoneSerialLag() of a newer serial = %d, want 0", lag)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack/dns/v2/zones"
@@ -120,3 +121,129 @@ func GetZone(
 
 	return zone, nil
 }
+
+// ZoneOpts - the desired state of a zone
+type ZoneOpts struct {
+	Name        string
+	Type        string
+	Email       string
+	TTL         int
+	Description string
+	Masters     []string
+	Attributes  map[string]string
+}
+
+// FindZoneByName returns the zone with the given name, nil if it does not exist
+func FindZoneByName(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+	name string,
+) (*zones.Zone, error) {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	allPages, err := zones.List(dnsClient, zones.ListOpts{Name: name}).AllPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	zoneList, err := zones.ExtractZones(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract zones from response: %w", err)
+	}
+	for _, zone := range zoneList {
+		if zone.Name == name {
+			return &zone, nil
+		}
+	}
+	return nil, nil
+}
+
+// EnsureZone creates the zone if it does not exist yet, and updates its
+// mutable fields otherwise. The zone is looked up by zoneID when set, by
+// name otherwise so that a zone created before the status got persisted is
+// adopted instead of created twice.
+func EnsureZone(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+	zoneID string,
+	opts ZoneOpts,
+) (*zones.Zone, error) {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	var zone *zones.Zone
+	if zoneID != "" {
+		zone, err = GetZone(ctx, osclient, zoneID)
+		if err != nil && !errors.Is(err, ErrZoneNotFound) {
+			return nil, err
+		}
+	}
+	if zone == nil {
+		zone, err = FindZoneByName(ctx, osclient, opts.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if zone == nil {
+		createOpts := zones.CreateOpts{
+			Name:        opts.Name,
+			Type:        opts.Type,
+			Email:       opts.Email,
+			TTL:         opts.TTL,
+			Description: opts.Description,
+			Masters:     opts.Masters,
+			Attributes:  opts.Attributes,
+		}
+		zone, err = zones.Create(ctx, dnsClient, createOpts).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zone %s: %w", opts.Name, err)
+		}
+		return zone, nil
+	}
+
+	if zoneNeedsUpdate(zone, opts) {
+		updateOpts := zones.UpdateOpts{
+			Email:       opts.Email,
+			TTL:         opts.TTL,
+			Masters:     opts.Masters,
+			Description: &opts.Description,
+		}
+		zone, err = zones.Update(ctx, dnsClient, zone.ID, updateOpts).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("failed to update zone %s: %w", opts.Name, err)
+		}
+	}
+	return zone, nil
+}
+
+// zoneNeedsUpdate returns true if a mutable field of the zone differs from opts.
+// Unset fields of opts are left to the Designate defaults.
+func zoneNeedsUpdate(zone *zones.Zone, opts ZoneOpts) bool {
+	return (opts.Email != "" && zone.Email != opts.Email) ||
+		(opts.TTL != 0 && zone.TTL != opts.TTL) ||
+		zone.Description != opts.Description ||
+		(len(opts.Masters) > 0 && !slices.Equal(zone.Masters, opts.Masters))
+}
+
+// DeleteZone deletes the zone, a zone which does not exist is ignored
+func DeleteZone(
+	ctx context.Context,
+	osclient *openstack.OpenStack,
+	zoneID string,
+) error {
+	dnsClient, err := GetDNSClient(osclient)
+	if err != nil {
+		return fmt.Errorf("failed to get DNS client: %w", err)
+	}
+
+	_, err = zones.Delete(ctx, dnsClient, zoneID).Extract()
+	if err != nil && !gophercloud.ResponseCodeIs(err, 404) {
+		return fmt.Errorf("failed to delete zone %s: %w", zoneID, err)
+	}
+	return nil
+}
@@ -16,7 +16,35 @@ limitations under the License.
 // Package designateapi contains designate API constants and configuration.
 package designateapi
 
+import "github.com/openstack-k8s-operators/lib-common/modules/storage"
+
 const (
 	// Component -
 	Component = "designate-api"
+
+	// ReadOnlyComponent - component of the read-only API pods, they aren't selected by the API Services
+	ReadOnlyComponent = "designate-api-readonly"
+
+	// LegacyReadOnlyServiceName - name of the Service of the read-only API pods before it was named after
+	// its DesignateAPI
+	LegacyReadOnlyServiceName = "designate-readonly"
+
+	// ReadOnlyConfigFileName - name of the slave_connection config of the read-only API pods in the config
+	// of Designate
+	ReadOnlyConfigFileName = "readonly.conf"
+
+	// Propagation - the extra mounts of the DesignateAPI pods
+	Propagation storage.PropagationType = "DesignateAPI"
+
+	// PolicyFileName - name of the policy override in the config of the API
+	PolicyFileName = "policy.yaml"
+
+	// PolicyFilePath - path of the policy override in the API pods
+	PolicyFilePath = "/etc/designate/policy.yaml"
+
+	// WSGIProcesses - default number of the mod_wsgi daemon processes of each endpoint
+	WSGIProcesses = 5
+
+	// WSGIThreads - default number of threads of each mod_wsgi daemon process
+	WSGIThreads = 1
 )
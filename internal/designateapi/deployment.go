@@ -17,15 +17,15 @@ package designateapi
 
 import (
 	"fmt"
+	"slices"
 
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
-	common "github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +38,12 @@ const (
 	ServiceCommand = "/usr/local/bin/kolla_set_configs && /usr/local/bin/kolla_start"
 )
 
+// The httpd of the API starts as root and drops its privileges itself, the
+// capabilities let the configuration be copied for the apache user
+var securityProfile = designate.SecurityProfile{
+	Capabilities: []corev1.Capability{"CHOWN", "DAC_OVERRIDE", "FOWNER", "SETGID", "SETUID"},
+}
+
 // Deployment func
 func Deployment(
 	instance *designatev1beta1.DesignateAPI,
@@ -49,13 +55,18 @@ func Deployment(
 	runAsUser := int64(0)
 	serviceName := fmt.Sprintf("%s-api", designate.ServiceName)
 
-	// Includes a r/w /var/run/designate for the concurrency lock path
 	volumeDefs := append(designate.GetStandardVolumeMapping(instance),
-		designate.VolumeMapping{Name: instance.Name + "-run", Type: designate.MergeMount, MountPath: "/var/run/designate"})
+		designate.GetCustomServiceConfigSecretsVolumeMapping(instance.Spec.CustomServiceConfigSecrets)...)
 
 	volumes, initVolumeMounts := designate.ProcessVolumes(volumeDefs)
 
-	volumeMounts := append(initVolumeMounts, corev1.VolumeMount{
+	// A r/w /var/run/designate for the concurrency lock path, only the service uses it
+	runVolumes, runVolumeMounts := designate.ProcessVolumes([]designate.VolumeMapping{
+		{Name: instance.Name + "-run", Type: designate.MergeMount, MountPath: "/var/run/designate"},
+	})
+	volumes = append(volumes, runVolumes...)
+
+	volumeMounts := append(slices.Concat(initVolumeMounts, runVolumeMounts), corev1.VolumeMount{
 		Name:      designate.MergedVolumeName(instance.Name),
 		MountPath: "/var/lib/kolla/config_files/config.json",
 		SubPath:   serviceName + "-config.json",
@@ -63,13 +74,11 @@ func Deployment(
 	})
 
 	livenessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       13,
 		InitialDelaySeconds: 3,
 	}
 	readinessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       15,
 		InitialDelaySeconds: 5,
@@ -131,8 +140,12 @@ func Deployment(
 			Replicas: instance.Spec.Replicas,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Annotations: annotations,
-					Labels:      labels,
+					Annotations: designate.PodAnnotations(annotations, configHash),
+					// the service label is shared by the API pods of all
+					// instances, scope the pods for the NetworkPolicies
+					Labels: util.MergeStringMaps(labels, map[string]string{
+						designate.InstanceLabel: instance.Name,
+					}),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: instance.Spec.ServiceAccount,
@@ -150,7 +163,6 @@ func Deployment(
 							},
 							Env:            env.MergeEnvs([]corev1.EnvVar{}, envVars),
 							VolumeMounts:   volumeMounts,
-							Resources:      instance.Spec.Resources,
 							ReadinessProbe: readinessProbe,
 							LivenessProbe:  livenessProbe,
 						},
@@ -163,31 +175,75 @@ func Deployment(
 	if instance.Spec.NodeSelector != nil {
 		deployment.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
+	deployment.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
 
 	if topology != nil {
 		topology.ApplyTo(&deployment.Spec.Template)
 	} else {
 		// If possible two pods of the same service should not
 		// run on the same worker node. If this is not possible
-		// the get still created on the same worker node.
-		deployment.Spec.Template.Spec.Affinity = affinity.DistributePods(
-			common.AppSelector,
-			[]string{
-				serviceName,
+		// the get still created on the same worker node, unless
+		// a required anti-affinity is set.
+		designate.DistributePods(&deployment.Spec.Template, serviceName, instance.Spec.AntiAffinity, instance.Spec.TopologySpreadConstraints)
+	}
+	// the init container merges the config of the service, it needs nothing else
+	initContainerDetails := designate.InitContainerDetails{
+		ContainerImage: instance.Spec.ContainerImage,
+		Resources: designate.InitResources{
+			VolumeMounts: map[designate.InitCapability][]corev1.VolumeMount{
+				designate.NeedsServiceConfig: initVolumeMounts,
 			},
-			corev1.LabelHostname,
-		)
+		},
+		Needs: []designate.InitCapability{designate.NeedsServiceConfig},
 	}
-	initContainerDetails := designate.APIDetails{
-		ContainerImage:       instance.Spec.ContainerImage,
-		DatabaseHost:         instance.Spec.DatabaseHostname,
-		DatabaseName:         designate.DatabaseName,
-		OSPSecret:            instance.Spec.Secret,
-		TransportURLSecret:   instance.Spec.TransportURLSecret,
-		UserPasswordSelector: instance.Spec.PasswordSelectors.Service,
-		VolumeMounts:         initVolumeMounts,
+	deployment.Spec.Template.Spec.InitContainers = []corev1.Container{
+		designate.InitContainer(initContainerDetails),
 	}
-	deployment.Spec.Template.Spec.InitContainers = designate.InitContainer(initContainerDetails)
 
+	designate.ApplyProbeOverrides(&deployment.Spec.Template.Spec.Containers[0], instance.Spec.Probes)
+	err := designate.ApplyExtraMounts(&deployment.Spec.Template.Spec, instance.Spec.ExtraMounts, designate.Designate, Propagation)
+	if err != nil {
+		return nil, err
+	}
+	designate.ApplySecurityProfile(&deployment.Spec.Template.Spec, instance.Spec.SecurityProfile, securityProfile)
+	designate.ApplyImagePullOptions(&deployment.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+	designate.ApplyResources(&deployment.Spec.Template.Spec, instance.Spec.Resources, instance.Spec.InitResources, instance.Spec.SidecarResources)
+	designate.ApplyPodClasses(&deployment.Spec.Template.Spec, instance.Spec.PriorityClassName, instance.Spec.RuntimeClassName)
+	designate.ApplyServiceMesh(&deployment.Spec.Template, instance.Spec.ServiceMesh, nil)
+	designate.ApplyPodMetadata(&deployment.Spec.Template, instance.Spec.PodAnnotations, instance.Spec.PodLabels)
+
+	return deployment, nil
+}
+
+// ReadOnlyName returns the name of the Deployment and of the Service of the
+// read-only API replicas of instance
+func ReadOnlyName(instance *designatev1beta1.DesignateAPI) string {
+	return instance.Name + "-readonly"
+}
+
+// ReadOnlyDeployment returns the Deployment of the read-only API replicas of
+// instance, the API pods with the kolla config adding the slave_connection to
+// the read replica of the database. labels must not select the pods of the
+// API Services.
+func ReadOnlyDeployment(
+	instance *designatev1beta1.DesignateAPI,
+	configHash string,
+	labels map[string]string,
+	annotations map[string]string,
+	topology *topologyv1.Topology,
+) (*appsv1.Deployment, error) {
+	deployment, err := Deployment(instance, configHash, labels, annotations, topology)
+	if err != nil {
+		return nil, err
+	}
+	deployment.Name = ReadOnlyName(instance)
+	deployment.Spec.Replicas = instance.Spec.ReadOnly.Replicas
+
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	for i, mount := range container.VolumeMounts {
+		if mount.MountPath == "/var/lib/kolla/config_files/config.json" {
+			container.VolumeMounts[i].SubPath = ReadOnlyComponent + "-config.json"
+		}
+	}
 	return deployment, nil
 }
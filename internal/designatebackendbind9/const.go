@@ -16,7 +16,46 @@ limitations under the License.
 // Package designatebackendbind9 contains designate backend bind9 constants and configuration.
 package designatebackendbind9
 
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+)
+
 const (
 	// Component -
 	Component = "designate-backendbind9"
+
+	// Propagation - the extra mounts of the DesignateBackendbind9 pods
+	Propagation storage.PropagationType = "DesignateBackendbind9"
+
+	// StatisticsPort - port of the named statistics-channels, only reachable from within the pod
+	StatisticsPort = 8053
+
+	// MetricsPort - port of the bind_exporter sidecar
+	MetricsPort = 9119
+
+	// GeoIPDirectory - directory of the MaxMind databases in the bind9 pods
+	GeoIPDirectory = "/usr/share/GeoIP"
+
+	// DefaultDNSPort - port named listens on when not set in the spec
+	DefaultDNSPort = 53
+
+	// DefaultMdnsPort - port of the mdns servers when not set in the spec
+	DefaultMdnsPort = 5354
 )
+
+// DNSPort returns the port named answers the DNS queries on
+func DNSPort(spec *designatev1beta1.DesignateBackendbind9SpecBase) int32 {
+	if spec.DNSPort > 0 {
+		return spec.DNSPort
+	}
+	return DefaultDNSPort
+}
+
+// MdnsPort returns the port of the mdns servers named transfers the zones from
+func MdnsPort(spec *designatev1beta1.DesignateBackendbind9SpecBase) int32 {
+	if spec.MdnsPort > 0 {
+		return spec.MdnsPort
+	}
+	return DefaultMdnsPort
+}
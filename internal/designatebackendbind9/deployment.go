@@ -17,13 +17,13 @@ package designatebackendbind9
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	common "github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/backup"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
@@ -42,8 +42,24 @@ import (
 const (
 	// PVCSuffix is the suffix used for PVC names
 	PVCSuffix = "-designate-bind"
+
+	// LogPVCSuffix is the suffix used for the log PVC names
+	LogPVCSuffix = "-designate-bind-log"
+
+	// healthCheckScript is the probe script of the bind9 container
+	healthCheckScript = "/usr/local/bin/container-scripts/healthcheck.sh"
+
+	// stopMarginSeconds is the part of the termination grace period kept
+	// for "rndc stop" once the zone transfers are drained
+	stopMarginSeconds = 15
 )
 
+// named starts as root and drops its privileges itself, the capabilities let
+// the configuration be copied for the named user
+var securityProfile = designate.SecurityProfile{
+	Capabilities: []corev1.Capability{"CHOWN", "DAC_OVERRIDE", "FOWNER", "NET_BIND_SERVICE", "SETGID", "SETUID"},
+}
+
 // StatefulSet creates a StatefulSet for the designate backend bind9 service
 func StatefulSet(
 	instance *designatev1beta1.DesignateBackendbind9,
@@ -59,27 +75,22 @@ func StatefulSet(
 	// directories require serious care.
 
 	livenessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       13,
 		InitialDelaySeconds: 15,
 	}
 	readinessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       13,
 		InitialDelaySeconds: 10,
 	}
 
-	// TODO(beagles): implement an rndc shutdown command to bring the pod down gracefully!
-
-	// Check for the rndc port.
-	livenessProbe.TCPSocket = &corev1.TCPSocketAction{
-		Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(953)},
-	}
-	readinessProbe.TCPSocket = &corev1.TCPSocketAction{
-		Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(953)},
+	// Check named answers rndc status and a SOA query, a listening but broken
+	// named gets restarted.
+	livenessProbe.Exec = &corev1.ExecAction{
+		Command: []string{healthCheckScript},
 	}
+	readinessProbe.Exec = livenessProbe.Exec
 
 	// Parse the storageRequest defined in the CR
 	storageRequest, err := resource.ParseQuantity(instance.Spec.StorageRequest)
@@ -91,6 +102,16 @@ func StatefulSet(
 	envVars["KOLLA_CONFIG_STRATEGY"] = env.SetValue("COPY_ALWAYS")
 	envVars["CONFIG_HASH"] = env.SetValue(configHash)
 
+	// The preStop hook waits for the running zone transfers before stopping
+	// named, leaving it enough time to stop before the pod gets killed.
+	xferDrainTimeout := int64(0)
+	if instance.Spec.TerminationGracePeriodSeconds != nil && *instance.Spec.TerminationGracePeriodSeconds > stopMarginSeconds {
+		xferDrainTimeout = *instance.Spec.TerminationGracePeriodSeconds - stopMarginSeconds
+	}
+	envVars["XFER_DRAIN_TIMEOUT"] = env.SetValue(strconv.FormatInt(xferDrainTimeout, 10))
+	// the probes query named on its DNS port
+	envVars["DNS_PORT"] = env.SetValue(strconv.Itoa(int(DNSPort(&instance.Spec.DesignateBackendbind9SpecBase))))
+
 	// Determine if TSIG is needed based on StatefulSet name
 	// Only non-default pools (pool1, pool2, etc.) need TSIG, not pool0 (default pool)
 	var tsigSecretName string
@@ -100,11 +121,45 @@ func StatefulSet(
 		// Note: All pools share the same TSIG secret (instance.Name + "-tsig")
 		tsigSecretName = instance.Name + designate.TsigSecretSuffix
 		includeTSIG = true
+	} else if instance.Spec.ControlTSIG {
+		// The default pool servers get a TSIG key scoped to the default pool
+		tsigSecretName = instance.Name + designate.ControlTSIGSecretSuffix
+		includeTSIG = true
 	}
 
 	// Use instance.Name for secret references (shared across pools in multipool mode)
 	serviceVolumes := getServicePodVolumes(instance.Name, bindIPConfigMapName, tsigSecretName)
 
+	// The init container adds the response policy zone to the named configuration
+	initResources := getInitResources(includeTSIG)
+	// the addresses on the networks other than the control network have a
+	// map per network
+	netIPVolumes, netIPMounts := designate.PredictableIPNetworkVolumes(bindIPConfigMapName, instance.Spec.PredictableIPNetworks)
+	serviceVolumes = append(serviceVolumes, netIPVolumes...)
+	initResources.VolumeMounts[designate.NeedsPredictableIPs] = append(
+		initResources.VolumeMounts[designate.NeedsPredictableIPs], netIPMounts...)
+	if rpz := instance.Spec.ResponsePolicyZone; rpz != nil {
+		serviceVolumes = append(serviceVolumes, getResponsePolicyZoneVolume(rpz.ConfigMapName, rpz.Key))
+		initResources.VolumeMounts[designate.NeedsServiceConfig] = append(
+			initResources.VolumeMounts[designate.NeedsServiceConfig], getResponsePolicyZoneVolumeMount())
+	}
+
+	// The log files are kept on a volume claim of the pod rather than the
+	// emptyDir when the log volume is persistent
+	logData := logVolume
+	persistentLogs := instance.Spec.LogTarget == "file" && instance.Spec.LogVolume != nil
+	if persistentLogs {
+		logData = instance.Name + LogPVCSuffix
+	}
+
+	// The operator deletes the outdated pods itself with the orchestrated update
+	updateStrategy := designate.StatefulSetUpdateStrategy(instance.Spec.UpdateStrategy)
+	if instance.Spec.OrchestratedUpdate {
+		updateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.OnDeleteStatefulSetStrategyType,
+		}
+	}
+
 	serviceName := fmt.Sprintf("%s-backendbind9", designate.ServiceName)
 	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -116,24 +171,32 @@ func StatefulSet(
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
-			Replicas: instance.Spec.Replicas,
+			Replicas:       instance.Spec.Replicas,
+			UpdateStrategy: updateStrategy,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Annotations: annotations,
+					Annotations: designate.PodAnnotations(annotations, configHash),
 					Labels:      labels,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: instance.Spec.ServiceAccount,
-					Volumes:            serviceVolumes,
+					ServiceAccountName:            instance.Spec.ServiceAccount,
+					Volumes:                       serviceVolumes,
+					TerminationGracePeriodSeconds: instance.Spec.TerminationGracePeriodSeconds,
 					Containers: []corev1.Container{
 						{
 							Name:           serviceName,
 							Image:          instance.Spec.ContainerImage,
 							Env:            env.MergeEnvs([]corev1.EnvVar{}, envVars),
-							VolumeMounts:   getServicePodVolumeMounts(instance.Name + PVCSuffix),
-							Resources:      instance.Spec.Resources,
+							VolumeMounts:   getServicePodVolumeMounts(instance.Name+PVCSuffix, logData),
 							LivenessProbe:  livenessProbe,
 							ReadinessProbe: readinessProbe,
+							Lifecycle: &corev1.Lifecycle{
+								PreStop: &corev1.LifecycleHandler{
+									Exec: &corev1.ExecAction{
+										Command: []string{"/usr/local/bin/container-scripts/stop.sh"},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -141,10 +204,8 @@ func StatefulSet(
 		},
 	}
 
-	statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
-		WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
-		WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
-	}
+	statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = designate.PVCRetentionPolicy(
+		instance.Spec.PersistentVolumeClaimRetentionPolicy, false)
 
 	statefulSet.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
 		{
@@ -171,58 +232,152 @@ func StatefulSet(
 		},
 	}
 
+	if geoIP := instance.Spec.GeoIP; geoIP != nil {
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, getGeoIPVolume(geoIP.ClaimName))
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(
+			statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, getGeoIPVolumeMount())
+	}
+
+	if persistentLogs {
+		logStorageRequest, err := resource.ParseQuantity(instance.Spec.LogVolume.StorageRequest)
+		if err != nil {
+			return nil, err
+		}
+		logClaim := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      logData,
+				Namespace: instance.Namespace,
+				Labels:    labels,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteOnce,
+				},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: logStorageRequest,
+					},
+				},
+			},
+		}
+		if instance.Spec.LogVolume.StorageClass != "" {
+			logClaim.Spec.StorageClassName = &instance.Spec.LogVolume.StorageClass
+		}
+		statefulSet.Spec.VolumeClaimTemplates = append(statefulSet.Spec.VolumeClaimTemplates, logClaim)
+	}
+
+	if instance.Spec.Metrics.Enabled {
+		statefulSet.Spec.Template.Spec.Containers = append(
+			statefulSet.Spec.Template.Spec.Containers,
+			exporterContainer(instance),
+		)
+	}
+
 	if instance.Spec.NodeSelector != nil {
 		statefulSet.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
+	statefulSet.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
 
 	if topology != nil {
 		topology.ApplyTo(&statefulSet.Spec.Template)
 	} else {
 		// If possible two pods of the same service should not
 		// run on the same worker node. If this is not possible
-		// the get still created on the same worker node.
-		statefulSet.Spec.Template.Spec.Affinity = affinity.DistributePods(
-			common.AppSelector,
-			[]string{
-				serviceName,
-			},
-			corev1.LabelHostname,
-		)
+		// the get still created on the same worker node, unless
+		// a required anti-affinity is set.
+		designate.DistributePods(&statefulSet.Spec.Template, serviceName, instance.Spec.AntiAffinity, instance.Spec.TopologySpreadConstraints)
+	}
+	// bind's init container doesn't use rabbitmq, redis or access the database, it only renders the named
+	// configuration with the rndc key of the pod
+	configEnv := map[string]env.Setter{
+		"CustomConf": env.SetValue(common.CustomServiceConfigFileName),
+	}
+	if instance.Spec.CatalogZone != nil {
+		// init.sh renders the catalog zone of the pool of the StatefulSet
+		configEnv["CATALOG_ZONE"] = env.SetValue(instance.Spec.CatalogZone.FQDN)
+	}
+	predIPEnv := designate.PredictableIPNetworkEnv(instance.Spec.ControlNetworkName, instance.Spec.PredictableIPNetworks)
+	predIPEnv["MAP_PREFIX"] = env.SetValue("bind_address_")
+	initResources.EnvVars = map[designate.InitCapability]map[string]env.Setter{
+		designate.NeedsServiceConfig:  configEnv,
+		designate.NeedsPodName:        {"POD_NAME": env.DownwardAPI("metadata.name")},
+		designate.NeedsPredictableIPs: predIPEnv,
+		designate.NeedsRndc:           {"RNDC_PREFIX": env.SetValue(designate.DesignateRndcKey)},
 	}
-	// If possible two pods of the same service should not run on the same worker node. If this is not possible they
-	// will be scheduled on the same node. Where the bind servers are stateful, it's best to have them all available
-	// even if they are on the same host.
-	statefulSet.Spec.Template.Spec.Affinity = affinity.DistributePods(
-		common.AppSelector,
-		[]string{
-			serviceName,
-		},
-		corev1.LabelHostname,
-	)
-	// TODO: bind's init container doesn't need most of this stuff. It doesn't use rabbitmq, redis or access the
-	// database. Should clean this up!
-	envVars = map[string]env.Setter{}
-	envVars["POD_NAME"] = env.DownwardAPI("metadata.name")
-	envVars["CustomConf"] = env.SetValue(common.CustomServiceConfigFileName)
-	envVars["MAP_PREFIX"] = env.SetValue("bind_address_")
-	envVars["RNDC_PREFIX"] = env.SetValue(designate.DesignateRndcKey)
-	env := env.MergeEnvs([]corev1.EnvVar{}, envVars)
 	initContainerDetails := designate.InitContainerDetails{
 		ContainerImage: instance.Spec.ContainerImage,
-		VolumeMounts:   getInitVolumeMounts(includeTSIG),
-		EnvVars:        env,
+		Resources:      initResources,
+		Needs: []designate.InitCapability{
+			designate.NeedsServiceConfig,
+			designate.NeedsPodName,
+			designate.NeedsPredictableIPs,
+			designate.NeedsRndc,
+		},
 	}
 	predIPContainerDetails := designate.PredIPContainerDetails{
 		ContainerImage: instance.Spec.NetUtilsImage,
-		VolumeMounts:   getPredIPVolumeMounts(),
-		EnvVars:        env,
+		Resources:      initResources,
 		Command:        designate.PredictableIPCommand,
 	}
 
 	statefulSet.Spec.Template.Spec.InitContainers = []corev1.Container{
-		designate.SimpleInitContainer(initContainerDetails),
-		designate.PredictableIPContainer(predIPContainerDetails),
+		designate.InitContainer(initContainerDetails),
+	}
+	if instance.Spec.HostNetwork {
+		// named listens on the addresses of the node, the init container
+		// still reads the rndc key of the pod from the predictable IP map
+		designate.ApplyHostNetwork(&statefulSet.Spec.Template.Spec, append(
+			designate.DNSContainerPorts(DNSPort(&instance.Spec.DesignateBackendbind9SpecBase)),
+			corev1.ContainerPort{
+				Name:          "rndc",
+				ContainerPort: designate.RNDCPort,
+				Protocol:      corev1.ProtocolTCP,
+			}))
+	} else if !instance.Spec.PodNetwork {
+		statefulSet.Spec.Template.Spec.InitContainers = append(statefulSet.Spec.Template.Spec.InitContainers,
+			designate.PredictableIPContainer(predIPContainerDetails))
 	}
 
+	designate.ApplyProbeOverrides(&statefulSet.Spec.Template.Spec.Containers[0], instance.Spec.Probes)
+	err = designate.ApplyExtraMounts(&statefulSet.Spec.Template.Spec, instance.Spec.ExtraMounts, designate.Designate, Propagation)
+	if err != nil {
+		return nil, err
+	}
+	designate.ApplySecurityProfile(&statefulSet.Spec.Template.Spec, instance.Spec.SecurityProfile, securityProfile)
+	designate.ApplyImagePullOptions(&statefulSet.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+	designate.ApplyResources(&statefulSet.Spec.Template.Spec, instance.Spec.Resources, instance.Spec.InitResources, instance.Spec.SidecarResources)
+	designate.ApplyPodClasses(&statefulSet.Spec.Template.Spec, instance.Spec.PriorityClassName, instance.Spec.RuntimeClassName)
+	designate.ApplyServiceMesh(&statefulSet.Spec.Template, instance.Spec.ServiceMesh, designate.DNSContainerPorts(DNSPort(&instance.Spec.DesignateBackendbind9SpecBase)))
+	designate.ApplyPodMetadata(&statefulSet.Spec.Template, instance.Spec.PodAnnotations, instance.Spec.PodLabels)
+
 	return statefulSet, nil
 }
+
+// exporterContainer returns the bind_exporter sidecar publishing the named
+// statistics as Prometheus metrics
+func exporterContainer(instance *designatev1beta1.DesignateBackendbind9) corev1.Container {
+	return corev1.Container{
+		Name:  fmt.Sprintf("%s-backendbind9-exporter", designate.ServiceName),
+		Image: instance.Spec.Metrics.ExporterImage,
+		Args: []string{
+			fmt.Sprintf("--bind.stats-url=http://127.0.0.1:%d/", StatisticsPort),
+			"--bind.stats-groups=server,view,tasks",
+			fmt.Sprintf("--web.listen-address=:%d", MetricsPort),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          designate.MetricsPortName,
+				ContainerPort: MetricsPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt32(MetricsPort),
+				},
+			},
+			PeriodSeconds: 30,
+		},
+	}
+}
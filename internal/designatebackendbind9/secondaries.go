@@ -0,0 +1,58 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designatebackendbind9
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+// AllowTransferACL returns the address match list of the allow-transfer
+// option of named admitting the secondary servers. A server with a TSIG key
+// is matched by its key, the others by their address.
+func AllowTransferACL(servers []designatev1beta1.Bind9SecondaryServerSpec) []string {
+	var acl []string
+	for _, server := range servers {
+		element := server.Host
+		if server.HasTSIGKey() {
+			element = fmt.Sprintf("key %q", server.GetTSIGKeyName())
+		}
+		if !slices.Contains(acl, element) {
+			acl = append(acl, element)
+		}
+	}
+	return acl
+}
+
+// SecondaryTSIGConfig returns the key statements of the TSIG keys of the
+// secondary servers, secrets holds the secret of the key of each server by
+// server name. A key shared by several servers is defined once.
+func SecondaryTSIGConfig(servers []designatev1beta1.Bind9SecondaryServerSpec, secrets map[string]string) string {
+	var config strings.Builder
+	defined := map[string]bool{}
+	for _, server := range servers {
+		if !server.HasTSIGKey() || defined[server.GetTSIGKeyName()] {
+			continue
+		}
+		defined[server.GetTSIGKeyName()] = true
+		fmt.Fprintf(&config, "key %q {\n    algorithm %s;\n    secret %q;\n};\n",
+			server.GetTSIGKeyName(), server.TSIGKeyAlgorithm, secrets[server.Name])
+	}
+	return config.String()
+}
@@ -30,6 +30,8 @@ const (
 	rndcKeys           = "designatebackendbind9-keys"
 	bindIPs            = "designate-bind-ips"
 	tsigKeys           = "designatebackendbind9-tsig"
+	rpzZone            = "designatebackendbind9-rpz"
+	geoIPVolume        = "designatebackendbind9-geoip"
 )
 
 // NOTE(beagles): I vacillated on using designate.GetVolumes() here and appending the extra entries and may still. There
@@ -118,12 +120,66 @@ func getServicePodVolumes(baseConfigMapName string, bindIPConfigMapName string,
 	return volumes
 }
 
+// getResponsePolicyZoneVolume - the zone file of the response policy zone is provided by the user in a ConfigMap
+func getResponsePolicyZoneVolume(configMapName string, key string) corev1.Volume {
+	var configMode int32 = 0640
+	return corev1.Volume{
+		Name: rpzZone,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				DefaultMode: &configMode,
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMapName,
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  key,
+						Path: "db.rpz",
+					},
+				},
+			},
+		},
+	}
+}
+
+// getResponsePolicyZoneVolumeMount - only the init container mounts the zone file, it is copied to the merged config
+func getResponsePolicyZoneVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      rpzZone,
+		MountPath: "/var/lib/rpz",
+		ReadOnly:  true,
+	}
+}
+
+// getGeoIPVolume - the MaxMind databases are provided by the user on a volume claim shared by the bind9 pods
+func getGeoIPVolume(claimName string) corev1.Volume {
+	return corev1.Volume{
+		Name: geoIPVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// getGeoIPVolumeMount - named reads the databases from the geoip-directory
+func getGeoIPVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      geoIPVolume,
+		MountPath: GeoIPDirectory,
+		ReadOnly:  true,
+	}
+}
+
 // TODO(beagles): we follow the old TripleO/kolla naming of these mounts, but do they really make sense here?
 
-// getInitVolumesMounts - the init container will use the scripts mounted in the scriptVolume and create completed named
-// configuration from the files in configVolume. The modified files will be stored in the mergedConfigVolume
-func getInitVolumeMounts(includeTSIG bool) []corev1.VolumeMount {
-	mounts := []corev1.VolumeMount{
+// getInitResources - the init containers use the scripts mounted in the scriptVolume. The init container creates
+// completed named configuration from the files in configVolume and the rndc key of the pod, the modified files are
+// stored in the mergedConfigVolume. The predictable IP container only gets the IPs of the pods.
+func getInitResources(includeTSIG bool) designate.InitResources {
+	configMounts := []corev1.VolumeMount{
 		{
 			Name:      configVolume,
 			MountPath: "/var/lib/config-data/default",
@@ -139,25 +195,10 @@ func getInitVolumeMounts(includeTSIG bool) []corev1.VolumeMount {
 			MountPath: "/var/lib/config-data/merged",
 			ReadOnly:  false,
 		},
-		{
-			Name:      scriptVolume,
-			MountPath: "/usr/local/bin/container-scripts",
-			ReadOnly:  true,
-		},
-		{
-			Name:      rndcKeys,
-			MountPath: "/var/lib/config-data/keys",
-			ReadOnly:  true,
-		},
-		{
-			Name:      bindIPs,
-			MountPath: "/var/lib/predictableips",
-			ReadOnly:  true,
-		},
 	}
 
 	if includeTSIG {
-		mounts = append(mounts, corev1.VolumeMount{
+		configMounts = append(configMounts, corev1.VolumeMount{
 			Name:      tsigKeys,
 			MountPath: "/var/lib/tsig/tsigkeys.conf",
 			SubPath:   "tsigkeys.conf",
@@ -165,26 +206,38 @@ func getInitVolumeMounts(includeTSIG bool) []corev1.VolumeMount {
 		})
 	}
 
-	return mounts
-}
-
-func getPredIPVolumeMounts() []corev1.VolumeMount {
-	return []corev1.VolumeMount{
-		{
-			Name:      scriptVolume,
-			MountPath: "/usr/local/bin/container-scripts",
-			ReadOnly:  true,
+	return designate.InitResources{
+		Scripts: []corev1.VolumeMount{
+			{
+				Name:      scriptVolume,
+				MountPath: "/usr/local/bin/container-scripts",
+				ReadOnly:  true,
+			},
 		},
-		{
-			Name:      bindIPs,
-			MountPath: "/var/lib/predictableips",
+		VolumeMounts: map[designate.InitCapability][]corev1.VolumeMount{
+			designate.NeedsServiceConfig: configMounts,
+			designate.NeedsRndc: {
+				{
+					Name:      rndcKeys,
+					MountPath: "/var/lib/config-data/keys",
+					ReadOnly:  true,
+				},
+			},
+			// in multipool mode the rndc key of each pod is listed in the predictable IPs ConfigMap
+			designate.NeedsPredictableIPs: {
+				{
+					Name:      bindIPs,
+					MountPath: "/var/lib/predictableips",
+					ReadOnly:  true,
+				},
+			},
 		},
 	}
 }
 
-func getServicePodVolumeMounts(persistentData string) []corev1.VolumeMount {
+func getServicePodVolumeMounts(persistentData string, logData string) []corev1.VolumeMount {
 	// Note: TSIG secret volume is defined in getServicePodVolumes(), but only the init container
-	// mounts the actual file via getInitVolumeMounts(). Init container copies it to merged config.
+	// mounts the actual file via getInitResources(). Init container copies it to merged config.
 	return []corev1.VolumeMount{
 		{
 			Name:      mergedConfigVolume,
@@ -208,7 +261,7 @@ func getServicePodVolumeMounts(persistentData string) []corev1.VolumeMount {
 			ReadOnly:  false,
 		},
 		{
-			Name:      logVolume,
+			Name:      logData,
 			MountPath: "/var/log/bind",
 			ReadOnly:  false,
 		},
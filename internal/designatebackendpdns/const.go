@@ -0,0 +1,33 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package designatebackendpdns contains designate backend PowerDNS constants and configuration.
+package designatebackendpdns
+
+import "github.com/openstack-k8s-operators/lib-common/modules/storage"
+
+const (
+	// Component -
+	Component = "designate-backendpdns"
+
+	// Propagation - the extra mounts of the DesignateBackendPDNS pods
+	Propagation storage.PropagationType = "DesignateBackendPDNS"
+
+	// PVCSuffix is the suffix used for PVC names
+	PVCSuffix = "-designate-pdns"
+
+	// PDNSUser is the uid of the pdns user of the PowerDNS image
+	PDNSUser int64 = 953
+)
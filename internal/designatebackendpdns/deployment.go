@@ -0,0 +1,211 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designatebackendpdns
+
+import (
+	"fmt"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/backup"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// pdns_server only writes its control socket out of its volumes
+var securityProfile = designate.SecurityProfile{
+	User:         ptr.To(PDNSUser),
+	Capabilities: []corev1.Capability{"NET_BIND_SERVICE"},
+	RunDir:       "/run/pdns",
+}
+
+// StatefulSet creates a StatefulSet for the designate backend PowerDNS service
+func StatefulSet(
+	instance *designatev1beta1.DesignateBackendPDNS,
+	configHash string,
+	labels map[string]string,
+	annotations map[string]string,
+	topology *topologyv1.Topology,
+) (*appsv1.StatefulSet, error) {
+	livenessProbe := &corev1.Probe{
+		TimeoutSeconds:      15,
+		PeriodSeconds:       13,
+		InitialDelaySeconds: 15,
+	}
+	readinessProbe := &corev1.Probe{
+		TimeoutSeconds:      15,
+		PeriodSeconds:       13,
+		InitialDelaySeconds: 10,
+	}
+
+	// Check for the API port, designate-worker manages the zones through it.
+	livenessProbe.TCPSocket = &corev1.TCPSocketAction{
+		Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(designate.PDNSAPIPort)},
+	}
+	readinessProbe.TCPSocket = &corev1.TCPSocketAction{
+		Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(designate.PDNSAPIPort)},
+	}
+
+	// Parse the storageRequest defined in the CR
+	storageRequest, err := resource.ParseQuantity(instance.Spec.StorageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars := map[string]env.Setter{}
+	envVars["CONFIG_HASH"] = env.SetValue(configHash)
+
+	persistentData := instance.Name + PVCSuffix
+	serviceName := fmt.Sprintf("%s-backendpdns", designate.ServiceName)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Replicas: instance.Spec.Replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: designate.PodAnnotations(annotations, configHash),
+					Labels:      labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: instance.Spec.ServiceAccount,
+					Volumes:            getServicePodVolumes(instance.Name, instance.Spec.APIKeySecret),
+					SecurityContext: &corev1.PodSecurityContext{
+						FSGroup: ptr.To(PDNSUser),
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  serviceName,
+							Image: instance.Spec.ContainerImage,
+							Command: []string{
+								"pdns_server",
+							},
+							Args: []string{
+								"--config-dir=/var/lib/config-data/merged",
+								"--daemon=no",
+								"--guardian=no",
+								"--disable-syslog",
+								"--write-pid=no",
+							},
+							Env:          env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							VolumeMounts: getServicePodVolumeMounts(persistentData),
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: ptr.To(PDNSUser),
+								Capabilities: &corev1.Capabilities{
+									Add: []corev1.Capability{"NET_BIND_SERVICE"},
+								},
+							},
+							LivenessProbe:  livenessProbe,
+							ReadinessProbe: readinessProbe,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = designate.PVCRetentionPolicy(
+		instance.Spec.PersistentVolumeClaimRetentionPolicy, false)
+
+	statefulSet.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      persistentData,
+				Namespace: instance.Namespace,
+				Labels: util.MergeMaps(
+					labels,
+					backup.GetBackupLabels(backup.CategoryControlPlane),
+					backup.GetRestoreLabels(backup.RestoreOrder00, backup.CategoryControlPlane),
+				),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteOnce,
+				},
+				StorageClassName: &instance.Spec.StorageClass,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: storageRequest,
+					},
+				},
+			},
+		},
+	}
+
+	if instance.Spec.NodeSelector != nil {
+		statefulSet.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
+	}
+	statefulSet.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
+
+	if topology != nil {
+		topology.ApplyTo(&statefulSet.Spec.Template)
+	} else {
+		// If possible two pods of the same service should not
+		// run on the same worker node. If this is not possible
+		// the get still created on the same worker node, unless
+		// a required anti-affinity is set.
+		designate.DistributePods(&statefulSet.Spec.Template, serviceName, instance.Spec.AntiAffinity, instance.Spec.TopologySpreadConstraints)
+	}
+
+	initResources := getInitResources(persistentData)
+	initContainerDetails := designate.InitContainerDetails{
+		ContainerImage: instance.Spec.ContainerImage,
+		Resources:      initResources,
+		Needs: []designate.InitCapability{
+			designate.NeedsServiceConfig,
+			designate.NeedsAPIKey,
+			designate.NeedsData,
+		},
+	}
+	predIPContainerDetails := designate.PredIPContainerDetails{
+		ContainerImage: instance.Spec.NetUtilsImage,
+		Resources:      initResources,
+		Command:        designate.PredictableIPCommand,
+	}
+
+	statefulSet.Spec.Template.Spec.InitContainers = []corev1.Container{
+		designate.InitContainer(initContainerDetails),
+		designate.PredictableIPContainer(predIPContainerDetails),
+	}
+
+	designate.ApplyProbeOverrides(&statefulSet.Spec.Template.Spec.Containers[0], instance.Spec.Probes)
+	err = designate.ApplyExtraMounts(&statefulSet.Spec.Template.Spec, instance.Spec.ExtraMounts, designate.Designate, Propagation)
+	if err != nil {
+		return nil, err
+	}
+	designate.ApplySecurityProfile(&statefulSet.Spec.Template.Spec, instance.Spec.SecurityProfile, securityProfile)
+	designate.ApplyImagePullOptions(&statefulSet.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+	designate.ApplyResources(&statefulSet.Spec.Template.Spec, instance.Spec.Resources, instance.Spec.InitResources, instance.Spec.SidecarResources)
+	designate.ApplyPodClasses(&statefulSet.Spec.Template.Spec, instance.Spec.PriorityClassName, instance.Spec.RuntimeClassName)
+	designate.ApplyServiceMesh(&statefulSet.Spec.Template, instance.Spec.ServiceMesh, designate.DNSContainerPorts(designate.DNSPort))
+	designate.ApplyPodMetadata(&statefulSet.Spec.Template, instance.Spec.PodAnnotations, instance.Spec.PodLabels)
+
+	return statefulSet, nil
+}
@@ -0,0 +1,152 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designatebackendpdns
+
+import (
+	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	scriptVolume       = "designatebackendpdns-scripts"
+	configVolume       = "designatebackendpdns-config-data"
+	mergedConfigVolume = "designatebackendpdns-config-data-merged"
+	apiKeyVolume       = "designatebackendpdns-api-key"
+	pdnsIPs            = "designate-pdns-ips"
+)
+
+func getServicePodVolumes(baseConfigMapName string, apiKeySecretName string) []corev1.Volume {
+	var scriptMode int32 = 0755
+	var configMode int32 = 0640
+	return []corev1.Volume{
+		{
+			Name: scriptVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &scriptMode,
+					SecretName:  baseConfigMapName + "-scripts",
+				},
+			},
+		},
+		{
+			Name: configVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &configMode,
+					SecretName:  baseConfigMapName + "-config-data",
+				},
+			},
+		},
+		{
+			Name: mergedConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: ""},
+			},
+		},
+		{
+			Name: apiKeyVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &configMode,
+					SecretName:  apiKeySecretName,
+				},
+			},
+		},
+		{
+			Name: pdnsIPs,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &configMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: designate.PDNSPredIPConfigMap,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getInitResources - the init container renders the final pdns.conf from the files in configVolume and the API
+// key into the mergedConfigVolume and creates the zone database on the persistent volume. The predictable IP
+// container only gets the IPs of the pods.
+func getInitResources(persistentData string) designate.InitResources {
+	return designate.InitResources{
+		Scripts: []corev1.VolumeMount{
+			{
+				Name:      scriptVolume,
+				MountPath: "/usr/local/bin/container-scripts",
+				ReadOnly:  true,
+			},
+		},
+		VolumeMounts: map[designate.InitCapability][]corev1.VolumeMount{
+			designate.NeedsServiceConfig: {
+				{
+					Name:      configVolume,
+					MountPath: "/var/lib/config-data/default",
+					ReadOnly:  true,
+				},
+				{
+					Name:      mergedConfigVolume,
+					MountPath: "/var/lib/config-data/merged",
+					ReadOnly:  false,
+				},
+			},
+			designate.NeedsAPIKey: {
+				{
+					Name:      apiKeyVolume,
+					MountPath: "/var/lib/config-data/api-key",
+					ReadOnly:  true,
+				},
+			},
+			designate.NeedsData: {
+				{
+					Name:      persistentData,
+					MountPath: "/var/lib/powerdns",
+					ReadOnly:  false,
+				},
+			},
+			designate.NeedsPredictableIPs: {
+				{
+					Name:      pdnsIPs,
+					MountPath: "/var/lib/predictableips",
+				},
+			},
+		},
+		EnvVars: map[designate.InitCapability]map[string]env.Setter{
+			designate.NeedsServiceConfig:  {"CustomConf": env.SetValue(common.CustomServiceConfigFileName)},
+			designate.NeedsAPIKey:         {"API_KEY_NAME": env.SetValue(designate.PDNSAPIKeySecretKey)},
+			designate.NeedsPodName:        {"POD_NAME": env.DownwardAPI("metadata.name")},
+			designate.NeedsPredictableIPs: {"MAP_PREFIX": env.SetValue("pdns_address_")},
+		},
+	}
+}
+
+func getServicePodVolumeMounts(persistentData string) []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      mergedConfigVolume,
+			MountPath: "/var/lib/config-data/merged",
+			ReadOnly:  true,
+		},
+		{
+			Name:      persistentData,
+			MountPath: "/var/lib/powerdns",
+			ReadOnly:  false,
+		},
+	}
+}
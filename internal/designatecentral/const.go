@@ -16,7 +16,12 @@ limitations under the License.
 // Package designatecentral contains designate central constants and configuration.
 package designatecentral
 
+import "github.com/openstack-k8s-operators/lib-common/modules/storage"
+
 const (
 	// Component -
 	Component = "designate-central"
+
+	// Propagation - the extra mounts of the DesignateCentral pods
+	Propagation storage.PropagationType = "DesignateCentral"
 )
@@ -21,15 +21,19 @@ import (
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
-	common "github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
+// The service runs as the designate user with the restricted profile
+var securityProfile = designate.SecurityProfile{
+	User: ptr.To(designate.DesignateUID),
+}
+
 // Deployment func
 func Deployment(
 	instance *designatev1beta1.DesignateCentral,
@@ -37,13 +41,17 @@ func Deployment(
 	labels map[string]string,
 	annotations map[string]string,
 	topology *topologyv1.Topology,
-) *appsv1.Deployment {
+) (*appsv1.Deployment, error) {
 	rootUser := int64(0)
 	serviceName := fmt.Sprintf("%s-central", designate.ServiceName)
 
 	// Includes a r/w /var/run/designate for the concurrency lock path
 	volumeDefs := append(designate.GetStandardVolumeMapping(instance),
 		designate.VolumeMapping{Name: instance.Name + "-run", Type: designate.MergeMount, MountPath: "/var/run/designate"})
+	// The client certificates of the coordination backend
+	volumeDefs = append(volumeDefs,
+		designate.VolumeMapping{Name: designate.DesignateCoordinationSecret, Type: designate.SecretMount, MountPath: designate.CoordinationCertDir})
+	volumeDefs = append(volumeDefs, designate.GetCustomServiceConfigSecretsVolumeMapping(instance.Spec.CustomServiceConfigSecrets)...)
 
 	volumes, initVolumeMounts := designate.ProcessVolumes(volumeDefs)
 
@@ -55,13 +63,11 @@ func Deployment(
 	})
 
 	livenessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      10,
 		PeriodSeconds:       15,
 		InitialDelaySeconds: 5,
 	}
 	startupProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      10,
 		PeriodSeconds:       15,
 		InitialDelaySeconds: 5,
@@ -96,7 +102,7 @@ func Deployment(
 			Replicas: instance.Spec.Replicas,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Annotations: annotations,
+					Annotations: designate.PodAnnotations(annotations, configHash),
 					Labels:      labels,
 				},
 				Spec: corev1.PodSpec{
@@ -111,7 +117,6 @@ func Deployment(
 							},
 							Env:           env.MergeEnvs([]corev1.EnvVar{}, envVars),
 							VolumeMounts:  volumeMounts,
-							Resources:     instance.Spec.Resources,
 							StartupProbe:  startupProbe,
 							LivenessProbe: livenessProbe,
 						},
@@ -124,31 +129,42 @@ func Deployment(
 	if instance.Spec.NodeSelector != nil {
 		deployment.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
+	deployment.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
 
 	if topology != nil {
 		topology.ApplyTo(&deployment.Spec.Template)
 	} else {
 		// If possible two pods of the same service should not
 		// run on the same worker node. If this is not possible
-		// the get still created on the same worker node.
-		deployment.Spec.Template.Spec.Affinity = affinity.DistributePods(
-			common.AppSelector,
-			[]string{
-				serviceName,
+		// the get still created on the same worker node, unless
+		// a required anti-affinity is set.
+		designate.DistributePods(&deployment.Spec.Template, serviceName, instance.Spec.AntiAffinity, instance.Spec.TopologySpreadConstraints)
+	}
+	// the init container merges the config of the service, it needs nothing else
+	initContainerDetails := designate.InitContainerDetails{
+		ContainerImage: instance.Spec.ContainerImage,
+		Resources: designate.InitResources{
+			VolumeMounts: map[designate.InitCapability][]corev1.VolumeMount{
+				designate.NeedsServiceConfig: initVolumeMounts,
 			},
-			corev1.LabelHostname,
-		)
+		},
+		Needs: []designate.InitCapability{designate.NeedsServiceConfig},
 	}
-	initContainerDetails := designate.APIDetails{
-		ContainerImage:       instance.Spec.ContainerImage,
-		DatabaseHost:         instance.Spec.DatabaseHostname,
-		DatabaseName:         designate.DatabaseName,
-		OSPSecret:            instance.Spec.Secret,
-		TransportURLSecret:   instance.Spec.TransportURLSecret,
-		UserPasswordSelector: instance.Spec.PasswordSelectors.Service,
-		VolumeMounts:         initVolumeMounts,
+	deployment.Spec.Template.Spec.InitContainers = []corev1.Container{
+		designate.InitContainer(initContainerDetails),
 	}
-	deployment.Spec.Template.Spec.InitContainers = designate.InitContainer(initContainerDetails)
 
-	return deployment
+	designate.ApplyProbeOverrides(&deployment.Spec.Template.Spec.Containers[0], instance.Spec.Probes)
+	err := designate.ApplyExtraMounts(&deployment.Spec.Template.Spec, instance.Spec.ExtraMounts, designate.Designate, Propagation)
+	if err != nil {
+		return nil, err
+	}
+	designate.ApplySecurityProfile(&deployment.Spec.Template.Spec, instance.Spec.SecurityProfile, securityProfile)
+	designate.ApplyImagePullOptions(&deployment.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+	designate.ApplyResources(&deployment.Spec.Template.Spec, instance.Spec.Resources, instance.Spec.InitResources, instance.Spec.SidecarResources)
+	designate.ApplyPodClasses(&deployment.Spec.Template.Spec, instance.Spec.PriorityClassName, instance.Spec.RuntimeClassName)
+	designate.ApplyServiceMesh(&deployment.Spec.Template, instance.Spec.ServiceMesh, nil)
+	designate.ApplyPodMetadata(&deployment.Spec.Template, instance.Spec.PodAnnotations, instance.Spec.PodLabels)
+
+	return deployment, nil
 }
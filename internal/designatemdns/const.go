@@ -16,7 +16,34 @@ limitations under the License.
 // Package designatemdns contains designate MDNS constants and configuration.
 package designatemdns
 
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+)
+
 const (
 	// Component -
 	Component = "designate-mdns"
+
+	// Propagation - the extra mounts of the DesignateMdns pods
+	Propagation storage.PropagationType = "DesignateMdns"
+
+	// DefaultWorkers is the number of worker processes of mdns when not set in the spec
+	DefaultWorkers = 2
+	// DefaultThreads is the number of threads of each mdns worker when not set in the spec
+	DefaultThreads = 1000
+	// DefaultTCPBacklog is the TCP backlog of mdns when not set in the spec
+	DefaultTCPBacklog = 100
+	// DefaultXFRTimeout is the zone transfer timeout of mdns when not set in the spec
+	DefaultXFRTimeout = 10
+	// DefaultPort is the port mdns listens on when not set in the spec
+	DefaultPort = 5354
 )
+
+// Port returns the port mdns serves the zone transfers on
+func Port(spec *designatev1beta1.DesignateMdnsSpecBase) int32 {
+	if spec.Port > 0 {
+		return spec.Port
+	}
+	return DefaultPort
+}
@@ -16,18 +16,27 @@ limitations under the License.
 package designatemdns
 
 import (
+	"slices"
+	"strconv"
+
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
-	common "github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
+// mdns runs as the designate user with the restricted profile, it may be
+// configured to listen on a privileged port
+var securityProfile = designate.SecurityProfile{
+	User:         ptr.To(designate.DesignateUID),
+	Capabilities: []corev1.Capability{"NET_BIND_SERVICE"},
+}
+
 // StatefulSet func
 func StatefulSet(
 	instance *designatev1beta1.DesignateMdns,
@@ -35,7 +44,7 @@ func StatefulSet(
 	labels map[string]string,
 	annotations map[string]string,
 	topology *topologyv1.Topology,
-) *appsv1.StatefulSet {
+) (*appsv1.StatefulSet, error) {
 	rootUser := int64(0)
 	serviceName := instance.Name
 
@@ -43,50 +52,77 @@ func StatefulSet(
 	// mount. Instead of putting some convoluted logic in the common case, just explode out the individual mappings
 	// here.
 	volumeDefs := []designate.VolumeMapping{
-		{Name: designate.ScriptsVolumeName(instance.Name), Type: designate.ScriptMount, MountPath: "/usr/local/bin/container-scripts"},
 		{Name: designate.ConfigVolumeName(designate.GetOwningDesignateName(instance)), Type: designate.SecretMount, MountPath: "/var/lib/config-data/default"},
 		{Name: designate.ConfigVolumeName(instance.Name), Type: designate.SecretMount, MountPath: "/var/lib/config-data/service"},
 		{Name: designate.MergedVolumeName(instance.Name), Type: designate.MergeMount, MountPath: "/var/lib/config-data/merged"},
-		{Name: designate.MdnsPredIPConfigMap, Type: designate.ConfigMount, MountPath: "/var/lib/predictableips"},
 		{Name: designate.DefaultsVolumeName(designate.GetOwningDesignateName(instance)), Type: designate.SecretMount, MountPath: "/var/lib/config-data/common-overwrites"},
 		{Name: designate.DefaultsVolumeName(instance.Name), Type: designate.SecretMount, MountPath: "/var/lib/config-data/overwrites"},
 		{Name: designate.MergedDefaultsVolumeName(instance.Name), Type: designate.MergeMount, MountPath: "/var/lib/config-data/config-overwrites"},
 	}
+	volumeDefs = append(volumeDefs, designate.GetCustomServiceConfigSecretsVolumeMapping(instance.Spec.CustomServiceConfigSecrets)...)
 
-	volumes, initVolumeMounts := designate.ProcessVolumes(volumeDefs)
+	configVolumes, configMounts := designate.ProcessVolumes(volumeDefs)
+	scriptVolumes, scriptMounts := designate.ProcessVolumes([]designate.VolumeMapping{
+		{Name: designate.ScriptsVolumeName(instance.Name), Type: designate.ScriptMount, MountPath: "/usr/local/bin/container-scripts"},
+	})
+	predIPVolumes, predIPMounts := designate.ProcessVolumes([]designate.VolumeMapping{
+		{Name: designate.MdnsPredIPConfigMap, Type: designate.ConfigMount, MountPath: "/var/lib/predictableips"},
+	})
+	// the addresses on the networks other than the control network have a
+	// map per network
+	netIPVolumes, netIPMounts := designate.PredictableIPNetworkVolumes(designate.MdnsPredIPConfigMap, instance.Spec.PredictableIPNetworks)
+	volumes := slices.Concat(scriptVolumes, configVolumes, predIPVolumes, netIPVolumes)
+
+	// The init containers only get the mounts they need: the init container
+	// the config, the predictable IP container the IPs of the pods
+	initResources := designate.InitResources{
+		Scripts: scriptMounts,
+		VolumeMounts: map[designate.InitCapability][]corev1.VolumeMount{
+			designate.NeedsServiceConfig:  configMounts,
+			designate.NeedsPredictableIPs: slices.Concat(predIPMounts, netIPMounts),
+		},
+	}
 
-	volumeMounts := append(initVolumeMounts, corev1.VolumeMount{
+	volumeMounts := append(slices.Concat(scriptMounts, configMounts, predIPMounts), corev1.VolumeMount{
 		Name:      designate.MergedVolumeName(instance.Name),
 		MountPath: "/var/lib/kolla/config_files/config.json",
 		SubPath:   serviceName + "-config.json",
 		ReadOnly:  true,
 	})
 
-	// Ideally we would use the connection probe but the mdns service does
-	// not listen on a cluster allocated IP
+	// The mdns service does not listen on a cluster allocated IP, the probes
+	// query it on localhost from within the container
 	livenessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       13,
 		InitialDelaySeconds: 15,
 	}
 	startupProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       13,
 		InitialDelaySeconds: 10,
 	}
 
+	readinessProbe := &corev1.Probe{
+		TimeoutSeconds: 15,
+		PeriodSeconds:  13,
+	}
+
+	// Check mdns answers a SOA query, a running but broken mdns gets restarted.
 	livenessProbe.Exec = &corev1.ExecAction{
 		Command: []string{
-			"/usr/bin/pgrep", "-r", "DRST", "-f", "designate.mdns",
+			"/usr/local/bin/container-scripts/healthcheck.py",
 		},
 	}
 	startupProbe.Exec = livenessProbe.Exec
+	readinessProbe.Exec = livenessProbe.Exec
 
 	envVars := map[string]env.Setter{}
 	envVars["KOLLA_CONFIG_STRATEGY"] = env.SetValue("COPY_ALWAYS")
 	envVars["CONFIG_HASH"] = env.SetValue(configHash)
+	// the scripts and the probes of the pod reach mdns on its port
+	mdnsPort := Port(&instance.Spec.DesignateMdnsSpecBase)
+	envVars["MDNS_PORT"] = env.SetValue(strconv.Itoa(int(mdnsPort)))
 
 	// Add the CA bundle
 	if instance.Spec.TLS.CaBundleSecretName != "" {
@@ -104,10 +140,11 @@ func StatefulSet(
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
-			Replicas: instance.Spec.Replicas,
+			Replicas:       instance.Spec.Replicas,
+			UpdateStrategy: designate.StatefulSetUpdateStrategy(instance.Spec.UpdateStrategy),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Annotations: annotations,
+					Annotations: designate.PodAnnotations(annotations, configHash),
 					Labels:      labels,
 				},
 				Spec: corev1.PodSpec{
@@ -120,11 +157,11 @@ func StatefulSet(
 							SecurityContext: &corev1.SecurityContext{
 								RunAsUser: &rootUser,
 							},
-							Env:           env.MergeEnvs([]corev1.EnvVar{}, envVars),
-							VolumeMounts:  volumeMounts,
-							Resources:     instance.Spec.Resources,
-							StartupProbe:  startupProbe,
-							LivenessProbe: livenessProbe,
+							Env:            env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							VolumeMounts:   volumeMounts,
+							StartupProbe:   startupProbe,
+							LivenessProbe:  livenessProbe,
+							ReadinessProbe: readinessProbe,
 						},
 					},
 				},
@@ -135,42 +172,59 @@ func StatefulSet(
 	if instance.Spec.NodeSelector != nil {
 		statefulSet.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
+	statefulSet.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
 
 	if topology != nil {
 		topology.ApplyTo(&statefulSet.Spec.Template)
 	} else {
 		// If possible two pods of the same service should not
 		// run on the same worker node. If this is not possible
-		// the get still created on the same worker node.
-		statefulSet.Spec.Template.Spec.Affinity = affinity.DistributePods(
-			common.AppSelector,
-			[]string{
-				serviceName,
-			},
-			corev1.LabelHostname,
-		)
+		// the get still created on the same worker node, unless
+		// a required anti-affinity is set.
+		designate.DistributePods(&statefulSet.Spec.Template, serviceName, instance.Spec.AntiAffinity, instance.Spec.TopologySpreadConstraints)
 	}
 
-	envVars = map[string]env.Setter{}
-	envVars["POD_NAME"] = env.DownwardAPI("metadata.name")
-	envVars["MAP_PREFIX"] = env.SetValue("mdns_address_")
-	podEnv := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	predIPEnv := designate.PredictableIPNetworkEnv(instance.Spec.ControlNetworkName, instance.Spec.PredictableIPNetworks)
+	predIPEnv["MAP_PREFIX"] = env.SetValue("mdns_address_")
+	predIPEnv["MDNS_PORT"] = envVars["MDNS_PORT"]
+	initResources.EnvVars = map[designate.InitCapability]map[string]env.Setter{
+		designate.NeedsPodName:        {"POD_NAME": env.DownwardAPI("metadata.name")},
+		designate.NeedsPredictableIPs: predIPEnv,
+	}
 	initContainerDetails := designate.InitContainerDetails{
 		ContainerImage: instance.Spec.ContainerImage,
-		VolumeMounts:   initVolumeMounts,
-		EnvVars:        podEnv,
+		Resources:      initResources,
+		Needs:          []designate.InitCapability{designate.NeedsServiceConfig},
 	}
 	predIPContainerDetails := designate.PredIPContainerDetails{
 		ContainerImage: instance.Spec.NetUtilsImage,
-		VolumeMounts:   initVolumeMounts,
-		EnvVars:        podEnv,
+		Resources:      initResources,
 		Command:        designate.PredictableIPCommand,
 	}
 
 	statefulSet.Spec.Template.Spec.InitContainers = []corev1.Container{
-		designate.SimpleInitContainer(initContainerDetails),
-		designate.PredictableIPContainer(predIPContainerDetails),
+		designate.InitContainer(initContainerDetails),
+	}
+	if instance.Spec.HostNetwork {
+		// mdns listens on the addresses of the node, there is no
+		// predictable IP to add
+		designate.ApplyHostNetwork(&statefulSet.Spec.Template.Spec, designate.DNSContainerPorts(mdnsPort))
+	} else if !instance.Spec.PodNetwork {
+		statefulSet.Spec.Template.Spec.InitContainers = append(statefulSet.Spec.Template.Spec.InitContainers,
+			designate.PredictableIPContainer(predIPContainerDetails))
 	}
 
-	return statefulSet
+	designate.ApplyProbeOverrides(&statefulSet.Spec.Template.Spec.Containers[0], instance.Spec.Probes)
+	err := designate.ApplyExtraMounts(&statefulSet.Spec.Template.Spec, instance.Spec.ExtraMounts, designate.Designate, Propagation)
+	if err != nil {
+		return nil, err
+	}
+	designate.ApplySecurityProfile(&statefulSet.Spec.Template.Spec, instance.Spec.SecurityProfile, securityProfile)
+	designate.ApplyImagePullOptions(&statefulSet.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+	designate.ApplyResources(&statefulSet.Spec.Template.Spec, instance.Spec.Resources, instance.Spec.InitResources, instance.Spec.SidecarResources)
+	designate.ApplyPodClasses(&statefulSet.Spec.Template.Spec, instance.Spec.PriorityClassName, instance.Spec.RuntimeClassName)
+	designate.ApplyServiceMesh(&statefulSet.Spec.Template, instance.Spec.ServiceMesh, designate.DNSContainerPorts(mdnsPort))
+	designate.ApplyPodMetadata(&statefulSet.Spec.Template, instance.Spec.PodAnnotations, instance.Spec.PodLabels)
+
+	return statefulSet, nil
 }
@@ -16,7 +16,12 @@ limitations under the License.
 // Package designateproducer contains designate producer constants and configuration.
 package designateproducer
 
+import "github.com/openstack-k8s-operators/lib-common/modules/storage"
+
 const (
 	// Component -
 	Component = "designate-producer"
+
+	// Propagation - the extra mounts of the DesignateProducer pods
+	Propagation storage.PropagationType = "DesignateProducer"
 )
@@ -0,0 +1,42 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package designatesink contains designate sink constants and configuration.
+package designatesink
+
+import (
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+)
+
+const (
+	// Component -
+	Component = "designate-sink"
+
+	// Propagation - the extra mounts of the DesignateSink pods
+	Propagation storage.PropagationType = "DesignateSink"
+
+	// DefaultNotificationTopic - the topic the notification handlers listen to when not set
+	DefaultNotificationTopic = "notifications"
+)
+
+// DefaultControlExchange returns the exchange the notifications of the
+// handler are sent on when not set in the spec
+func DefaultControlExchange(handler string) string {
+	if handler == designatev1beta1.SinkHandlerNeutronFloatingIP {
+		return "neutron"
+	}
+	return "nova"
+}
@@ -0,0 +1,164 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designatesink
+
+import (
+	"fmt"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// The service runs as the designate user with the restricted profile
+var securityProfile = designate.SecurityProfile{
+	User: ptr.To(designate.DesignateUID),
+}
+
+// Deployment func
+func Deployment(
+	instance *designatev1beta1.DesignateSink,
+	configHash string,
+	labels map[string]string,
+	annotations map[string]string,
+	topology *topologyv1.Topology,
+) (*appsv1.Deployment, error) {
+	rootAsUser := int64(0)
+	serviceName := fmt.Sprintf("%s-sink", designate.ServiceName)
+
+	volumeDefs := append(designate.GetStandardVolumeMapping(instance),
+		designate.GetCustomServiceConfigSecretsVolumeMapping(instance.Spec.CustomServiceConfigSecrets)...)
+	volumes, initVolumeMounts := designate.ProcessVolumes(volumeDefs)
+
+	volumeMounts := append(initVolumeMounts, corev1.VolumeMount{
+		Name:      designate.MergedVolumeName(instance.Name),
+		MountPath: "/var/lib/kolla/config_files/config.json",
+		SubPath:   serviceName + "-config.json",
+		ReadOnly:  true,
+	})
+
+	livenessProbe := &corev1.Probe{
+		TimeoutSeconds:      10,
+		PeriodSeconds:       15,
+		InitialDelaySeconds: 5,
+	}
+	startupProbe := &corev1.Probe{
+		TimeoutSeconds:      10,
+		PeriodSeconds:       15,
+		InitialDelaySeconds: 5,
+	}
+	livenessProbe.Exec = &corev1.ExecAction{
+		Command: []string{
+			"/usr/bin/pgrep", "-r", "DRST", "-f", "designate.sink",
+		},
+	}
+	startupProbe.Exec = livenessProbe.Exec
+
+	envVars := map[string]env.Setter{}
+	envVars["KOLLA_CONFIG_STRATEGY"] = env.SetValue("COPY_ALWAYS")
+	envVars["CONFIG_HASH"] = env.SetValue(configHash)
+
+	// Add the CA bundle
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		volumes = append(volumes, instance.Spec.TLS.CreateVolume())
+		volumeMounts = append(volumeMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Replicas: instance.Spec.Replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: designate.PodAnnotations(annotations, configHash),
+					Labels:      labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: instance.Spec.ServiceAccount,
+					Volumes:            volumes,
+					Containers: []corev1.Container{
+						{
+							Name:  serviceName,
+							Image: instance.Spec.ContainerImage,
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: &rootAsUser,
+							},
+							Env:           env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							VolumeMounts:  volumeMounts,
+							StartupProbe:  startupProbe,
+							LivenessProbe: livenessProbe,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if instance.Spec.NodeSelector != nil {
+		deployment.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
+	}
+	deployment.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
+
+	if topology != nil {
+		topology.ApplyTo(&deployment.Spec.Template)
+	} else {
+		// If possible two pods of the same service should not
+		// run on the same worker node. If this is not possible
+		// the get still created on the same worker node, unless
+		// a required anti-affinity is set.
+		designate.DistributePods(&deployment.Spec.Template, serviceName, instance.Spec.AntiAffinity, instance.Spec.TopologySpreadConstraints)
+	}
+	// the init container merges the config of the service, it needs nothing else
+	initContainerDetails := designate.InitContainerDetails{
+		ContainerImage: instance.Spec.ContainerImage,
+		Resources: designate.InitResources{
+			VolumeMounts: map[designate.InitCapability][]corev1.VolumeMount{
+				designate.NeedsServiceConfig: initVolumeMounts,
+			},
+		},
+		Needs: []designate.InitCapability{designate.NeedsServiceConfig},
+	}
+	deployment.Spec.Template.Spec.InitContainers = []corev1.Container{
+		designate.InitContainer(initContainerDetails),
+	}
+
+	designate.ApplyProbeOverrides(&deployment.Spec.Template.Spec.Containers[0], instance.Spec.Probes)
+	err := designate.ApplyExtraMounts(&deployment.Spec.Template.Spec, instance.Spec.ExtraMounts, designate.Designate, Propagation)
+	if err != nil {
+		return nil, err
+	}
+	designate.ApplySecurityProfile(&deployment.Spec.Template.Spec, instance.Spec.SecurityProfile, securityProfile)
+	designate.ApplyImagePullOptions(&deployment.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+	designate.ApplyResources(&deployment.Spec.Template.Spec, instance.Spec.Resources, instance.Spec.InitResources, instance.Spec.SidecarResources)
+	designate.ApplyPodClasses(&deployment.Spec.Template.Spec, instance.Spec.PriorityClassName, instance.Spec.RuntimeClassName)
+	designate.ApplyServiceMesh(&deployment.Spec.Template, instance.Spec.ServiceMesh, nil)
+	designate.ApplyPodMetadata(&deployment.Spec.Template, instance.Spec.PodAnnotations, instance.Spec.PodLabels)
+
+	return deployment, nil
+}
@@ -17,13 +17,32 @@ limitations under the License.
 // Package designateunbound contains designate unbound constants and configuration.
 package designateunbound
 
+import "github.com/openstack-k8s-operators/lib-common/modules/storage"
+
 const (
 	// Component represents the designate unbound component name
 	Component = "designate-unbound"
+	// Propagation - the extra mounts of the DesignateUnbound pods
+	Propagation storage.PropagationType = "DesignateUnbound"
 	// ServiceName is the name of the designate unbound service
 	ServiceName = "designateunbound"
 	// DefaultJoinSubnetV4 is the default join subnet for IPv4
 	DefaultJoinSubnetV4 = "100.64.0.0/16"
 	// DefaultJoinSubnetV6 is the default join subnet for IPv6
 	DefaultJoinSubnetV6 = "fd98::/64"
+	// DefaultNumThreads is the number of threads of unbound when not set in the spec
+	DefaultNumThreads = 1
+	// DefaultMsgCacheSize is the message cache size of unbound when not set in the spec
+	DefaultMsgCacheSize = "50m"
+	// DefaultRRSetCacheSize is the RRset cache size of unbound when not set in the spec
+	DefaultRRSetCacheSize = "100m"
+	// DefaultCacheMaxTTL is the maximum time to live of the unbound cache when not set in the spec
+	DefaultCacheMaxTTL = 86400
+	// DNSOverTLSPort is the port of the DNS-over-TLS listener
+	DNSOverTLSPort int32 = 853
+	// DNSOverHTTPSPort is the port of the DNS-over-HTTPS listener
+	DNSOverHTTPSPort int32 = 443
+	// EncryptedDNSCertID names the mounted certificate and key of the encrypted listeners,
+	// /etc/pki/tls/certs/<id>.crt and /etc/pki/tls/private/<id>.key
+	EncryptedDNSCertID = "unbound"
 )
@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designateunbound
+
+import (
+	"fmt"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// EncryptedDNSPorts returns the service ports of the enabled encrypted listeners
+func EncryptedDNSPorts(spec *designatev1beta1.UnboundEncryptedDNSSpec) []corev1.ServicePort {
+	ports := []corev1.ServicePort{}
+	if spec.DNSOverTLS {
+		ports = append(ports, corev1.ServicePort{
+			Name:        "dns-over-tls",
+			Port:        DNSOverTLSPort,
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: ptr.To("tls"),
+		})
+	}
+	if spec.DNSOverHTTPS {
+		ports = append(ports, corev1.ServicePort{
+			Name:        "dns-over-https",
+			Port:        DNSOverHTTPSPort,
+			Protocol:    corev1.ProtocolTCP,
+			AppProtocol: ptr.To("https"),
+		})
+	}
+	return ports
+}
+
+// EncryptedDNSParameters returns the template parameters of the encrypted listeners
+func EncryptedDNSParameters(spec *designatev1beta1.UnboundEncryptedDNSSpec) map[string]any {
+	params := map[string]any{
+		"DNSOverTLS":   spec.DNSOverTLS,
+		"DNSOverHTTPS": spec.DNSOverHTTPS,
+	}
+	if spec.Enabled() {
+		params["DNSOverTLSPort"] = DNSOverTLSPort
+		params["DNSOverHTTPSPort"] = DNSOverHTTPSPort
+		params["TLSServicePem"] = fmt.Sprintf("/etc/pki/tls/certs/%s.crt", EncryptedDNSCertID)
+		params["TLSServiceKey"] = fmt.Sprintf("/etc/pki/tls/private/%s.key", EncryptedDNSCertID)
+	}
+	return params
+}
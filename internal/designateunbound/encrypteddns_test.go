@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package designateunbound
+
+import (
+	"testing"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"k8s.io/utils/ptr"
+)
+
+func TestEncryptedDNS(t *testing.T) {
+	spec := &designatev1beta1.UnboundEncryptedDNSSpec{}
+	if ports := EncryptedDNSPorts(spec); len(ports) != 0 {
+		t.Errorf("expected no encrypted ports by default, got %v", ports)
+	}
+	if params := EncryptedDNSParameters(spec); params["DNSOverTLS"] != false || params["DNSOverHTTPS"] != false {
+		t.Errorf("expected the encrypted listeners to be disabled by default, got %v", params)
+	}
+
+	spec = &designatev1beta1.UnboundEncryptedDNSSpec{
+		DNSOverTLS:     true,
+		DNSOverHTTPS:   true,
+		GenericService: tls.GenericService{SecretName: ptr.To("cert-designate-unbound")},
+	}
+	ports := EncryptedDNSPorts(spec)
+	if len(ports) != 2 || ports[0].Port != DNSOverTLSPort || ports[1].Port != DNSOverHTTPSPort {
+		t.Errorf("expected the DoT and DoH ports, got %v", ports)
+	}
+	params := EncryptedDNSParameters(spec)
+	if params["TLSServicePem"] != "/etc/pki/tls/certs/unbound.crt" || params["TLSServiceKey"] != "/etc/pki/tls/private/unbound.key" {
+		t.Errorf("unexpected certificate paths %v", params)
+	}
+}
@@ -21,8 +21,6 @@ import (
 	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	designate "github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
-	common "github.com/openstack-k8s-operators/lib-common/modules/common"
-	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,13 +33,20 @@ const (
 	configVolume = "designateunbound-config"
 )
 
+// unbound starts as root and drops its privileges itself, it only writes its
+// pid file out of its volumes
+var securityProfile = designate.SecurityProfile{
+	Capabilities: []corev1.Capability{"NET_BIND_SERVICE", "SETGID", "SETUID"},
+	RunDir:       "/run/unbound",
+}
+
 // StatefulSet func
 func StatefulSet(instance *designatev1beta1.DesignateUnbound,
 	configHash string,
 	labels map[string]string,
 	annotations map[string]string,
 	topology *topologyv1.Topology,
-) *appsv1.StatefulSet {
+) (*appsv1.StatefulSet, error) {
 	var configMode int32 = 0640
 
 	volumes := []corev1.Volume{
@@ -63,14 +68,21 @@ func StatefulSet(instance *designatev1beta1.DesignateUnbound,
 		},
 	}
 
+	if instance.Spec.EncryptedDNS.Enabled() && instance.Spec.EncryptedDNS.SecretName != nil {
+		svc, err := instance.Spec.EncryptedDNS.ToService()
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, svc.CreateVolume(EncryptedDNSCertID))
+		mounts = append(mounts, svc.CreateVolumeMounts(EncryptedDNSCertID)...)
+	}
+
 	livenessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       13,
 		InitialDelaySeconds: 15,
 	}
 	readinessProbe := &corev1.Probe{
-		// TODO might need tuning
 		TimeoutSeconds:      15,
 		PeriodSeconds:       15,
 		InitialDelaySeconds: 10,
@@ -109,7 +121,7 @@ func StatefulSet(instance *designatev1beta1.DesignateUnbound,
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Annotations: annotations,
+					Annotations: designate.PodAnnotations(annotations, configHash),
 					Labels:      labels,
 				},
 				Spec: corev1.PodSpec{
@@ -132,7 +144,6 @@ func StatefulSet(instance *designatev1beta1.DesignateUnbound,
 						},
 						Env:            env.MergeEnvs([]corev1.EnvVar{}, envVars),
 						VolumeMounts:   mounts,
-						Resources:      instance.Spec.Resources,
 						ReadinessProbe: readinessProbe,
 						LivenessProbe:  livenessProbe,
 					}},
@@ -147,20 +158,29 @@ func StatefulSet(instance *designatev1beta1.DesignateUnbound,
 	if instance.Spec.NodeSelector != nil {
 		statefulSet.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
 	}
+	statefulSet.Spec.Template.Spec.Tolerations = instance.Spec.Tolerations
 
 	if topology != nil {
 		topology.ApplyTo(&statefulSet.Spec.Template)
 	} else {
 		// If possible two pods of the same service should not
 		// run on the same worker node. If this is not possible
-		// the get still created on the same worker node.
-		statefulSet.Spec.Template.Spec.Affinity = affinity.DistributePods(
-			common.AppSelector,
-			[]string{
-				designate.ServiceName,
-			},
-			corev1.LabelHostname,
-		)
+		// the get still created on the same worker node, unless
+		// a required anti-affinity is set.
+		designate.DistributePods(&statefulSet.Spec.Template, designate.ServiceName, instance.Spec.AntiAffinity, instance.Spec.TopologySpreadConstraints)
+	}
+
+	designate.ApplyProbeOverrides(&statefulSet.Spec.Template.Spec.Containers[0], instance.Spec.Probes)
+	err := designate.ApplyExtraMounts(&statefulSet.Spec.Template.Spec, instance.Spec.ExtraMounts, designate.Designate, Propagation)
+	if err != nil {
+		return nil, err
 	}
-	return statefulSet
+	designate.ApplySecurityProfile(&statefulSet.Spec.Template.Spec, instance.Spec.SecurityProfile, securityProfile)
+	designate.ApplyImagePullOptions(&statefulSet.Spec.Template.Spec, instance.Spec.ImagePullSecrets, instance.Spec.ImagePullPolicy)
+	designate.ApplyResources(&statefulSet.Spec.Template.Spec, instance.Spec.Resources, instance.Spec.InitResources, instance.Spec.SidecarResources)
+	designate.ApplyPodClasses(&statefulSet.Spec.Template.Spec, instance.Spec.PriorityClassName, instance.Spec.RuntimeClassName)
+	designate.ApplyServiceMesh(&statefulSet.Spec.Template, instance.Spec.ServiceMesh, designate.DNSContainerPorts(designate.DNSPort))
+	designate.ApplyPodMetadata(&statefulSet.Spec.Template, instance.Spec.PodAnnotations, instance.Spec.PodLabels)
+
+	return statefulSet, nil
 }
@@ -16,7 +16,12 @@ limitations under the License.
 // Package designateworker contains designate worker constants and configuration.
 package designateworker
 
+import "github.com/openstack-k8s-operators/lib-common/modules/storage"
+
 const (
 	// Component -
 	Component = "designate-worker"
+
+	// Propagation - the extra mounts of the DesignateWorker pods
+	Propagation storage.PropagationType = "DesignateWorker"
 )
@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -43,7 +44,7 @@ var designatelog = logf.Log.WithName("designate-resource")
 // SetupDesignateWebhookWithManager registers the webhook for Designate in the manager.
 func SetupDesignateWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&designatev1beta1.Designate{}).
-		WithValidator(&DesignateCustomValidator{}).
+		WithValidator(&DesignateCustomValidator{Reader: mgr.GetAPIReader()}).
 		WithDefaulter(&DesignateCustomDefaulter{}).
 		Complete()
 }
@@ -87,13 +88,14 @@ func (d *DesignateCustomDefaulter) Default(_ context.Context, obj runtime.Object
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type DesignateCustomValidator struct {
-	// TODO(user): Add more fields as needed for validation
+	// Reader reads the network attachments of the designate network
+	Reader client.Reader
 }
 
 var _ webhook.CustomValidator = &DesignateCustomValidator{}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Designate.
-func (v *DesignateCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *DesignateCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	designate, ok := obj.(*designatev1beta1.Designate)
 	if !ok {
 		return nil, fmt.Errorf("expected a Designate object but got %T: %w", obj, ErrInvalidObjectType)
@@ -101,11 +103,17 @@ func (v *DesignateCustomValidator) ValidateCreate(_ context.Context, obj runtime
 	designatelog.Info("Validation for Designate upon creation", "name", designate.GetName())
 
 	// Call the ValidateCreate method on the Designate type
-	return designate.ValidateCreate()
+	warns, err := designate.ValidateCreate()
+	if err != nil {
+		return warns, err
+	}
+
+	networkWarns, err := validateDesignateNetwork(ctx, v.Reader, designate)
+	return append(warns, networkWarns...), err
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Designate.
-func (v *DesignateCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *DesignateCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	designate, ok := newObj.(*designatev1beta1.Designate)
 	if !ok {
 		return nil, fmt.Errorf("expected a Designate object for the newObj but got %T: %w", newObj, ErrInvalidObjectType)
@@ -113,7 +121,13 @@ func (v *DesignateCustomValidator) ValidateUpdate(_ context.Context, oldObj, new
 	designatelog.Info("Validation for Designate upon update", "name", designate.GetName())
 
 	// Call the ValidateUpdate method on the Designate type
-	return designate.ValidateUpdate(oldObj)
+	warns, err := designate.ValidateUpdate(oldObj)
+	if err != nil {
+		return warns, err
+	}
+
+	networkWarns, err := validateDesignateNetwork(ctx, v.Reader, designate)
+	return append(warns, networkWarns...), err
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Designate.
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var designatebackendbind9log = logf.Log.WithName("designatebackendbind9-resource")
+
+// SetupDesignateBackendbind9WebhookWithManager registers the webhook for DesignateBackendbind9 in the manager.
+func SetupDesignateBackendbind9WebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&designatev1beta1.DesignateBackendbind9{}).
+		WithValidator(&DesignateBackendbind9CustomValidator{Reader: mgr.GetAPIReader()}).
+		WithDefaulter(&DesignateBackendbind9CustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-designate-openstack-org-v1beta1-designatebackendbind9,mutating=true,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designatebackendbind9s,verbs=create;update,versions=v1beta1,name=mdesignatebackendbind9-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateBackendbind9CustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind DesignateBackendbind9 when those are created or updated.
+type DesignateBackendbind9CustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &DesignateBackendbind9CustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind DesignateBackendbind9.
+func (d *DesignateBackendbind9CustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*designatev1beta1.DesignateBackendbind9)
+	if !ok {
+		return fmt.Errorf("expected a DesignateBackendbind9 object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designatebackendbind9log.Info("Defaulting for DesignateBackendbind9", "name", instance.GetName())
+
+	instance.Default()
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-designate-openstack-org-v1beta1-designatebackendbind9,mutating=false,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designatebackendbind9s,verbs=create;update,versions=v1beta1,name=vdesignatebackendbind9-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateBackendbind9CustomValidator struct is responsible for validating the DesignateBackendbind9 resource
+// when it is created, updated, or deleted.
+type DesignateBackendbind9CustomValidator struct {
+	// Reader reads the control network attachment
+	Reader client.Reader
+}
+
+var _ webhook.CustomValidator = &DesignateBackendbind9CustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DesignateBackendbind9.
+func (v *DesignateBackendbind9CustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*designatev1beta1.DesignateBackendbind9)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateBackendbind9 object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designatebackendbind9log.Info("Validation for DesignateBackendbind9 upon creation", "name", instance.GetName())
+
+	warns, err := instance.ValidateCreate()
+	if err != nil {
+		return warns, err
+	}
+
+	networkWarns, err := v.validateControlNetwork(ctx, instance)
+	return append(warns, networkWarns...), err
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DesignateBackendbind9.
+func (v *DesignateBackendbind9CustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	instance, ok := newObj.(*designatev1beta1.DesignateBackendbind9)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateBackendbind9 object for the newObj but got %T: %w", newObj, ErrInvalidObjectType)
+	}
+	designatebackendbind9log.Info("Validation for DesignateBackendbind9 upon update", "name", instance.GetName())
+
+	warns, err := instance.ValidateUpdate(oldObj)
+	if err != nil {
+		return warns, err
+	}
+
+	networkWarns, err := v.validateControlNetwork(ctx, instance)
+	return append(warns, networkWarns...), err
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DesignateBackendbind9.
+func (v *DesignateBackendbind9CustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*designatev1beta1.DesignateBackendbind9)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateBackendbind9 object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designatebackendbind9log.Info("Validation for DesignateBackendbind9 upon deletion", "name", instance.GetName())
+
+	return instance.ValidateDelete()
+}
+
+// validateControlNetwork checks that the IP range of the control network has
+// room for the replicas
+func (v *DesignateBackendbind9CustomValidator) validateControlNetwork(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateBackendbind9,
+) (admission.Warnings, error) {
+	if !slices.Contains(instance.Spec.NetworkAttachments, instance.Spec.ControlNetworkName) {
+		return nil, nil
+	}
+
+	warns, errs := validateNADRange(
+		ctx,
+		v.Reader,
+		field.NewPath("spec").Child("controlNetworkName"),
+		instance.Namespace,
+		instance.Spec.ControlNetworkName,
+		replicas(instance.Spec.Replicas, nil),
+		0,
+		nil,
+	)
+	return warns, invalid(instance, "DesignateBackendbind9", errs)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var designatecentrallog = logf.Log.WithName("designatecentral-resource")
+
+// SetupDesignateCentralWebhookWithManager registers the webhook for DesignateCentral in the manager.
+func SetupDesignateCentralWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&designatev1beta1.DesignateCentral{}).
+		WithDefaulter(&DesignateCentralCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-designate-openstack-org-v1beta1-designatecentral,mutating=true,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designatecentrals,verbs=create;update,versions=v1beta1,name=mdesignatecentral-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateCentralCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind DesignateCentral when those are created or updated.
+type DesignateCentralCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &DesignateCentralCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind DesignateCentral.
+func (d *DesignateCentralCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*designatev1beta1.DesignateCentral)
+	if !ok {
+		return fmt.Errorf("expected a DesignateCentral object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designatecentrallog.Info("Defaulting for DesignateCentral", "name", instance.GetName())
+
+	instance.Default()
+
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var designatemdnslog = logf.Log.WithName("designatemdns-resource")
+
+// SetupDesignateMdnsWebhookWithManager registers the webhook for DesignateMdns in the manager.
+func SetupDesignateMdnsWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&designatev1beta1.DesignateMdns{}).
+		WithValidator(&DesignateMdnsCustomValidator{Reader: mgr.GetAPIReader()}).
+		WithDefaulter(&DesignateMdnsCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-designate-openstack-org-v1beta1-designatemdns,mutating=true,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designatemdnses,verbs=create;update,versions=v1beta1,name=mdesignatemdns-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateMdnsCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind DesignateMdns when those are created or updated.
+type DesignateMdnsCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &DesignateMdnsCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind DesignateMdns.
+func (d *DesignateMdnsCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*designatev1beta1.DesignateMdns)
+	if !ok {
+		return fmt.Errorf("expected a DesignateMdns object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designatemdnslog.Info("Defaulting for DesignateMdns", "name", instance.GetName())
+
+	instance.Default()
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-designate-openstack-org-v1beta1-designatemdns,mutating=false,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designatemdnses,verbs=create;update,versions=v1beta1,name=vdesignatemdns-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateMdnsCustomValidator struct is responsible for validating the DesignateMdns resource
+// when it is created, updated, or deleted.
+type DesignateMdnsCustomValidator struct {
+	// Reader reads the control network attachment
+	Reader client.Reader
+}
+
+var _ webhook.CustomValidator = &DesignateMdnsCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DesignateMdns.
+func (v *DesignateMdnsCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*designatev1beta1.DesignateMdns)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateMdns object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designatemdnslog.Info("Validation for DesignateMdns upon creation", "name", instance.GetName())
+
+	warns, err := instance.ValidateCreate()
+	if err != nil {
+		return warns, err
+	}
+
+	networkWarns, err := v.validateControlNetwork(ctx, instance)
+	return append(warns, networkWarns...), err
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DesignateMdns.
+func (v *DesignateMdnsCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	instance, ok := newObj.(*designatev1beta1.DesignateMdns)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateMdns object for the newObj but got %T: %w", newObj, ErrInvalidObjectType)
+	}
+	designatemdnslog.Info("Validation for DesignateMdns upon update", "name", instance.GetName())
+
+	warns, err := instance.ValidateUpdate(oldObj)
+	if err != nil {
+		return warns, err
+	}
+
+	networkWarns, err := v.validateControlNetwork(ctx, instance)
+	return append(warns, networkWarns...), err
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DesignateMdns.
+func (v *DesignateMdnsCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*designatev1beta1.DesignateMdns)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateMdns object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designatemdnslog.Info("Validation for DesignateMdns upon deletion", "name", instance.GetName())
+
+	return instance.ValidateDelete()
+}
+
+// validateControlNetwork checks that the IP range of the control network has
+// room for the replicas
+func (v *DesignateMdnsCustomValidator) validateControlNetwork(
+	ctx context.Context,
+	instance *designatev1beta1.DesignateMdns,
+) (admission.Warnings, error) {
+	if !slices.Contains(instance.Spec.NetworkAttachments, instance.Spec.ControlNetworkName) {
+		return nil, nil
+	}
+
+	warns, errs := validateNADRange(
+		ctx,
+		v.Reader,
+		field.NewPath("spec").Child("controlNetworkName"),
+		instance.Namespace,
+		instance.Spec.ControlNetworkName,
+		replicas(instance.Spec.Replicas, nil),
+		0,
+		nil,
+	)
+	return warns, invalid(instance, "DesignateMdns", errs)
+}
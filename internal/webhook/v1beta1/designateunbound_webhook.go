@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var designateunboundlog = logf.Log.WithName("designateunbound-resource")
+
+// SetupDesignateUnboundWebhookWithManager registers the webhook for DesignateUnbound in the manager.
+func SetupDesignateUnboundWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&designatev1beta1.DesignateUnbound{}).
+		WithValidator(&DesignateUnboundCustomValidator{}).
+		WithDefaulter(&DesignateUnboundCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-designate-openstack-org-v1beta1-designateunbound,mutating=true,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designateunbounds,verbs=create;update,versions=v1beta1,name=mdesignateunbound-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateUnboundCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind DesignateUnbound when those are created or updated.
+type DesignateUnboundCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &DesignateUnboundCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind DesignateUnbound.
+func (d *DesignateUnboundCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*designatev1beta1.DesignateUnbound)
+	if !ok {
+		return fmt.Errorf("expected a DesignateUnbound object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designateunboundlog.Info("Defaulting for DesignateUnbound", "name", instance.GetName())
+
+	instance.Default()
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-designate-openstack-org-v1beta1-designateunbound,mutating=false,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designateunbounds,verbs=create;update,versions=v1beta1,name=vdesignateunbound-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateUnboundCustomValidator struct is responsible for validating the DesignateUnbound resource
+// when it is created, updated, or deleted.
+type DesignateUnboundCustomValidator struct{}
+
+var _ webhook.CustomValidator = &DesignateUnboundCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DesignateUnbound.
+func (v *DesignateUnboundCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*designatev1beta1.DesignateUnbound)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateUnbound object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designateunboundlog.Info("Validation for DesignateUnbound upon creation", "name", instance.GetName())
+
+	return instance.ValidateCreate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DesignateUnbound.
+func (v *DesignateUnboundCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	instance, ok := newObj.(*designatev1beta1.DesignateUnbound)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateUnbound object for the newObj but got %T: %w", newObj, ErrInvalidObjectType)
+	}
+	designateunboundlog.Info("Validation for DesignateUnbound upon update", "name", instance.GetName())
+
+	return instance.ValidateUpdate(oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DesignateUnbound.
+func (v *DesignateUnboundCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	instance, ok := obj.(*designatev1beta1.DesignateUnbound)
+	if !ok {
+		return nil, fmt.Errorf("expected a DesignateUnbound object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designateunboundlog.Info("Validation for DesignateUnbound upon deletion", "name", instance.GetName())
+
+	return instance.ValidateDelete()
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var designateworkerlog = logf.Log.WithName("designateworker-resource")
+
+// SetupDesignateWorkerWebhookWithManager registers the webhook for DesignateWorker in the manager.
+func SetupDesignateWorkerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&designatev1beta1.DesignateWorker{}).
+		WithDefaulter(&DesignateWorkerCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-designate-openstack-org-v1beta1-designateworker,mutating=true,failurePolicy=fail,sideEffects=None,groups=designate.openstack.org,resources=designateworkers,verbs=create;update,versions=v1beta1,name=mdesignateworker-v1beta1.kb.io,admissionReviewVersions=v1
+
+// DesignateWorkerCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind DesignateWorker when those are created or updated.
+type DesignateWorkerCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &DesignateWorkerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind DesignateWorker.
+func (d *DesignateWorkerCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	instance, ok := obj.(*designatev1beta1.DesignateWorker)
+	if !ok {
+		return fmt.Errorf("expected a DesignateWorker object but got %T: %w", obj, ErrInvalidObjectType)
+	}
+	designateworkerlog.Info("Defaulting for DesignateWorker", "name", instance.GetName())
+
+	instance.Default()
+
+	return nil
+}
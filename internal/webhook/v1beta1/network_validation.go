@@ -0,0 +1,325 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	designatev1beta1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+)
+
+// validateNADRange checks that the IPAM ranges of the network attachment
+// nadName hold an address for each of the pods attached to it, and, when
+// predictableIPs is set, that the predictable IPs of the bind9, mdns and
+// PowerDNS pods fit after the ranges, skipping the exclude CIDRs. A network
+// attachment which doesn't exist yet only gets a warning, as it can be
+// created after the service.
+func validateNADRange(
+	ctx context.Context,
+	reader client.Reader,
+	path *field.Path,
+	namespace string,
+	nadName string,
+	pods int32,
+	predictableIPs int32,
+	exclude []netip.Prefix,
+) (admission.Warnings, field.ErrorList) {
+	nad := &networkv1.NetworkAttachmentDefinition{}
+	err := reader.Get(ctx, types.NamespacedName{Name: nadName, Namespace: namespace}, nad)
+	if apierrors.IsNotFound(err) {
+		return admission.Warnings{
+			fmt.Sprintf("network attachment %s not found, the size of its IP range is not validated", nadName),
+		}, nil
+	}
+	if err != nil {
+		return nil, field.ErrorList{field.InternalError(path, err)}
+	}
+
+	nadConfig, err := designate.GetNADConfig(nad)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(path, nadName,
+			fmt.Sprintf("cannot read the IPAM configuration of the network attachment: %s", err))}
+	}
+
+	var allErrs field.ErrorList
+	for _, ipam := range nadConfig.IPAM.Ranges() {
+		if !ipam.RangeStart.IsValid() || !ipam.RangeEnd.IsValid() {
+			continue
+		}
+		if !ipam.HasAddresses(pods) {
+			allErrs = append(allErrs, field.Invalid(path, nadName, fmt.Sprintf(
+				"the IP range %s-%s of the network attachment is too small for %d pods",
+				ipam.RangeStart, ipam.RangeEnd, pods)))
+		}
+	}
+
+	if predictableIPs > 0 {
+		if predictableIPs > designate.BindProvPredictablePoolSize {
+			allErrs = append(allErrs, field.Invalid(path, nadName, fmt.Sprintf(
+				"%d predictable IPs requested for the bind9, mdns and PowerDNS pods, at most %d are available",
+				predictableIPs, designate.BindProvPredictablePoolSize)))
+		}
+		networkParameters, err := designate.GetAllNetworkParametersFromNAD(nad)
+		if err == nil {
+			_, err = designate.GetPredictableIPAMs(networkParameters, exclude)
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, nadName, err.Error()))
+		}
+	}
+
+	return nil, allErrs
+}
+
+// replicas returns the number of pods of a service, or the maximum number of
+// pods when the service is autoscaled
+func replicas(replicas *int32, autoscaling *designatev1beta1.DesignateAutoscalingSpec) int32 {
+	var count int32
+	if replicas != nil {
+		count = *replicas
+	}
+	if autoscaling != nil {
+		count = max(count, autoscaling.MaxReplicas)
+	}
+	return count
+}
+
+// podsOnNetwork returns the number of pods of the services of designate
+// attached to the network attachment nadName
+func podsOnNetwork(spec *designatev1beta1.DesignateSpec, nadName string) int32 {
+	services := []struct {
+		networkAttachments []string
+		pods               int32
+	}{
+		{spec.DesignateAPI.NetworkAttachments, replicas(spec.DesignateAPI.Replicas, spec.DesignateAPI.Autoscaling)},
+		{spec.DesignateCentral.NetworkAttachments, replicas(spec.DesignateCentral.Replicas, spec.DesignateCentral.Autoscaling)},
+		{spec.DesignateWorker.NetworkAttachments, replicas(spec.DesignateWorker.Replicas, spec.DesignateWorker.Autoscaling)},
+		{spec.DesignateProducer.NetworkAttachments, replicas(spec.DesignateProducer.Replicas, spec.DesignateProducer.Autoscaling)},
+		{spec.DesignateMdns.NetworkAttachments, replicas(spec.DesignateMdns.Replicas, nil)},
+		{spec.DesignateBackendbind9.NetworkAttachments, replicas(spec.DesignateBackendbind9.Replicas, nil)},
+		{spec.DesignateBackendPDNS.NetworkAttachments, replicas(spec.DesignateBackendPDNS.Replicas, nil)},
+		{spec.DesignateUnbound.NetworkAttachments, replicas(spec.DesignateUnbound.Replicas, nil)},
+	}
+
+	var pods int32
+	for _, s := range services {
+		if slices.Contains(s.networkAttachments, nadName) {
+			pods += s.pods
+		}
+	}
+	return pods
+}
+
+// validateDesignateNetwork checks that the designate network attachment has
+// room for the pods attached to it and for the predictable IPs, and that the
+// other networks the mdns and bind9 pods get a predictable IP on have room
+// for them
+func validateDesignateNetwork(
+	ctx context.Context,
+	reader client.Reader,
+	d *designatev1beta1.Designate,
+) (admission.Warnings, error) {
+	warns, errs := validatePredictableIPNetworks(ctx, reader, d)
+	if d.Spec.DesignateNetworkAttachment == "" {
+		return warns, invalid(d, "Designate", errs)
+	}
+
+	predictableIPs := replicas(d.Spec.DesignateMdns.Replicas, nil) +
+		replicas(d.Spec.DesignateBackendbind9.Replicas, nil)
+	if d.IsPDNSEnabled() {
+		predictableIPs += replicas(d.Spec.DesignateBackendPDNS.Replicas, nil)
+	}
+	// the mdns and bind9 pods are reached on the addresses of their nodes
+	// or pods
+	if !d.UsesControlNetwork() {
+		predictableIPs = 0
+	}
+	pods := podsOnNetwork(&d.Spec, d.Spec.DesignateNetworkAttachment)
+	if predictableIPs == 0 && pods == 0 {
+		// nothing uses the network attachment, which might not exist on
+		// clusters without Multus
+		return warns, invalid(d, "Designate", errs)
+	}
+
+	nadWarns, nadErrs := validateNADRange(
+		ctx,
+		reader,
+		field.NewPath("spec").Child("designateNetworkAttachment"),
+		d.Namespace,
+		d.Spec.DesignateNetworkAttachment,
+		pods,
+		predictableIPs,
+		predictableIPExcludes(d),
+	)
+	errs = append(errs, nadErrs...)
+	if predictableIPs > 0 {
+		errs = append(errs, validateStaticIPRanges(ctx, reader, d)...)
+	}
+	return append(warns, nadWarns...), invalid(d, "Designate", errs)
+}
+
+// validateStaticIPRanges checks that the static IPs of the mdns and bind9
+// pods are in the subnets of the designate network attachment and outside of
+// the range it hands out to the pods. A network attachment which doesn't
+// exist yet is already reported by validateNADRange.
+func validateStaticIPRanges(
+	ctx context.Context,
+	reader client.Reader,
+	d *designatev1beta1.Designate,
+) field.ErrorList {
+	services := []struct {
+		path      *field.Path
+		staticIPs map[string]string
+	}{
+		{field.NewPath("spec", "designateMdns", "staticIPs"), d.Spec.DesignateMdns.StaticIPs},
+		{field.NewPath("spec", "designateBackendbind9", "staticIPs"), d.Spec.DesignateBackendbind9.StaticIPs},
+	}
+	if len(services[0].staticIPs) == 0 && len(services[1].staticIPs) == 0 {
+		return nil
+	}
+
+	nad := &networkv1.NetworkAttachmentDefinition{}
+	err := reader.Get(ctx, types.NamespacedName{Name: d.Spec.DesignateNetworkAttachment, Namespace: d.Namespace}, nad)
+	if err != nil {
+		return nil
+	}
+	nadConfig, err := designate.GetNADConfig(nad)
+	if err != nil {
+		return nil
+	}
+	ranges := nadConfig.IPAM.Ranges()
+
+	var allErrs field.ErrorList
+	for _, s := range services {
+		for _, index := range slices.Sorted(maps.Keys(s.staticIPs)) {
+			for _, ip := range designate.SplitPredictableIPs(s.staticIPs[index]) {
+				addr, err := netip.ParseAddr(ip)
+				if err != nil {
+					// reported by the validation of the spec
+					continue
+				}
+				i := slices.IndexFunc(ranges, func(r designate.NADIpam) bool { return r.CIDR.Contains(addr) })
+				if i < 0 {
+					allErrs = append(allErrs, field.Invalid(s.path.Key(index), ip, fmt.Sprintf(
+						"the address is outside of the subnets of the network attachment %s", d.Spec.DesignateNetworkAttachment)))
+					continue
+				}
+				r := ranges[i]
+				if r.RangeStart.IsValid() && r.RangeEnd.IsValid() &&
+					addr.Compare(r.RangeStart) >= 0 && addr.Compare(r.RangeEnd) <= 0 {
+					allErrs = append(allErrs, field.Invalid(s.path.Key(index), ip, fmt.Sprintf(
+						"the address is in the range %s-%s the network attachment hands out to the pods",
+						r.RangeStart, r.RangeEnd)))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+// validatePredictableIPNetworks checks that the networks other than the
+// control network the mdns and bind9 pods get a predictable IP on have room
+// for the pods attached to them and for the predictable IPs
+func validatePredictableIPNetworks(
+	ctx context.Context,
+	reader client.Reader,
+	d *designatev1beta1.Designate,
+) (admission.Warnings, field.ErrorList) {
+	services := []struct {
+		path     *field.Path
+		networks []string
+		pods     int32
+	}{
+		{
+			field.NewPath("spec", "designateMdns", "predictableIPNetworks"),
+			d.Spec.DesignateMdns.PredictableIPNetworks,
+			replicas(d.Spec.DesignateMdns.Replicas, nil),
+		},
+		{
+			field.NewPath("spec", "designateBackendbind9", "predictableIPNetworks"),
+			d.Spec.DesignateBackendbind9.PredictableIPNetworks,
+			replicas(d.Spec.DesignateBackendbind9.Replicas, nil),
+		},
+	}
+
+	var warns admission.Warnings
+	var errs field.ErrorList
+	validated := map[string]bool{}
+	for _, s := range services {
+		for i, network := range s.networks {
+			if validated[network] {
+				continue
+			}
+			validated[network] = true
+			// the mdns and bind9 addresses of a network are allocated from
+			// the same range
+			var predictableIPs int32
+			for _, other := range services {
+				if slices.Contains(other.networks, network) {
+					predictableIPs += other.pods
+				}
+			}
+			networkWarns, networkErrs := validateNADRange(
+				ctx,
+				reader,
+				s.path.Index(i),
+				d.Namespace,
+				network,
+				podsOnNetwork(&d.Spec, network),
+				predictableIPs,
+				predictableIPExcludes(d),
+			)
+			warns = append(warns, networkWarns...)
+			errs = append(errs, networkErrs...)
+		}
+	}
+	return warns, errs
+}
+
+// predictableIPExcludes returns the CIDRs excluded from the predictable IPs
+// by the spec, the invalid ones are reported by the validation of the spec
+func predictableIPExcludes(d *designatev1beta1.Designate) []netip.Prefix {
+	exclude, err := designate.ParseExcludes(d.Spec.PredictableIPs.Exclude)
+	if err != nil {
+		return nil
+	}
+	return exclude
+}
+
+// invalid returns the Invalid error of the object of kind for errs, or nil
+// when there is no error
+func invalid(obj client.Object, kind string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "designate.openstack.org", Kind: kind},
+		obj.GetName(), errs)
+}
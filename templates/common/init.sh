@@ -56,3 +56,11 @@ fi
 if ! test -e /var/lib/config-data/merged/custom.conf; then
     echo "# Custom conf - see CustomServiceConfig" > /var/lib/config-data/merged/custom.conf
 fi
+
+# Merge the CustomServiceConfigSecrets snippets into custom.conf
+if test -d /var/lib/config-data/custom-secrets; then
+    for conf in $(find /var/lib/config-data/custom-secrets -type f); do
+        echo merging ${conf} into /var/lib/config-data/merged/custom.conf
+        crudini --merge /var/lib/config-data/merged/custom.conf < ${conf}
+    done
+fi
@@ -1,6 +1,7 @@
 #!/usr/bin/python3
 import os
 import sys
+import time
 import ipaddress
 import netifaces
 from pyroute2 import IPRoute
@@ -23,41 +24,67 @@ namepieces = podname.split('-')
 pod_index = namepieces[-1]
 nodefile = f"{mapping_prefix}{pod_index}"
 
+# the interface of each network attachment is named after it
 interface_name = os.environ.get("NAD_NAME", "designate").strip()
+# the networks other than the control network the pod has a predictable IP on
+networks = [x.strip() for x in os.environ.get("PREDICTABLE_IP_NETWORKS", "").split(",") if x.strip()]
+networks_path = '/var/lib/predictableips-networks'
+# The address of a new replica is added to the ConfigMap right before the
+# pod is created, wait for the kubelet to sync the mounted ConfigMap instead
+# of failing the init container and going through the restart back-off.
+wait_timeout = int(os.environ.get("MAP_WAIT_TIMEOUT", "90"))
 
-print(f"working with address file {nodefile}", file=sys.stderr)
-filename = os.path.join('/var/lib/predictableips', nodefile)
-if not os.path.exists(filename):
-    print(f"Required alias address file {filename} does not exist", file=sys.stderr)
-    sys.exit(1)
 
-ip = IPRoute()
-designateinterface = ip.link_lookup(ifname=interface_name)
+def read_addresses(filename):
+    print(f"working with address file {filename}", file=sys.stderr)
+    deadline = time.monotonic() + wait_timeout
+    while not os.path.exists(filename) and time.monotonic() < deadline:
+        print(f"Waiting for alias address file {filename}", file=sys.stderr)
+        time.sleep(5)
+    if not os.path.exists(filename):
+        print(f"Required alias address file {filename} does not exist", file=sys.stderr)
+        sys.exit(1)
+    with open(filename, "r") as ipfile:
+        return [x.strip() for x in ipfile.read().split(",") if x.strip()]
 
-if not len(designateinterface):
-    print(f"{interface_name} attachment not present", file=sys.stderr)
-    sys.exit(1)
 
+def set_addresses(ip, interface_name, ipaddrs):
+    interface = ip.link_lookup(ifname=interface_name)
+    if not len(interface):
+        print(f"{interface_name} attachment not present", file=sys.stderr)
+        sys.exit(1)
+    for ipaddr in ipaddrs:
+        print(f"Setting {ipaddr} on {interface_name}", file=sys.stderr)
+        # Get our current addresses so we can avoid trying to set the
+        # same address again.
+        version = ipaddress.ip_address(ipaddr).version
+        ifaceinfo = netifaces.ifaddresses(interface_name).get(
+            netifaces.AF_INET if version == 4 else netifaces.AF_INET6, [])
+        current_addresses = [x['addr'].split('%')[0] for x in ifaceinfo]
+        if ipaddr not in current_addresses:
+            mask_value = 32
+            if version == 6:
+                mask_value = 128
+            ip.addr('add', index = interface[0], address=ipaddr, mask=mask_value)
 
-ipfile = open(filename, "r")
-ipaddr = ipfile.read()
-ipfile.close()
 
-if ipaddr:
-    print(f"Setting {ipaddr} on {interface_name}", file=sys.stderr)
-    # output the ipaddr to stdout so that the container-scripts/setipalias.sh can read it
-    print(f"{ipaddr}")
-    # Get our current addresses so we can avoid trying to set the
-    # same address again.
-    version = ipaddress.ip_address(ipaddr).version
-    ifaceinfo = netifaces.ifaddresses(interface_name)[
-        netifaces.AF_INET if version == 4 else netifaces.AF_INET6]
-    current_addresses = [x['addr'] for x in ifaceinfo]
-    if ipaddr not in current_addresses:
-        mask_value = 32
-        if version == 6:
-            mask_value = 128
-        ip.addr('add', index = designateinterface[0], address=ipaddr, mask=mask_value)
+ip = IPRoute()
+alladdrs = []
+targets = [(interface_name, os.path.join('/var/lib/predictableips', nodefile))]
+targets += [(network, os.path.join(networks_path, network, nodefile)) for network in networks]
+for target_interface, filename in targets:
+    ipaddrs = read_addresses(filename)
+    if ipaddrs:
+        set_addresses(ip, target_interface, ipaddrs)
+        alladdrs += ipaddrs
+    else:
+        print(f"No IP address found for {target_interface}", file=sys.stderr)
+
+if alladdrs:
+    # output the addresses to stdout so that the container-scripts/setipalias.sh can read them,
+    # the addresses of the control network come first and on dual stack networks there is one
+    # address per IP version
+    print(",".join(alladdrs))
 else:
     print('No IP address found', file=sys.stderr)
 
@@ -17,4 +17,6 @@ set -ex
 
 # designate-manage database upgrade head
 designate-manage database sync
+# The operator reports the schema revision from the termination message of the pod
+designate-manage database version 2>&1 | tail -n 1 > /dev/termination-log || true
 exit 0
@@ -0,0 +1,100 @@
+#!/usr/bin/env python3
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+#
+# Checks that the services designate depends on are reachable with the
+# config of the db sync job. The failed checks are written to the
+# termination message of the pod, the operator reports them in the
+# DesignatePreflightReady condition.
+
+import configparser
+import functools
+import os
+import socket
+import sys
+from urllib import parse
+
+TIMEOUT = 10
+
+
+def check_database(conf):
+    import sqlalchemy
+
+    engine = sqlalchemy.create_engine(conf.get('database', 'connection'))
+    with engine.connect() as conn:
+        conn.execute(sqlalchemy.text('SELECT 1'))
+
+
+def check_rabbitmq(conf):
+    import amqp
+
+    url = parse.urlsplit(conf.get('DEFAULT', 'transport_url'))
+    ssl = parse.parse_qs(url.query).get('ssl', ['0'])[0].lower() in ('1', 'true', 'yes')
+    vhost = parse.unquote(url.path[1:]) or '/'
+    for netloc in url.netloc.split(','):
+        userinfo, _, host = netloc.rpartition('@')
+        user, _, password = userinfo.partition(':')
+        conn = amqp.Connection(host=host, userid=parse.unquote(user),
+                               password=parse.unquote(password),
+                               virtual_host=vhost, ssl=ssl,
+                               connect_timeout=TIMEOUT)
+        conn.connect()
+        conn.close()
+
+
+def check_coordination(conf):
+    url = parse.urlsplit(conf.get('coordination', 'backend_url', fallback=''))
+    if not url.hostname:
+        return
+    port = url.port
+    if port is None and url.scheme.startswith('redis'):
+        port = 6379
+    socket.create_connection((url.hostname, port), TIMEOUT).close()
+
+
+def check_rndc(target):
+    host, _, port = target.rpartition(':')
+    socket.create_connection((host.strip('[]'), int(port)), TIMEOUT).close()
+
+
+def main():
+    conf = configparser.RawConfigParser(strict=False)
+    conf.read(sys.argv[1:])
+
+    checks = [
+        ('database', functools.partial(check_database, conf)),
+        ('rabbitmq', functools.partial(check_rabbitmq, conf)),
+        ('coordination', functools.partial(check_coordination, conf)),
+    ]
+    for target in os.environ.get('PREFLIGHT_RNDC_TARGETS', '').split():
+        checks.append(('rndc ' + target, functools.partial(check_rndc, target)))
+
+    failed = []
+    for name, check in checks:
+        try:
+            check()
+        except Exception as e:
+            print('%s: failed: %s' % (name, e))
+            failed.append('%s: %s' % (name, e))
+        else:
+            print('%s: ok' % name)
+
+    if failed:
+        with open('/dev/termination-log', 'w') as f:
+            f.write('; '.join(failed))
+        return 1
+    return 0
+
+
+if __name__ == '__main__':
+    sys.exit(main())
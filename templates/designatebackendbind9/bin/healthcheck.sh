@@ -0,0 +1,35 @@
+#!/bin/bash
+#
+# Copyright 2026 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# Liveness and readiness probe of the bind9 container: named has to answer
+# on its control channel and to a SOA query on localhost. named is not
+# authoritative for the root zone, any answer to the query, including
+# REFUSED, shows the query path works. Depending on the IP version named
+# only listens on 127.0.0.1 or on ::1.
+
+if ! rndc -s 127.0.0.1 -p 953 -k /etc/named/rndc-local.key status > /dev/null; then
+    echo "named does not answer on its control channel"
+    exit 1
+fi
+
+for server in 127.0.0.1 ::1; do
+    if dig +norecurse +time=2 +tries=1 -p ${DNS_PORT:-53} @${server} . SOA > /dev/null 2>&1; then
+        exit 0
+    fi
+done
+
+echo "named does not answer DNS queries"
+exit 1
@@ -34,6 +34,20 @@ if [[ -f "/var/lib/tsig/tsigkeys.conf" ]]; then
     echo "Added TSIG configuration to named.conf"
 fi
 
+# Load the response policy zone provided by the user
+if [[ -f "/var/lib/rpz/db.rpz" ]]; then
+    cp /var/lib/rpz/db.rpz /var/lib/config-data/merged/named/db.rpz
+    echo 'include "/etc/named/rpz.conf";' >> /var/lib/config-data/merged/named.conf
+    echo "Added response policy zone to named.conf"
+fi
+
+# Consume the catalog zone of the pool, the member zones are transferred from mdns
+if [[ -n "${CATALOG_ZONE}" ]] && grep -q "@CATALOG_ZONE@" /var/lib/config-data/merged/named/options.conf; then
+    sed -i "s/@CATALOG_ZONE@/${CATALOG_ZONE}/g" /var/lib/config-data/merged/named/options.conf /var/lib/config-data/merged/named/catalogzone.conf
+    echo 'include "/etc/named/catalogzone.conf";' >> /var/lib/config-data/merged/named.conf
+    echo "Added catalog zone ${CATALOG_ZONE} to named.conf"
+fi
+
 # Using the index for the podname, get the matching rndc key and copy it into the proper location
 
 if [[ -z "${POD_NAME}" ]]; then
@@ -66,7 +80,20 @@ fi
 
 if [[ -f "${rndc_key_filename}" ]]; then
     cp ${rndc_key_filename} /var/lib/config-data/merged/named/rndc.key
+    # rndc run inside the pod, e.g. by the preStop hook, only uses the current key
+    cp ${rndc_key_filename} /var/lib/config-data/merged/named/rndc-local.key
 else
     echo "ERROR: rndc key not found at ${rndc_key_filename}!"
     exit 1
 fi
+
+# During an rndc key rotation the new or the previous key is accepted as well
+for suffix in next previous; do
+    if [[ -f "${rndc_key_filename}-${suffix}" ]]; then
+        echo "" >> /var/lib/config-data/merged/named/rndc.key
+        cat "${rndc_key_filename}-${suffix}" >> /var/lib/config-data/merged/named/rndc.key
+        echo "Accepting the ${suffix} rndc key"
+    fi
+done
+rndc_key_names=$(sed -n 's/^key "\([^"]*\)".*/"\1";/p' /var/lib/config-data/merged/named/rndc.key | tr '\n' ' ')
+sed -i "s/@RNDC_KEY_NAMES@/${rndc_key_names}/" /var/lib/config-data/merged/named/rndc.conf
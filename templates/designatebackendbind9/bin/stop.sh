@@ -0,0 +1,33 @@
+#!/bin/bash
+#
+# Copyright 2026 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# preStop hook of the bind9 container: let the running zone transfers
+# complete, for at most XFER_DRAIN_TIMEOUT seconds, then stop named through
+# rndc so the zone changes are flushed to disk before the pod goes away.
+
+RNDC="rndc -s 127.0.0.1 -p 953 -k /etc/named/rndc-local.key"
+deadline=$((SECONDS + ${XFER_DRAIN_TIMEOUT:-60}))
+
+while [[ ${SECONDS} -lt ${deadline} ]]; do
+    xfers=$(${RNDC} status 2>/dev/null | sed -n 's/^xfers running: \([0-9]*\).*/\1/p')
+    if [[ -z "${xfers}" || "${xfers}" -eq 0 ]]; then
+        break
+    fi
+    echo "Waiting for ${xfers} zone transfer(s) to complete"
+    sleep 2
+done
+
+${RNDC} stop
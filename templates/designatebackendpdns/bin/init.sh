@@ -0,0 +1,49 @@
+#!/bin/bash
+#
+# Copyright 2026 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+set -ex
+
+# The PowerDNS image has no crudini, the custom config is appended to
+# pdns.conf where later settings override the earlier ones.
+cp -f /var/lib/config-data/default/pdns.conf /var/lib/config-data/merged/pdns.conf
+if [[ -s /var/lib/config-data/default/${CustomConf} ]]; then
+    cat /var/lib/config-data/default/${CustomConf} >> /var/lib/config-data/merged/pdns.conf
+fi
+
+api_key_file="/var/lib/config-data/api-key/${API_KEY_NAME}"
+if [[ ! -f "${api_key_file}" ]]; then
+    echo "ERROR: PowerDNS API key not found at ${api_key_file}!"
+    exit 1
+fi
+set +x
+echo "api-key=$(cat ${api_key_file})" >> /var/lib/config-data/merged/pdns.conf
+set -x
+
+# Create the zone database on the first start of the pod
+if [[ ! -f /var/lib/powerdns/pdns.sqlite3 ]]; then
+    schema_file="${PDNS_SCHEMA_FILE:-/usr/local/share/doc/pdns/schema.sqlite3.sql}"
+    if ! command -v sqlite3 &> /dev/null; then
+        echo "ERROR: sqlite3 is not available in the PowerDNS image!"
+        exit 1
+    fi
+    if [[ ! -f "${schema_file}" ]]; then
+        echo "ERROR: PowerDNS sqlite3 schema not found at ${schema_file}!"
+        exit 1
+    fi
+    sqlite3 /var/lib/powerdns/pdns.sqlite3 < "${schema_file}"
+fi
+
+chown -R pdns:pdns /var/lib/powerdns /var/lib/config-data/merged
+chmod 0640 /var/lib/config-data/merged/pdns.conf
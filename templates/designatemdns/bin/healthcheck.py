@@ -0,0 +1,35 @@
+#!/usr/bin/python3
+#
+# Copyright 2026 Red Hat Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"); you may
+# not use this file except in compliance with the License. You may obtain
+# a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+# WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+# License for the specific language governing permissions and limitations
+# under the License.
+
+# Startup, liveness and readiness probe of the mdns container: mdns has to
+# answer a SOA query for a canary zone. The canary zone doesn't exist, mdns
+# answers REFUSED, which shows it reads and answers queries.
+import os
+import sys
+
+import dns.exception
+import dns.message
+import dns.query
+
+CANARY_ZONE = "designate-healthcheck.invalid."
+MDNS_PORT = int(os.environ.get("MDNS_PORT", 5354))
+
+query = dns.message.make_query(CANARY_ZONE, "SOA")
+try:
+    dns.query.udp(query, "127.0.0.1", port=MDNS_PORT, timeout=5)
+except (dns.exception.DNSException, OSError) as e:
+    print(f"designate-mdns does not answer SOA queries: {e}", file=sys.stderr)
+    sys.exit(1)
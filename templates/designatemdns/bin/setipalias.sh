@@ -34,19 +34,23 @@ format_listen_addr() {
     fi
 }
 
-IPADDR=$(/usr/local/bin/container-scripts/setipalias.py) || true
+# On dual stack networks setipalias.py returns one address per IP version,
+# separated by commas.
+IPADDRS=$(/usr/local/bin/container-scripts/setipalias.py) || true
 LISTEN_VALUE=""
 SEPARATOR=""
-if [ -n "$IPADDR" ]; then
-    LISTEN_VALUE=$(format_listen_addr "$IPADDR" 5354)
-    SEPARATOR=","
+if [ -n "$IPADDRS" ]; then
+    for IPADDR in ${IPADDRS//,/ }; do
+        LISTEN_VALUE="${LISTEN_VALUE}${SEPARATOR}$(format_listen_addr "$IPADDR" "${MDNS_PORT:-5354}")"
+        SEPARATOR=","
+    done
 else
     echo "No predictable IP found"
 fi
 
 POD_IP=$(grep "$HOSTNAME" /etc/hosts | awk '{print $1}' | head -1)
 if [ -n "$POD_IP" ]; then
-    LISTEN_VALUE="${LISTEN_VALUE}${SEPARATOR}$(format_listen_addr "$POD_IP" 5354)"
+    LISTEN_VALUE="${LISTEN_VALUE}${SEPARATOR}$(format_listen_addr "$POD_IP" "${MDNS_PORT:-5354}")"
 else
     echo "No POD_IP found"
 fi
@@ -359,6 +359,51 @@ func DesignateBackendbind9ConditionGetter(name types.NamespacedName) condition.C
 	return instance.Status.Conditions
 }
 
+// DesignateBackendPDNS
+func GetDefaultDesignateBackendPDNSSpec() map[string]any {
+	return map[string]any{
+		"secret":         SecretName,
+		"containerImage": "repo/designate-backendpdns-image",
+		"serviceAccount": "designate",
+		"apiKeySecret":   designate.DesignatePDNSAPIKeySecret,
+	}
+}
+
+func CreateDesignateBackendPDNS(name types.NamespacedName, spec map[string]any) client.Object {
+	ownerReferences := []map[string]any{{
+		"apiVersion":         "designate.openstack.org/v1beta1",
+		"blockOwnerDeletion": true,
+		"controller":         true,
+		"kind":               "Designate",
+		"name":               "designate",
+		"uid":                uuid.New().String(),
+	}}
+	raw := map[string]any{
+		"apiVersion": "designate.openstack.org/v1beta1",
+		"kind":       "DesignateBackendPDNS",
+		"metadata": map[string]any{
+			"name":            name.Name,
+			"namespace":       name.Namespace,
+			"ownerReferences": ownerReferences,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetDesignateBackendPDNS(name types.NamespacedName) *designatev1.DesignateBackendPDNS {
+	instance := &designatev1.DesignateBackendPDNS{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func DesignateBackendPDNSConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := GetDesignateBackendPDNS(name)
+	return instance.Status.Conditions
+}
+
 // DesignateMdns
 func GetDefaultDesignateMdnsSpec() map[string]any {
 	return map[string]any{
@@ -509,6 +554,66 @@ func DesignateProducerConditionGetter(name types.NamespacedName) condition.Condi
 	return instance.Status.Conditions
 }
 
+// DesignateSink
+func GetDefaultDesignateSinkSpec() map[string]any {
+	return map[string]any{
+		"databaseHostname": "hostname-for-designate-sink",
+		"secret":           SecretName,
+		"containerImage":   "repo/designate-sink-image",
+		"serviceAccount":   "designate",
+		"replicas":         1,
+		"notificationHandlers": []map[string]any{
+			{
+				"name":     "nova_fixed",
+				"zoneID":   "8d1b3b8e-5e29-4c77-a7b6-8bd4d58d0b52",
+				"formatV4": []string{"%(octet0)s-%(octet1)s-%(octet2)s-%(octet3)s.%(zone)s"},
+			},
+			{
+				"name":               "neutron_floatingip",
+				"zoneID":             "8d1b3b8e-5e29-4c77-a7b6-8bd4d58d0b53",
+				"notificationTopics": []string{"notifications", "notifications_designate"},
+				"formatV4":           []string{"%(octet0)s-%(octet1)s-%(octet2)s-%(octet3)s.%(zone)s"},
+				"formatV6":           []string{"%(hostname)s.%(zone)s"},
+			},
+		},
+	}
+}
+
+func CreateDesignateSink(name types.NamespacedName, spec map[string]any) client.Object {
+	ownerReferences := []map[string]any{{
+		"apiVersion":         "designate.openstack.org/v1beta1",
+		"blockOwnerDeletion": true,
+		"controller":         true,
+		"kind":               "Designate",
+		"name":               "designate",
+		"uid":                uuid.New().String(),
+	}}
+	raw := map[string]any{
+		"apiVersion": "designate.openstack.org/v1beta1",
+		"kind":       "DesignateSink",
+		"metadata": map[string]any{
+			"name":            name.Name,
+			"namespace":       name.Namespace,
+			"ownerReferences": ownerReferences,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetDesignateSink(name types.NamespacedName) *designatev1.DesignateSink {
+	instance := &designatev1.DesignateSink{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func DesignateSinkConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := GetDesignateSink(name)
+	return instance.Status.Conditions
+}
+
 // DesignateUnbound
 func GetDefaultUnboundSpec() map[string]any {
 	return map[string]any{
@@ -550,6 +655,129 @@ func GetDesignateUnbound(name types.NamespacedName) *designatev1.DesignateUnboun
 	return instance
 }
 
+// DesignatePool
+func GetDefaultDesignatePoolSpec(designateName string) map[string]any {
+	return map[string]any{
+		"designateName": designateName,
+		"description":   "External pool",
+		"nsRecords": []map[string]any{
+			{"hostname": "ns1.example.org.", "priority": 1},
+		},
+		"nameservers": []map[string]any{
+			{"host": "192.0.2.10", "port": 53},
+		},
+		"targets": []map[string]any{
+			{
+				"type": "pdns4",
+				"masters": []map[string]any{
+					{"host": "192.0.2.1", "port": 5354},
+				},
+				"options": map[string]any{
+					"host":         "192.0.2.10",
+					"api_endpoint": "http://192.0.2.10:8081",
+				},
+			},
+		},
+	}
+}
+
+func CreateDesignatePool(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "designate.openstack.org/v1beta1",
+		"kind":       "DesignatePool",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetDesignatePool(name types.NamespacedName) *designatev1.DesignatePool {
+	instance := &designatev1.DesignatePool{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func DesignatePoolConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := GetDesignatePool(name)
+	return instance.Status.Conditions
+}
+
+// DesignateZone
+func GetDefaultDesignateZoneSpec(designateName string) map[string]any {
+	return map[string]any{
+		"designateName": designateName,
+		"zoneName":      "example.org.",
+		"email":         "admin@example.org",
+	}
+}
+
+func CreateDesignateZone(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "designate.openstack.org/v1beta1",
+		"kind":       "DesignateZone",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetDesignateZone(name types.NamespacedName) *designatev1.DesignateZone {
+	instance := &designatev1.DesignateZone{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func DesignateZoneConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := GetDesignateZone(name)
+	return instance.Status.Conditions
+}
+
+// DesignateRecordSet
+func GetDefaultDesignateRecordSetSpec(zoneRef string) map[string]any {
+	return map[string]any{
+		"zoneRef": zoneRef,
+		"name":    "www.example.org.",
+		"type":    "A",
+		"records": []string{"192.0.2.10"},
+	}
+}
+
+func CreateDesignateRecordSet(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "designate.openstack.org/v1beta1",
+		"kind":       "DesignateRecordSet",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetDesignateRecordSet(name types.NamespacedName) *designatev1.DesignateRecordSet {
+	instance := &designatev1.DesignateRecordSet{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func DesignateRecordSetConditionGetter(name types.NamespacedName) condition.Conditions {
+	instance := GetDesignateRecordSet(name)
+	return instance.Status.Conditions
+}
+
 func CreateBindIPMap(namespace string, configData map[string]any) client.Object {
 	raw := map[string]any{
 		"apiVersion": "v1",
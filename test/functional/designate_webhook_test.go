@@ -26,6 +26,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 )
 
 var _ = Describe("Designate webhook", func() {
@@ -75,4 +77,354 @@ var _ = Describe("Designate webhook", func() {
 				ContainSubstring("use \"spec.messagingBus.cluster\" instead"))
 		}, timeout, interval).Should(Succeed())
 	})
+
+	It("rejects a bind9 storageRequest which is not a quantity", func() {
+		spec := GetDefaultDesignateSpec(1, 1, 0)
+		spec["designateBackendbind9"] = map[string]any{
+			"replicas":       1,
+			"storageRequest": "lots",
+		}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "Designate",
+			"metadata": map[string]any{
+				"name":      "designate-storage-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("Designate"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("spec.designateBackendbind9.storageRequest"))
+	})
+
+	It("rejects an unknown bind9 volume claim retention policy", func() {
+		spec := GetDefaultDesignateSpec(1, 1, 0)
+		spec["designateBackendbind9"] = map[string]any{
+			"replicas": 1,
+			"persistentVolumeClaimRetentionPolicy": map[string]any{
+				"whenDeleted": "Orphan",
+			},
+		}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "Designate",
+			"metadata": map[string]any{
+				"name":      "designate-retention-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("Designate"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("spec.designateBackendbind9.persistentVolumeClaimRetentionPolicy.whenDeleted"))
+	})
+
+	It("rejects a Designate without databaseInstance nor externalDatabase", func() {
+		spec := GetDefaultDesignateSpec(1, 1, 0)
+		delete(spec, "databaseInstance")
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "Designate",
+			"metadata": map[string]any{
+				"name":      "designate-database-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("Designate"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("databaseInstance is required unless externalDatabase is set"))
+	})
+
+	It("rejects PowerDNS backends on the host network", func() {
+		spec := GetDefaultDesignateSpec(1, 1, 0)
+		spec["predictableIPs"] = map[string]any{
+			"mode": "HostNetwork",
+		}
+		spec["designateBackendPDNS"] = map[string]any{
+			"replicas": 1,
+		}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "Designate",
+			"metadata": map[string]any{
+				"name":      "designate-hostnetwork-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("Designate"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("designateBackendPDNS requires a control network"))
+	})
+
+	It("rejects a bind9 query network without a predictable IP", func() {
+		spec := GetDefaultDesignateSpec(1, 1, 0)
+		spec["designateBackendbind9"] = map[string]any{
+			"replicas":              1,
+			"networkAttachments":    []string{"designate", "public"},
+			"predictableIPNetworks": []string{"public"},
+			"queryNetworkName":      "designate",
+		}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "Designate",
+			"metadata": map[string]any{
+				"name":      "designate-querynetwork-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("Designate"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("spec.designateBackendbind9.queryNetworkName"))
+	})
+
+	It("rejects a static IP pinned to both a mdns and a bind9 pod", func() {
+		spec := GetDefaultDesignateSpec(1, 1, 0)
+		spec["designateMdns"] = map[string]any{
+			"replicas":  1,
+			"staticIPs": map[string]string{"0": "172.28.0.200"},
+		}
+		spec["designateBackendbind9"] = map[string]any{
+			"replicas":  1,
+			"staticIPs": map[string]string{"0": "172.28.0.200"},
+		}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "Designate",
+			"metadata": map[string]any{
+				"name":      "designate-staticips-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("Designate"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("spec.designateBackendbind9.staticIPs[0]: Duplicate value"))
+	})
+
+	It("rejects a DesignateBackendbind9 control network which is not attached", func() {
+		spec := GetDefaultDesignateBackendbind9Spec()
+		spec["controlNetworkName"] = "designate"
+		spec["networkAttachments"] = []string{"internalapi"}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "DesignateBackendbind9",
+			"metadata": map[string]any{
+				"name":      "designate-bind9-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("DesignateBackendbind9"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("spec.controlNetworkName"))
+	})
+
+	It("rejects DesignateBackendbind9 views without a view of the managed zones", func() {
+		spec := GetDefaultDesignateBackendbind9Spec()
+		spec["views"] = []map[string]any{
+			{"name": "internal", "matchClients": []string{"10.0.0.0/8"}},
+			{"name": "external", "matchClients": []string{"any"}},
+		}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "DesignateBackendbind9",
+			"metadata": map[string]any{
+				"name":      "designate-bind9-views-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("DesignateBackendbind9"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("exactly one view must set managedZones"))
+	})
+
+	It("rejects DesignateBackendbind9 hidden primaries without valid secondary servers", func() {
+		spec := GetDefaultDesignateBackendbind9Spec()
+		spec["hiddenPrimary"] = true
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "DesignateBackendbind9",
+			"metadata": map[string]any{
+				"name":      "designate-bind9-hidden-primary-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("requires secondaryServers"))
+
+		spec["secondaryServers"] = []map[string]any{
+			{"name": "corp1", "host": "ns1.example.com"},
+		}
+		err = k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("must be an IP address"))
+		Expect(statusError.ErrStatus.Message).ToNot(
+			ContainSubstring("requires secondaryServers"))
+	})
+
+	It("rejects a DesignateMdns with more replicas than control network addresses", func() {
+		nad := th.CreateUnstructured(map[string]any{
+			"apiVersion": "k8s.cni.cncf.io/v1",
+			"kind":       "NetworkAttachmentDefinition",
+			"metadata": map[string]any{
+				"name":      "designate-small",
+				"namespace": namespace,
+			},
+			"spec": map[string]any{
+				"config": `{
+					"cniVersion": "0.3.1",
+					"name": "designate-small",
+					"type": "bridge",
+					"ipam": {
+						"type": "whereabouts",
+						"range": "172.28.0.0/24",
+						"range_start": "172.28.0.30",
+						"range_end": "172.28.0.31"
+					}
+				}`,
+			},
+		})
+		DeferCleanup(k8sClient.Delete, ctx, nad)
+
+		spec := GetDefaultDesignateMdnsSpec()
+		spec["replicas"] = 3
+		spec["controlNetworkName"] = "designate-small"
+		spec["networkAttachments"] = []string{"designate-small"}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "DesignateMdns",
+			"metadata": map[string]any{
+				"name":      "designate-mdns-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		err := k8sClient.Create(ctx, &unstructured.Unstructured{Object: raw})
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Details.Kind).To(Equal("DesignateMdns"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("too small for 3 pods"))
+	})
+
+	It("defaults the container images of the sub-CRs", func() {
+		unboundName := types.NamespacedName{
+			Namespace: namespace,
+			Name:      "designate-unbound-webhook-test",
+		}
+		spec := GetDefaultUnboundSpec()
+		delete(spec, "containerImage")
+		DeferCleanup(th.DeleteInstance, CreateDesignateUnbound(unboundName, spec))
+
+		Expect(GetDesignateUnbound(unboundName).Spec.ContainerImage).To(
+			Equal(designatev1.DesignateUnboundContainerImage))
+
+		bind9Name := types.NamespacedName{
+			Namespace: namespace,
+			Name:      "designate-bind9-defaults-webhook-test",
+		}
+		spec = GetDefaultDesignateBackendbind9Spec()
+		delete(spec, "containerImage")
+		DeferCleanup(th.DeleteInstance, CreateDesignateBackendbind9(bind9Name, spec))
+
+		bind9 := GetDesignateBackendbind9(bind9Name)
+		Expect(bind9.Spec.ContainerImage).To(Equal(designatev1.DesignateBackendbind9ContainerImage))
+		Expect(bind9.Spec.NetUtilsImage).To(Equal(designatev1.NetUtilsContainerImage))
+		Expect(bind9.Spec.Metrics.ExporterImage).To(Equal(designatev1.Bind9ExporterContainerImage))
+	})
+
+	It("rejects a DesignateUnbound with an invalid forward zone", func() {
+		spec := GetDefaultUnboundSpec()
+		spec["stubZones"] = []map[string]any{{"name": "example.org"}}
+		spec["forwardZones"] = []map[string]any{
+			{"name": "example.org.", "forwarders": []string{"192.0.2.1"}},
+			{"name": ".", "forwarders": []string{"dns.example.com"}},
+		}
+
+		raw := map[string]any{
+			"apiVersion": "designate.openstack.org/v1beta1",
+			"kind":       "DesignateUnbound",
+			"metadata": map[string]any{
+				"name":      "designate-unbound-forward-webhook-test",
+				"namespace": namespace,
+			},
+			"spec": spec,
+		}
+
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).Should(HaveOccurred())
+		var statusError *k8s_errors.StatusError
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("zone is already configured as a stub zone"))
+		Expect(statusError.ErrStatus.Message).To(
+			ContainSubstring("must be an IP address with an optional @port suffix"))
+	})
 })
@@ -29,6 +29,7 @@ import (
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	//revive:disable-next-line:dot-imports
+	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
@@ -226,6 +227,66 @@ var _ = Describe("DesignateAPI controller", func() {
 				ContainSubstring("[DEFAULT]\ndebug=True\n"))
 		})
 
+		It("renders the API policy override into the config", func() {
+			Eventually(func(g Gomega) {
+				designateAPI := GetDesignateAPI(designateAPIName)
+				designateAPI.Spec.APIPolicyOverride = &designatev1.APIPolicyOverrideSpec{
+					Policy: "\"create_zone\": \"role:admin\"\n",
+				}
+				g.Expect(k8sClient.Update(ctx, designateAPI)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				configData := th.GetSecret(
+					types.NamespacedName{
+						Namespace: designateAPIName.Namespace,
+						Name:      fmt.Sprintf("%s-config-data", designateAPIName.Name)})
+				g.Expect(string(configData.Data["policy.yaml"])).Should(
+					Equal("\"create_zone\": \"role:admin\"\n"))
+				g.Expect(string(configData.Data["designate.conf"])).Should(
+					ContainSubstring("policy_file=/etc/designate/policy.yaml"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("renders only the internal vhost when the API is internal only", func() {
+			Eventually(func(g Gomega) {
+				designateAPI := GetDesignateAPI(designateAPIName)
+				designateAPI.Spec.InternalOnly = true
+				g.Expect(k8sClient.Update(ctx, designateAPI)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				configData := th.GetSecret(
+					types.NamespacedName{
+						Namespace: designateAPIName.Namespace,
+						Name:      fmt.Sprintf("%s-config-data", designateAPIName.Name)})
+				httpdConf := string(configData.Data["httpd.conf"])
+				g.Expect(httpdConf).Should(ContainSubstring("# internal vhost"))
+				g.Expect(httpdConf).ShouldNot(ContainSubstring("# public vhost"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("renders the kolla config of the read-only replicas", func() {
+			Eventually(func(g Gomega) {
+				designateAPI := GetDesignateAPI(designateAPIName)
+				designateAPI.Spec.ReadOnly = &designatev1.APIReadOnlySpec{
+					DatabaseHostname: "galera-replica",
+				}
+				g.Expect(k8sClient.Update(ctx, designateAPI)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				configData := th.GetSecret(
+					types.NamespacedName{
+						Namespace: designateAPIName.Namespace,
+						Name:      fmt.Sprintf("%s-config-data", designateAPIName.Name)})
+				g.Expect(string(configData.Data["designate-api-readonly-config.json"])).Should(
+					ContainSubstring("/etc/designate/designate.conf.d/readonly.conf"))
+				g.Expect(string(configData.Data["designate-api-config.json"])).ShouldNot(
+					ContainSubstring("readonly.conf"))
+			}, timeout, interval).Should(Succeed())
+		})
+
 		It("updates the KeystoneAuthURL if keystone internal endpoint changes", func() {
 			newInternalEndpoint := "https://keystone-internal"
 
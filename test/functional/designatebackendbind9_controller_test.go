@@ -153,6 +153,99 @@ var _ = Describe("DesignateBackendbind9 controller", func() {
 			)
 		})
 
+		It("should render the graceful shutdown script", func() {
+			scripts := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-scripts", designateBackendbind9Name.Name),
+			})
+			Expect(scripts.Data).Should(HaveKey("stop.sh"))
+			Expect(string(scripts.Data["stop.sh"])).Should(ContainSubstring("${RNDC} stop"))
+
+			configNamed := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-named", designateBackendbind9Name.Name),
+			})
+			Expect(string(configNamed.Data["rndc.conf"])).Should(ContainSubstring("allow { 127.0.0.1;"))
+		})
+
+		It("should not sign the default pool transfers by default", func() {
+			th.ExpectCondition(
+				designateBackendbind9Name,
+				ConditionGetterFunc(DesignateBackendbind9ConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+			th.AssertSecretDoesNotExist(types.NamespacedName{
+				Namespace: namespace,
+				Name:      designateBackendbind9Name.Name + designate.ControlTSIGSecretSuffix,
+			})
+		})
+
+		It("should not enable the statistics channel by default", func() {
+			configNamed := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-named", designateBackendbind9Name.Name),
+			})
+			Expect(configNamed.Data).Should(HaveKey("statistics.conf"))
+			Expect(string(configNamed.Data["statistics.conf"])).ShouldNot(ContainSubstring("statistics-channels"))
+			Expect(string(configNamed.Data["options.conf"])).ShouldNot(ContainSubstring("zone-statistics"))
+		})
+
+		It("should send the named logs to the container output by default", func() {
+			configNamed := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-named", designateBackendbind9Name.Name),
+			})
+			Expect(string(configNamed.Data["logging.conf"])).Should(
+				ContainSubstring("channel default_channel {\n        stderr;\n"))
+		})
+
+		It("should not log the queries nor limit the responses by default", func() {
+			configNamed := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-named", designateBackendbind9Name.Name),
+			})
+			Expect(string(configNamed.Data["logging.conf"])).ShouldNot(ContainSubstring("category queries"))
+			Expect(string(configNamed.Data["options.conf"])).ShouldNot(ContainSubstring("rate-limit"))
+			Expect(string(configNamed.Data["options.conf"])).ShouldNot(ContainSubstring("response-policy"))
+		})
+
+		It("should not use GeoIP databases by default", func() {
+			configNamed := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-named", designateBackendbind9Name.Name),
+			})
+			Expect(string(configNamed.Data["options.conf"])).ShouldNot(ContainSubstring("geoip-directory"))
+		})
+
+		It("should not allow zone transfers without secondary servers", func() {
+			configNamed := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-named", designateBackendbind9Name.Name),
+			})
+			Expect(string(configNamed.Data["options.conf"])).ShouldNot(ContainSubstring("allow-transfer"))
+			Expect(configNamed.Data).Should(HaveKeyWithValue("secondarykeys.conf", BeEmpty()))
+		})
+
+		It("should listen on the standard DNS port", func() {
+			configNamed := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-named", designateBackendbind9Name.Name),
+			})
+			Expect(string(configNamed.Data["options.conf"])).Should(ContainSubstring("listen-on port 53 { any; };"))
+		})
+
+		It("should report the pods without a predictable IP", func() {
+			th.ExpectConditionWithDetails(
+				designateBackendbind9Name,
+				ConditionGetterFunc(DesignateBackendbind9ConditionGetter),
+				designatev1.DesignatePredictableIPsReadyCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				fmt.Sprintf("No predictable IP reserved for pods %s-0", designateBackendbind9Name.Name),
+			)
+		})
+
 		It("should add predictableip labels to pods", func() {
 			// Create predictable IP configmap
 			configData := map[string]any{
@@ -197,8 +290,9 @@ var _ = Describe("DesignateBackendbind9 controller", func() {
 				logger,
 			)
 			Expect(err).ShouldNot(HaveOccurred())
-			err = designate.HandlePodLabeling(ctx, h, designateBackendbind9Name.Name, namespace, config)
+			podIPs, err := designate.HandlePodLabeling(ctx, h, designateBackendbind9Name.Name, namespace, config)
 			Expect(err).ShouldNot(HaveOccurred())
+			Expect(podIPs).Should(Equal(map[string]string{podName.Name: "172.28.0.31"}))
 
 			// Verify the pod has the predictableip label
 			Eventually(func(g Gomega) {
@@ -254,7 +348,7 @@ var _ = Describe("DesignateBackendbind9 controller", func() {
 				logger,
 			)
 			Expect(err).ShouldNot(HaveOccurred())
-			err = designate.HandlePodLabeling(ctx, h, designateBackendbind9Name.Name, namespace, config)
+			_, err = designate.HandlePodLabeling(ctx, h, designateBackendbind9Name.Name, namespace, config)
 			Expect(err).ShouldNot(HaveOccurred())
 
 			// Verify the label was updated to match the configmap's IP
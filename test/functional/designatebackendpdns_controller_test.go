@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("DesignateBackendPDNS controller", func() {
+	var spec map[string]any
+	var designateBackendPDNSName types.NamespacedName
+
+	BeforeEach(func() {
+		spec = GetDefaultDesignateBackendPDNSSpec()
+		designateBackendPDNSName = types.NamespacedName{
+			Name:      fmt.Sprintf("designate-backendpdns-%s", uuid.New().String()),
+			Namespace: namespace,
+		}
+	})
+
+	When("a DesignateBackendPDNS instance is created", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateDesignateBackendPDNS(designateBackendPDNSName, spec))
+		})
+
+		It("should have the Status fields initialized", func() {
+			designateBackendPDNS := GetDesignateBackendPDNS(designateBackendPDNSName)
+			Expect(designateBackendPDNS.Status.ReadyCount).Should(Equal(int32(0)))
+		})
+
+		It("should have a finalizer", func() {
+			Eventually(func() []string {
+				return GetDesignateBackendPDNS(designateBackendPDNSName).Finalizers
+			}, timeout, interval).Should(ContainElement("openstack.org/designatebackendpdns"))
+		})
+	})
+
+	When("the control network attachment exists", func() {
+		BeforeEach(func() {
+			DeferCleanup(k8sClient.Delete, ctx, CreateNAD(types.NamespacedName{
+				Name:      "designate",
+				Namespace: namespace,
+			}))
+			spec["networkAttachments"] = []string{"designate"}
+			spec["customServiceConfig"] = "loglevel=6"
+			DeferCleanup(th.DeleteInstance, CreateDesignateBackendPDNS(designateBackendPDNSName, spec))
+		})
+
+		It("should render pdns.conf for the control network", func() {
+			configData := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-data", designateBackendPDNSName.Name),
+			})
+			Expect(configData).ShouldNot(BeNil())
+			pdnsConf := string(configData.Data["pdns.conf"])
+			Expect(pdnsConf).Should(ContainSubstring("allow-notify-from=172.28.0.0/24"))
+			Expect(pdnsConf).Should(ContainSubstring("webserver-port=8081"))
+			Expect(pdnsConf).Should(ContainSubstring("local-address=0.0.0.0"))
+			Expect(string(configData.Data["custom.conf"])).Should(Equal("loglevel=6"))
+		})
+	})
+})
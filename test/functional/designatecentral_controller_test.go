@@ -23,10 +23,11 @@ import (
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	// "sigs.k8s.io/controller-runtime/pkg/client"
 	// revive:disable-next-line:dot-imports
 	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
@@ -84,6 +85,17 @@ var _ = Describe("DesignateCentral controller", func() {
 			Expect(designateCentral.Spec.PasswordSelectors.Service).Should(Equal("DesignatePassword"))
 		})
 
+		It("should be scalable through the scale subresource", func() {
+			scale := &autoscalingv1.Scale{}
+			Expect(k8sClient.SubResource("scale").Get(ctx, GetDesignateCentral(designateCentralName), scale)).Should(Succeed())
+			Expect(scale.Spec.Replicas).Should(Equal(int32(1)))
+
+			scale.Spec.Replicas = 2
+			Expect(k8sClient.SubResource("scale").Update(
+				ctx, GetDesignateCentral(designateCentralName), client.WithSubResourceBody(scale))).Should(Succeed())
+			Expect(*GetDesignateCentral(designateCentralName).Spec.Replicas).Should(Equal(int32(2)))
+		})
+
 		It("should not create a secret", func() {
 			secret := types.NamespacedName{
 				Namespace: designateCentralName.Namespace,
@@ -109,6 +121,26 @@ var _ = Describe("DesignateCentral controller", func() {
 		})
 	})
 
+	When("a customServiceConfigSecrets Secret is missing", func() {
+		BeforeEach(func() {
+			spec["customServiceConfigSecrets"] = []string{"central-custom-config"}
+			DeferCleanup(th.DeleteInstance, CreateDesignateCentral(designateCentralName, spec))
+			DeferCleanup(k8sClient.Delete, ctx, CreateDesignateSecret(namespace))
+			DeferCleanup(k8sClient.Delete, ctx, CreateTransportURLSecret(transportURLSecretName))
+		})
+
+		It("should wait for the Secret", func() {
+			th.ExpectConditionWithDetails(
+				designateCentralName,
+				ConditionGetterFunc(DesignateCentralConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				condition.InputReadyWaitingMessage,
+			)
+		})
+	})
+
 	// Notes: DesignateCentral's config file is basically hard coded and merged with the main config file
 	// in the designate controller.
 	When("config files are created", func() {
@@ -166,6 +198,18 @@ var _ = Describe("DesignateCentral controller", func() {
 			)
 		})
 
+		It("should report the hash of every input of the pods", func() {
+			th.ExpectCondition(
+				designateCentralName,
+				ConditionGetterFunc(DesignateCentralConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+			inputHashes := GetDesignateCentral(designateCentralName).Status.InputHashes
+			Expect(inputHashes).Should(HaveKey(fmt.Sprintf("%s-config-data", designateCentralName.Name)))
+			Expect(inputHashes).Should(HaveKey("secret-" + transportURLSecretName.Name))
+		})
+
 		// Notes: DesignateCentral's config file is basically hard coded and merged with the main
 		// config file in the designate controller so there is no need to test this here. The
 		// customServiceConfig however is specific to this controller so should be here.
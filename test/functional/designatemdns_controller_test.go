@@ -113,6 +113,33 @@ var _ = Describe("DesignateMdns controller", func() {
 			)
 		})
 
+		It("renders the mdns tuning into designate.conf", func() {
+			Eventually(func(g Gomega) {
+				configData := th.GetSecret(
+					types.NamespacedName{
+						Namespace: designateMdnsName.Namespace,
+						Name:      fmt.Sprintf("%s-config-data", designateMdnsName.Name)})
+				g.Expect(string(configData.Data["designate.conf"])).Should(
+					ContainSubstring("workers=2\nthreads=1000\ntcp_backlog=100\nxfr_timeout=10\n"))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				designateMdns := GetDesignateMdns(designateMdnsName)
+				designateMdns.Spec.Workers = 4
+				designateMdns.Spec.XFRTimeout = 60
+				g.Expect(k8sClient.Update(ctx, designateMdns)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				configData := th.GetSecret(
+					types.NamespacedName{
+						Namespace: designateMdnsName.Namespace,
+						Name:      fmt.Sprintf("%s-config-data", designateMdnsName.Name)})
+				g.Expect(string(configData.Data["designate.conf"])).Should(
+					ContainSubstring("workers=4\nthreads=1000\ntcp_backlog=100\nxfr_timeout=60\n"))
+			}, timeout, interval).Should(Succeed())
+		})
+
 		It("should add predictableip labels to pods", func() {
 			// Create predictable IP configmap for mdns
 			configMap := &corev1.ConfigMap{
@@ -177,7 +204,7 @@ var _ = Describe("DesignateMdns controller", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(podList.Items).To(HaveLen(1), "Should find exactly one pod")
 
-			err = designate.HandlePodLabeling(ctx, h, designateMdnsName.Name, namespace, config)
+			_, err = designate.HandlePodLabeling(ctx, h, designateMdnsName.Name, namespace, config)
 			Expect(err).ShouldNot(HaveOccurred())
 
 			// Verify the pod has the predictableip label
@@ -242,7 +269,7 @@ var _ = Describe("DesignateMdns controller", func() {
 			)
 			Expect(err).ShouldNot(HaveOccurred())
 
-			err = designate.HandlePodLabeling(ctx, h, designateMdnsName.Name, namespace, config)
+			_, err = designate.HandlePodLabeling(ctx, h, designateMdnsName.Name, namespace, config)
 			Expect(err).ShouldNot(HaveOccurred())
 
 			// Verify the label was updated to match the configmap's IP
@@ -0,0 +1,288 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+)
+
+// getPoolJobConfigHash returns the CONFIG_HASH of the pool job
+func getPoolJobConfigHash(g Gomega, name types.NamespacedName) string {
+	job := th.GetJob(name)
+	g.Expect(job.Spec.Template.Spec.Containers).To(HaveLen(1))
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "CONFIG_HASH" {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+var _ = Describe("DesignatePool controller", func() {
+	var designateName types.NamespacedName
+	var designatePoolName types.NamespacedName
+	var poolUpdateJobName types.NamespacedName
+
+	BeforeEach(func() {
+		designateName = types.NamespacedName{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("designate-%s", uuid.New().String()[:10]),
+		}
+		designatePoolName = types.NamespacedName{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("pool-%s", uuid.New().String()[:10]),
+		}
+		poolUpdateJobName = types.NamespacedName{
+			Namespace: namespace,
+			Name:      designatePoolName.Name + "-pool-update",
+		}
+	})
+
+	When("a DesignatePool referencing a Designate is created", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateDesignate(designateName, GetDefaultDesignateSpec(1, 1, 1)))
+			simulateCentralReadyCount(designateName, 1)
+			DeferCleanup(th.DeleteInstance, CreateDesignatePool(designatePoolName, GetDefaultDesignatePoolSpec(designateName.Name)))
+		})
+
+		It("should render the pool into its pools.yaml ConfigMap", func() {
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+
+			poolsYamlConfigMap := th.GetConfigMap(types.NamespacedName{
+				Name:      designate.PoolConfigMapName(designatePoolName.Name),
+				Namespace: namespace})
+
+			var pools []designate.Pool
+			Expect(yaml.Unmarshal([]byte(poolsYamlConfigMap.Data[designate.PoolsYamlContent]), &pools)).To(Succeed())
+			Expect(pools).To(HaveLen(1))
+			Expect(pools[0].Name).To(Equal(designatePoolName.Name))
+			Expect(pools[0].Description).To(Equal("External pool"))
+			Expect(pools[0].Nameservers).To(Equal([]designate.Nameserver{{Host: "192.0.2.10", Port: 53}}))
+			Expect(pools[0].Targets).To(HaveLen(1))
+			Expect(pools[0].Targets[0].Type).To(Equal("pdns4"))
+			Expect(pools[0].Targets[0].Masters).To(Equal([]designate.Master{{Host: "192.0.2.1", Port: 5354}}))
+			Expect(pools[0].Targets[0].Options.Host).To(Equal("192.0.2.10"))
+			Expect(pools[0].Targets[0].Options.Extra).To(Equal(map[string]string{
+				"api_endpoint": "http://192.0.2.10:8081",
+			}))
+		})
+
+		It("should run the pool update job and re-run it when the pool changes", func() {
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				designatev1.DesignatePoolUpdateReadyCondition,
+				corev1.ConditionFalse,
+			)
+
+			var firstConfigHash string
+			Eventually(func(g Gomega) {
+				firstConfigHash = getPoolJobConfigHash(g, poolUpdateJobName)
+				g.Expect(firstConfigHash).ToNot(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+			th.SimulateJobSuccess(poolUpdateJobName)
+
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				designatev1.DesignatePoolUpdateReadyCondition,
+				corev1.ConditionTrue,
+			)
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionTrue,
+			)
+			firstJobHash := GetDesignatePool(designatePoolName).Status.Hash[designatev1.PoolUpdateHash]
+			Expect(firstJobHash).ToNot(BeEmpty())
+
+			Eventually(func(g Gomega) {
+				pool := GetDesignatePool(designatePoolName)
+				pool.Spec.Description = "Updated external pool"
+				g.Expect(k8sClient.Update(ctx, pool)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			// the succeeded job is replaced by one applying the new pools.yaml
+			Eventually(func(g Gomega) {
+				g.Expect(getPoolJobConfigHash(g, poolUpdateJobName)).ToNot(Equal(firstConfigHash))
+			}, timeout, interval).Should(Succeed())
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				designatev1.DesignatePoolUpdateReadyCondition,
+				corev1.ConditionFalse,
+			)
+			th.SimulateJobSuccess(poolUpdateJobName)
+
+			Eventually(func(g Gomega) {
+				pool := GetDesignatePool(designatePoolName)
+				g.Expect(pool.Status.Hash[designatev1.PoolUpdateHash]).ToNot(Equal(firstJobHash))
+			}, timeout, interval).Should(Succeed())
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				designatev1.DesignatePoolUpdateReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("should remove the pool from designate when deleted", func() {
+			th.SimulateJobSuccess(poolUpdateJobName)
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionTrue,
+			)
+
+			// the pools of the Designate CR itself
+			designatePoolsYaml := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      designate.PoolsYamlConfigMap,
+					Namespace: namespace,
+				},
+				Data: map[string]string{
+					designate.PoolsYamlContent: `---
+- name: default
+  description: Default pool
+`,
+				},
+			}
+			Expect(k8sClient.Create(ctx, designatePoolsYaml)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, designatePoolsYaml)
+
+			Expect(k8sClient.Delete(ctx, GetDesignatePool(designatePoolName))).To(Succeed())
+
+			// the remaining pools are applied with --delete
+			poolDeleteJobName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      designatePoolName.Name + "-pool-delete",
+			}
+			poolDeleteJob := th.GetJob(poolDeleteJobName)
+			Expect(poolDeleteJob.Spec.Template.Spec.Containers[0].Command[2]).To(HaveSuffix("--delete"))
+
+			deleteConfigMap := th.GetConfigMap(types.NamespacedName{
+				Name:      designate.PoolDeleteConfigMapName(designatePoolName.Name),
+				Namespace: namespace})
+			var pools []designate.Pool
+			Expect(yaml.Unmarshal([]byte(deleteConfigMap.Data[designate.PoolsYamlContent]), &pools)).To(Succeed())
+			Expect(pools).To(HaveLen(1))
+			Expect(pools[0].Name).To(Equal(designate.DefaultPoolName))
+
+			// the finalizer is kept until the job completes
+			Consistently(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, designatePoolName, &designatev1.DesignatePool{})).To(Succeed())
+			}, "3s", interval).Should(Succeed())
+
+			th.SimulateJobSuccess(poolDeleteJobName)
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, designatePoolName, &designatev1.DesignatePool{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a DesignatePool manages the default pool of a Designate", func() {
+		var spec map[string]any
+
+		BeforeEach(func() {
+			spec = GetDefaultDesignateSpec(1, 1, 1)
+			transportURLName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      designateName.Name + "-designate-transport",
+			}
+			transportURLSecretName := types.NamespacedName{
+				Namespace: namespace,
+				Name:      RabbitmqSecretName,
+			}
+
+			poolSpec := GetDefaultDesignatePoolSpec(designateName.Name)
+			poolSpec["poolName"] = designate.DefaultPoolName
+			DeferCleanup(th.DeleteInstance, CreateDesignatePool(designatePoolName, poolSpec))
+
+			createAndSimulateKeystone(designateName)
+			createAndSimulateRedis(types.NamespacedName{Namespace: namespace, Name: "designate-redis"})
+			createAndSimulateDesignateSecrets(designateName)
+			createAndSimulateTransportURL(transportURLName, transportURLSecretName)
+			createAndSimulateDB(spec)
+
+			DeferCleanup(k8sClient.Delete, ctx, CreateNAD(types.NamespacedName{
+				Name:      spec["designateNetworkAttachment"].(string),
+				Namespace: namespace,
+			}))
+			DeferCleanup(th.DeleteInstance, CreateDesignate(designateName, spec))
+			th.SimulateJobSuccess(types.NamespacedName{Namespace: namespace, Name: designateName.Name + "-db-sync"})
+
+			createAndSimulateBind9(types.NamespacedName{Namespace: namespace, Name: fmt.Sprintf("designate-bind9-%s", uuid.New().String())})
+			createAndSimulateMdns(types.NamespacedName{Namespace: namespace, Name: fmt.Sprintf("designate-mdns-%s", uuid.New().String())})
+			createAndSimulateNSRecordsConfigMap(types.NamespacedName{Namespace: namespace, Name: designate.NsRecordsConfigMap})
+			simulateCentralReadyCount(designateName, 1)
+		})
+
+		It("should leave the pool to the DesignatePool and take it back on delete", func() {
+			poolsYamlConfigMapName := types.NamespacedName{
+				Name:      designate.PoolsYamlConfigMap,
+				Namespace: namespace,
+			}
+
+			th.ExpectCondition(
+				designatePoolName,
+				ConditionGetterFunc(DesignatePoolConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+			Consistently(func(g Gomega) {
+				err := k8sClient.Get(ctx, poolsYamlConfigMapName, &corev1.ConfigMap{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, "3s", interval).Should(Succeed())
+
+			th.DeleteInstance(GetDesignatePool(designatePoolName))
+
+			Eventually(func(g Gomega) {
+				poolsYamlConfigMap := &corev1.ConfigMap{}
+				g.Expect(k8sClient.Get(ctx, poolsYamlConfigMapName, poolsYamlConfigMap)).To(Succeed())
+
+				var pools []designate.Pool
+				g.Expect(yaml.Unmarshal([]byte(poolsYamlConfigMap.Data[designate.PoolsYamlContent]), &pools)).To(Succeed())
+				g.Expect(pools).To(HaveLen(1))
+				g.Expect(pools[0].Name).To(Equal(designate.DefaultPoolName))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
@@ -126,6 +126,15 @@ var _ = Describe("DesignateProducer controller", func() {
 			createOwnerSecrets(namespace)
 
 			spec["customServiceConfig"] = "[DEFAULT]\ndebug=True\n"
+			spec["zonePurge"] = map[string]any{
+				"age": 86400,
+			}
+			spec["logging"] = map[string]any{
+				"level":             "debug",
+				"json":              true,
+				"rateLimitInterval": 30,
+				"rateLimitBurst":    100,
+			}
 			DeferCleanup(th.DeleteInstance, CreateDesignateProducer(designateProducerName, spec))
 
 			mariaDBDatabaseName := mariadb.CreateMariaDBDatabase(namespace, designate.DatabaseCRName, mariadbv1.MariaDBDatabaseSpec{})
@@ -175,5 +184,27 @@ var _ = Describe("DesignateProducer controller", func() {
 			Expect(conf).Should(
 				ContainSubstring("[DEFAULT]\ndebug=True\n"))
 		})
+
+		It("should configure the purge of the deleted zones", func() {
+			configData := th.GetSecret(
+				types.NamespacedName{
+					Namespace: designateProducerName.Namespace,
+					Name:      fmt.Sprintf("%s-config-data", designateProducerName.Name)})
+			Expect(configData).ShouldNot(BeNil())
+			conf := string(configData.Data["designate.conf"])
+			Expect(conf).Should(
+				ContainSubstring("[producer_task:zone_purge]\ninterval=3600\ntime_threshold=86400\nbatch_size=100\n"))
+		})
+
+		It("should configure the logging", func() {
+			configData := th.GetSecret(
+				types.NamespacedName{
+					Namespace: designateProducerName.Namespace,
+					Name:      fmt.Sprintf("%s-config-data", designateProducerName.Name)})
+			Expect(configData).ShouldNot(BeNil())
+			conf := string(configData.Data["designate.conf"])
+			Expect(conf).Should(
+				HavePrefix("[DEFAULT]\ndebug=true\nuse_json=true\nrate_limit_interval=30\nrate_limit_burst=100\n\n[service:producer]\n"))
+		})
 	})
 })
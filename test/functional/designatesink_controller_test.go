@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openstack-k8s-operators/designate-operator/internal/designate"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
+)
+
+var _ = Describe("DesignateSink controller", func() {
+	var spec map[string]any
+	var designateSinkName types.NamespacedName
+	var transportURLSecretName types.NamespacedName
+
+	BeforeEach(func() {
+		spec = GetDefaultDesignateSinkSpec()
+
+		transportURLSecretName = types.NamespacedName{
+			Namespace: namespace,
+			Name:      RabbitmqSecretName,
+		}
+
+		designateSinkName = types.NamespacedName{
+			Name:      fmt.Sprintf("designate-sink-%s", uuid.New().String()),
+			Namespace: namespace,
+		}
+	})
+
+	When("a DesignateSink instance is created", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateDesignateSink(designateSinkName, spec))
+		})
+
+		It("should have the Status fields initialized", func() {
+			designateSink := GetDesignateSink(designateSinkName)
+			Expect(designateSink.Status.ReadyCount).Should(Equal(int32(0)))
+		})
+
+		It("should have a finalizer", func() {
+			Eventually(func() []string {
+				return GetDesignateSink(designateSinkName).Finalizers
+			}, timeout, interval).Should(ContainElement("openstack.org/designatesink"))
+		})
+
+		It("should default the notification topics", func() {
+			designateSink := GetDesignateSink(designateSinkName)
+			Expect(designateSink.Spec.NotificationHandlers[0].NotificationTopics).Should(
+				Equal([]string{"notifications"}))
+		})
+	})
+
+	When("config files are created", func() {
+		BeforeEach(func() {
+			keystoneName := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneName)
+			SimulateKeystoneReady(keystoneName,
+				fmt.Sprintf("http://keystone-for-%s-public", designateSinkName.Name),
+				fmt.Sprintf("http://keystone-for-%s-internal", designateSinkName.Name))
+
+			DeferCleanup(k8sClient.Delete, ctx, CreateDesignateSecret(namespace))
+			DeferCleanup(k8sClient.Delete, ctx, CreateTransportURLSecret(transportURLSecretName))
+			createOwnerSecrets(namespace)
+
+			DeferCleanup(th.DeleteInstance, CreateDesignateSink(designateSinkName, spec))
+
+			mariaDBDatabaseName := mariadb.CreateMariaDBDatabase(namespace, designate.DatabaseCRName, mariadbv1.MariaDBDatabaseSpec{})
+			mariaDBDatabase := mariadb.GetMariaDBDatabase(mariaDBDatabaseName)
+			DeferCleanup(k8sClient.Delete, ctx, mariaDBDatabase)
+
+			designateSink := GetDesignateSink(designateSinkName)
+			sinkMariaDBAccount, sinkMariaDBSecret := mariadb.CreateMariaDBAccountAndSecret(
+				types.NamespacedName{
+					Namespace: namespace,
+					Name:      designateSink.Spec.DatabaseAccount,
+				}, mariadbv1.MariaDBAccountSpec{})
+			DeferCleanup(k8sClient.Delete, ctx, sinkMariaDBAccount)
+			DeferCleanup(k8sClient.Delete, ctx, sinkMariaDBSecret)
+		})
+
+		It("should set Service Config Ready Condition", func() {
+			th.ExpectCondition(
+				designateSinkName,
+				ConditionGetterFunc(DesignateSinkConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("should render the notification handlers", func() {
+			configData := th.GetSecret(types.NamespacedName{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-config-data", designateSinkName.Name),
+			})
+			Expect(configData).ShouldNot(BeNil())
+			conf := string(configData.Data["designate.conf"])
+			Expect(conf).Should(ContainSubstring("enabled_notification_handlers=nova_fixed,neutron_floatingip"))
+			Expect(conf).Should(ContainSubstring(
+				"[handler:nova_fixed]\nzone_id=8d1b3b8e-5e29-4c77-a7b6-8bd4d58d0b52\nnotification_topics=notifications\ncontrol_exchange=nova\n"))
+			Expect(conf).Should(ContainSubstring(
+				"notification_topics=notifications,notifications_designate\ncontrol_exchange=neutron\n"))
+			Expect(conf).Should(ContainSubstring("formatv6=%(hostname)s.%(zone)s"))
+		})
+	})
+})
@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	designatev1 "github.com/openstack-k8s-operators/designate-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	//revive:disable-next-line:dot-imports
+	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
+)
+
+var _ = Describe("DesignateZone controller", func() {
+	var designateName types.NamespacedName
+	var zoneName types.NamespacedName
+	var recordSetName types.NamespacedName
+
+	BeforeEach(func() {
+		designateName = types.NamespacedName{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("designate-%s", uuid.New().String()[:10]),
+		}
+		zoneName = types.NamespacedName{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("zone-%s", uuid.New().String()[:10]),
+		}
+		recordSetName = types.NamespacedName{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("recordset-%s", uuid.New().String()[:10]),
+		}
+	})
+
+	When("a DesignateZone referencing a missing Designate is created", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateDesignateZone(zoneName, GetDefaultDesignateZoneSpec(designateName.Name)))
+		})
+
+		It("should wait for the Designate CR", func() {
+			th.ExpectConditionWithDetails(
+				zoneName,
+				ConditionGetterFunc(DesignateZoneConditionGetter),
+				designatev1.DesignateZoneReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(designatev1.DesignateZoneReadyWaitingMessage, "Designate "+designateName.Name),
+			)
+			Expect(GetDesignateZone(zoneName).Status.ZoneID).To(BeEmpty())
+		})
+
+		It("should be deleted without a zone in Designate", func() {
+			// the finalizer is removed as the zone was never created
+			th.DeleteInstance(GetDesignateZone(zoneName))
+		})
+	})
+
+	When("a DesignateZone of a Designate without a ready API is created", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateDesignate(designateName, GetDefaultDesignateSpec(1, 1, 1)))
+			DeferCleanup(th.DeleteInstance, CreateDesignateZone(zoneName, GetDefaultDesignateZoneSpec(designateName.Name)))
+		})
+
+		It("should wait for the Designate API", func() {
+			th.ExpectConditionWithDetails(
+				zoneName,
+				ConditionGetterFunc(DesignateZoneConditionGetter),
+				designatev1.DesignateZoneReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(designatev1.DesignateZoneReadyWaitingMessage, "the Designate API"),
+			)
+			th.ExpectCondition(
+				zoneName,
+				ConditionGetterFunc(DesignateZoneConditionGetter),
+				condition.ReadyCondition,
+				corev1.ConditionFalse,
+			)
+		})
+	})
+
+	When("a DesignateRecordSet referencing a zone without ID is created", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateDesignateZone(zoneName, GetDefaultDesignateZoneSpec(designateName.Name)))
+			DeferCleanup(th.DeleteInstance, CreateDesignateRecordSet(recordSetName, GetDefaultDesignateRecordSetSpec(zoneName.Name)))
+		})
+
+		It("should wait for the DesignateZone", func() {
+			th.ExpectConditionWithDetails(
+				recordSetName,
+				ConditionGetterFunc(DesignateRecordSetConditionGetter),
+				designatev1.DesignateRecordSetReadyCondition,
+				corev1.ConditionFalse,
+				condition.RequestedReason,
+				fmt.Sprintf(designatev1.DesignateRecordSetReadyWaitingMessage, "DesignateZone "+zoneName.Name),
+			)
+		})
+	})
+
+	When("a SECONDARY DesignateZone without masters is created", func() {
+		It("should be rejected", func() {
+			spec := GetDefaultDesignateZoneSpec(designateName.Name)
+			spec["type"] = "SECONDARY"
+			raw := map[string]any{
+				"apiVersion": "designate.openstack.org/v1beta1",
+				"kind":       "DesignateZone",
+				"metadata": map[string]any{
+					"name":      zoneName.Name,
+					"namespace": zoneName.Namespace,
+				},
+				"spec": spec,
+			}
+			unstructuredObj := &unstructured.Unstructured{Object: raw}
+			err := k8sClient.Create(ctx, unstructuredObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("masters are required for SECONDARY zones"))
+		})
+	})
+})
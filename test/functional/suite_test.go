@@ -50,6 +50,7 @@ import (
 	controllers "github.com/openstack-k8s-operators/designate-operator/internal/controller"
 	webhooks "github.com/openstack-k8s-operators/designate-operator/internal/webhook/v1beta1"
 	memcachedv1 "github.com/openstack-k8s-operators/infra-operator/apis/memcached/v1beta1"
+	infranetworkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
 	infra_test "github.com/openstack-k8s-operators/infra-operator/apis/test/helpers"
@@ -167,6 +168,8 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	err = topologyv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = infranetworkv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 	//+kubebuilder:scaffold:scheme
 
 	logger = ctrl.Log.WithName("---Test---")
@@ -202,14 +205,33 @@ var _ = BeforeSuite(func() {
 
 	err = webhooks.SetupDesignateWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateBackendbind9WebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateMdnsWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateAPIWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateCentralWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateWorkerWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateProducerWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateSinkWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateBackendPDNSWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhooks.SetupDesignateUnboundWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
 
 	kclient, err := kubernetes.NewForConfig(cfg)
 	Expect(err).ToNot(HaveOccurred(), "failed to create kclient")
 
 	err = (&controllers.DesignateReconciler{
-		Client:  k8sManager.GetClient(),
-		Scheme:  k8sManager.GetScheme(),
-		Kclient: kclient,
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Kclient:  kclient,
+		Recorder: k8sManager.GetEventRecorderFor("designate-controller"),
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 	err = (&controllers.DesignateAPIReconciler{
@@ -219,6 +241,13 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 	err = (&controllers.DesignateBackendbind9Reconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Kclient:  kclient,
+		Recorder: k8sManager.GetEventRecorderFor("designatebackendbind9-controller"),
+	}).SetupWithManager(ctx, k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+	err = (&controllers.DesignateBackendPDNSReconciler{
 		Client:  k8sManager.GetClient(),
 		Scheme:  k8sManager.GetScheme(),
 		Kclient: kclient,
@@ -236,12 +265,19 @@ var _ = BeforeSuite(func() {
 		Kclient: kclient,
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
-	err = (&controllers.DesignateMdnsReconciler{
+	err = (&controllers.DesignateSinkReconciler{
 		Client:  k8sManager.GetClient(),
 		Scheme:  k8sManager.GetScheme(),
 		Kclient: kclient,
 	}).SetupWithManager(ctx, k8sManager)
 	Expect(err).ToNot(HaveOccurred())
+	err = (&controllers.DesignateMdnsReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Kclient:  kclient,
+		Recorder: k8sManager.GetEventRecorderFor("designatemdns-controller"),
+	}).SetupWithManager(ctx, k8sManager)
+	Expect(err).ToNot(HaveOccurred())
 	err = (&controllers.DesignateWorkerReconciler{
 		Client:  k8sManager.GetClient(),
 		Scheme:  k8sManager.GetScheme(),
@@ -254,6 +290,24 @@ var _ = BeforeSuite(func() {
 		Kclient: kclient,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
+	err = (&controllers.DesignatePoolReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(ctx, k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+	err = (&controllers.DesignateZoneReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(ctx, k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+	err = (&controllers.DesignateRecordSetReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(ctx, k8sManager)
+	Expect(err).ToNot(HaveOccurred())
 
 	// Acquire environmental defaults and initialize operator defaults with them
 	designatev1.SetupDefaults()